@@ -13,7 +13,9 @@ const (
 
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                    MemberRole,
+	atc.DryRunConfig:                  MemberRole,
 	atc.GetConfig:                     ViewerRole,
+	atc.GetConfigHistory:              ViewerRole,
 	atc.GetCC:                         ViewerRole,
 	atc.GetBuild:                      ViewerRole,
 	atc.GetBuildPlan:                  ViewerRole,
@@ -23,6 +25,9 @@ var DefaultRoles = map[string]string{
 	atc.BuildResources:                ViewerRole,
 	atc.AbortBuild:                    OperatorRole,
 	atc.GetBuildPreparation:           ViewerRole,
+	atc.GetBuildDiff:                  ViewerRole,
+	atc.ListBuildApprovals:            ViewerRole,
+	atc.ApproveBuild:                  OperatorRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
 	atc.RerunJobBuild:                 OperatorRole,
@@ -46,6 +51,7 @@ var DefaultRoles = map[string]string{
 	atc.SetPinCommentOnResource:       OperatorRole,
 	atc.CheckResource:                 OperatorRole,
 	atc.CheckResourceWebHook:          OperatorRole,
+	atc.TriggerResourceWebHook:        OperatorRole,
 	atc.CheckResourceType:             OperatorRole,
 	atc.ListResourceVersions:          ViewerRole,
 	atc.GetResourceVersion:            ViewerRole,
@@ -96,6 +102,11 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                    OwnerRole,
 	atc.DestroyTeam:                   OwnerRole,
 	atc.ListTeamBuilds:                ViewerRole,
+	atc.SavePipelineLibrary:           MemberRole,
+	atc.GetPipelineLibrary:            ViewerRole,
+	atc.GetPipelineSync:               ViewerRole,
+	atc.SyncPipelines:                 MemberRole,
+	atc.PipelineSyncWebhook:           MemberRole,
 	atc.CreateArtifact:                MemberRole,
 	atc.GetArtifact:                   MemberRole,
 	atc.ListBuildArtifacts:            ViewerRole,
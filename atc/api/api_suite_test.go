@@ -218,6 +218,7 @@ var _ = BeforeEach(func() {
 		interceptTimeoutFactory,
 		time.Second,
 		dbWall,
+		fakePolicyChecker,
 		fakeClock,
 	)
 
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -636,6 +637,30 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the events of the build have been archived", func() {
+							BeforeEach(func() {
+								build.EventsArchivedReturns(true)
+							})
+
+							It("does not present the build as reaped", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"name": "1",
+						"status": "succeeded",
+						"job_name": "job1",
+						"pipeline_id": 123,
+						"pipeline_name": "pipeline1",
+						"team_name": "some-team",
+						"api_url": "/api/v1/builds/1",
+						"start_time": 1,
+						"end_time": 100
+					}`))
+							})
+						})
 					})
 				})
 			})
@@ -1326,6 +1351,550 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/diff/:other_build_id", func() {
+		var (
+			otherBuild *dbfakes.FakeBuild
+			fromEvents *dbfakes.FakeEventSource
+			toEvents   *dbfakes.FakeEventSource
+			response   *http.Response
+		)
+
+		envelope := func(ev atc.Event) event.Envelope {
+			payload, err := json.Marshal(ev)
+			Expect(err).NotTo(HaveOccurred())
+
+			return event.Envelope{
+				Data:    (*json.RawMessage)(&payload),
+				Event:   ev.EventType(),
+				Version: ev.Version(),
+			}
+		}
+
+		eventSource := func(envelopes ...event.Envelope) *dbfakes.FakeEventSource {
+			source := new(dbfakes.FakeEventSource)
+			for i, e := range envelopes {
+				source.NextReturnsOnCall(i, e, nil)
+			}
+			source.NextReturnsOnCall(len(envelopes), event.Envelope{}, db.ErrEndOfBuildEventStream)
+			return source
+		}
+
+		imagePlan := func(digest string) *json.RawMessage {
+			return atc.Plan{
+				ID: "image-get",
+				Get: &atc.GetPlan{
+					Name:    "image",
+					Type:    "registry-image",
+					Version: &atc.Version{"digest": digest},
+				},
+			}.Public()
+		}
+
+		BeforeEach(func() {
+			build.IDReturns(1)
+			build.TeamNameReturns("some-team")
+			build.JobIDReturns(42)
+			build.JobNameReturns("some-job")
+			build.PipelineIDReturns(42)
+			build.PipelineReturns(fakePipeline, true, nil)
+			build.IsCompletedReturns(true)
+			build.HasPlanReturns(true)
+			build.PublicPlanReturns(atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{ID: "1", Get: &atc.GetPlan{Name: "repo", Type: "git", Resource: "repo"}},
+					{ID: "2", Task: &atc.TaskPlan{Name: "unit"}},
+				},
+			}.Public())
+			build.ResourcesReturns([]db.BuildInput{
+				{Name: "repo", Version: atc.Version{"ref": "v1"}},
+			}, nil, nil)
+			fromEvents = eventSource(
+				envelope(event.InitializeTask{
+					Origin:     event.Origin{ID: "2"},
+					Time:       10,
+					TaskConfig: event.TaskConfig{Run: event.TaskRunConfig{Path: "old"}},
+				}),
+				envelope(event.ImageGet{Origin: event.Origin{ID: "2"}, PublicPlan: imagePlan("a")}),
+				envelope(event.SelectedWorker{Origin: event.Origin{ID: "2"}, WorkerName: "worker-a"}),
+				envelope(event.StartTask{Origin: event.Origin{ID: "2"}, Time: 12}),
+				envelope(event.FinishTask{Origin: event.Origin{ID: "2"}, Time: 20}),
+			)
+			build.EventsReturns(fromEvents, nil)
+
+			otherBuild = new(dbfakes.FakeBuild)
+			otherBuild.IDReturns(2)
+			otherBuild.TeamNameReturns("some-team")
+			otherBuild.JobIDReturns(42)
+			otherBuild.JobNameReturns("some-job")
+			otherBuild.PipelineIDReturns(42)
+			otherBuild.IsCompletedReturns(true)
+			otherBuild.HasPlanReturns(true)
+			otherBuild.PublicPlanReturns(atc.Plan{
+				ID: "a",
+				Do: &atc.DoPlan{
+					{ID: "b", Get: &atc.GetPlan{Name: "repo", Type: "git", Resource: "repo"}},
+					{ID: "c", Task: &atc.TaskPlan{Name: "unit", Privileged: true}},
+				},
+			}.Public())
+			otherBuild.ResourcesReturns([]db.BuildInput{
+				{Name: "repo", Version: atc.Version{"ref": "v2"}},
+			}, nil, nil)
+			toEvents = eventSource(
+				envelope(event.InitializeTask{
+					Origin:     event.Origin{ID: "c"},
+					Time:       10,
+					TaskConfig: event.TaskConfig{Run: event.TaskRunConfig{Path: "new"}},
+				}),
+				envelope(event.ImageGet{Origin: event.Origin{ID: "c"}, PublicPlan: imagePlan("b")}),
+				envelope(event.SelectedWorker{Origin: event.Origin{ID: "c"}, WorkerName: "worker-b"}),
+				envelope(event.StartTask{Origin: event.Origin{ID: "c"}, Time: 12}),
+				envelope(event.FinishTask{Origin: event.Origin{ID: "c"}, Time: 40}),
+			)
+			otherBuild.EventsReturns(toEvents, nil)
+
+			dbBuildFactory.BuildStub = func(id int) (db.Build, bool, error) {
+				switch id {
+				case 1:
+					return build, true, nil
+				case 2:
+					return otherBuild, true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/1/diff/2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response).To(IncludeHeaderEntries(map[string]string{
+					"Content-Type": "application/json",
+				}))
+			})
+
+			It("returns the differences between the builds", func() {
+				var diff atc.BuildDiff
+				err := json.NewDecoder(response.Body).Decode(&diff)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(diff.From.ID).To(Equal(1))
+				Expect(diff.To.ID).To(Equal(2))
+
+				Expect(diff.Inputs).To(Equal([]atc.BuildVersionDiff{
+					{Name: "repo", From: atc.Version{"ref": "v1"}, To: atc.Version{"ref": "v2"}},
+				}))
+
+				Expect(diff.Plan).To(HaveLen(1))
+				Expect(diff.Plan[0].Step).To(Equal("task:unit"))
+				Expect(*diff.Plan[0].From).To(MatchJSON(`{"name":"unit","privileged":false}`))
+				Expect(*diff.Plan[0].To).To(MatchJSON(`{"name":"unit","privileged":true}`))
+
+				Expect(diff.Tasks).To(HaveLen(1))
+				Expect(diff.Tasks[0].Step).To(Equal("task:unit"))
+				Expect(*diff.Tasks[0].From).To(ContainSubstring(`"path":"old"`))
+				Expect(*diff.Tasks[0].To).To(ContainSubstring(`"path":"new"`))
+
+				Expect(diff.Images).To(Equal([]atc.BuildVersionDiff{
+					{Name: "task:unit", From: atc.Version{"digest": "a"}, To: atc.Version{"digest": "b"}},
+				}))
+
+				Expect(diff.Workers).To(Equal([]atc.BuildWorkerDiff{
+					{Step: "task:unit", From: "worker-a", To: "worker-b"},
+				}))
+
+				Expect(diff.Durations).To(HaveLen(1))
+				Expect(diff.Durations[0].Step).To(Equal("task:unit"))
+				Expect(*diff.Durations[0].From).To(Equal(int64(10)))
+				Expect(*diff.Durations[0].To).To(Equal(int64(30)))
+			})
+
+			It("closes the event sources", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fromEvents.CloseCallCount()).To(Equal(1))
+				Expect(toEvents.CloseCallCount()).To(Equal(1))
+			})
+
+			Context("when one of the builds is still running", func() {
+				BeforeEach(func() {
+					otherBuild.IsCompletedReturns(false)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the other build is not found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildStub = func(id int) (db.Build, bool, error) {
+						if id == 1 {
+							return build, true, nil
+						}
+						return nil, false, nil
+					}
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when reading the events fails", func() {
+				BeforeEach(func() {
+					otherBuild.EventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline and job are public", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(true)
+
+				fakeJob := new(dbfakes.FakeJob)
+				fakeJob.PublicReturns(true)
+				fakePipeline.JobReturns(fakeJob, true, nil)
+			})
+
+			Context("when the other build belongs to the same job", func() {
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the other build belongs to another job", func() {
+				BeforeEach(func() {
+					otherBuild.JobIDReturns(43)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when authenticated but not authorized for the other build's team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedStub = func(team string) bool {
+					return team == "some-team"
+				}
+
+				otherBuild.TeamNameReturns("other-team")
+				otherBuild.JobIDReturns(43)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/approvals", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.TeamNameReturns("some-team")
+			build.PrivatePlanReturns(atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{ID: "1", Approve: &atc.ApprovePlan{Name: "deploy", Required: 1}},
+				},
+			})
+			build.ApprovalsReturns([]db.BuildApproval{
+				{
+					PlanID:    "1",
+					UserName:  "some-user",
+					Approved:  true,
+					Comment:   "lgtm",
+					CreatedAt: time.Unix(100, 0),
+				},
+			}, nil)
+			dbBuildFactory.BuildReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/1/approvals")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the approvals", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"plan_id": "1",
+						"step_name": "deploy",
+						"user_name": "some-user",
+						"approved": true,
+						"comment": "lgtm",
+						"time": 100
+					}
+				]`))
+			})
+
+			Context("when getting the approvals fails", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/builds/:build_id/approvals", func() {
+		var (
+			request  atc.BuildApprovalRequest
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			request = atc.BuildApprovalRequest{
+				Approved: true,
+				Comment:  "lgtm",
+			}
+
+			build.TeamNameReturns("some-team")
+			build.PrivatePlanReturns(atc.Plan{
+				ID: "0",
+				Do: &atc.DoPlan{
+					{ID: "1", Task: &atc.TaskPlan{Name: "unit"}},
+					{ID: "2", Approve: &atc.ApprovePlan{Name: "deploy", Required: 1}},
+				},
+			})
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			fakeAccess.UserInfoReturns(atc.UserInfo{
+				DisplayUserId: "some-user",
+				Teams:         map[string][]string{"some-team": {"member"}},
+			})
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(server.URL+"/api/v1/builds/1/approvals", "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not record the approval", func() {
+				Expect(build.SaveApprovalCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("records the approval of the approve step", func() {
+				Expect(build.SaveApprovalCallCount()).To(Equal(1))
+				Expect(build.SaveApprovalArgsForCall(0)).To(Equal(db.BuildApproval{
+					PlanID:   "2",
+					UserName: "some-user",
+					Approved: true,
+					Comment:  "lgtm",
+				}))
+			})
+
+			Context("when the named step does not exist", func() {
+				BeforeEach(func() {
+					request.StepName = "bogus"
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build has more than one approve step", func() {
+				BeforeEach(func() {
+					build.PrivatePlanReturns(atc.Plan{
+						ID: "0",
+						Do: &atc.DoPlan{
+							{ID: "1", Approve: &atc.ApprovePlan{Name: "staging", Required: 1}},
+							{ID: "2", Approve: &atc.ApprovePlan{Name: "production", Required: 1}},
+						},
+					})
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				Context("when the step is named", func() {
+					BeforeEach(func() {
+						request.StepName = "production"
+					})
+
+					It("records the approval of the named step", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						Expect(build.SaveApprovalArgsForCall(0).PlanID).To(Equal(atc.PlanID("2")))
+					})
+				})
+			})
+
+			Context("when the step restricts its approvers", func() {
+				BeforeEach(func() {
+					build.PrivatePlanReturns(atc.Plan{
+						ID: "2",
+						Approve: &atc.ApprovePlan{
+							Name:     "deploy",
+							Required: 1,
+							Approvers: &atc.ApproversConfig{
+								Roles: []string{"owner"},
+								Users: []string{"some-release-manager"},
+							},
+						},
+					})
+				})
+
+				Context("when the user has none of the roles and is not listed", func() {
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(build.SaveApprovalCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the user has one of the roles", func() {
+					BeforeEach(func() {
+						fakeAccess.UserInfoReturns(atc.UserInfo{
+							DisplayUserId: "some-user",
+							Teams:         map[string][]string{"some-team": {"owner"}},
+						})
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+
+				Context("when the user is listed", func() {
+					BeforeEach(func() {
+						fakeAccess.UserInfoReturns(atc.UserInfo{
+							DisplayUserId: "some-release-manager",
+						})
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAdminReturns(true)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+			})
+
+			Context("when the build has completed", func() {
+				BeforeEach(func() {
+					build.IsCompletedReturns(true)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(build.SaveApprovalCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the user has already decided", func() {
+				BeforeEach(func() {
+					build.SaveApprovalReturns(db.ErrBuildApprovalAlreadyRecorded)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the step is not waiting for approval", func() {
+				BeforeEach(func() {
+					build.SaveApprovalReturns(db.ErrBuildNotWaitingForApproval)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when recording the approval fails", func() {
+				BeforeEach(func() {
+					build.SaveApprovalReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var plan *json.RawMessage
 
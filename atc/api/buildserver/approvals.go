@@ -0,0 +1,159 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildApprovals(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-approvals", build.LagerData())
+
+		approvals, err := build.Approvals()
+		if err != nil {
+			logger.Error("failed-to-get-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		stepNames := map[atc.PlanID]string{}
+		for _, plan := range approvePlans(build) {
+			stepNames[plan.ID] = plan.Approve.Name
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.BuildApprovals(approvals, stepNames))
+		if err != nil {
+			logger.Error("failed-to-encode-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("approve-build", build.LagerData())
+
+		var request atc.BuildApprovalRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var candidates []atc.Plan
+		for _, plan := range approvePlans(build) {
+			if request.PlanID != "" && plan.ID != request.PlanID {
+				continue
+			}
+
+			if request.StepName != "" && plan.Approve.Name != request.StepName {
+				continue
+			}
+
+			candidates = append(candidates, plan)
+		}
+
+		if len(candidates) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if len(candidates) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "build has more than one matching approve step; specify the step name or plan ID")
+			return
+		}
+
+		plan := candidates[0]
+
+		acc := accessor.GetAccessor(r)
+		userInfo := acc.UserInfo()
+		if !acc.IsAdmin() && !canApprove(plan.Approve.Approvers, userInfo, build.TeamName()) {
+			s.rejector.Forbidden(w, r)
+			return
+		}
+
+		if build.IsCompleted() {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintln(w, "build has already completed")
+			return
+		}
+
+		err = build.SaveApproval(db.BuildApproval{
+			PlanID:   plan.ID,
+			UserName: userInfo.DisplayUserId,
+			Approved: request.Approved,
+			Comment:  request.Comment,
+		})
+		if err == db.ErrBuildApprovalAlreadyRecorded || err == db.ErrBuildNotWaitingForApproval {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-save-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("recorded-approval", lager.Data{
+			"step":     plan.Approve.Name,
+			"user":     userInfo.DisplayUserId,
+			"approved": request.Approved,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// approvePlans returns the approve steps within the build's plan.
+func approvePlans(build db.Build) []atc.Plan {
+	var plans []atc.Plan
+
+	plan := build.PrivatePlan()
+	plan.Each(func(p *atc.Plan) {
+		if p.Approve != nil {
+			plans = append(plans, *p)
+		}
+	})
+
+	return plans
+}
+
+// canApprove determines whether the user is one of the step's approvers. If
+// the step does not restrict its approvers, anyone who passed the
+// ApproveBuild role check may approve it. Admins may approve any step, and
+// are checked before this is called.
+func canApprove(approvers *atc.ApproversConfig, userInfo atc.UserInfo, teamName string) bool {
+	if approvers == nil {
+		return true
+	}
+
+	for _, user := range approvers.Users {
+		if user == userInfo.DisplayUserId || user == userInfo.UserName {
+			return true
+		}
+	}
+
+	for _, role := range userInfo.Teams[teamName] {
+		for _, approverRole := range approvers.Roles {
+			if role == approverRole {
+				return true
+			}
+		}
+	}
+
+	return false
+}
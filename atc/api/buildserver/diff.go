@@ -0,0 +1,399 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// GetBuildDiff compares a build with the build identified by the
+// `other_build_id` param. Both builds must have completed, as their events are
+// read in full.
+func (s *Server) GetBuildDiff(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-diff")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherBuildID, err := strconv.Atoi(r.FormValue(":other_build_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		otherBuild, found, err := s.buildFactory.Build(otherBuildID)
+		if err != nil {
+			logger.Error("failed-to-get-build", err, lager.Data{"build": otherBuildID})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		if !canDiff(build, otherBuild, acc) {
+			if acc.IsAuthenticated() {
+				s.rejector.Forbidden(w, r)
+			} else {
+				s.rejector.Unauthorized(w, r)
+			}
+			return
+		}
+
+		if !build.IsCompleted() || !otherBuild.IsCompleted() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		from, err := summarizeBuild(build)
+		if err != nil {
+			logger.Error("failed-to-summarize-build", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		to, err := summarizeBuild(otherBuild)
+		if err != nil {
+			logger.Error("failed-to-summarize-build", err, lager.Data{"build": otherBuild.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(diffBuilds(from, to))
+		if err != nil {
+			logger.Error("failed-to-encode-build-diff", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// canDiff determines whether the other build may be compared with a build
+// that the requester already has access to. Builds of the same job share the
+// same visibility, otherwise the requester must be authorized for the other
+// build's team.
+func canDiff(build db.Build, otherBuild db.Build, acc accessor.Access) bool {
+	if acc.IsAuthenticated() && acc.IsAuthorized(otherBuild.TeamName()) {
+		return true
+	}
+
+	return build.JobID() != 0 && build.JobID() == otherBuild.JobID()
+}
+
+type buildSummary struct {
+	build db.Build
+
+	steps []string
+
+	inputs    map[string]atc.Version
+	plan      map[string]*json.RawMessage
+	tasks     map[string]*json.RawMessage
+	images    map[string]atc.Version
+	workers   map[string]string
+	starts    map[string]int64
+	durations map[string]int64
+}
+
+func summarizeBuild(build db.Build) (buildSummary, error) {
+	summary := buildSummary{
+		build: build,
+
+		inputs:    map[string]atc.Version{},
+		plan:      map[string]*json.RawMessage{},
+		tasks:     map[string]*json.RawMessage{},
+		images:    map[string]atc.Version{},
+		workers:   map[string]string{},
+		starts:    map[string]int64{},
+		durations: map[string]int64{},
+	}
+
+	inputs, _, err := build.Resources()
+	if err != nil {
+		return buildSummary{}, fmt.Errorf("get resources: %w", err)
+	}
+
+	for _, input := range inputs {
+		summary.inputs[input.Name] = present.PublicBuildInput(input, build.PipelineID()).Version
+	}
+
+	origins := map[event.OriginID]string{}
+	if build.HasPlan() {
+		var plan atc.Plan
+		err := json.Unmarshal(*build.PublicPlan(), &plan)
+		if err != nil {
+			return buildSummary{}, fmt.Errorf("unmarshal plan: %w", err)
+		}
+
+		occurrences := map[string]int{}
+		plan.Each(func(p *atc.Plan) {
+			step, public := publicStep(p)
+			if step == "" {
+				return
+			}
+
+			occurrences[step]++
+			if occurrences[step] > 1 {
+				step = fmt.Sprintf("%s#%d", step, occurrences[step])
+			}
+
+			origins[event.OriginID(p.ID)] = step
+			summary.steps = append(summary.steps, step)
+			summary.plan[step] = public
+		})
+	}
+
+	events, err := build.Events(0)
+	if err != nil {
+		return buildSummary{}, fmt.Errorf("get events: %w", err)
+	}
+
+	defer db.Close(events)
+
+	for {
+		envelope, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return buildSummary{}, fmt.Errorf("read event: %w", err)
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			// events from older versions are not relevant to the diff
+			continue
+		}
+
+		summary.record(origins, ev)
+	}
+
+	return summary, nil
+}
+
+func (summary buildSummary) record(origins map[event.OriginID]string, ev atc.Event) {
+	switch e := ev.(type) {
+	case event.InitializeTask:
+		if step, found := origins[e.Origin.ID]; found {
+			summary.tasks[step] = rawJSON(e.TaskConfig)
+			summary.start(step, e.Time)
+		}
+	case event.StartTask:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.InitializeGet:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.StartGet:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.InitializePut:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.StartPut:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.Initialize:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.Start:
+		summary.start(origins[e.Origin.ID], e.Time)
+	case event.FinishTask:
+		summary.finish(origins[e.Origin.ID], e.Time)
+	case event.FinishGet:
+		summary.finish(origins[e.Origin.ID], e.Time)
+	case event.FinishPut:
+		summary.finish(origins[e.Origin.ID], e.Time)
+	case event.Finish:
+		summary.finish(origins[e.Origin.ID], e.Time)
+	case event.SelectedWorker:
+		if step, found := origins[e.Origin.ID]; found {
+			summary.workers[step] = e.WorkerName
+		}
+	case event.ImageGet:
+		step, found := origins[e.Origin.ID]
+		if !found || e.PublicPlan == nil {
+			return
+		}
+
+		var plan atc.Plan
+		err := json.Unmarshal(*e.PublicPlan, &plan)
+		if err == nil && plan.Get != nil && plan.Get.Version != nil {
+			summary.images[step] = *plan.Get.Version
+		}
+	}
+}
+
+func (summary buildSummary) start(step string, time int64) {
+	if step == "" || time == 0 {
+		return
+	}
+
+	if start, found := summary.starts[step]; !found || time < start {
+		summary.starts[step] = time
+	}
+}
+
+func (summary buildSummary) finish(step string, time int64) {
+	if step == "" || time == 0 {
+		return
+	}
+
+	if start, found := summary.starts[step]; found {
+		summary.durations[step] = time - start
+	}
+}
+
+// publicStep returns the name used to identify a step across builds along
+// with its public plan, or an empty name if the plan is not a step of its
+// own.
+func publicStep(plan *atc.Plan) (string, *json.RawMessage) {
+	switch {
+	case plan.Get != nil:
+		return "get:" + plan.Get.Name, plan.Get.Public()
+	case plan.Put != nil:
+		return "put:" + plan.Put.Name, plan.Put.Public()
+	case plan.Task != nil:
+		return "task:" + plan.Task.Name, plan.Task.Public()
+	case plan.Check != nil:
+		return "check:" + plan.Check.Name, plan.Check.Public()
+	case plan.SetPipeline != nil:
+		return "set_pipeline:" + plan.SetPipeline.Name, plan.SetPipeline.Public()
+	case plan.LoadVar != nil:
+		return "load_var:" + plan.LoadVar.Name, plan.LoadVar.Public()
+	case plan.Approve != nil:
+		return "approve:" + plan.Approve.Name, plan.Approve.Public()
+	default:
+		return "", nil
+	}
+}
+
+func diffBuilds(from buildSummary, to buildSummary) atc.BuildDiff {
+	diff := atc.BuildDiff{
+		From: present.Build(from.build),
+		To:   present.Build(to.build),
+
+		Inputs:    []atc.BuildVersionDiff{},
+		Plan:      []atc.BuildStepDiff{},
+		Tasks:     []atc.BuildStepDiff{},
+		Images:    []atc.BuildVersionDiff{},
+		Workers:   []atc.BuildWorkerDiff{},
+		Durations: []atc.BuildDurationDiff{},
+	}
+
+	inputs := map[string]bool{}
+	for name := range from.inputs {
+		inputs[name] = true
+	}
+	for name := range to.inputs {
+		inputs[name] = true
+	}
+
+	inputNames := make([]string, 0, len(inputs))
+	for name := range inputs {
+		inputNames = append(inputNames, name)
+	}
+
+	sort.Strings(inputNames)
+
+	for _, name := range inputNames {
+		if !reflect.DeepEqual(from.inputs[name], to.inputs[name]) {
+			diff.Inputs = append(diff.Inputs, atc.BuildVersionDiff{
+				Name: name,
+				From: from.inputs[name],
+				To:   to.inputs[name],
+			})
+		}
+	}
+
+	for _, step := range mergeSteps(from.steps, to.steps) {
+		if !equalJSON(from.plan[step], to.plan[step]) {
+			diff.Plan = append(diff.Plan, atc.BuildStepDiff{
+				Step: step,
+				From: from.plan[step],
+				To:   to.plan[step],
+			})
+		}
+
+		if !equalJSON(from.tasks[step], to.tasks[step]) {
+			diff.Tasks = append(diff.Tasks, atc.BuildStepDiff{
+				Step: step,
+				From: from.tasks[step],
+				To:   to.tasks[step],
+			})
+		}
+
+		if !reflect.DeepEqual(from.images[step], to.images[step]) {
+			diff.Images = append(diff.Images, atc.BuildVersionDiff{
+				Name: step,
+				From: from.images[step],
+				To:   to.images[step],
+			})
+		}
+
+		if from.workers[step] != to.workers[step] {
+			diff.Workers = append(diff.Workers, atc.BuildWorkerDiff{
+				Step: step,
+				From: from.workers[step],
+				To:   to.workers[step],
+			})
+		}
+
+		fromDuration, fromFound := from.durations[step]
+		toDuration, toFound := to.durations[step]
+		if fromFound != toFound || fromDuration != toDuration {
+			durationDiff := atc.BuildDurationDiff{Step: step}
+			if fromFound {
+				durationDiff.From = &fromDuration
+			}
+			if toFound {
+				durationDiff.To = &toDuration
+			}
+
+			diff.Durations = append(diff.Durations, durationDiff)
+		}
+	}
+
+	return diff
+}
+
+// mergeSteps returns the steps of both builds, in the order of the first
+// build followed by any steps which only exist in the second build.
+func mergeSteps(from []string, to []string) []string {
+	seen := map[string]bool{}
+
+	var steps []string
+	for _, step := range append(append([]string{}, from...), to...) {
+		if !seen[step] {
+			seen[step] = true
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
+
+func equalJSON(a *json.RawMessage, b *json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	var aVal, bVal interface{}
+	if json.Unmarshal(*a, &aVal) != nil || json.Unmarshal(*b, &bVal) != nil {
+		return string(*a) == string(*b)
+	}
+
+	return reflect.DeepEqual(aVal, bVal)
+}
+
+func rawJSON(val interface{}) *json.RawMessage {
+	payload, _ := json.Marshal(val)
+	return (*json.RawMessage)(&payload)
+}
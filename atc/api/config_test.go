@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
@@ -325,6 +326,123 @@ var _ = Describe("Config API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/history", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.GetConfigHistory, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			var (
+				fakeTeam     *dbfakes.FakeTeam
+				fakePipeline *dbfakes.FakePipeline
+			)
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeTeam = new(dbfakes.FakeTeam)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakePipeline = new(dbfakes.FakePipeline)
+				fakeTeam.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when the pipeline has a history", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+						{
+							Version:   7,
+							Config:    atc.Config{Resources: atc.ResourceConfigs{{Name: "some-resource", Type: "some-type"}}},
+							Author:    "some-user",
+							CreatedAt: time.Unix(200, 0),
+						},
+						{
+							Version:   3,
+							Config:    atc.Config{},
+							Author:    "some-pipeline/some-job #1",
+							CreatedAt: time.Unix(100, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the pipeline", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				})
+
+				It("returns the configs with their authors, most recent first", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{
+							"version": 7,
+							"author": "some-user",
+							"created_at": 200,
+							"config": {
+								"resources": [{"name": "some-resource", "type": "some-type", "source": null}]
+							}
+						},
+						{
+							"version": 3,
+							"author": "some-pipeline/some-job #1",
+							"created_at": 100,
+							"config": {}
+						}
+					]`))
+				})
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the history fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigHistoryReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
 			request  *http.Request
@@ -350,6 +468,7 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				fakeAccess.IsAuthenticatedReturns(true)
 				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 			})
 
 			Context("when an identifier is invalid", func() {
@@ -448,7 +567,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -479,7 +598,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -510,10 +629,17 @@ var _ = Describe("Config API", func() {
 							Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
 						})
 
+						It("records the user as the author of the config", func() {
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+							author, _, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+							Expect(author).To(Equal("some-user"))
+						})
+
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							_, ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -522,7 +648,7 @@ var _ = Describe("Config API", func() {
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -537,7 +663,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -578,7 +704,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 							})
 						})
 					})
@@ -609,9 +735,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							_, ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -656,9 +782,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+								_, ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineAsArgsForCall(0)
 								Expect(ref.Name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -724,7 +850,7 @@ jobs:
 									})
 
 									It("passes validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 									})
 
 									It("returns 200 ok", func() {
@@ -740,7 +866,7 @@ jobs:
 									})
 
 									It("fail validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 									})
 
 									It("returns 400", func() {
@@ -965,9 +1091,9 @@ jobs:
 									})
 
 									It("passes validation and saves it un-interpolated", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-										ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+										_, ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineAsArgsForCall(0)
 										Expect(ref.Name).To(Equal("a-pipeline"))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -1015,7 +1141,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -1029,7 +1155,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -1070,7 +1196,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 							})
 						})
 
@@ -1103,7 +1229,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -1115,9 +1241,9 @@ jobs:
 								})
 
 								It("saves an instanced pipeline", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-									ref, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									_, ref, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
 									Expect(ref).To(Equal(atc.PipelineRef{
 										Name:         "a-pipeline",
 										InstanceVars: atc.InstanceVars{"branch": "feature"},
@@ -1175,7 +1301,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1215,9 +1341,9 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-						ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+						_, ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineAsArgsForCall(0)
 						Expect(ref.Name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1269,7 +1395,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1300,7 +1426,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1315,7 +1441,174 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/dry_run", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+			payload  string
+		)
+
+		BeforeEach(func() {
+			payload = `---
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    secret: ((some-secret))
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+
+			fakePipeline.ConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"secret": "((some-secret))"},
+					},
+				},
+			}, nil)
+
+			fakeSecretManager.GetReturns("some-value", nil, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.DryRunConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/x-yaml")
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the changes without saving the config", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var dryRun atc.DryRunConfigResponse
+				Expect(json.NewDecoder(response.Body).Decode(&dryRun)).To(Succeed())
+
+				Expect(dryRun.Rejected()).To(BeFalse())
+				Expect(dryRun.NewPipeline).To(BeFalse())
+				Expect(dryRun.Changes).To(HaveLen(1))
+				Expect(dryRun.Changes[0].Kind).To(Equal("job"))
+				Expect(dryRun.Changes[0].Name).To(Equal("some-job"))
+				Expect(dryRun.Changes[0].Action).To(Equal(atc.ConfigChangeAdded))
+				Expect(dryRun.Changes[0].After).To(ContainSubstring("name: some-job"))
+
+				Expect(dbTeam.PipelineCallCount()).To(Equal(1))
+				Expect(dbTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
+			})
+
+			It("checks the policy for saving the config", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+
+				action, _, req := fakePolicyChecker.CheckArgsForCall(0)
+				Expect(action).To(Equal(atc.SaveConfig))
+				Expect(ioutil.ReadAll(req.Body)).To(Equal([]byte(payload)))
+			})
+
+			Context("when the policy check fails", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+						Allowed: false,
+						Reasons: []string{"no privileged tasks"},
+					}, nil)
+				})
+
+				It("reports the failure rather than rejecting the request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var dryRun atc.DryRunConfigResponse
+					Expect(json.NewDecoder(response.Body).Decode(&dryRun)).To(Succeed())
+					Expect(dryRun.Policy).To(Equal(atc.PolicyResult{
+						Allowed: false,
+						Reasons: []string{"no privileged tasks"},
+					}))
+					Expect(dryRun.Rejected()).To(BeTrue())
+				})
+			})
+
+			Context("when the policy check errors", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.FailedPolicyCheck(), errors.New("nope"))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a credential does not exist", func() {
+				BeforeEach(func() {
+					fakeSecretManager.GetReturns(nil, nil, false, nil)
+				})
+
+				It("reports it without the values of any credentials", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var dryRun atc.DryRunConfigResponse
+					Expect(json.NewDecoder(response.Body).Decode(&dryRun)).To(Succeed())
+					Expect(dryRun.CredentialErrors).To(ConsistOf(ContainSubstring("some-secret")))
+					Expect(dryRun.Rejected()).To(BeTrue())
+				})
+			})
+
+			Context("when the pipeline does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns everything in the config as added", func() {
+					var dryRun atc.DryRunConfigResponse
+					Expect(json.NewDecoder(response.Body).Decode(&dryRun)).To(Succeed())
+					Expect(dryRun.NewPipeline).To(BeTrue())
+					Expect(dryRun.Changes).To(HaveLen(2))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					payload = "jobs: [{name: some-job}, {name: some-job}]"
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var saveResponse atc.SaveConfigResponse
+					Expect(json.NewDecoder(response.Body).Decode(&saveResponse)).To(Succeed())
+					Expect(saveResponse.Errors).NotTo(BeEmpty())
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
 			})
 		})
 	})
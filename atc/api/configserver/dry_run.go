@@ -0,0 +1,105 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/hashicorp/go-multierror"
+	"github.com/tedsuo/rata"
+)
+
+// DryRunConfig checks a config the way SaveConfig would, and returns what
+// would change, without saving it. Unlike SaveConfig, the credentials are
+// always checked, and a failed policy check is reported rather than
+// rejecting the request.
+func (s *Server) DryRunConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("dry-run-config")
+
+	pipelineRef, config, warnings, ok := s.readConfig(session, w, r)
+	if !ok {
+		return
+	}
+
+	teamName := rata.Param(r, "team_name")
+
+	response := atc.DryRunConfigResponse{
+		Warnings: warnings,
+		Changes:  []atc.ConfigChange{},
+	}
+
+	// only whether the credentials exist is checked, so none of their values
+	// end up in the response
+	variables := creds.NewVariables(s.secretManager, teamName, pipelineRef.Name, false)
+	err := validateCredParams(variables, config, session)
+	if err != nil {
+		if merr, ok := err.(*multierror.Error); ok {
+			for _, e := range merr.Errors {
+				response.CredentialErrors = append(response.CredentialErrors, e.Error())
+			}
+		} else {
+			response.CredentialErrors = append(response.CredentialErrors, err.Error())
+		}
+	}
+
+	result, err := s.policyChecker.Check(atc.SaveConfig, accessor.GetAccessor(r), r)
+	if err != nil {
+		session.Error("failed-to-check-policy", err)
+		s.handleBadRequest(w, fmt.Sprintf("policy check error: %s", err))
+		return
+	}
+
+	response.Policy = atc.PolicyResult{
+		Allowed: result.Allowed,
+		Reasons: result.Reasons,
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	existingConfig := atc.Config{}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		session.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		existingConfig, err = pipeline.Config()
+		if err != nil {
+			session.Error("failed-to-get-pipeline-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	} else {
+		response.NewPipeline = true
+	}
+
+	response.Changes = existingConfig.Changes(config)
+
+	session.Info("checked", lager.Data{"changes": len(response.Changes), "rejected": response.Rejected()})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		session.Error("failed-to-encode-response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
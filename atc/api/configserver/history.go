@@ -0,0 +1,73 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) GetConfigHistory(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-history")
+	teamName := rata.Param(r, "team_name")
+	pipelineName := rata.Param(r, "pipeline_name")
+	pipelineRef := atc.PipelineRef{Name: pipelineName}
+	var err error
+	pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		logger.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	history, err := pipeline.ConfigHistory()
+	if err != nil {
+		logger.Error("failed-to-get-pipeline-config-history", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	entries := []atc.ConfigHistoryEntry{}
+	for _, entry := range history {
+		entries = append(entries, present.ConfigHistoryEntry(entry))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(entries)
+	if err != nil {
+		logger.Error("failed-to-encode-config-history", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
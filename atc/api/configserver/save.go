@@ -1,6 +1,7 @@
 package configserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -38,15 +40,83 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	pipelineRef, config, warnings, ok := s.readConfig(session, w, r)
+	if !ok {
+		return
+	}
+
+	teamName := rata.Param(r, "team_name")
+	pipelineName := pipelineRef.Name
+
+	if checkCredentials {
+		variables := creds.NewVariables(s.secretManager, teamName, pipelineName, false)
+
+		errs := validateCredParams(variables, config, session)
+		if errs != nil {
+			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
+			return
+		}
+	}
+
+	session.Info("saving")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	author := accessor.GetAccessor(r).UserInfo().DisplayUserId
+
+	_, created, err := team.SavePipelineAs(author, pipelineRef, config, version, true)
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	if !created {
+		if err = s.teamFactory.NotifyResourceScanner(); err != nil {
+			session.Error("failed-to-notify-resource-scanner", err)
+		}
+	}
+
+	session.Info("saved")
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+}
+
+// readConfig reads and validates the config being saved, writing a response
+// and returning false if it is invalid.
+func (s *Server) readConfig(session lager.Logger, w http.ResponseWriter, r *http.Request) (atc.PipelineRef, atc.Config, []atc.ConfigWarning, bool) {
 	var config atc.Config
 	switch r.Header.Get("Content-type") {
 	case "application/json", "application/x-yaml":
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
 			s.handleBadRequest(w, fmt.Sprintf("read failed: %s", err))
-			return
+			return atc.PipelineRef{}, atc.Config{}, nil, false
 		}
 
+		// leave the body to be read again by a policy check
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
 		err = atc.UnmarshalConfig(body, &config)
 		if err != nil {
 			session.Error("malformed-request-payload", err, lager.Data{
@@ -54,18 +124,18 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 			})
 
 			s.handleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
-			return
+			return atc.PipelineRef{}, atc.Config{}, nil, false
 		}
 	default:
 		w.WriteHeader(http.StatusUnsupportedMediaType)
-		return
+		return atc.PipelineRef{}, atc.Config{}, nil, false
 	}
 
 	warnings, errorMessages := configvalidate.Validate(config)
 	if len(errorMessages) > 0 {
 		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
 		s.handleBadRequest(w, errorMessages...)
-		return
+		return atc.PipelineRef{}, atc.Config{}, nil, false
 	}
 
 	pipelineName := rata.Param(r, "pipeline_name")
@@ -73,7 +143,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		session.Info("ignoring-pipeline-name", lager.Data{"error": err.Error()})
 		s.handleBadRequest(w, err.Error())
-		return
+		return atc.PipelineRef{}, atc.Config{}, nil, false
 	}
 	if warning != nil {
 		warnings = append(warnings, *warning)
@@ -84,7 +154,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		session.Info("ignoring-team-name", lager.Data{"error": err.Error()})
 		s.handleBadRequest(w, err.Error())
-		return
+		return atc.PipelineRef{}, atc.Config{}, nil, false
 	}
 	if warning != nil {
 		warnings = append(warnings, *warning)
@@ -96,63 +166,14 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			session.Error("malformed-instance-vars", err)
 			s.handleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
-			return
+			return atc.PipelineRef{}, atc.Config{}, nil, false
 		}
 	} else if pipelineRef.InstanceVars != nil {
 		s.handleBadRequest(w, "support for `instance vars` is disabled")
-		return
+		return atc.PipelineRef{}, atc.Config{}, nil, false
 	}
 
-	if checkCredentials {
-		variables := creds.NewVariables(s.secretManager, teamName, pipelineName, false)
-
-		errs := validateCredParams(variables, config, session)
-		if errs != nil {
-			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
-			return
-		}
-	}
-
-	session.Info("saving")
-
-	team, found, err := s.teamFactory.FindTeam(teamName)
-	if err != nil {
-		session.Error("failed-to-find-team", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	if !found {
-		session.Debug("team-not-found")
-		w.WriteHeader(http.StatusNotFound)
-		return
-	}
-
-	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
-	if err != nil {
-		session.Error("failed-to-save-config", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, "failed to save config: %s", err)
-		return
-	}
-
-	if !created {
-		if err = s.teamFactory.NotifyResourceScanner(); err != nil {
-			session.Error("failed-to-notify-resource-scanner", err)
-		}
-	}
-
-	session.Info("saved")
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if created {
-		w.WriteHeader(http.StatusCreated)
-	} else {
-		w.WriteHeader(http.StatusOK)
-	}
-
-	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+	return pipelineRef, config, warnings, true
 }
 
 // Simply validate that the credentials exist; don't do anything with the actual secrets
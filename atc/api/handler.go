@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
@@ -73,6 +74,7 @@ func NewHandler(
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	dbWall db.Wall,
+	policyChecker policychecker.PolicyChecker,
 	clock clock.Clock,
 ) (http.Handler, error) {
 
@@ -91,7 +93,7 @@ func NewHandler(
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, policyChecker)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
 	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
@@ -105,8 +107,10 @@ func NewHandler(
 	wallServer := wallserver.NewServer(dbWall, logger)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:        http.HandlerFunc(configServer.GetConfig),
+		atc.GetConfigHistory: http.HandlerFunc(configServer.GetConfigHistory),
+		atc.SaveConfig:       http.HandlerFunc(configServer.SaveConfig),
+		atc.DryRunConfig:     http.HandlerFunc(configServer.DryRunConfig),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -119,6 +123,9 @@ func NewHandler(
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.GetBuildDiff:        buildHandlerFactory.HandlerFor(buildServer.GetBuildDiff),
+		atc.ListBuildApprovals:  buildHandlerFactory.HandlerFor(buildServer.ListBuildApprovals),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -164,6 +171,7 @@ func NewHandler(
 		atc.SetPinCommentOnResource: pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
 		atc.CheckResource:           pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+		atc.TriggerResourceWebHook:  pipelineHandlerFactory.HandlerFor(resourceServer.TriggerResourceWebHook),
 		atc.CheckResourceType:       pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
@@ -210,6 +218,13 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.SavePipelineLibrary: teamHandlerFactory.HandlerFor(teamServer.SavePipelineLibrary),
+		atc.GetPipelineLibrary:  teamHandlerFactory.HandlerFor(teamServer.GetPipelineLibrary),
+
+		atc.GetPipelineSync:     teamHandlerFactory.HandlerFor(teamServer.GetPipelineSync),
+		atc.SyncPipelines:       teamHandlerFactory.HandlerFor(teamServer.SyncPipelines),
+		atc.PipelineSyncWebhook: teamHandlerFactory.HandlerFor(teamServer.PipelineSyncWebhook),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
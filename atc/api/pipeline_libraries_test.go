@@ -0,0 +1,259 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline Libraries API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("a-team")
+		dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipeline_libraries/:library_name", func() {
+		var (
+			libraryName string
+			config      string
+		)
+
+		BeforeEach(func() {
+			libraryName = "some-library"
+			config = "jobs:\n- name: ((prefix))-job\n  plan: []\n"
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", fmt.Sprintf("%s/api/v1/teams/a-team/pipeline_libraries/%s", server.URL, libraryName), bytes.NewBufferString(config))
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("Content-Type", "application/x-yaml")
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when saving the library succeeds", func() {
+				BeforeEach(func() {
+					fakeTeam.SavePipelineLibraryReturns(db.PipelineLibrary{
+						TeamName:  "a-team",
+						Name:      "some-library",
+						Version:   3,
+						Config:    []byte(config),
+						Digest:    "sha256:abc",
+						CreatedAt: time.Unix(42, 0),
+					}, nil)
+				})
+
+				It("saves the config as the library", func() {
+					Expect(fakeTeam.SavePipelineLibraryCallCount()).To(Equal(1))
+					name, savedConfig := fakeTeam.SavePipelineLibraryArgsForCall(0)
+					Expect(name).To(Equal("some-library"))
+					Expect(string(savedConfig)).To(Equal(config))
+				})
+
+				It("returns the saved version", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"name": "some-library",
+						"team_name": "a-team",
+						"version": 3,
+						"config": "jobs:\n- name: ((prefix))-job\n  plan: []\n",
+						"digest": "sha256:abc",
+						"created_at": 42
+					}`))
+				})
+			})
+
+			Context("when saving the library fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SavePipelineLibraryReturns(db.PipelineLibrary{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the config has fields which cannot be imported", func() {
+				BeforeEach(func() {
+					config = "groups: []\njobs: []\n"
+				})
+
+				It("returns 400 without saving the library", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"errors": ["only jobs, resources, resource_types may be imported, not groups"]
+					}`))
+
+					Expect(fakeTeam.SavePipelineLibraryCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the library name is not a valid identifier", func() {
+				BeforeEach(func() {
+					libraryName = "Some-Library"
+				})
+
+				It("returns 400 without saving the library", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SavePipelineLibraryCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SavePipelineLibraryCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline_libraries/:library_name", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(fmt.Sprintf("%s/api/v1/teams/a-team/pipeline_libraries/some-library%s", server.URL, query))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the library exists", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineLibraryReturns(db.PipelineLibrary{
+						TeamName:  "a-team",
+						Name:      "some-library",
+						Version:   2,
+						Config:    []byte("jobs: []\n"),
+						Digest:    "sha256:abc",
+						CreatedAt: time.Unix(42, 0),
+					}, true, nil)
+				})
+
+				It("returns the latest version", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					name, version := fakeTeam.PipelineLibraryArgsForCall(0)
+					Expect(name).To(Equal("some-library"))
+					Expect(version).To(Equal(0))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"name": "some-library",
+						"team_name": "a-team",
+						"version": 2,
+						"config": "jobs: []\n",
+						"digest": "sha256:abc",
+						"created_at": 42
+					}`))
+				})
+
+				Context("when a version is given", func() {
+					BeforeEach(func() {
+						query = "?version=1"
+					})
+
+					It("looks up that version", func() {
+						_, version := fakeTeam.PipelineLibraryArgsForCall(0)
+						Expect(version).To(Equal(1))
+					})
+				})
+
+				Context("when the version is invalid", func() {
+					BeforeEach(func() {
+						query = "?version=latest"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.PipelineLibraryCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the library does not exist", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineLibraryReturns(db.PipelineLibrary{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the library fails", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineLibraryReturns(db.PipelineLibrary{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,231 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline Sync API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("a-team")
+		fakeTeam.PipelineSyncReturns(atc.PipelineSyncConfig{
+			URI:          "https://example.com/pipelines.git",
+			Branch:       "main",
+			Paths:        "ci/*.yml",
+			WebhookToken: "some-token",
+		})
+		dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline_sync", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipeline_sync")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the statuses succeeds", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+						{
+							PipelineName:  "some-pipeline",
+							File:          "ci/some-pipeline.yml",
+							Commit:        "abcdef",
+							ConfigVersion: 3,
+							Status:        atc.PipelineSyncStatusSynced,
+							Drifted:       true,
+							SyncedAt:      time.Unix(42, 0),
+						},
+						{
+							PipelineName: "other-pipeline",
+							File:         "ci/other-pipeline.yml",
+							Status:       atc.PipelineSyncStatusErrored,
+							Error:        "failed to fetch: nope",
+							SyncedAt:     time.Unix(43, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the config without the webhook token, and the statuses", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"config": {
+							"uri": "https://example.com/pipelines.git",
+							"branch": "main",
+							"paths": "ci/*.yml"
+						},
+						"pipelines": [
+							{
+								"pipeline": "some-pipeline",
+								"file": "ci/some-pipeline.yml",
+								"commit": "abcdef",
+								"status": "synced",
+								"drifted": true,
+								"synced_at": 42
+							},
+							{
+								"pipeline": "other-pipeline",
+								"file": "ci/other-pipeline.yml",
+								"status": "errored",
+								"error": "failed to fetch: nope",
+								"synced_at": 43
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when getting the statuses fails", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineSyncStatusesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the team does not sync its pipelines", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineSyncReturns(atc.PipelineSyncConfig{})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipeline_sync/sync", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/pipeline_sync/sync", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("notifies the pipeline syncer", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+				Expect(dbTeamFactory.NotifyPipelineSyncerCallCount()).To(Equal(1))
+			})
+
+			Context("when notifying fails", func() {
+				BeforeEach(func() {
+					dbTeamFactory.NotifyPipelineSyncerReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeamFactory.NotifyPipelineSyncerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipeline_sync/webhook", func() {
+		var token string
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(fmt.Sprintf("%s/api/v1/teams/a-team/pipeline_sync/webhook?webhook_token=%s", server.URL, token), "", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the token matches", func() {
+			BeforeEach(func() {
+				token = "some-token"
+			})
+
+			It("notifies the pipeline syncer without authentication", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+				Expect(dbTeamFactory.NotifyPipelineSyncerCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the token does not match", func() {
+			BeforeEach(func() {
+				token = "bogus-token"
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeamFactory.NotifyPipelineSyncerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the team has no webhook token", func() {
+			BeforeEach(func() {
+				token = "some-token"
+				fakeTeam.PipelineSyncReturns(atc.PipelineSyncConfig{
+					URI:   "https://example.com/pipelines.git",
+					Paths: "ci/*.yml",
+				})
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when no token is given", func() {
+			BeforeEach(func() {
+				token = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -30,6 +30,9 @@ func Build(build db.Build) atc.Build {
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
 		CreatedBy:            build.CreatedBy(),
+		ParentBuildID:        build.ParentBuildID(),
+		MatrixValues:         build.MatrixValues(),
+		Reason:               build.Reason(),
 	}
 
 	if build.RerunOf() != 0 {
@@ -48,7 +51,9 @@ func Build(build db.Build) atc.Build {
 		atcBuild.EndTime = build.EndTime().Unix()
 	}
 
-	if !build.ReapTime().IsZero() {
+	// the events of archived builds can still be read back, so they are not
+	// presented as reaped
+	if !build.ReapTime().IsZero() && !build.EventsArchived() {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
@@ -0,0 +1,22 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildApprovals(approvals []db.BuildApproval, stepNames map[atc.PlanID]string) []atc.BuildApproval {
+	presented := []atc.BuildApproval{}
+	for _, approval := range approvals {
+		presented = append(presented, atc.BuildApproval{
+			PlanID:   approval.PlanID,
+			StepName: stepNames[approval.PlanID],
+			UserName: approval.UserName,
+			Approved: approval.Approved,
+			Comment:  approval.Comment,
+			Time:     approval.CreatedAt.Unix(),
+		})
+	}
+
+	return presented
+}
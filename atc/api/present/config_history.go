@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func ConfigHistoryEntry(entry db.PipelineConfigVersion) atc.ConfigHistoryEntry {
+	return atc.ConfigHistoryEntry{
+		Version:   int(entry.Version),
+		Author:    entry.Author,
+		CreatedAt: entry.CreatedAt.Unix(),
+		Config:    entry.Config,
+	}
+}
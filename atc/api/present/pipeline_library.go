@@ -0,0 +1,17 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PipelineLibrary(library db.PipelineLibrary) atc.PipelineLibrary {
+	return atc.PipelineLibrary{
+		Name:      library.Name,
+		TeamName:  library.TeamName,
+		Version:   library.Version,
+		Config:    string(library.Config),
+		Digest:    library.Digest,
+		CreatedAt: library.CreatedAt.Unix(),
+	}
+}
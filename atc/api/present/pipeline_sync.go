@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PipelineSyncStatus(status db.PipelineSyncStatus) atc.PipelineSyncStatus {
+	return atc.PipelineSyncStatus{
+		Pipeline: status.PipelineName,
+		File:     status.File,
+		Commit:   status.Commit,
+		Status:   status.Status,
+		Drifted:  status.Drifted,
+		Error:    status.Error,
+		SyncedAt: status.SyncedAt.Unix(),
+	}
+}
@@ -6,9 +6,20 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	atcTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if quotas := team.Quotas(); !quotas.IsZero() {
+		atcTeam.Quotas = &quotas
+	}
+
+	if pipelineSync := team.PipelineSync(); !pipelineSync.IsZero() {
+		atcTeam.PipelineSync = &pipelineSync
+		atcTeam.PipelineSync.WebhookToken = ""
+	}
+
+	return atcTeam
 }
@@ -40,6 +40,9 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Rootless:         workerInfo.Rootless(),
+		RuntimeClasses:   workerInfo.RuntimeClasses(),
+		NetworkPolicies:  workerInfo.NetworkPolicies(),
 	}
 
 	if !workerInfo.StartTime().IsZero() {
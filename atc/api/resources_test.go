@@ -1608,4 +1608,184 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/trigger/webhook", func() {
+		var (
+			payload      string
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			payload = `{"ref":"refs/heads/master","head_commit":{"id":"abc123"}}`
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+			fakeResource.IDReturns(10)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/trigger/webhook?webhook_token=fake-token", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			var (
+				triggeredJob   *dbfakes.FakeJob
+				untriggeredJob *dbfakes.FakeJob
+				fakeBuild      *dbfakes.FakeBuild
+			)
+
+			BeforeEach(func() {
+				fakeResource.WebhookTokenReturns("fake-token")
+				fakeResource.ConfigReturns(atc.ResourceConfig{
+					Name:         "resource-name",
+					WebhookToken: "fake-token",
+					WebhookFilter: &atc.WebhookFilter{
+						Match: []atc.WebhookMatch{
+							{Path: ".ref", Patterns: []string{"refs/heads/master"}},
+						},
+						Vars: map[string]string{
+							"commit": ".head_commit.id",
+						},
+					},
+				})
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+
+				fakeBuild = new(dbfakes.FakeBuild)
+				fakeBuild.IDReturns(42)
+				fakeBuild.NameReturns("1")
+				fakeBuild.JobNameReturns("triggered-job")
+				fakeBuild.TeamNameReturns("a-team")
+				fakeBuild.StatusReturns(db.BuildStatusPending)
+
+				triggeredJob = new(dbfakes.FakeJob)
+				triggeredJob.NameReturns("triggered-job")
+				triggeredJob.InputsReturns([]atc.JobInput{
+					{Name: "some-input", Resource: "resource-name", Trigger: true},
+				}, nil)
+				triggeredJob.CreateBuildWithVarsReturns(fakeBuild, nil)
+
+				untriggeredJob = new(dbfakes.FakeJob)
+				untriggeredJob.NameReturns("untriggered-job")
+				untriggeredJob.InputsReturns([]atc.JobInput{
+					{Name: "some-input", Resource: "resource-name", Trigger: false},
+				}, nil)
+
+				fakePipeline.JobsReturns(db.Jobs{triggeredJob, untriggeredJob}, nil)
+			})
+
+			It("returns 201 with the created builds", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[{
+					"id": 42,
+					"name": "1",
+					"job_name": "triggered-job",
+					"team_name": "a-team",
+					"status": "pending",
+					"api_url": "/api/v1/builds/42"
+				}]`))
+			})
+
+			It("creates builds of the jobs triggered by the resource with the payload vars", func() {
+				Expect(triggeredJob.CreateBuildWithVarsCallCount()).To(Equal(1))
+				createdBy, buildVars := triggeredJob.CreateBuildWithVarsArgsForCall(0)
+				Expect(createdBy).To(Equal("webhook"))
+				Expect(buildVars).To(Equal(vars.StaticVariables{"commit": "abc123"}))
+
+				Expect(untriggeredJob.CreateBuildWithVarsCallCount()).To(BeZero())
+			})
+
+			It("checks the inputs of the triggered jobs", func() {
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				_, actualResource, _, _, manuallyTriggered := dbCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(actualResource).To(Equal(fakeResource))
+				Expect(manuallyTriggered).To(BeTrue())
+			})
+
+			Context("when the triggered job is paused", func() {
+				BeforeEach(func() {
+					triggeredJob.PausedReturns(true)
+				})
+
+				It("does not create a build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(triggeredJob.CreateBuildWithVarsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the payload does not match the filter", func() {
+				BeforeEach(func() {
+					payload = `{"ref":"refs/heads/docs"}`
+				})
+
+				It("returns 204 without creating builds", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(triggeredJob.CreateBuildWithVarsCallCount()).To(BeZero())
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the payload is not valid JSON", func() {
+				BeforeEach(func() {
+					payload = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when creating a build fails", func() {
+				BeforeEach(func() {
+					triggeredJob.CreateBuildWithVarsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the jobs fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourceReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeResource.WebhookTokenReturns("wrong-token")
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not log the token", func() {
+				Expect(logger.LogMessages()).To(ContainElement("api.trigger-resource-webhook.invalid-token"))
+				Expect(string(logger.Buffer().Contents())).ToNot(ContainSubstring("fake-token"))
+			})
+		})
+	})
 })
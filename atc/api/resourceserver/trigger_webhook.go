@@ -0,0 +1,220 @@
+package resourceserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/webhook"
+	"github.com/tedsuo/rata"
+)
+
+// TriggerResourceWebHook defines a handler which matches a webhook payload
+// against the resource's webhook filter and, if it matches, creates a build
+// of every job which is triggered by the resource. Fields of the payload are
+// exposed to the builds as vars.
+//
+// The payload does not determine the versions of the builds' inputs. The
+// builds are created like manually triggered builds: a check of their inputs
+// is queued, and they are scheduled with the latest versions once the check
+// has finished. A payload about a version which the check doesn't find (for
+// example, a commit which has since been superseded) still results in builds
+// of the latest versions, with the vars of that payload.
+func (s *Server) TriggerResourceWebHook(dbPipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		logger := s.logger.Session("trigger-resource-webhook", lager.Data{
+			"resource": resourceName,
+		})
+
+		if webhookToken == "" {
+			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		variables, err := dbPipeline.Variables(logger, s.secretManager, s.varSourcePool)
+		if err != nil {
+			logger.Error("failed-to-create-var-sources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
+		if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if token != webhookToken {
+			logger.Info("invalid-token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var payload interface{}
+		err = json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			logger.Info("malformed-payload", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		filter, err := webhook.NewFilter(dbResource.Config().WebhookFilter)
+		if err != nil {
+			logger.Error("failed-to-parse-webhook-filter", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		matches, err := filter.Matches(payload)
+		if err != nil {
+			logger.Error("failed-to-match-payload", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !matches {
+			logger.Debug("payload-filtered")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		buildVars, err := filter.Vars(payload)
+		if err != nil {
+			logger.Error("failed-to-extract-vars", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobs, err := dbPipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var triggeredJobs []db.Job
+		var jobInputs []atc.JobInput
+		for _, job := range jobs {
+			if job.Paused() {
+				continue
+			}
+
+			inputs, err := job.Inputs()
+			if err != nil {
+				logger.Error("failed-to-get-job-inputs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if isTriggeredBy(inputs, resourceName) {
+				triggeredJobs = append(triggeredJobs, job)
+				jobInputs = append(jobInputs, inputs...)
+			}
+		}
+
+		builds := []atc.Build{}
+		for _, job := range triggeredJobs {
+			build, err := job.CreateBuildWithVars("webhook", buildVars)
+			if err != nil {
+				logger.Error("failed-to-create-job-build", err, lager.Data{"job": job.Name()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			builds = append(builds, present.Build(build))
+		}
+
+		err = s.checkInputs(logger, dbPipeline, jobInputs)
+		if err != nil {
+			logger.Error("failed-to-check-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(builds)
+		if err != nil {
+			logger.Error("failed-to-encode-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// checkInputs queues a check for every resource used as an input so that the
+// manually triggered builds can determine their inputs as soon as possible.
+func (s *Server) checkInputs(logger lager.Logger, dbPipeline db.Pipeline, inputs []atc.JobInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	resources, err := dbPipeline.Resources()
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := dbPipeline.ResourceTypes()
+	if err != nil {
+		return err
+	}
+
+	checked := map[string]bool{}
+	for _, input := range inputs {
+		if checked[input.Resource] {
+			continue
+		}
+
+		checked[input.Resource] = true
+
+		resource, found := resources.Lookup(input.Resource)
+		if !found {
+			continue
+		}
+
+		_, _, err := s.checkFactory.TryCreateCheck(
+			lagerctx.NewContext(context.Background(), logger),
+			resource,
+			resourceTypes,
+			resource.CurrentPinnedVersion(),
+			true,
+		)
+		if err != nil {
+			logger.Error("failed-to-create-check", err, lager.Data{"input": input.Resource})
+		}
+	}
+
+	return nil
+}
+
+func isTriggeredBy(inputs []atc.JobInput, resourceName string) bool {
+	for _, input := range inputs {
+		if input.Resource == resourceName && input.Trigger && len(input.Passed) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
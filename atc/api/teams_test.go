@@ -314,12 +314,77 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team exists and quotas are given", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Auth:   teamAuth,
+						Quotas: &atc.TeamQuotas{MaxConcurrentBuilds: 2, MaxContainers: 10},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the quotas", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateQuotasArgsForCall(0)).To(Equal(atc.TeamQuotas{
+						MaxConcurrentBuilds: 2,
+						MaxContainers:       10,
+					}))
+				})
+
+				Context("when updating the quotas fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateQuotasReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the quotas are invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Quotas = &atc.TeamQuotas{MaxVolumes: -1}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
 					dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
 				})
 
+				Context("when quotas are given", func() {
+					BeforeEach(func() {
+						atcTeam.Quotas = &atc.TeamQuotas{MaxVolumes: 20}
+						fakeTeam.QuotasReturns(atc.TeamQuotas{MaxVolumes: 20})
+					})
+
+					It("creates the team with the quotas", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						createdTeam := dbTeamFactory.CreateTeamArgsForCall(0)
+						Expect(createdTeam.Quotas).To(Equal(&atc.TeamQuotas{MaxVolumes: 20}))
+					})
+
+					It("returns the quotas in the response body", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+								{
+									"team": {
+										"id": 5,
+										"name": "some-team",
+										"quotas": {"max_volumes": 20}
+									}
+								}`))
+					})
+				})
+
 				It("creates the team", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusCreated))
 					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(1))
@@ -393,6 +458,107 @@ var _ = Describe("Teams API", func() {
 					Expect(dbTeamFactory.CreateTeamCallCount()).To(Equal(0))
 				})
 			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotasReturns(atc.TeamQuotas{MaxConcurrentBuilds: 2})
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				Context("when the quotas are changed", func() {
+					BeforeEach(func() {
+						atcTeam.Quotas = &atc.TeamQuotas{MaxConcurrentBuilds: 20}
+					})
+
+					It("returns 403 Forbidden and does not update the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the quotas are unchanged", func() {
+					BeforeEach(func() {
+						atcTeam.Quotas = &atc.TeamQuotas{MaxConcurrentBuilds: 2}
+					})
+
+					It("updates the team without updating the quotas", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a pipeline sync is given", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineSync = &atc.PipelineSyncConfig{
+							URI:   "https://example.com/pipelines.git",
+							Paths: "ci/*.yml",
+						}
+						fakeTeam.PipelineSyncStub = func() atc.PipelineSyncConfig {
+							if fakeTeam.UpdatePipelineSyncCallCount() == 0 {
+								return atc.PipelineSyncConfig{}
+							}
+
+							return fakeTeam.UpdatePipelineSyncArgsForCall(0)
+						}
+					})
+
+					It("updates the pipeline sync and notifies the syncer", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdatePipelineSyncCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdatePipelineSyncArgsForCall(0)).To(Equal(atc.PipelineSyncConfig{
+							URI:   "https://example.com/pipelines.git",
+							Paths: "ci/*.yml",
+						}))
+						Expect(dbTeamFactory.NotifyPipelineSyncerCallCount()).To(Equal(1))
+					})
+
+					Context("when updating the pipeline sync fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdatePipelineSyncReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the pipeline sync is invalid", func() {
+						BeforeEach(func() {
+							atcTeam.PipelineSync = &atc.PipelineSyncConfig{URI: "https://example.com/pipelines.git"}
+						})
+
+						It("returns 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeTeam.UpdatePipelineSyncCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when an empty pipeline sync is given", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineSync = &atc.PipelineSyncConfig{}
+						fakeTeam.PipelineSyncReturns(atc.PipelineSyncConfig{
+							URI:   "https://example.com/pipelines.git",
+							Paths: "ci/*.yml",
+						})
+					})
+
+					It("removes the pipeline sync", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdatePipelineSyncCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdatePipelineSyncArgsForCall(0)).To(BeZero())
+					})
+				})
+
+				Context("when the pipeline sync is omitted", func() {
+					It("leaves it as it is", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdatePipelineSyncCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,104 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SavePipelineLibrary(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("save-pipeline-library")
+
+		libraryName := r.FormValue(":library_name")
+
+		config, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// libraries are new, so unlike pipelines there are no existing names
+		// to stay compatible with and invalid identifiers are rejected outright
+		var errs []string
+		warning, err := atc.ValidateIdentifier(libraryName, "pipeline library")
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if warning != nil {
+			errs = append(errs, warning.Message)
+		}
+
+		err = atc.ValidatePipelineLibrary(config)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+
+		if len(errs) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Errors: errs})
+			if err != nil {
+				logger.Error("failed-to-encode-response", err)
+			}
+			return
+		}
+
+		library, err := team.SavePipelineLibrary(libraryName, config)
+		if err != nil {
+			logger.Error("failed-to-save-pipeline-library", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(present.PipelineLibrary(library))
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-library", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetPipelineLibrary(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-pipeline-library")
+
+		libraryName := r.FormValue(":library_name")
+
+		var version int
+		if urlVersion := r.FormValue("version"); urlVersion != "" {
+			var err error
+			version, err = strconv.Atoi(urlVersion)
+			if err != nil || version < 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		library, found, err := team.PipelineLibrary(libraryName, version)
+		if err != nil {
+			logger.Error("failed-to-get-pipeline-library", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(present.PipelineLibrary(library))
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-library", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
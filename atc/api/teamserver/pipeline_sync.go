@@ -0,0 +1,103 @@
+package teamserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetPipelineSync(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-pipeline-sync")
+
+		config := team.PipelineSync()
+		if config.IsZero() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		statuses, err := team.PipelineSyncStatuses()
+		if err != nil {
+			logger.Error("failed-to-get-pipeline-sync-statuses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the token is a secret shared with the git host only
+		config.WebhookToken = ""
+
+		response := atc.PipelineSyncResponse{
+			Config:    config,
+			Pipelines: []atc.PipelineSyncStatus{},
+		}
+
+		for _, status := range statuses {
+			response.Pipelines = append(response.Pipelines, present.PipelineSyncStatus(status))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-sync", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SyncPipelines(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("sync-pipelines")
+
+		if team.PipelineSync().IsZero() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err := s.teamFactory.NotifyPipelineSyncer()
+		if err != nil {
+			logger.Error("failed-to-notify-pipeline-syncer", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func (s *Server) PipelineSyncWebhook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("pipeline-sync-webhook")
+
+		webhookToken := r.URL.Query().Get("webhook_token")
+		if webhookToken == "" {
+			logger.Info("no-webhook-token")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		config := team.PipelineSync()
+		if config.IsZero() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if config.WebhookToken == "" || subtle.ConstantTimeCompare([]byte(config.WebhookToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		err := s.teamFactory.NotifyPipelineSyncer()
+		if err != nil {
+			logger.Error("failed-to-notify-pipeline-syncer", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
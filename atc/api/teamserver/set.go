@@ -51,6 +51,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	response := SetTeamResponse{}
 	if found {
+		// quotas are left as they are when omitted, and may only be changed
+		// by admins so that teams cannot lift their own limits
+		quotasChanged := atcTeam.Quotas != nil && *atcTeam.Quotas != team.Quotas()
+		if quotasChanged && !acc.IsAdmin() {
+			hLog.Info("non-admin-cannot-change-quotas", lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("updating-credentials")
 		err = team.UpdateProviderAuth(atcTeam.Auth)
 		if err != nil {
@@ -59,6 +68,28 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if quotasChanged {
+			hLog.Debug("updating-quotas")
+			err = team.UpdateQuotas(*atcTeam.Quotas)
+			if err != nil {
+				hLog.Error("failed-to-update-team-quotas", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// the pipeline sync is also left as it is when omitted, and removed
+		// when it is empty
+		if atcTeam.PipelineSync != nil && *atcTeam.PipelineSync != team.PipelineSync() {
+			hLog.Debug("updating-pipeline-sync")
+			err = team.UpdatePipelineSync(*atcTeam.PipelineSync)
+			if err != nil {
+				hLog.Error("failed-to-update-team-pipeline-sync", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
@@ -92,6 +123,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !team.PipelineSync().IsZero() {
+		err = s.teamFactory.NotifyPipelineSyncer()
+		if err != nil {
+			hLog.Error("failed-to-notify-pipeline-syncer", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
 	response.Team = present.Team(team)
 
 	err = json.NewEncoder(w).Encode(response)
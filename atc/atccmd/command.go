@@ -13,6 +13,7 @@ import (
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -42,6 +43,7 @@ import (
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/pipelinesync"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -49,6 +51,7 @@ import (
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/remotecache"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal/dexserver"
 	"github.com/concourse/concourse/skymarshal/legacyserver"
@@ -148,6 +151,10 @@ type RunCommand struct {
 
 	LidarScannerInterval time.Duration `long:"lidar-scanner-interval" default:"10s" description:"Interval on which the resource scanner will run to see if new checks need to be scheduled"`
 
+	PipelineSyncInterval time.Duration `long:"pipeline-sync-interval" default:"1m" description:"Interval on which teams' pipelines are synced from their git repositories."`
+	PipelineSyncTimeout  time.Duration `long:"pipeline-sync-timeout" default:"5m" description:"Time limit on syncing a single team's pipelines, including fetching its git repository."`
+	PipelineSyncDir      string        `long:"pipeline-sync-dir" description:"Directory in which to keep clones of teams' pipeline repositories. Defaults to a directory in the system's temporary directory."`
+
 	GlobalResourceCheckTimeout          time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
@@ -172,6 +179,10 @@ type RunCommand struct {
 
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
 
+	RemoteCache remotecache.Config `group:"Remote Cache" namespace:"remote-cache"`
+
+	BuildEventArchive remotecache.Config `group:"Build Event Archive" namespace:"build-event-archive"`
+
 	PolicyCheckers struct {
 		Filter policy.Filter
 	} `group:"Policy Checking"`
@@ -245,7 +256,7 @@ type RunCommand struct {
 
 	FeatureFlags struct {
 		EnableGlobalResources                bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
-		EnableRedactSecrets                  bool `long:"enable-redact-secrets" description:"Enable redacting secrets in build logs."`
+		EnableRedactSecrets                  bool `long:"enable-redact-secrets" description:"Enable redacting secrets, including common encodings of them, from build logs and events."`
 		EnableBuildRerunWhenWorkerDisappears bool `long:"enable-rerun-when-worker-disappears" description:"Enable automatically build rerun when worker disappears or a network error occurs"`
 		EnableAcrossStep                     bool `long:"enable-across-step" description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
 		EnablePipelineInstances              bool `long:"enable-pipeline-instances" description:"Enable pipeline instances"`
@@ -575,6 +586,11 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
 
+	buildEventArchive, err := cmd.BuildEventArchive.RemoteCache()
+	if err != nil {
+		return nil, err
+	}
+
 	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.APIMaxOpenConnections, cmd.APIMaxOpenConnections/2, "api", lockFactory)
 	if err != nil {
 		return nil, err
@@ -585,6 +601,9 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	apiConn = db.WithBuildEventArchive(apiConn, buildEventArchive)
+	backendConn = db.WithBuildEventArchive(backendConn, buildEventArchive)
+
 	gcConn, err := cmd.constructDBConn(retryingDriverName, logger, 5, 2, "gc", lockFactory)
 	if err != nil {
 		return nil, err
@@ -767,8 +786,13 @@ func (cmd *RunCommand) constructAPIMembers(
 
 	userFactory := db.NewUserFactory(dbConn)
 
+	remoteCache, err := cmd.RemoteCache.RemoteCache()
+	if err != nil {
+		return nil, err
+	}
+
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
-	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
+	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory, remoteCache)
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
@@ -795,12 +819,13 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbVolumeRepository,
 		teamFactory,
 		dbWorkerFactory,
+		remoteCache,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, teamFactory)
 
 	credsManagers := cmd.CredentialManagers
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -1007,8 +1032,13 @@ func (cmd *RunCommand) backendComponents(
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 
 	resourceFactory := resource.NewResourceFactory()
+	remoteCache, err := cmd.RemoteCache.RemoteCache()
+	if err != nil {
+		return nil, err
+	}
+
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
-	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
+	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory, remoteCache)
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
@@ -1053,12 +1083,13 @@ func (cmd *RunCommand) backendComponents(
 		dbVolumeRepository,
 		teamFactory,
 		dbWorkerFactory,
+		remoteCache,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, teamFactory)
 	artifactStreamer := worker.NewArtifactStreamer(pool, compressionLib)
 	artifactSourcer := worker.NewArtifactSourcer(compressionLib, pool, cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout, dbResourceCacheFactory)
 
@@ -1120,6 +1151,18 @@ func (cmd *RunCommand) backendComponents(
 			},
 			Runnable: lidar.NewScanner(dbCheckFactory),
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentPipelineSyncer,
+				Interval: cmd.PipelineSyncInterval,
+			},
+			Runnable: pipelinesync.NewSyncer(
+				teamFactory,
+				pipelinesync.GitFetcher{Dir: cmd.pipelineSyncDir()},
+				policyChecker,
+				cmd.PipelineSyncTimeout,
+			),
+		},
 		{
 			Component: atc.Component{
 				Name:     atc.ComponentScheduler,
@@ -1134,7 +1177,8 @@ func (cmd *RunCommand) backendComponents(
 						builds.NewPlanner(
 							atc.NewPlanFactory(time.Now().Unix()),
 						),
-						alg),
+						alg,
+					),
 				},
 				cmd.JobSchedulingMaxInFlight,
 			),
@@ -1162,6 +1206,7 @@ func (cmd *RunCommand) backendComponents(
 					cmd.MaxDaysToRetainBuildLogs,
 				),
 				syslogDrainConfigured,
+				dbConn.BuildEventArchive(),
 			),
 		},
 	}
@@ -1185,6 +1230,14 @@ func (cmd *RunCommand) backendComponents(
 	return components, err
 }
 
+func (cmd *RunCommand) pipelineSyncDir() string {
+	if cmd.PipelineSyncDir != "" {
+		return cmd.PipelineSyncDir
+	}
+
+	return filepath.Join(os.TempDir(), "concourse-pipeline-sync")
+}
+
 func (cmd *RunCommand) gcComponents(
 	logger lager.Logger,
 	gcConn db.Conn,
@@ -1227,6 +1280,19 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
 	}
 
+	remoteCache, err := cmd.RemoteCache.RemoteCache()
+	if err != nil {
+		return nil, err
+	}
+
+	if remoteCache != nil {
+		collectors[atc.ComponentCollectorRemoteCaches] = gc.NewRemoteCacheCollector(
+			remoteCache,
+			db.NewTaskCacheFactory(gcConn),
+			db.NewResourceCacheFactory(gcConn, lockFactory),
+		)
+	}
+
 	var components []RunnableComponent
 	for collectorName, collector := range collectors {
 		components = append(components, RunnableComponent{
@@ -1902,13 +1968,15 @@ func (cmd *RunCommand) constructAPIHandler(
 		return nil, err
 	}
 
+	apiPolicyChecker := policychecker.NewApiPolicyChecker(policyChecker)
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewConcurrentRequestLimitsWrappa(
 			logger,
 			wrappa.NewConcurrentRequestPolicy(cmd.ConcurrentRequestLimits),
 		),
 		wrappa.NewAPIMetricsWrappa(logger),
-		wrappa.NewPolicyCheckWrappa(logger, policychecker.NewApiPolicyChecker(policyChecker)),
+		wrappa.NewPolicyCheckWrappa(logger, apiPolicyChecker),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1963,6 +2031,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
+		apiPolicyChecker,
 		clock.NewClock(),
 	)
 }
@@ -65,6 +65,9 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.BuildResources,
 		atc.AbortBuild,
 		atc.GetBuildPreparation,
+		atc.GetBuildDiff,
+		atc.ListBuildApprovals,
+		atc.ApproveBuild,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
@@ -114,6 +117,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.SetPinCommentOnResource,
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
+		atc.TriggerResourceWebHook,
 		atc.CheckResourceType,
 		atc.ListResourceVersions,
 		atc.GetResourceVersion,
@@ -124,7 +128,9 @@ func (a *auditor) ValidateAction(action string) bool {
 		return a.EnableResourceAuditLog
 	case
 		atc.SaveConfig,
+		atc.DryRunConfig,
 		atc.GetConfig,
+		atc.GetConfigHistory,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -144,6 +150,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.SavePipelineLibrary,
+		atc.GetPipelineLibrary,
+		atc.GetPipelineSync,
+		atc.SyncPipelines,
+		atc.PipelineSyncWebhook,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
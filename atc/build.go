@@ -32,6 +32,9 @@ type Build struct {
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
+	ParentBuildID        int           `json:"parent_build_id,omitempty"`
+	MatrixValues         MatrixValues  `json:"matrix_values,omitempty"`
+	Reason               string        `json:"reason,omitempty"`
 }
 
 type RerunOfBuild struct {
@@ -0,0 +1,20 @@
+package atc
+
+type BuildApproval struct {
+	PlanID   PlanID `json:"plan_id"`
+	StepName string `json:"step_name,omitempty"`
+	UserName string `json:"user_name"`
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+	Time     int64  `json:"time"`
+}
+
+// BuildApprovalRequest approves or rejects an approve step of a build. The
+// step is identified either by its plan ID or by its name; the name may be
+// omitted if the build has a single approve step.
+type BuildApprovalRequest struct {
+	PlanID   PlanID `json:"plan_id,omitempty"`
+	StepName string `json:"step_name,omitempty"`
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+}
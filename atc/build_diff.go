@@ -0,0 +1,51 @@
+package atc
+
+import "encoding/json"
+
+// BuildDiff describes what changed between two builds. Only the entries
+// which differ between the two builds are included.
+//
+// Steps are identified by their type and name, e.g. `task:unit`, with a
+// `#<n>` suffix if the same step appears more than once in the plan.
+type BuildDiff struct {
+	From Build `json:"from"`
+	To   Build `json:"to"`
+
+	Inputs    []BuildVersionDiff  `json:"inputs"`
+	Plan      []BuildStepDiff     `json:"plan"`
+	Tasks     []BuildStepDiff     `json:"tasks"`
+	Images    []BuildVersionDiff  `json:"images"`
+	Workers   []BuildWorkerDiff   `json:"workers"`
+	Durations []BuildDurationDiff `json:"durations"`
+}
+
+// BuildVersionDiff describes an input or image whose version changed. A nil
+// version means the input or image was not present in that build.
+type BuildVersionDiff struct {
+	Name string  `json:"name"`
+	From Version `json:"from,omitempty"`
+	To   Version `json:"to,omitempty"`
+}
+
+// BuildStepDiff describes a step whose public plan or task config changed. A
+// nil value means the step was not present in that build.
+type BuildStepDiff struct {
+	Step string           `json:"step"`
+	From *json.RawMessage `json:"from,omitempty"`
+	To   *json.RawMessage `json:"to,omitempty"`
+}
+
+// BuildWorkerDiff describes a step which ran on a different worker.
+type BuildWorkerDiff struct {
+	Step string `json:"step"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// BuildDurationDiff describes a step whose duration, in seconds, changed. A
+// nil duration means the step did not run to completion in that build.
+type BuildDurationDiff struct {
+	Step string `json:"step"`
+	From *int64 `json:"from,omitempty"`
+	To   *int64 `json:"to,omitempty"`
+}
@@ -37,6 +37,71 @@ func (planner Planner) Create(
 	return visitor.plan, nil
 }
 
+// OneOffOptions configures how a job's steps are planned when they are run in
+// a one-off build, outside of their pipeline.
+type OneOffOptions struct {
+	// Artifacts maps the names of get steps to uploaded artifacts which are
+	// used in place of fetching the resource.
+	Artifacts map[string]int
+
+	// DryRun skips put steps, along with the get steps implicitly following
+	// them, so that nothing is pushed to the resources.
+	DryRun bool
+}
+
+// CreateOneOff creates a plan for running the steps in a one-off build. As
+// there are no versions from the pipeline to use, get steps which are not
+// given an artifact fetch the version pinned in their config, or otherwise
+// check for the latest version of the resource.
+func (planner Planner) CreateOneOff(
+	planConfig atc.StepConfig,
+	resources db.SchedulerResources,
+	resourceTypes atc.VersionedResourceTypes,
+	options OneOffOptions,
+) (atc.Plan, error) {
+	visitor := &planVisitor{
+		planFactory: planner.planFactory,
+
+		resources:     resources,
+		resourceTypes: resourceTypes,
+		oneOff:        &options,
+	}
+
+	err := planConfig.Visit(visitor)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return visitor.plan, nil
+}
+
+// CreateMatrix creates a MatrixPlan containing a plan for each combination of
+// the job's matrix vars.
+func (planner Planner) CreateMatrix(
+	config atc.JobConfig,
+	resources db.SchedulerResources,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	matrixPlan := atc.MatrixPlan{
+		Builds: []atc.MatrixBuildPlan{},
+	}
+
+	for _, values := range config.MatrixCombinations() {
+		plan, err := planner.Create(config.MatrixStepConfig(values), resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		matrixPlan.Builds = append(matrixPlan.Builds, atc.MatrixBuildPlan{
+			Values: values,
+			Plan:   plan,
+		})
+	}
+
+	return planner.planFactory.NewPlan(matrixPlan), nil
+}
+
 type planVisitor struct {
 	planFactory atc.PlanFactory
 
@@ -44,6 +109,9 @@ type planVisitor struct {
 	resourceTypes atc.VersionedResourceTypes
 	inputs        []db.BuildInput
 
+	// set when planning a one-off build, in which case inputs are not used
+	oneOff *OneOffOptions
+
 	plan atc.Plan
 }
 
@@ -61,6 +129,8 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		RuntimeClass:      step.RuntimeClass,
+		Network:           step.Network,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -79,6 +149,11 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 		return UnknownResourceError{resourceName}
 	}
 
+	if visitor.oneOff != nil {
+		visitor.visitOneOffGet(step, resourceName, resource)
+		return nil
+	}
+
 	var version atc.Version
 	for _, input := range visitor.inputs {
 		if input.Name == step.Name {
@@ -110,6 +185,57 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) visitOneOffGet(step *atc.GetStep, resourceName string, resource *db.SchedulerResource) {
+	if artifactID, found := visitor.oneOff.Artifacts[step.Name]; found {
+		visitor.plan = visitor.planFactory.NewPlan(atc.ArtifactInputPlan{
+			ArtifactID: artifactID,
+			Name:       step.Name,
+		})
+
+		return
+	}
+
+	resource.ApplySourceDefaults(visitor.resourceTypes)
+
+	getPlan := atc.GetPlan{
+		Name: step.Name,
+
+		Type:     resource.Type,
+		Resource: resourceName,
+		Source:   resource.Source,
+		Params:   step.Params,
+		Tags:     step.Tags,
+		Timeout:  step.Timeout,
+
+		VersionedResourceTypes: visitor.resourceTypes,
+	}
+
+	if step.Version != nil && step.Version.Pinned != nil {
+		version := step.Version.Pinned
+		getPlan.Version = &version
+
+		visitor.plan = visitor.planFactory.NewPlan(getPlan)
+		return
+	}
+
+	checkPlan := visitor.planFactory.NewPlan(atc.CheckPlan{
+		Name:    step.Name,
+		Type:    resource.Type,
+		Source:  resource.Source,
+		Tags:    step.Tags,
+		Timeout: step.Timeout,
+
+		VersionedResourceTypes: visitor.resourceTypes,
+	})
+
+	getPlan.VersionFrom = &checkPlan.ID
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.OnSuccessPlan{
+		Step: checkPlan,
+		Next: visitor.planFactory.NewPlan(getPlan),
+	})
+}
+
 func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 	logicalName := step.Name
 
@@ -123,6 +249,11 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		return UnknownResourceError{resourceName}
 	}
 
+	if visitor.oneOff != nil && visitor.oneOff.DryRun {
+		visitor.plan = visitor.planFactory.NewPlan(atc.DoPlan{})
+		return nil
+	}
+
 	resource.ApplySourceDefaults(visitor.resourceTypes)
 
 	atcPutPlan := atc.PutPlan{
@@ -252,6 +383,7 @@ func (visitor *planVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
 		Vars:         step.Vars,
 		VarFiles:     step.VarFiles,
 		InstanceVars: step.InstanceVars,
+		DryRun:       step.DryRun,
 	})
 
 	return nil
@@ -268,6 +400,21 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitApprove(step *atc.ApproveStep) error {
+	required := step.Required
+	if required == 0 {
+		required = 1
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovePlan{
+		Name:      step.Name,
+		Approvers: step.Approvers,
+		Required:  required,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
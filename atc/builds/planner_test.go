@@ -243,6 +243,8 @@ var factoryTests = []PlannerTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			RuntimeClass:      "runsc",
+			Network:           &atc.NetworkConfig{Allow: []string{"10.0.0.0/8"}},
 		},
 
 		PlanJSON: `{
@@ -250,6 +252,8 @@ var factoryTests = []PlannerTest{
 			"task": {
 				"name": "some-task",
 				"privileged": true,
+				"runtime_class": "runsc",
+				"network": {"allow": ["10.0.0.0/8"]},
 				"config": {
 					"platform": "linux",
 					"run": {"path": "hello"}
@@ -337,6 +341,7 @@ var factoryTests = []PlannerTest{
 			Vars:         atc.Params{"some": "vars"},
 			VarFiles:     []string{"file-1", "file-2"},
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
 		},
 
 		PlanJSON: `{
@@ -346,7 +351,8 @@ var factoryTests = []PlannerTest{
 				"file": "some-pipeline-file",
 				"vars": {"some": "vars"},
 				"var_files": ["file-1", "file-2"],
-				"instance_vars": {"branch": "feature/foo"}
+				"instance_vars": {"branch": "feature/foo"},
+				"dry_run": true
 			}
 		}`,
 	},
@@ -370,6 +376,45 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "approve step",
+
+		Config: &atc.ApproveStep{
+			Name: "deploy",
+			Approvers: &atc.ApproversConfig{
+				Roles: []string{"owner"},
+				Users: []string{"some-user"},
+			},
+			Required: 2,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approve": {
+				"name": "deploy",
+				"approvers": {
+					"roles": ["owner"],
+					"users": ["some-user"]
+				},
+				"required": 2
+			}
+		}`,
+	},
+	{
+		Title: "approve step without a required count",
+
+		Config: &atc.ApproveStep{
+			Name: "deploy",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approve": {
+				"name": "deploy",
+				"required": 1
+			}
+		}`,
+	},
 	{
 		Title: "try step",
 
@@ -854,6 +899,267 @@ func (s *PlannerSuite) TestFactory() {
 	atc.LoadBaseResourceTypeDefaults(map[string]atc.Source{})
 }
 
+func (s *PlannerSuite) TestCreateMatrix() {
+	factory := builds.NewPlanner(atc.NewPlanFactory(0))
+
+	config := atc.JobConfig{
+		Name: "some-job",
+		Matrix: []atc.MatrixVarConfig{
+			{Var: "os", Values: []interface{}{"linux", "windows"}},
+		},
+		PlanSequence: []atc.Step{
+			{
+				Config: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+			},
+		},
+	}
+
+	actualPlan, err := factory.CreateMatrix(config, resources, resourceTypes, nil)
+	s.NoError(err)
+
+	seenIDs := map[atc.PlanID]bool{}
+	actualPlan.Each(func(p *atc.Plan) {
+		s.False(seenIDs[p.ID], "duplicate plan id: %s", p.ID)
+		seenIDs[p.ID] = true
+		p.ID = "(unique)"
+	})
+
+	actualJSON, err := json.Marshal(actualPlan)
+	s.NoError(err)
+
+	s.JSONEq(`{
+		"id": "(unique)",
+		"matrix": {
+			"builds": [
+				{
+					"values": {"os": "linux"},
+					"plan": {
+						"id": "(unique)",
+						"across": {
+							"vars": [{"name": "os", "values": ["linux"]}],
+							"steps": [
+								{
+									"values": ["linux"],
+									"step": {
+										"id": "(unique)",
+										"do": [
+											{
+												"id": "(unique)",
+												"load_var": {
+													"name": "some-var",
+													"file": "some-file"
+												}
+											}
+										]
+									}
+								}
+							]
+						}
+					}
+				},
+				{
+					"values": {"os": "windows"},
+					"plan": {
+						"id": "(unique)",
+						"across": {
+							"vars": [{"name": "os", "values": ["windows"]}],
+							"steps": [
+								{
+									"values": ["windows"],
+									"step": {
+										"id": "(unique)",
+										"do": [
+											{
+												"id": "(unique)",
+												"load_var": {
+													"name": "some-var",
+													"file": "some-file"
+												}
+											}
+										]
+									}
+								}
+							]
+						}
+					}
+				}
+			]
+		}
+	}`, string(actualJSON))
+}
+
+func (s *PlannerSuite) TestCreateOneOff() {
+	atc.LoadBaseResourceTypeDefaults(baseResourceTypeDefaults)
+	defer atc.LoadBaseResourceTypeDefaults(map[string]atc.Source{})
+
+	factory := builds.NewPlanner(atc.NewPlanFactory(0))
+
+	config := &atc.DoStep{
+		Steps: []atc.Step{
+			{
+				Config: &atc.GetStep{
+					Name:     "local",
+					Resource: "some-resource",
+				},
+			},
+			{
+				Config: &atc.GetStep{
+					Name:     "pinned",
+					Resource: "some-resource",
+					Version:  &atc.VersionConfig{Pinned: atc.Version{"some": "version"}},
+				},
+			},
+			{
+				Config: &atc.GetStep{
+					Name:     "latest",
+					Resource: "some-base-resource",
+					Params:   atc.Params{"some": "params"},
+				},
+			},
+			{
+				Config: &atc.PutStep{
+					Name:     "some-put",
+					Resource: "some-resource",
+				},
+			},
+		},
+	}
+
+	s.Run("with local artifacts", func() {
+		actualPlan, err := factory.CreateOneOff(config, resources, resourceTypes, builds.OneOffOptions{
+			Artifacts: map[string]int{"local": 42},
+			DryRun:    true,
+		})
+		s.NoError(err)
+
+		s.JSONEq(`{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"artifact_input": {
+						"artifact_id": 42,
+						"name": "local"
+					}
+				},
+				{
+					"id": "(unique)",
+					"get": {
+						"name": "pinned",
+						"type": "some-resource-type",
+						"resource": "some-resource",
+						"source": {"some": "source", "default-key": "default-value"},
+						"version": {"some": "version"},
+						"resource_types": [
+							{
+								"name": "some-resource-type",
+								"type": "some-base-resource-type",
+								"source": {"some": "type-source"},
+								"defaults": {"default-key": "default-value"},
+								"version": {"some": "type-version"}
+							}
+						]
+					}
+				},
+				{
+					"id": "(unique)",
+					"on_success": {
+						"step": {
+							"id": "(check)",
+							"check": {
+								"name": "latest",
+								"type": "some-base-resource-type",
+								"source": {"some": "source", "default-key": "default-value"},
+								"resource_types": [
+									{
+										"name": "some-resource-type",
+										"type": "some-base-resource-type",
+										"source": {"some": "type-source"},
+										"defaults": {"default-key": "default-value"},
+										"version": {"some": "type-version"}
+									}
+								]
+							}
+						},
+						"on_success": {
+							"id": "(unique)",
+							"get": {
+								"name": "latest",
+								"type": "some-base-resource-type",
+								"resource": "some-base-resource",
+								"source": {"some": "source", "default-key": "default-value"},
+								"params": {"some": "params"},
+								"version_from": "(check)",
+								"resource_types": [
+									{
+										"name": "some-resource-type",
+										"type": "some-base-resource-type",
+										"source": {"some": "type-source"},
+										"defaults": {"default-key": "default-value"},
+										"version": {"some": "type-version"}
+									}
+								]
+							}
+						}
+					}
+				},
+				{
+					"id": "(unique)",
+					"do": []
+				}
+			]
+		}`, string(s.normalizeOneOffIDs(actualPlan)))
+	})
+
+	s.Run("without dry run", func() {
+		actualPlan, err := factory.CreateOneOff(config, resources, resourceTypes, builds.OneOffOptions{})
+		s.NoError(err)
+
+		var put *atc.PutPlan
+		actualPlan.Each(func(p *atc.Plan) {
+			if p.Put != nil {
+				put = p.Put
+			}
+		})
+
+		s.NotNil(put)
+		s.Equal("some-put", put.Name)
+	})
+
+	s.Run("with an unknown resource", func() {
+		_, err := factory.CreateOneOff(&atc.GetStep{Name: "bogus"}, resources, resourceTypes, builds.OneOffOptions{})
+		s.Equal(builds.UnknownResourceError{Resource: "bogus"}, err)
+	})
+}
+
+// normalizeOneOffIDs replaces the plan IDs so that the plan can be compared,
+// preserving the reference from get steps to the checks they fetch the
+// version of.
+func (s *PlannerSuite) normalizeOneOffIDs(plan atc.Plan) []byte {
+	checkIDs := map[atc.PlanID]bool{}
+	plan.Each(func(p *atc.Plan) {
+		if p.Check != nil {
+			checkIDs[p.ID] = true
+			p.ID = "(check)"
+		} else {
+			p.ID = "(unique)"
+		}
+
+		if p.Get != nil && p.Get.VersionFrom != nil && checkIDs[*p.Get.VersionFrom] {
+			checkID := atc.PlanID("(check)")
+			p.Get.VersionFrom = &checkID
+		}
+	})
+
+	actualJSON, err := json.Marshal(plan)
+	s.NoError(err)
+
+	return actualJSON
+}
+
 func newCPULimit(cpuLimit uint64) *atc.CPULimit {
 	limit := atc.CPULimit(cpuLimit)
 	return &limit
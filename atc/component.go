@@ -8,6 +8,7 @@ const (
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentPipelineSyncer             = "pipeline_syncer"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
 	ComponentCollectorBuilds            = "collector_builds"
@@ -20,6 +21,7 @@ const (
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
+	ComponentCollectorRemoteCaches      = "collector_remote_caches"
 )
 
 type Component struct {
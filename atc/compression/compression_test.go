@@ -1,6 +1,9 @@
 package compression_test
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/compression"
 
@@ -21,6 +24,10 @@ var _ = Describe("Compression", func() {
 		It("returns gzip", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.GzipEncoding))
 		})
+
+		It("reads what it writes", func() {
+			Expect(roundTrip(comp, "some-contents")).To(Equal("some-contents"))
+		})
 	})
 
 	Describe("Zstd", func() {
@@ -31,5 +38,30 @@ var _ = Describe("Compression", func() {
 		It("returns zstd", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.ZstdEncoding))
 		})
+
+		It("reads what it writes", func() {
+			Expect(roundTrip(comp, "some-contents")).To(Equal("some-contents"))
+		})
 	})
 })
+
+func roundTrip(comp compression.Compression, contents string) string {
+	buf := new(bytes.Buffer)
+
+	writer, err := comp.NewWriter(buf)
+	Expect(err).ToNot(HaveOccurred())
+
+	_, err = writer.Write([]byte(contents))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(writer.Close()).To(Succeed())
+
+	reader, err := comp.NewReader(ioutil.NopCloser(buf))
+	Expect(err).ToNot(HaveOccurred())
+
+	defer reader.Close()
+
+	decompressed, err := ioutil.ReadAll(reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	return string(decompressed)
+}
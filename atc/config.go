@@ -26,6 +26,7 @@ type Config struct {
 	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
+	Imports       ImportConfigs    `json:"imports,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -37,6 +38,7 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		Imports       interface{} `json:"imports,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -188,6 +190,32 @@ type ResourceConfig struct {
 	Version              Version     `json:"version,omitempty"`
 	Icon                 string      `json:"icon,omitempty"`
 	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+
+	WebhookFilter *WebhookFilter `json:"webhook_filter,omitempty"`
+}
+
+// WebhookFilter configures which payloads sent to a resource's trigger
+// webhook result in builds of its dependent jobs, and which fields of the
+// payload are exposed to those builds as vars. The builds' inputs are not
+// taken from the payload; they are the latest versions found by the check
+// which the webhook queues.
+type WebhookFilter struct {
+	// Match is a list of conditions which must all hold for a payload to
+	// trigger builds.
+	Match []WebhookMatch `json:"match,omitempty"`
+
+	// Vars maps build var names to JSONPath expressions which are evaluated
+	// against the payload.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// WebhookMatch is a condition on a webhook payload. The condition holds if any
+// value found at the JSONPath matches one of the patterns (or there are no
+// patterns) and none of the ignore patterns.
+type WebhookMatch struct {
+	Path     string   `json:"path"`
+	Patterns []string `json:"patterns,omitempty"`
+	Ignore   []string `json:"ignore,omitempty"`
 }
 
 type ResourceType struct {
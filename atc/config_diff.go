@@ -25,6 +25,34 @@ type Diff struct {
 	After  interface{}
 }
 
+const (
+	ConfigChangeAdded   = "added"
+	ConfigChangeRemoved = "removed"
+	ConfigChangeChanged = "changed"
+)
+
+// ConfigChange is one difference between two pipeline configs, as shown by
+// Config.Diff. Before and After are the YAML of the thing before and after the
+// change, and are empty when it was added or removed.
+type ConfigChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Render prints the change the same way Config.Diff does.
+func (change ConfigChange) Render(to io.Writer) {
+	label := change.Kind
+	if change.Name != "" {
+		label += " " + change.Name
+	}
+
+	fmt.Fprintf(to, ansi.Color("%s has been %s:", "yellow")+"\n", label, change.Action)
+	renderDiff(to, change.Before, change.After)
+}
+
 type DisplayDiff struct {
 	Before *DisplayConfig
 	After  *DisplayConfig
@@ -335,5 +363,105 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		displayDiff.Render(indent)
 	}
 
+	if (len(c.Imports) > 0 || len(newConfig.Imports) > 0) && practicallyDifferent(c.Imports, newConfig.Imports) {
+		diffExists = true
+		fmt.Fprintln(out, ansi.Color("imports have changed:", "yellow"))
+
+		payloadA, _ := yaml.Marshal(c.Imports)
+		payloadB, _ := yaml.Marshal(newConfig.Imports)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
 	return diffExists
 }
+
+// Changes returns the differences between the config and the new config, in
+// the order Config.Diff shows them.
+func (c Config) Changes(newConfig Config) []ConfigChange {
+	changes := []ConfigChange{}
+
+	// a group which has changed and moved is only one change
+	groups := map[string]bool{}
+	for _, change := range configChanges("group", groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))) {
+		if groups[change.Name] {
+			continue
+		}
+
+		groups[change.Name] = true
+		changes = append(changes, change)
+	}
+
+	changes = append(changes, configChanges("variable source", diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources)))...)
+	changes = append(changes, configChanges("resource", diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources)))...)
+	changes = append(changes, configChanges("resource type", diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes)))...)
+	changes = append(changes, configChanges("job", diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs)))...)
+
+	if displayDiff, diff := diffDisplay(c.Display, newConfig.Display); diff {
+		changes = append(changes, configChange("display configuration", "", displayDiff.Before, displayDiff.After))
+	}
+
+	if (len(c.Imports) > 0 || len(newConfig.Imports) > 0) && practicallyDifferent(c.Imports, newConfig.Imports) {
+		changes = append(changes, configChange("imports", "", c.Imports, newConfig.Imports))
+	}
+
+	return changes
+}
+
+func configChanges(kind string, diffs Diffs) []ConfigChange {
+	changes := []ConfigChange{}
+	for _, diff := range diffs {
+		var thing interface{}
+		if diff.Before != nil {
+			thing = diff.Before
+		} else {
+			thing = diff.After
+		}
+
+		changes = append(changes, configChange(kind, name(thing), diff.Before, diff.After))
+	}
+
+	return changes
+}
+
+func configChange(kind string, name string, before interface{}, after interface{}) ConfigChange {
+	change := ConfigChange{
+		Kind: kind,
+		Name: name,
+	}
+
+	if !isNil(before) {
+		payload, _ := yaml.Marshal(before)
+		change.Before = string(payload)
+	}
+
+	if !isNil(after) {
+		payload, _ := yaml.Marshal(after)
+		change.After = string(payload)
+	}
+
+	switch {
+	case change.Before == "":
+		change.Action = ConfigChangeAdded
+	case change.After == "":
+		change.Action = ConfigChangeRemoved
+	default:
+		change.Action = ConfigChangeChanged
+	}
+
+	return change
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+
+	return false
+}
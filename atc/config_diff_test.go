@@ -216,4 +216,107 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("imports", func() {
+		var imports ImportConfigs
+		BeforeEach(func() {
+			imports = ImportConfigs{
+				{
+					Library:  "some-library",
+					Resolved: &ResolvedImport{Version: 1, Digest: "sha256:abc", Jobs: []string{"some-job"}},
+				},
+			}
+		})
+
+		Context("when there are no imports to change", func() {
+			It("says there are no changes to apply", func() {
+				diff := Config{Imports: imports}.Diff(GinkgoWriter, Config{Imports: imports})
+				Expect(diff).To(BeFalse())
+			})
+		})
+
+		Context("when an import resolves to a different version", func() {
+			It("shows the change", func() {
+				newConfig := Config{
+					Imports: ImportConfigs{
+						{
+							Library:  "some-library",
+							Resolved: &ResolvedImport{Version: 2, Digest: "sha256:def", Jobs: []string{"some-job"}},
+						},
+					},
+				}
+
+				buffer := NewBuffer()
+				diff := Config{Imports: imports}.Diff(buffer, newConfig)
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("imports have changed:"))
+				Eventually(buffer).Should(Say("-.*digest: sha256:abc"))
+				Eventually(buffer).Should(Say(`\+.*digest: sha256:def`))
+			})
+		})
+	})
+
+	Describe("Changes", func() {
+		var config Config
+
+		BeforeEach(func() {
+			config = Config{
+				Groups: GroupConfigs{
+					{Name: "some-group", Jobs: []string{"some-job"}},
+					{Name: "other-group", Jobs: []string{"some-job"}},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job", Public: true},
+					{Name: "removed-job"},
+				},
+			}
+		})
+
+		It("returns nothing when the configs are the same", func() {
+			Expect(config.Changes(config)).To(BeEmpty())
+		})
+
+		It("returns what was added, removed and changed", func() {
+			newConfig := Config{
+				Groups: GroupConfigs{
+					{Name: "other-group", Jobs: []string{"some-job", "new-job"}},
+					{Name: "some-group", Jobs: []string{"some-job"}},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job"},
+					{Name: "new-job"},
+				},
+				Display: &DisplayConfig{BackgroundImage: "some-image"},
+			}
+
+			changes := config.Changes(newConfig)
+
+			var summary []string
+			for _, change := range changes {
+				summary = append(summary, change.Kind+" "+change.Name+" "+change.Action)
+			}
+
+			Expect(summary).To(Equal([]string{
+				"group some-group changed",
+				"group other-group changed",
+				"job some-job changed",
+				"job removed-job removed",
+				"job new-job added",
+				"display configuration  added",
+			}))
+
+			Expect(changes[3].Before).To(Equal("name: removed-job\nplan: null\n"))
+			Expect(changes[3].After).To(BeEmpty())
+		})
+
+		It("renders a change the way Diff does", func() {
+			changes := config.Changes(Config{Jobs: config.Jobs[:1], Groups: config.Groups})
+			Expect(changes).To(HaveLen(1))
+
+			buffer := NewBuffer()
+			changes[0].Render(buffer)
+			Expect(buffer).To(Say("job removed-job has been removed:"))
+			Expect(buffer).To(Say("-.*name: removed-job"))
+		})
+	})
 })
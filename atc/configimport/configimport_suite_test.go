@@ -0,0 +1,13 @@
+package configimport_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigimport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configimport Suite")
+}
@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package configimportfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configimport"
+)
+
+type FakeLibraries struct {
+	PipelineLibraryStub        func(string, int) (atc.PipelineLibrary, bool, error)
+	pipelineLibraryMutex       sync.RWMutex
+	pipelineLibraryArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	pipelineLibraryReturns struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	pipelineLibraryReturnsOnCall map[int]struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLibraries) PipelineLibrary(arg1 string, arg2 int) (atc.PipelineLibrary, bool, error) {
+	fake.pipelineLibraryMutex.Lock()
+	ret, specificReturn := fake.pipelineLibraryReturnsOnCall[len(fake.pipelineLibraryArgsForCall)]
+	fake.pipelineLibraryArgsForCall = append(fake.pipelineLibraryArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.PipelineLibraryStub
+	fakeReturns := fake.pipelineLibraryReturns
+	fake.recordInvocation("PipelineLibrary", []interface{}{arg1, arg2})
+	fake.pipelineLibraryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeLibraries) PipelineLibraryCallCount() int {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	return len(fake.pipelineLibraryArgsForCall)
+}
+
+func (fake *FakeLibraries) PipelineLibraryCalls(stub func(string, int) (atc.PipelineLibrary, bool, error)) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = stub
+}
+
+func (fake *FakeLibraries) PipelineLibraryArgsForCall(i int) (string, int) {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	argsForCall := fake.pipelineLibraryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLibraries) PipelineLibraryReturns(result1 atc.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	fake.pipelineLibraryReturns = struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLibraries) PipelineLibraryReturnsOnCall(i int, result1 atc.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	if fake.pipelineLibraryReturnsOnCall == nil {
+		fake.pipelineLibraryReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineLibrary
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineLibraryReturnsOnCall[i] = struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLibraries) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLibraries) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ configimport.Libraries = new(FakeLibraries)
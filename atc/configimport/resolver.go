@@ -0,0 +1,162 @@
+package configimport
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Libraries
+type Libraries interface {
+	PipelineLibrary(libraryName string, version int) (atc.PipelineLibrary, bool, error)
+}
+
+//counterfeiter:generate . Files
+type Files interface {
+	// ReadFile reads a file imported by the config. The path is as given by
+	// the import.
+	ReadFile(path string) ([]byte, error)
+}
+
+// Dir reads file imports relative to a directory.
+type Dir string
+
+func (dir Dir) ReadFile(path string) ([]byte, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(string(dir), path)
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+// Resolver resolves the imports of a config, merging the jobs, resources, and
+// resource types they define into the config.
+//
+// Files and Libraries may be nil, in which case importing a file or a library
+// respectively is an error.
+type Resolver struct {
+	Files     Files
+	Libraries Libraries
+}
+
+// Resolve resolves each import which has not already been resolved. Imports
+// which have been resolved, e.g. in a config fetched with `fly get-pipeline`,
+// were merged into the config when they were resolved.
+func (resolver Resolver) Resolve(config *atc.Config) error {
+	for i, imp := range config.Imports {
+		if imp.Resolved != nil {
+			continue
+		}
+
+		resolved, imported, err := resolver.resolve(imp)
+		if err != nil {
+			return fmt.Errorf("failed to resolve imports[%d]: %s", i, err)
+		}
+
+		for _, job := range imported.Jobs {
+			if _, found := config.Jobs.Lookup(job.Name); found {
+				return fmt.Errorf("failed to resolve imports[%d]: job '%s' is already defined", i, job.Name)
+			}
+
+			config.Jobs = append(config.Jobs, job)
+			resolved.Jobs = append(resolved.Jobs, job.Name)
+		}
+
+		for _, resource := range imported.Resources {
+			if _, found := config.Resources.Lookup(resource.Name); found {
+				return fmt.Errorf("failed to resolve imports[%d]: resource '%s' is already defined", i, resource.Name)
+			}
+
+			config.Resources = append(config.Resources, resource)
+			resolved.Resources = append(resolved.Resources, resource.Name)
+		}
+
+		for _, resourceType := range imported.ResourceTypes {
+			if _, found := config.ResourceTypes.Lookup(resourceType.Name); found {
+				return fmt.Errorf("failed to resolve imports[%d]: resource type '%s' is already defined", i, resourceType.Name)
+			}
+
+			config.ResourceTypes = append(config.ResourceTypes, resourceType)
+			resolved.ResourceTypes = append(resolved.ResourceTypes, resourceType.Name)
+		}
+
+		config.Imports[i].Resolved = &resolved
+	}
+
+	return nil
+}
+
+func (resolver Resolver) resolve(imp atc.ImportConfig) (atc.ResolvedImport, atc.Config, error) {
+	var resolved atc.ResolvedImport
+	var template []byte
+
+	switch {
+	case imp.File != "" && imp.Library != "":
+		return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("cannot import both a file and a library")
+
+	case imp.File != "":
+		if resolver.Files == nil {
+			return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("cannot import file '%s' here", imp.File)
+		}
+
+		var err error
+		template, err = resolver.Files.ReadFile(imp.File)
+		if err != nil {
+			return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("could not read file: %s", err)
+		}
+
+		resolved.Digest = fmt.Sprintf("sha256:%x", sha256.Sum256(template))
+
+	case imp.Library != "":
+		if resolver.Libraries == nil {
+			return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("cannot import library '%s' without a target", imp.Library)
+		}
+
+		library, found, err := resolver.Libraries.PipelineLibrary(imp.Library, imp.Version)
+		if err != nil {
+			return atc.ResolvedImport{}, atc.Config{}, err
+		}
+
+		if !found {
+			if imp.Version != 0 {
+				return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("library '%s' has no version %d", imp.Library, imp.Version)
+			}
+
+			return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("library '%s' not found", imp.Library)
+		}
+
+		template = []byte(library.Config)
+		resolved.Version = library.Version
+		resolved.Digest = library.Digest
+
+	default:
+		return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("no file or library to import")
+	}
+
+	err := atc.ValidatePipelineLibrary(template)
+	if err != nil {
+		return atc.ResolvedImport{}, atc.Config{}, err
+	}
+
+	// vars which are not given by the import are left as-is, so that they
+	// may still be resolved by the pipeline's credential manager
+	evaluated, err := vars.NewTemplateResolver(template, []vars.Variables{vars.StaticVariables(imp.Vars)}).Resolve(false, false)
+	if err != nil {
+		return atc.ResolvedImport{}, atc.Config{}, err
+	}
+
+	var imported atc.Config
+	err = yaml.Unmarshal(evaluated, &imported)
+	if err != nil {
+		return atc.ResolvedImport{}, atc.Config{}, fmt.Errorf("malformed config: %s", err)
+	}
+
+	return resolved, imported, nil
+}
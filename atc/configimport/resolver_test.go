@@ -0,0 +1,218 @@
+package configimport_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/configimport"
+	"github.com/concourse/concourse/atc/configimport/configimportfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+	var (
+		tmpdir        string
+		fakeLibraries *configimportfakes.FakeLibraries
+		resolver      Resolver
+		config        atc.Config
+		resolveErr    error
+		fileContent   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "imports-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		fileContent = `
+resources:
+- name: ((name))-repo
+  type: git
+  source: {uri: ((uri))}
+jobs:
+- name: ((name))-test
+  plan:
+  - get: ((name))-repo
+    trigger: true
+`
+
+		fakeLibraries = new(configimportfakes.FakeLibraries)
+		resolver = Resolver{
+			Files:     Dir(tmpdir),
+			Libraries: fakeLibraries,
+		}
+
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "deploy"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	JustBeforeEach(func() {
+		err := ioutil.WriteFile(filepath.Join(tmpdir, "snippet.yml"), []byte(fileContent), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		resolveErr = resolver.Resolve(&config)
+	})
+
+	Context("when importing a file", func() {
+		BeforeEach(func() {
+			config.Imports = atc.ImportConfigs{
+				{
+					File: "snippet.yml",
+					Vars: atc.Params{"name": "app"},
+				},
+			}
+		})
+
+		It("merges the evaluated config", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+
+			Expect(config.Jobs).To(HaveLen(2))
+			Expect(config.Jobs[1].Name).To(Equal("app-test"))
+			Expect(config.Resources).To(HaveLen(1))
+			Expect(config.Resources[0].Name).To(Equal("app-repo"))
+		})
+
+		It("leaves vars which are not given by the import", func() {
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "((uri))"}))
+		})
+
+		It("records how the import was resolved", func() {
+			Expect(config.Imports[0].Resolved).To(Equal(&atc.ResolvedImport{
+				Digest:    fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(fileContent))),
+				Jobs:      []string{"app-test"},
+				Resources: []string{"app-repo"},
+			}))
+		})
+
+		Context("when the import defines a job which already exists", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs, atc.JobConfig{Name: "app-test"})
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError("failed to resolve imports[0]: job 'app-test' is already defined"))
+			})
+		})
+
+		Context("when the file defines fields which cannot be imported", func() {
+			BeforeEach(func() {
+				fileContent = "groups: []\n"
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError("failed to resolve imports[0]: only jobs, resources, resource_types may be imported, not groups"))
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			BeforeEach(func() {
+				config.Imports[0].File = "missing.yml"
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError(ContainSubstring("could not read file")))
+			})
+		})
+
+		Context("when files cannot be imported", func() {
+			BeforeEach(func() {
+				resolver.Files = nil
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError("failed to resolve imports[0]: cannot import file 'snippet.yml' here"))
+			})
+		})
+	})
+
+	Context("when importing a library", func() {
+		BeforeEach(func() {
+			config.Imports = atc.ImportConfigs{
+				{
+					Library: "some-library",
+					Version: 3,
+					Vars:    atc.Params{"name": "lib"},
+				},
+			}
+		})
+
+		Context("when the library exists", func() {
+			BeforeEach(func() {
+				fakeLibraries.PipelineLibraryReturns(atc.PipelineLibrary{
+					Name:    "some-library",
+					Version: 3,
+					Config:  "jobs:\n- name: ((name))-job\n  plan: []\n",
+					Digest:  "sha256:abc",
+				}, true, nil)
+			})
+
+			It("fetches the requested version", func() {
+				Expect(fakeLibraries.PipelineLibraryCallCount()).To(Equal(1))
+				name, version := fakeLibraries.PipelineLibraryArgsForCall(0)
+				Expect(name).To(Equal("some-library"))
+				Expect(version).To(Equal(3))
+			})
+
+			It("merges the evaluated config and records the library version", func() {
+				Expect(resolveErr).NotTo(HaveOccurred())
+				Expect(config.Jobs[1].Name).To(Equal("lib-job"))
+				Expect(config.Imports[0].Resolved).To(Equal(&atc.ResolvedImport{
+					Version: 3,
+					Digest:  "sha256:abc",
+					Jobs:    []string{"lib-job"},
+				}))
+			})
+		})
+
+		Context("when the library does not exist", func() {
+			BeforeEach(func() {
+				fakeLibraries.PipelineLibraryReturns(atc.PipelineLibrary{}, false, nil)
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError("failed to resolve imports[0]: library 'some-library' has no version 3"))
+			})
+		})
+
+		Context("when fetching the library fails", func() {
+			BeforeEach(func() {
+				fakeLibraries.PipelineLibraryReturns(atc.PipelineLibrary{}, false, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(resolveErr).To(MatchError("failed to resolve imports[0]: nope"))
+			})
+		})
+	})
+
+	Context("when the import has already been resolved", func() {
+		BeforeEach(func() {
+			config.Imports = atc.ImportConfigs{
+				{
+					Library:  "some-library",
+					Resolved: &atc.ResolvedImport{Version: 1, Digest: "sha256:abc"},
+				},
+			}
+		})
+
+		It("leaves it alone", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+			Expect(fakeLibraries.PipelineLibraryCallCount()).To(BeZero())
+			Expect(config.Jobs).To(HaveLen(1))
+		})
+	})
+})
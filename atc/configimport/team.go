@@ -0,0 +1,27 @@
+package configimport
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// TeamLibraries imports the pipeline libraries of a team.
+type TeamLibraries struct {
+	Team db.Team
+}
+
+func (libraries TeamLibraries) PipelineLibrary(name string, version int) (atc.PipelineLibrary, bool, error) {
+	library, found, err := libraries.Team.PipelineLibrary(name, version)
+	if err != nil || !found {
+		return atc.PipelineLibrary{}, found, err
+	}
+
+	return atc.PipelineLibrary{
+		Name:      library.Name,
+		TeamName:  library.TeamName,
+		Version:   library.Version,
+		Config:    string(library.Config),
+		Digest:    library.Digest,
+		CreatedAt: library.CreatedAt.Unix(),
+	}, true, nil
+}
@@ -0,0 +1,232 @@
+package configvalidate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// LintRule is a semantic check run against an otherwise valid pipeline
+// config. Unlike the errors and warnings returned by Validate, every finding
+// reported by a rule is tagged with the rule's ID so that it can be disabled
+// inline or tracked by other tooling.
+type LintRule interface {
+	// ID is a short, stable identifier for the rule, e.g. 'task-timeout'.
+	ID() string
+
+	// Description explains what the rule checks for.
+	Description() string
+
+	// Check returns the rule's findings for the config.
+	Check(atc.Config) []LintFinding
+}
+
+// LintFinding is a single problem reported by a LintRule.
+type LintFinding struct {
+	RuleID  string `json:"rule_id"`
+	Message string `json:"message"`
+
+	// Path locates the offending part of the config, e.g.
+	// '$.jobs[0].plan[1]'.
+	Path string `json:"path"`
+
+	// Line and Column are resolved from the config's source by Lint, and are
+	// zero if the source was not available or the path could not be found.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// LintRules are the rules run by Lint when none are given.
+var LintRules = []LintRule{
+	UnsatisfiablePassedRule{},
+	NoTriggerRule{},
+	SingleJobSerialGroupRule{},
+	UnpinnedImageRule{},
+	HardcodedSecretRule{},
+}
+
+// OptionalLintRules are rules which are too noisy to run by default, and
+// must be asked for by ID.
+var OptionalLintRules = []LintRule{
+	TaskTimeoutRule{},
+}
+
+// LookupLintRule finds a rule in LintRules or OptionalLintRules by its ID.
+func LookupLintRule(id string) (LintRule, bool) {
+	for _, rules := range [][]LintRule{LintRules, OptionalLintRules} {
+		for _, rule := range rules {
+			if rule.ID() == id {
+				return rule, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Lint runs the rules against the config, falling back to LintRules if no
+// rules are given.
+//
+// The config should be loaded from the source before any ((vars)) are
+// interpolated, as otherwise HardcodedSecretRule cannot tell an interpolated
+// secret from a literal one.
+//
+// The source is the raw YAML the config was loaded from. It is used to
+// resolve the line of each finding and to honor inline directives, which
+// take the form of a comment listing rule IDs:
+//
+//	# lint:disable task-timeout, unpinned-image
+//	# lint:disable-file hardcoded-secret
+//
+// A 'lint:disable' directive suppresses findings on the line it is on or on
+// the line following it, while 'lint:disable-file' suppresses findings
+// anywhere in the config. The source may be nil, in which case findings have
+// no line and cannot be suppressed.
+func Lint(config atc.Config, source []byte, rules ...LintRule) ([]LintFinding, error) {
+	if len(rules) == 0 {
+		rules = LintRules
+	}
+
+	var file *ast.File
+	if len(source) > 0 {
+		var err error
+		file, err = parser.ParseBytes(source, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse config source: %w", err)
+		}
+	}
+
+	directives := parseLintDirectives(source)
+
+	findings := []LintFinding{}
+	for _, rule := range rules {
+		for _, finding := range rule.Check(config) {
+			finding.RuleID = rule.ID()
+
+			if file != nil {
+				finding.Line, finding.Column = locate(file, finding.Path)
+			}
+
+			if directives.disables(finding) {
+				continue
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings, nil
+}
+
+var lintDirectiveRegexp = regexp.MustCompile(`#\s*lint:(disable|disable-file)\s+([\w\-, ]+)`)
+
+type lintDirectives struct {
+	file  map[string]bool
+	lines map[int]map[string]bool
+}
+
+func parseLintDirectives(source []byte) lintDirectives {
+	directives := lintDirectives{
+		file:  map[string]bool{},
+		lines: map[int]map[string]bool{},
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		match := lintDirectiveRegexp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		ids := strings.FieldsFunc(match[2], func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+
+		for _, id := range ids {
+			if match[1] == "disable-file" {
+				directives.file[id] = true
+				continue
+			}
+
+			// a directive applies to the line it is on, so that it may trail
+			// the offending config, and the line after it
+			for _, l := range []int{line, line + 1} {
+				if directives.lines[l] == nil {
+					directives.lines[l] = map[string]bool{}
+				}
+
+				directives.lines[l][id] = true
+			}
+		}
+	}
+
+	return directives
+}
+
+func (directives lintDirectives) disables(finding LintFinding) bool {
+	if directives.file[finding.RuleID] {
+		return true
+	}
+
+	return finding.Line != 0 && directives.lines[finding.Line][finding.RuleID]
+}
+
+// locate finds the line and column of the node at the path. If the path
+// cannot be found, e.g. because a step uses a different syntax than the path
+// assumes, its parents are tried in turn.
+func locate(file *ast.File, path string) (int, int) {
+	for path != "" && path != "$" {
+		node := lookup(file, path)
+		if node == nil && strings.Contains(path, ".in_parallel.steps[") {
+			// in_parallel may be configured as a list of steps directly
+			node = lookup(file, strings.Replace(path, ".in_parallel.steps[", ".in_parallel[", -1))
+		}
+
+		if node != nil {
+			position := node.GetToken().Position
+			return position.Line, position.Column
+		}
+
+		path = parentPath(path)
+	}
+
+	return 0, 0
+}
+
+func lookup(file *ast.File, path string) ast.Node {
+	yamlPath, err := yaml.PathString(path)
+	if err != nil {
+		return nil
+	}
+
+	node, err := yamlPath.FilterFile(file)
+	if err != nil || node == nil || node.GetToken() == nil {
+		return nil
+	}
+
+	return node
+}
+
+func parentPath(path string) string {
+	if strings.HasSuffix(path, "]") {
+		return path[:strings.LastIndex(path, "[")]
+	}
+
+	return path[:strings.LastIndex(path, ".")]
+}
@@ -0,0 +1,515 @@
+package configvalidate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// UnsatisfiablePassedRule reports `passed:` constraints which no version of
+// the resource can ever satisfy.
+type UnsatisfiablePassedRule struct{}
+
+func (UnsatisfiablePassedRule) ID() string { return "unsatisfiable-passed" }
+
+func (UnsatisfiablePassedRule) Description() string {
+	return "`passed:` constraints must be satisfiable by some version of the resource"
+}
+
+func (UnsatisfiablePassedRule) Check(c atc.Config) []LintFinding {
+	// for each resource, the jobs each job's gets of it must have passed
+	passedGraph := map[string]map[string][]string{}
+
+	// for each resource, the jobs which put to it without ever getting it, so
+	// the only versions which pass through them are the ones they create
+	putOnly := map[string]map[string]bool{}
+
+	for _, job := range c.Jobs {
+		gets := map[string]bool{}
+		puts := map[string]bool{}
+
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				resource := step.ResourceName()
+				gets[resource] = true
+
+				if passedGraph[resource] == nil {
+					passedGraph[resource] = map[string][]string{}
+				}
+
+				passedGraph[resource][job.Name] = append(passedGraph[resource][job.Name], step.Passed...)
+				return nil
+			},
+			OnPut: func(step *atc.PutStep) error {
+				puts[step.ResourceName()] = true
+				return nil
+			},
+		})
+
+		for resource := range puts {
+			if gets[resource] {
+				continue
+			}
+
+			if putOnly[resource] == nil {
+				putOnly[resource] = map[string]bool{}
+			}
+
+			putOnly[resource][job.Name] = true
+		}
+	}
+
+	var findings []LintFinding
+	for i, job := range c.Jobs {
+		walkLintSteps(job, fmt.Sprintf("$.jobs[%d]", i), func(config atc.StepConfig, path string, _ bool) {
+			step, ok := config.(*atc.GetStep)
+			if !ok || len(step.Passed) == 0 {
+				return
+			}
+
+			resource := step.ResourceName()
+
+			for _, passed := range step.Passed {
+				if dependsOnPassed(passedGraph[resource], passed, job.Name) {
+					findings = append(findings, LintFinding{
+						Path:    path + ".passed",
+						Message: fmt.Sprintf("jobs.%s get '%s' requires versions of resource '%s' to have passed job '%s', which itself depends on versions that have passed job '%s'", job.Name, step.Name, resource, passed, job.Name),
+					})
+				}
+			}
+
+			var producers []string
+			for _, passed := range step.Passed {
+				if putOnly[resource][passed] {
+					producers = append(producers, passed)
+				}
+			}
+
+			if len(producers) > 1 {
+				findings = append(findings, LintFinding{
+					Path:    path + ".passed",
+					Message: fmt.Sprintf("jobs.%s get '%s' requires versions of resource '%s' to have passed jobs '%s', which each only put their own versions to it", job.Name, step.Name, resource, strings.Join(producers, "', '")),
+				})
+			}
+		})
+	}
+
+	return findings
+}
+
+// dependsOnPassed returns true if versions of the resource must have passed
+// the target job before they can pass the given job.
+func dependsOnPassed(passedGraph map[string][]string, job string, target string) bool {
+	seen := map[string]bool{}
+	queue := []string{job}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == target {
+			return true
+		}
+
+		if seen[current] {
+			continue
+		}
+
+		seen[current] = true
+
+		queue = append(queue, passedGraph[current]...)
+	}
+
+	return false
+}
+
+// NoTriggerRule reports jobs which can never run: they have manual
+// triggering disabled, and neither a `trigger: true` get nor a schedule to
+// trigger them instead. Jobs which are only triggered manually are fine.
+type NoTriggerRule struct{}
+
+func (NoTriggerRule) ID() string { return "no-trigger" }
+
+func (NoTriggerRule) Description() string {
+	return "jobs with `disable_manual_trigger: true` must be triggered by a `trigger: true` get or a `schedule:`"
+}
+
+func (NoTriggerRule) Check(c atc.Config) []LintFinding {
+	var findings []LintFinding
+	for i, job := range c.Jobs {
+		if !job.DisableManualTrigger || job.Schedule != nil {
+			continue
+		}
+
+		triggered := false
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				if step.Trigger {
+					triggered = true
+				}
+				return nil
+			},
+		})
+
+		if triggered {
+			continue
+		}
+
+		findings = append(findings, LintFinding{
+			Path:    fmt.Sprintf("$.jobs[%d]", i),
+			Message: fmt.Sprintf("jobs.%s has no get step with `trigger: true` and no schedule, and manual triggering is disabled, so it will never run", job.Name),
+		})
+	}
+
+	return findings
+}
+
+// SingleJobSerialGroupRule reports serial groups which only contain one job,
+// and so serialize nothing that `serial: true` wouldn't.
+type SingleJobSerialGroupRule struct{}
+
+func (SingleJobSerialGroupRule) ID() string { return "single-job-serial-group" }
+
+func (SingleJobSerialGroupRule) Description() string {
+	return "serial groups should be shared by more than one job"
+}
+
+func (SingleJobSerialGroupRule) Check(c atc.Config) []LintFinding {
+	jobsByGroup := map[string][]int{}
+	for i, job := range c.Jobs {
+		for _, group := range job.SerialGroups {
+			jobsByGroup[group] = append(jobsByGroup[group], i)
+		}
+	}
+
+	groups := make([]string, 0, len(jobsByGroup))
+	for group := range jobsByGroup {
+		groups = append(groups, group)
+	}
+
+	sort.Strings(groups)
+
+	var findings []LintFinding
+	for _, group := range groups {
+		jobs := jobsByGroup[group]
+		if len(jobs) != 1 {
+			continue
+		}
+
+		findings = append(findings, LintFinding{
+			Path:    fmt.Sprintf("$.jobs[%d].serial_groups", jobs[0]),
+			Message: fmt.Sprintf("jobs.%s is the only job in serial group '%s' - consider `serial: true` instead", c.Jobs[jobs[0]].Name, group),
+		})
+	}
+
+	return findings
+}
+
+// TaskTimeoutRule reports task steps which are not bounded by a timeout, and
+// so may hold on to a worker indefinitely.
+//
+// Whether a task is long-running can't be told from its config, so the rule
+// reports every task without a timeout. It is one of the OptionalLintRules,
+// for pipelines which want all of their tasks to be bounded.
+type TaskTimeoutRule struct{}
+
+func (TaskTimeoutRule) ID() string { return "task-timeout" }
+
+func (TaskTimeoutRule) Description() string {
+	return "task steps should be configured with a `timeout:`"
+}
+
+func (TaskTimeoutRule) Check(c atc.Config) []LintFinding {
+	var findings []LintFinding
+	for i, job := range c.Jobs {
+		walkLintSteps(job, fmt.Sprintf("$.jobs[%d]", i), func(config atc.StepConfig, path string, timeout bool) {
+			step, ok := config.(*atc.TaskStep)
+			if !ok || timeout || step.Timeout != "" {
+				return
+			}
+
+			findings = append(findings, LintFinding{
+				Path:    path,
+				Message: fmt.Sprintf("jobs.%s task '%s' has no timeout", job.Name, step.Name),
+			})
+		})
+	}
+
+	return findings
+}
+
+// UnpinnedImageRule reports task images which are not pinned to a version,
+// digest, or tag other than 'latest', and so may change from build to build.
+type UnpinnedImageRule struct{}
+
+func (UnpinnedImageRule) ID() string { return "unpinned-image" }
+
+func (UnpinnedImageRule) Description() string {
+	return "task `image_resource:` should be pinned to a tag, digest, or version"
+}
+
+func (UnpinnedImageRule) Check(c atc.Config) []LintFinding {
+	var findings []LintFinding
+	for i, job := range c.Jobs {
+		walkLintSteps(job, fmt.Sprintf("$.jobs[%d]", i), func(config atc.StepConfig, path string, _ bool) {
+			step, ok := config.(*atc.TaskStep)
+			if !ok || step.Config == nil || step.Config.ImageResource == nil {
+				return
+			}
+
+			image := step.Config.ImageResource
+			if imagePinned(image) {
+				return
+			}
+
+			findings = append(findings, LintFinding{
+				Path:    path + ".config.image_resource",
+				Message: fmt.Sprintf("jobs.%s task '%s' uses an image_resource which is not pinned to a tag, digest, or version", job.Name, step.Name),
+			})
+		})
+	}
+
+	return findings
+}
+
+func imagePinned(image *atc.ImageResource) bool {
+	if len(image.Version) > 0 {
+		return true
+	}
+
+	if digest, ok := image.Source["digest"].(string); ok && digest != "" {
+		return true
+	}
+
+	if repository, ok := image.Source["repository"].(string); ok && strings.Contains(repository, "@sha256:") {
+		return true
+	}
+
+	tag, ok := image.Source["tag"]
+	if !ok {
+		return false
+	}
+
+	return fmt.Sprintf("%v", tag) != "latest" && fmt.Sprintf("%v", tag) != ""
+}
+
+var secretParamRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|private[_-]?key|access[_-]?key|api[_-]?key|credential)`)
+
+// HardcodedSecretRule reports params which look like credentials but are
+// configured with a literal value instead of a ((var)).
+type HardcodedSecretRule struct{}
+
+func (HardcodedSecretRule) ID() string { return "hardcoded-secret" }
+
+func (HardcodedSecretRule) Description() string {
+	return "secrets in `params:` should be provided by a credential manager using ((vars))"
+}
+
+func (HardcodedSecretRule) Check(c atc.Config) []LintFinding {
+	var findings []LintFinding
+	for i, job := range c.Jobs {
+		walkLintSteps(job, fmt.Sprintf("$.jobs[%d]", i), func(config atc.StepConfig, path string, _ bool) {
+			check := func(name string, params map[string]interface{}, paramsPath string) {
+				for _, key := range hardcodedSecrets(params, "") {
+					findings = append(findings, LintFinding{
+						Path:    paramsPath + "." + key,
+						Message: fmt.Sprintf("jobs.%s %s has a hard-coded value for param '%s' - use a ((var)) instead", job.Name, name, key),
+					})
+				}
+			}
+
+			switch step := config.(type) {
+			case *atc.GetStep:
+				check(fmt.Sprintf("get '%s'", step.Name), step.Params, path+".params")
+			case *atc.PutStep:
+				check(fmt.Sprintf("put '%s'", step.Name), step.Params, path+".params")
+				check(fmt.Sprintf("put '%s'", step.Name), step.GetParams, path+".get_params")
+			case *atc.TaskStep:
+				check(fmt.Sprintf("task '%s'", step.Name), taskEnvParams(step.Params), path+".params")
+				if step.Config != nil {
+					check(fmt.Sprintf("task '%s'", step.Name), taskEnvParams(step.Config.Params), path+".config.params")
+				}
+			}
+		})
+	}
+
+	return findings
+}
+
+// hardcodedSecrets returns the sorted keys of params, including nested
+// params, whose names look like credentials and whose values are literal.
+func hardcodedSecrets(params map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range params {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			keys = append(keys, hardcodedSecrets(v, prefix+key+".")...)
+		case string:
+			if v != "" && !strings.Contains(v, "((") && secretParamRegexp.MatchString(key) {
+				keys = append(keys, prefix+key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func taskEnvParams(env atc.TaskEnv) map[string]interface{} {
+	params := make(map[string]interface{}, len(env))
+	for key, value := range env {
+		params[key] = value
+	}
+
+	return params
+}
+
+// walkLintSteps calls the function for every step in the job's plan and
+// hooks, along with the YAML path to the step and whether it is bounded by a
+// timeout.
+func walkLintSteps(job atc.JobConfig, jobPath string, f func(atc.StepConfig, string, bool)) {
+	for i, step := range job.PlanSequence {
+		lintStepWalker{
+			path: fmt.Sprintf("%s.plan[%d]", jobPath, i),
+			f:    f,
+		}.walk(step.Config)
+	}
+
+	hooks := []struct {
+		key  string
+		step *atc.Step
+	}{
+		{"on_success", job.OnSuccess},
+		{"on_failure", job.OnFailure},
+		{"on_abort", job.OnAbort},
+		{"on_error", job.OnError},
+		{"ensure", job.Ensure},
+	}
+
+	for _, hook := range hooks {
+		if hook.step == nil {
+			continue
+		}
+
+		lintStepWalker{
+			path: jobPath + "." + hook.key,
+			f:    f,
+		}.walk(hook.step.Config)
+	}
+}
+
+// lintStepWalker is a StepVisitor which keeps track of the YAML path to each
+// step it visits.
+type lintStepWalker struct {
+	path    string
+	timeout bool
+	f       func(atc.StepConfig, string, bool)
+}
+
+func (walker lintStepWalker) walk(step atc.StepConfig) {
+	_ = step.Visit(walker)
+}
+
+func (walker lintStepWalker) at(path string) lintStepWalker {
+	walker.path = path
+	return walker
+}
+
+func (walker lintStepWalker) VisitTask(step *atc.TaskStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitGet(step *atc.GetStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitPut(step *atc.PutStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitSetPipeline(step *atc.SetPipelineStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitLoadVar(step *atc.LoadVarStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitApprove(step *atc.ApproveStep) error {
+	walker.f(step, walker.path, walker.timeout)
+	return nil
+}
+
+func (walker lintStepWalker) VisitTry(step *atc.TryStep) error {
+	walker.at(walker.path + ".try").walk(step.Step.Config)
+	return nil
+}
+
+func (walker lintStepWalker) VisitDo(step *atc.DoStep) error {
+	for i, sub := range step.Steps {
+		walker.at(fmt.Sprintf("%s.do[%d]", walker.path, i)).walk(sub.Config)
+	}
+	return nil
+}
+
+func (walker lintStepWalker) VisitInParallel(step *atc.InParallelStep) error {
+	for i, sub := range step.Config.Steps {
+		walker.at(fmt.Sprintf("%s.in_parallel.steps[%d]", walker.path, i)).walk(sub.Config)
+	}
+	return nil
+}
+
+func (walker lintStepWalker) VisitAcross(step *atc.AcrossStep) error {
+	walker.walk(step.Step)
+	return nil
+}
+
+func (walker lintStepWalker) VisitTimeout(step *atc.TimeoutStep) error {
+	walker.timeout = true
+	walker.walk(step.Step)
+	return nil
+}
+
+func (walker lintStepWalker) VisitRetry(step *atc.RetryStep) error {
+	walker.walk(step.Step)
+	return nil
+}
+
+func (walker lintStepWalker) VisitOnSuccess(step *atc.OnSuccessStep) error {
+	walker.walk(step.Step)
+	walker.at(walker.path + ".on_success").walk(step.Hook.Config)
+	return nil
+}
+
+func (walker lintStepWalker) VisitOnFailure(step *atc.OnFailureStep) error {
+	walker.walk(step.Step)
+	walker.at(walker.path + ".on_failure").walk(step.Hook.Config)
+	return nil
+}
+
+func (walker lintStepWalker) VisitOnAbort(step *atc.OnAbortStep) error {
+	walker.walk(step.Step)
+	walker.at(walker.path + ".on_abort").walk(step.Hook.Config)
+	return nil
+}
+
+func (walker lintStepWalker) VisitOnError(step *atc.OnErrorStep) error {
+	walker.walk(step.Step)
+	walker.at(walker.path + ".on_error").walk(step.Hook.Config)
+	return nil
+}
+
+func (walker lintStepWalker) VisitEnsure(step *atc.EnsureStep) error {
+	walker.walk(step.Step)
+	walker.at(walker.path + ".ensure").walk(step.Hook.Config)
+	return nil
+}
@@ -0,0 +1,418 @@
+package configvalidate_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lint", func() {
+	var (
+		source   string
+		rules    []configvalidate.LintRule
+		findings []configvalidate.LintFinding
+		lintErr  error
+	)
+
+	BeforeEach(func() {
+		rules = nil
+	})
+
+	JustBeforeEach(func() {
+		var config atc.Config
+		err := yaml.Unmarshal([]byte(source), &config)
+		Expect(err).ToNot(HaveOccurred())
+
+		findings, lintErr = configvalidate.Lint(config, []byte(source), rules...)
+	})
+
+	ruleIDs := func() []string {
+		ids := []string{}
+		for _, finding := range findings {
+			ids = append(ids, finding.RuleID)
+		}
+		return ids
+	}
+
+	Context("when the config is clean", func() {
+		BeforeEach(func() {
+			source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: some-job
+  serial_groups: [some-group]
+  plan:
+  - get: some-resource
+    trigger: true
+  - task: some-task
+    timeout: 1h
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ubuntu, tag: focal}
+      params:
+        PASSWORD: ((password))
+      run: {path: echo}
+  - put: some-resource
+
+- name: some-other-job
+  serial_groups: [some-group]
+  schedule: {cron: "0 * * * *"}
+  plan:
+  - get: some-resource
+    passed: [some-job]
+`
+		})
+
+		It("has no findings", func() {
+			Expect(lintErr).ToNot(HaveOccurred())
+			Expect(findings).To(BeEmpty())
+		})
+	})
+
+	Context("when no rules are given", func() {
+		BeforeEach(func() {
+			source = `
+jobs:
+- name: some-job
+  plan:
+  - task: unbounded
+    file: some/task.yml
+`
+		})
+
+		It("does not run the optional rules", func() {
+			Expect(lintErr).ToNot(HaveOccurred())
+			Expect(findings).To(BeEmpty())
+		})
+	})
+
+	Describe("LookupLintRule", func() {
+		It("finds default and optional rules", func() {
+			rule, found := configvalidate.LookupLintRule("no-trigger")
+			Expect(found).To(BeTrue())
+			Expect(rule).To(Equal(configvalidate.NoTriggerRule{}))
+
+			rule, found = configvalidate.LookupLintRule("task-timeout")
+			Expect(found).To(BeTrue())
+			Expect(rule).To(Equal(configvalidate.TaskTimeoutRule{}))
+		})
+
+		It("does not find unknown rules", func() {
+			_, found := configvalidate.LookupLintRule("bogus")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("unsatisfiable-passed", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.UnsatisfiablePassedRule{}}
+		})
+
+		Context("when passed constraints form a cycle", func() {
+			BeforeEach(func() {
+				source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: job-a
+  plan:
+  - get: some-resource
+    passed: [job-b]
+- name: job-b
+  plan:
+  - get: some-resource
+    passed: [job-a]
+`
+			})
+
+			It("reports each get in the cycle", func() {
+				Expect(findings).To(HaveLen(2))
+				Expect(findings[0].RuleID).To(Equal("unsatisfiable-passed"))
+				Expect(findings[0].Path).To(Equal("$.jobs[0].plan[0].passed"))
+				Expect(findings[0].Line).To(Equal(10))
+				Expect(findings[0].Message).To(ContainSubstring("passed job 'job-b', which itself depends on versions that have passed job 'job-a'"))
+				Expect(findings[1].Path).To(Equal("$.jobs[1].plan[0].passed"))
+			})
+		})
+
+		Context("when passed constraints require versions put by different jobs", func() {
+			BeforeEach(func() {
+				source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: job-a
+  plan:
+  - put: some-resource
+- name: job-b
+  plan:
+  - put: some-resource
+- name: job-c
+  plan:
+  - get: some-resource
+    passed: [job-a, job-b]
+`
+			})
+
+			It("reports the get", func() {
+				Expect(findings).To(HaveLen(1))
+				Expect(findings[0].Path).To(Equal("$.jobs[2].plan[0].passed"))
+				Expect(findings[0].Message).To(ContainSubstring("passed jobs 'job-a', 'job-b', which each only put their own versions to it"))
+			})
+		})
+
+		Context("when a job put to the resource also gets it", func() {
+			BeforeEach(func() {
+				source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: job-a
+  plan:
+  - put: some-resource
+- name: job-b
+  plan:
+  - get: some-resource
+    passed: [job-a]
+  - put: some-resource
+- name: job-c
+  plan:
+  - get: some-resource
+    passed: [job-a, job-b]
+`
+			})
+
+			It("has no findings", func() {
+				Expect(findings).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("no-trigger", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.NoTriggerRule{}}
+			source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: manual-job
+  plan:
+  - get: some-resource
+- name: nested-trigger-job
+  plan:
+  - in_parallel:
+    - get: some-resource
+      trigger: true
+- name: scheduled-job
+  schedule: {cron: "0 * * * *"}
+  plan:
+  - get: some-resource
+- name: orphaned-job
+  disable_manual_trigger: true
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("reports jobs which can never run", func() {
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Path).To(Equal("$.jobs[3]"))
+			Expect(findings[0].Message).To(Equal("jobs.orphaned-job has no get step with `trigger: true` and no schedule, and manual triggering is disabled, so it will never run"))
+		})
+	})
+
+	Describe("single-job-serial-group", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.SingleJobSerialGroupRule{}}
+			source = `
+jobs:
+- name: job-a
+  serial_groups: [shared, lonely]
+  plan: []
+- name: job-b
+  serial_groups: [shared]
+  plan: []
+`
+		})
+
+		It("reports groups with a single job", func() {
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].serial_groups"))
+			Expect(findings[0].Message).To(Equal("jobs.job-a is the only job in serial group 'lonely' - consider `serial: true` instead"))
+		})
+	})
+
+	Describe("task-timeout", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.TaskTimeoutRule{}}
+			source = `
+jobs:
+- name: some-job
+  plan:
+  - task: unbounded
+    file: some/task.yml
+  - do:
+    - task: bounded-by-parent
+      file: some/task.yml
+    timeout: 1h
+  - task: bounded
+    file: some/task.yml
+    timeout: 10m
+  on_failure:
+    task: unbounded-hook
+    file: some/task.yml
+`
+		})
+
+		It("reports tasks which are not bounded by a timeout", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].plan[0]"))
+			Expect(findings[0].Line).To(Equal(5))
+			Expect(findings[1].Path).To(Equal("$.jobs[0].on_failure"))
+			Expect(findings[1].Line).To(Equal(15))
+		})
+	})
+
+	Describe("unpinned-image", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.UnpinnedImageRule{}}
+			source = `
+jobs:
+- name: some-job
+  plan:
+  - task: no-tag
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: ubuntu}}
+      run: {path: echo}
+  - task: latest
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: ubuntu, tag: latest}}
+      run: {path: echo}
+  - task: tagged
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: ubuntu, tag: focal}}
+      run: {path: echo}
+  - task: digest
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: ubuntu@sha256:abcdef}}
+      run: {path: echo}
+  - task: version
+    config:
+      platform: linux
+      image_resource: {type: registry-image, source: {repository: ubuntu}, version: {digest: sha256:abcdef}}
+      run: {path: echo}
+`
+		})
+
+		It("reports images which are not pinned", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].plan[0].config.image_resource"))
+			Expect(findings[0].Line).To(Equal(8))
+			Expect(findings[1].Path).To(Equal("$.jobs[0].plan[1].config.image_resource"))
+			Expect(findings[1].Line).To(Equal(13))
+		})
+	})
+
+	Describe("hardcoded-secret", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.HardcodedSecretRule{}}
+			source = `
+resources:
+- name: some-resource
+  type: some-type
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    params:
+      nested: {api_key: hunter2}
+  - task: some-task
+    file: some/task.yml
+    params:
+      DB_PASSWORD: hunter2
+      DB_USER: admin
+      GITHUB_TOKEN: ((github-token))
+  - put: some-resource
+    params:
+      secret_access_key: prefix-((key))
+`
+		})
+
+		It("reports literal values for params which look like credentials", func() {
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].plan[0].params.nested.api_key"))
+			Expect(findings[0].Line).To(Equal(11))
+			Expect(findings[1].Path).To(Equal("$.jobs[0].plan[1].params.DB_PASSWORD"))
+			Expect(findings[1].Message).To(Equal("jobs.some-job task 'some-task' has a hard-coded value for param 'DB_PASSWORD' - use a ((var)) instead"))
+			Expect(findings[1].Line).To(Equal(15))
+		})
+	})
+
+	Describe("inline directives", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.TaskTimeoutRule{}, configvalidate.NoTriggerRule{}}
+			source = `
+# lint:disable-file no-trigger
+jobs:
+- name: some-job
+  disable_manual_trigger: true
+  plan:
+  # lint:disable task-timeout
+  - task: disabled-above
+    file: some/task.yml
+  - task: disabled-inline # lint:disable task-timeout, unpinned-image
+    file: some/task.yml
+  - task: not-disabled
+    file: some/task.yml
+`
+		})
+
+		It("suppresses the disabled findings", func() {
+			Expect(ruleIDs()).To(Equal([]string{"task-timeout"}))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].plan[2]"))
+			Expect(findings[0].Line).To(Equal(12))
+		})
+	})
+
+	Context("when in_parallel is configured with a list of steps", func() {
+		BeforeEach(func() {
+			rules = []configvalidate.LintRule{configvalidate.TaskTimeoutRule{}}
+			source = `
+jobs:
+- name: some-job
+  plan:
+  - in_parallel:
+    - get: some-resource
+    - task: some-task
+      file: some/task.yml
+`
+		})
+
+		It("resolves the line of the step", func() {
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Path).To(Equal("$.jobs[0].plan[0].in_parallel.steps[1]"))
+			Expect(findings[0].Line).To(Equal(7))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/webhook"
 	"github.com/gobwas/glob"
 )
 
@@ -62,6 +63,11 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, displayWarnings...)
 
+	importsErr := validateImports(c)
+	if importsErr != nil {
+		errorMessages = append(errorMessages, formatErr("imports", importsErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -184,6 +190,24 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Type == "time" {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "pipeline",
+				Message: identifier + " uses the time resource type, which runs a check container on every check interval - consider configuring `schedule:` on the jobs it triggers instead",
+			})
+		}
+
+		if resource.WebhookFilter != nil {
+			if resource.WebhookToken == "" {
+				errorMessages = append(errorMessages, identifier+" has a webhook_filter but no webhook_token")
+			}
+
+			_, err := webhook.NewFilter(resource.WebhookFilter)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.webhook_filter: %s", identifier, err))
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -346,6 +370,29 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		if len(job.Matrix) > 0 {
+			errorMessages = append(errorMessages, validateMatrix(identifier, job.Matrix)...)
+		}
+
+		if job.Schedule != nil {
+			err := job.Schedule.Validate()
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.schedule: %s", identifier, err))
+			}
+		}
+
+		if job.Concurrency != nil {
+			var inputNames []string
+			for _, input := range job.Inputs() {
+				inputNames = append(inputNames, input.Name)
+			}
+
+			err := job.Concurrency.Validate(inputNames)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.concurrency: %s", identifier, err))
+			}
+		}
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -360,6 +407,33 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateMatrix(identifier string, matrix []atc.MatrixVarConfig) []string {
+	var errorMessages []string
+
+	if !atc.EnableAcrossStep {
+		errorMessages = append(errorMessages, identifier+" has a matrix, which must be explicitly opted-in to using the `--enable-across-step` flag")
+	}
+
+	seen := map[string]bool{}
+	for i, v := range matrix {
+		varIdentifier := fmt.Sprintf("%s.matrix[%d]", identifier, i)
+
+		if v.Var == "" {
+			errorMessages = append(errorMessages, varIdentifier+" has no var name")
+		} else if seen[v.Var] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has repeated var name '%s'", varIdentifier, v.Var))
+		}
+
+		seen[v.Var] = true
+
+		if len(v.Values) == 0 {
+			errorMessages = append(errorMessages, varIdentifier+" has no values")
+		}
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -424,6 +498,30 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateImports(c atc.Config) error {
+	var errorMessages []string
+
+	for i, imp := range c.Imports {
+		identifier := fmt.Sprintf("imports[%d]", i)
+
+		if imp.File == "" && imp.Library == "" {
+			errorMessages = append(errorMessages, identifier+" has no file or library")
+		} else if imp.File != "" && imp.Library != "" {
+			errorMessages = append(errorMessages, identifier+" has both a file and a library")
+		}
+
+		if imp.Version != 0 && imp.Library == "" {
+			errorMessages = append(errorMessages, identifier+" has a version but no library")
+		}
+
+		if imp.Resolved == nil {
+			errorMessages = append(errorMessages, identifier+" has not been resolved")
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 
@@ -497,6 +497,61 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource uses the time resource type", func() {
+			BeforeEach(func() {
+				config.Resources[0].Type = "time"
+			})
+
+			It("returns a warning suggesting a schedule", func() {
+				Expect(errorMessages).To(HaveLen(0))
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0].Message).To(ContainSubstring("resources.some-resource uses the time resource type"))
+				Expect(warnings[0].Message).To(ContainSubstring("consider configuring `schedule:`"))
+			})
+		})
+
+		Context("when a resource has a webhook filter", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookToken = "some-token"
+				config.Resources[0].WebhookFilter = &atc.WebhookFilter{
+					Match: []atc.WebhookMatch{
+						{Path: ".ref", Patterns: []string{"refs/heads/*"}},
+					},
+					Vars: map[string]string{
+						"branch": ".ref",
+					},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the resource has no webhook token", func() {
+				BeforeEach(func() {
+					config.Resources[0].WebhookToken = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a webhook_filter but no webhook_token"))
+				})
+			})
+
+			Context("when a path is invalid", func() {
+				BeforeEach(func() {
+					config.Resources[0].WebhookFilter.Vars["branch"] = "{.ref"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook_filter: vars.branch: invalid path '{.ref'"))
+				})
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, atc.ResourceConfig{
@@ -1003,6 +1058,52 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan allows an invalid network destination", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Network: &atc.NetworkConfig{
+								Allow: []string{"10.0.0.0/8", "1.2.3.4", "api.example.com", "not a host"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network.allow: 'not a host' is not a CIDR, IP address or hostname"))
+					Expect(errorMessages[0]).ToNot(ContainSubstring("api.example.com"))
+				})
+			})
+
+			Context("when a task plan allows an IPv6 network destination", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Network: &atc.NetworkConfig{
+								Allow: []string{"::ffff:1.2.3.4", "fd00::/8", "2001:db8::1"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network.allow: 'fd00::/8' is an IPv6 CIDR, which is not supported"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network.allow: '2001:db8::1' is an IPv6 address, which is not supported"))
+					Expect(errorMessages[0]).ToNot(ContainSubstring("1.2.3.4"))
+				})
+			})
+
 			Context("when a put plan has refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1791,6 +1892,45 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an approve step requires more approvals than there are allowed users", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApproveStep{
+							Name: "deploy",
+							Approvers: &atc.ApproversConfig{
+								Users: []string{"some-user"},
+							},
+							Required: 2,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approve(deploy): requires 2 approvals but only 1 users are allowed to approve"))
+				})
+			})
+
+			Context("when an approve step lists no approvers", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApproveStep{
+							Name:      "deploy",
+							Approvers: &atc.ApproversConfig{},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approve(deploy): approvers must list at least one role or user"))
+				})
+			})
+
 			Context("when a step has unknown fields", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2055,6 +2195,144 @@ var _ = Describe("ValidateConfig", func() {
 				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has negative build_log_retention.days: -1"))
 			})
 		})
+
+		Context("when a job has a schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &atc.ScheduleConfig{
+					Cron:     "0 9 * * 1-5",
+					Location: "America/Toronto",
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the cron expression is invalid", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Schedule.Cron = "every morning"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule: invalid cron 'every morning'"))
+				})
+			})
+
+			Context("when the location is invalid", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Schedule.Location = "Middle/Earth"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule: invalid location 'Middle/Earth'"))
+				})
+			})
+		})
+
+		Context("when a job has a concurrency key", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Concurrency = &atc.ConcurrencyConfig{
+					Key:              "pr-((some-input.number))",
+					CancelInProgress: true,
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the key is empty", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Concurrency.Key = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.concurrency: key is empty"))
+				})
+			})
+
+			Context("when the key refers to an unknown input", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Concurrency.Key = "((bogus-input.number))"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.concurrency: key refers to unknown input 'bogus-input'"))
+				})
+			})
+
+			Context("when the key refers to a whole version", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Concurrency.Key = "((some-input))"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.concurrency: invalid reference '((some-input))' in key"))
+				})
+			})
+		})
+
+		Context("when a job has a matrix", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Matrix = []atc.MatrixVarConfig{
+					{Var: "os", Values: []interface{}{"linux", "windows"}},
+					{Var: "go", Values: []interface{}{"1.15", "1.16"}},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the across step is not enabled", func() {
+				BeforeEach(func() {
+					atc.EnableAcrossStep = false
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has a matrix, which must be explicitly opted-in to using the `--enable-across-step` flag"))
+				})
+			})
+
+			Context("when a var has no name", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Matrix[1].Var = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.matrix[1] has no var name"))
+				})
+			})
+
+			Context("when a var name is repeated", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Matrix[1].Var = "os"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.matrix[1] has repeated var name 'os'"))
+				})
+			})
+
+			Context("when a var has no values", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Matrix[0].Values = nil
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.matrix[0] has no values"))
+				})
+			})
+		})
 	})
 
 	Describe("validating display config", func() {
@@ -2111,6 +2389,66 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("validating imports", func() {
+		Context("when the imports have been resolved", func() {
+			BeforeEach(func() {
+				config.Imports = atc.ImportConfigs{
+					{File: "some/file.yml", Resolved: &atc.ResolvedImport{Digest: "sha256:some-digest"}},
+					{Library: "some-library", Version: 2, Resolved: &atc.ResolvedImport{Version: 2, Digest: "sha256:some-digest"}},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when an import has not been resolved", func() {
+			BeforeEach(func() {
+				config.Imports = atc.ImportConfigs{{Library: "some-library"}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid imports:"))
+				Expect(errorMessages[0]).To(ContainSubstring("imports[0] has not been resolved"))
+			})
+		})
+
+		Context("when an import has neither a file nor a library", func() {
+			BeforeEach(func() {
+				config.Imports = atc.ImportConfigs{{Resolved: &atc.ResolvedImport{}}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("imports[0] has no file or library"))
+			})
+		})
+
+		Context("when an import has both a file and a library", func() {
+			BeforeEach(func() {
+				config.Imports = atc.ImportConfigs{{File: "some/file.yml", Library: "some-library", Resolved: &atc.ResolvedImport{}}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("imports[0] has both a file and a library"))
+			})
+		})
+
+		Context("when a file import has a version", func() {
+			BeforeEach(func() {
+				config.Imports = atc.ImportConfigs{{File: "some/file.yml", Version: 1, Resolved: &atc.ResolvedImport{}}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("imports[0] has a version but no library"))
+			})
+		})
+	})
+
 	Describe("invalid pipeline", func() {
 		Context("contains zero jobs", func() {
 			BeforeEach(func() {
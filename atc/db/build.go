@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -96,7 +97,13 @@ var buildsQuery = psql.Select(`
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
-		b.span_context
+		b.span_context,
+		b.parent_build_id,
+		b.matrix_values,
+		b.vars,
+		b.reason,
+		b.events_archived,
+		b.concurrency_key
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -109,9 +116,12 @@ var buildsQuery = psql.Select(`
 var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0)").
 	From("builds as b")
 
+// latestCompletedBuildQuery leaves out the builds of a matrix, which belong to
+// their parent build rather than directly to the job.
 var latestCompletedBuildQuery = psql.Select("max(id)").
 	From("builds").
-	Where(sq.Expr(`status NOT IN ('pending', 'started')`))
+	Where(sq.Expr(`status NOT IN ('pending', 'started')`)).
+	Where(sq.Eq{"parent_build_id": nil})
 
 //counterfeiter:generate . Build
 type Build interface {
@@ -151,6 +161,12 @@ type Build interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	ParentBuildID() int
+	MatrixValues() atc.MatrixValues
+	BuildVars() vars.StaticVariables
+	Reason() string
+	EventsArchived() bool
+	ConcurrencyKey() string
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -169,12 +185,21 @@ type Build interface {
 	Start(atc.Plan) (bool, error)
 	Finish(BuildStatus) error
 
+	StartMatrixBuild(int, atc.MatrixValues, atc.Plan) (Build, error)
+	MatrixBuilds() ([]Build, error)
+
+	SetWaitingForApproval(atc.PlanID, bool) error
+	SaveApproval(BuildApproval) error
+	Approvals() ([]BuildApproval, error)
+
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
 
 	SetInterceptible(bool) error
+	SaveConcurrencyKey(string) error
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
+	ArchiveEvents(context.Context, BuildEventStore) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
@@ -234,6 +259,17 @@ type build struct {
 	rerunOfName string
 	rerunNumber int
 
+	parentBuildID int
+	matrixValues  atc.MatrixValues
+
+	buildVars vars.StaticVariables
+
+	reason string
+
+	eventsArchived bool
+
+	concurrencyKey string
+
 	schema      string
 	privatePlan atc.Plan
 	publicPlan  *json.RawMessage
@@ -381,6 +417,15 @@ func (b *build) RerunOf() int          { return b.rerunOf }
 func (b *build) RerunOfName() string   { return b.rerunOfName }
 func (b *build) RerunNumber() int      { return b.rerunNumber }
 func (b *build) CreatedBy() *string    { return b.createdBy }
+func (b *build) ParentBuildID() int    { return b.parentBuildID }
+
+func (b *build) MatrixValues() atc.MatrixValues { return b.matrixValues }
+
+func (b *build) BuildVars() vars.StaticVariables { return b.buildVars }
+
+func (b *build) Reason() string         { return b.reason }
+func (b *build) EventsArchived() bool   { return b.eventsArchived }
+func (b *build) ConcurrencyKey() string { return b.concurrencyKey }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -440,6 +485,35 @@ func (b *build) SetInterceptible(i bool) error {
 	return nil
 }
 
+// SaveConcurrencyKey records the key of the build's job's concurrency config,
+// as interpolated with the build's inputs, so that newer builds with the same
+// key can supersede it.
+func (b *build) SaveConcurrencyKey(key string) error {
+	rows, err := psql.Update("builds").
+		Set("concurrency_key", key).
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.concurrencyKey = key
+
+	return nil
+}
+
 func (b *build) ResourcesChecked() (bool, error) {
 	var notChecked bool
 	err := b.conn.QueryRow(`
@@ -472,6 +546,16 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 	defer Rollback(tx)
 
 	started, err := b.start(tx, plan)
+	if _, ok := err.(BuildsQuotaReachedError); ok {
+		// commit the event saved while the build waits for its team's quota
+		commitErr := tx.Commit()
+		if commitErr != nil {
+			return false, commitErr
+		}
+
+		return false, err
+	}
+
 	if err != nil {
 		return false, err
 	}
@@ -499,6 +583,15 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 }
 
 func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
+	err := reserveBuildSlot(tx, b.teamID, 0)
+	if quotaErr, ok := err.(BuildsQuotaReachedError); ok {
+		return false, b.waitForQuota(tx, quotaErr)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
 	metadata, err := json.Marshal(plan)
 	if err != nil {
 		return false, err
@@ -544,6 +637,151 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 	return true, nil
 }
 
+// waitForQuota saves a WaitingForTeamQuota event the first time the build
+// can't be started because of its team's quota, and returns the quota error.
+func (b *build) waitForQuota(tx Tx, quotaErr BuildsQuotaReachedError) error {
+	result, err := psql.Update("builds").
+		Set("waiting_for_quota", true).
+		Where(sq.Eq{
+			"id":                b.id,
+			"waiting_for_quota": false,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return quotaErr
+	}
+
+	err = b.saveEvent(tx, event.WaitingForTeamQuota{
+		Time:  time.Now().Unix(),
+		Quota: quotaErr.Quota(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return quotaErr
+}
+
+// StartMatrixBuild creates and starts the build for the matrix combination at
+// the given index. The build belongs to the same job as this build and adopts
+// its inputs. If the build has already been created, e.g. because this build
+// is being resumed after an ATC restart, the existing build is returned.
+//
+// The build counts towards the team's quota of concurrent builds in place of
+// this build. If the quota has been reached, a BuildsQuotaReachedError is
+// returned and the build is not created.
+func (b *build) StartMatrixBuild(index int, values atc.MatrixValues, plan atc.Plan) (Build, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	name := fmt.Sprintf("%s-%d", b.name, index+1)
+
+	matrixBuild := newEmptyBuild(b.conn, b.lockFactory)
+	err = scanBuild(matrixBuild, buildsQuery.
+		Where(sq.Eq{
+			"b.parent_build_id": b.id,
+			"b.name":            name,
+		}).
+		RunWith(tx).
+		QueryRow(),
+		b.conn.EncryptionStrategy(),
+	)
+	if err == nil {
+		return matrixBuild, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	err = reserveBuildSlot(tx, b.teamID, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	matrixValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	extraValues := map[string]interface{}{
+		"job_id":          b.jobID,
+		"parent_build_id": b.id,
+		"matrix_values":   string(matrixValues),
+		"created_by":      b.createdBy,
+		"scheduled":       true,
+		"inputs_ready":    true,
+	}
+
+	if b.buildVars != nil {
+		buildVars, err := json.Marshal(b.buildVars)
+		if err != nil {
+			return nil, err
+		}
+
+		extraValues["vars"] = string(buildVars)
+	}
+
+	err = createStartedBuild(tx, matrixBuild, startedBuildArgs{
+		Name:              name,
+		PipelineID:        b.pipelineID,
+		TeamID:            b.teamID,
+		Plan:              plan,
+		ManuallyTriggered: b.isManuallyTriggered,
+		SpanContext:       b.spanContext,
+		ExtraValues:       extraValues,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = psql.Insert("build_resource_config_version_inputs").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
+		Select(psql.Select("i.resource_id", "i.version_md5", "i.name", "i.first_occurrence").
+			Column("?", matrixBuild.id).
+			From("build_resource_config_version_inputs i").
+			Where(sq.Eq{"i.build_id": b.id})).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.conn.Bus().Notify(atc.ComponentBuildTracker)
+	if err != nil {
+		return nil, err
+	}
+
+	return matrixBuild, nil
+}
+
+// MatrixBuilds returns the builds started by the build's matrix, ordered by
+// their index.
+func (b *build) MatrixBuilds() ([]Build, error) {
+	return getBuilds(buildsQuery.
+		Where(sq.Eq{"b.parent_build_id": b.id}).
+		OrderBy("b.id ASC"), b.conn, b.lockFactory)
+}
+
 func (b *build) Finish(status BuildStatus) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -584,7 +822,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	if b.jobID != 0 && status == BuildStatusSucceeded {
+	// the builds of a matrix are aggregated by their parent build, which takes
+	// care of updating the job once all of them have finished
+	isMatrixBuild := b.parentBuildID != 0
+
+	if b.jobID != 0 && !isMatrixBuild && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches").
 			Where(sq.And{
 				sq.Eq{
@@ -602,8 +844,11 @@ func (b *build) Finish(status BuildStatus) error {
 
 		rows, err := psql.Select("o.resource_id", "o.version_md5").
 			From("build_resource_config_version_outputs o").
-			Where(sq.Eq{
-				"o.build_id": b.id,
+			Where(sq.Or{
+				sq.Eq{
+					"o.build_id": b.id,
+				},
+				sq.Expr("o.build_id IN (SELECT id FROM builds WHERE parent_build_id = ?)", b.id),
 			}).
 			RunWith(tx).
 			Query()
@@ -718,7 +963,7 @@ WITH RECURSIVE pipelines_to_archive AS (
 		}
 	}
 
-	if b.jobID != 0 {
+	if b.jobID != 0 && !isMatrixBuild {
 		err = requestScheduleOnDownstreamJobs(tx, b.jobID)
 		if err != nil {
 			return err
@@ -1068,6 +1313,12 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.eventsArchived {
+		if archive := b.conn.BuildEventArchive(); archive != nil {
+			return newArchivedBuildEventSource(context.Background(), b.id, archive, from)
+		}
+	}
+
 	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -1720,7 +1971,13 @@ func (b *build) SavePipeline(
 
 	jobID := newNullInt64(b.jobID)
 	buildID := newNullInt64(b.id)
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID)
+
+	author := fmt.Sprintf("build #%d", b.id)
+	if b.jobID != 0 {
+		author = fmt.Sprintf("%s/%s #%s", b.PipelineRef(), b.jobName, b.name)
+	}
+
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID, author)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1765,11 +2022,11 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber                                 sql.NullInt64
+		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber, parentBuildID                  sql.NullInt64
 		schema, privatePlan, jobName, resourceName, resourceTypeName, pipelineName, publicPlan, rerunOfName sql.NullString
 		createTime, startTime, endTime, reapTime                                                            pq.NullTime
-		nonce, spanContext, createdBy                                                                       sql.NullString
-		drained, aborted, completed                                                                         bool
+		nonce, spanContext, createdBy, matrixValues, buildVars, reason, concurrencyKey                      sql.NullString
+		drained, aborted, completed, eventsArchived                                                         bool
 		status                                                                                              string
 		pipelineInstanceVars                                                                                sql.NullString
 	)
@@ -1808,6 +2065,12 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
+		&parentBuildID,
+		&matrixValues,
+		&buildVars,
+		&reason,
+		&eventsArchived,
+		&concurrencyKey,
 	)
 	if err != nil {
 		return err
@@ -1833,6 +2096,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
+	b.parentBuildID = int(parentBuildID.Int64)
 
 	var (
 		noncense      *string
@@ -1881,6 +2145,26 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		b.createdBy = &createdBy.String
 	}
 
+	b.reason = reason.String
+	b.eventsArchived = eventsArchived
+	b.concurrencyKey = concurrencyKey.String
+
+	b.matrixValues = nil
+	if matrixValues.Valid {
+		err = json.Unmarshal([]byte(matrixValues.String), &b.matrixValues)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.buildVars = nil
+	if buildVars.Valid {
+		err = json.Unmarshal([]byte(buildVars.String), &b.buildVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -2041,6 +2325,7 @@ func updateNextBuildForJob(tx Tx, jobID int, latestNonRerunId int) error {
 			INNER JOIN jobs j ON j.id = b.job_id
 			WHERE b.job_id = $1
 			AND b.status IN ('pending', 'started')
+			AND b.parent_build_id IS NULL
 			AND (b.rerun_of IS NULL OR b.rerun_of = $2)
 		)
 		WHERE j.id = $1
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var ErrBuildApprovalAlreadyRecorded = errors.New("user has already approved or rejected this step")
+
+var ErrBuildNotWaitingForApproval = errors.New("step is not waiting for approval")
+
+// BuildApproval records a user's decision on an approve step of a build.
+type BuildApproval struct {
+	PlanID    atc.PlanID
+	UserName  string
+	Approved  bool
+	Comment   string
+	CreatedAt time.Time
+}
+
+// SetWaitingForApproval records whether the approve step is waiting for
+// approvals. Approvals are only accepted while it is.
+func (b *build) SetWaitingForApproval(planID atc.PlanID, waiting bool) error {
+	var err error
+	if waiting {
+		_, err = psql.Insert("build_waiting_approvals").
+			Columns("build_id", "plan_id").
+			Values(b.id, string(planID)).
+			Suffix("ON CONFLICT DO NOTHING").
+			RunWith(b.conn).
+			Exec()
+	} else {
+		_, err = psql.Delete("build_waiting_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			Exec()
+	}
+
+	return err
+}
+
+// SaveApproval records the approval or rejection of an approve step. Each user
+// may only decide once per step so that the recorded decisions can serve as
+// an audit trail. The decision is only recorded while the build is running
+// and the step is waiting for approvals.
+func (b *build) SaveApproval(approval BuildApproval) error {
+	result, err := psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "user_name", "approved", "comment").
+		Select(psql.Select().
+			Column("w.build_id").
+			Column("w.plan_id").
+			Column("?", approval.UserName).
+			Column("?::boolean", approval.Approved).
+			Column("?", approval.Comment).
+			From("build_waiting_approvals w").
+			Join("builds b ON b.id = w.build_id").
+			Where(sq.Eq{
+				"w.build_id": b.id,
+				"w.plan_id":  string(approval.PlanID),
+				"b.status":   BuildStatusStarted,
+			})).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrBuildApprovalAlreadyRecorded
+		}
+
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildNotWaitingForApproval
+	}
+
+	return nil
+}
+
+// Approvals returns the approvals and rejections recorded for the build's
+// approve steps, in the order they were made.
+func (b *build) Approvals() ([]BuildApproval, error) {
+	rows, err := psql.Select("plan_id", "user_name", "approved", "comment", "created_at").
+		From("build_approvals").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	approvals := []BuildApproval{}
+	for rows.Next() {
+		var approval BuildApproval
+		var planID string
+
+		err = rows.Scan(&planID, &approval.UserName, &approval.Approved, &approval.Comment, &approval.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		approval.PlanID = atc.PlanID(planID)
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
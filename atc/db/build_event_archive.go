@@ -0,0 +1,215 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/event"
+)
+
+//counterfeiter:generate . BuildEventStore
+
+// BuildEventStore is a blob store which the events of builds are archived
+// to.
+type BuildEventStore interface {
+	Upload(ctx context.Context, key string, contents io.Reader) error
+	Download(ctx context.Context, key string) (io.ReadCloser, bool, error)
+}
+
+// WithBuildEventArchive returns a wrapper of the DB connection whose builds
+// read the events of archived builds back from the store.
+func WithBuildEventArchive(conn Conn, store BuildEventStore) Conn {
+	return &archivingConn{
+		Conn:  conn,
+		store: store,
+	}
+}
+
+type archivingConn struct {
+	Conn
+
+	store BuildEventStore
+}
+
+func (c *archivingConn) BuildEventArchive() BuildEventStore {
+	return c.store
+}
+
+var buildEventArchiveCompression = compression.NewZstdCompression()
+
+func buildEventArchiveKey(buildID int) string {
+	return fmt.Sprintf("build-events/%d.json.zst", buildID)
+}
+
+// ArchiveEvents uploads the events of the build to the store, as
+// zstd-compressed newline-delimited JSON envelopes, and marks the build's
+// events as archived. The events are left in the database; it is up to the
+// caller to delete them once they have been archived.
+func (b *build) ArchiveEvents(ctx context.Context, store BuildEventStore) error {
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(b.writeArchivedEvents(writer))
+	}()
+
+	err := store.Upload(ctx, buildEventArchiveKey(b.id), reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+
+	_, err = psql.Update("builds").
+		Set("events_archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = true
+
+	return nil
+}
+
+func (b *build) writeArchivedEvents(w io.Writer) error {
+	compressed, err := buildEventArchiveCompression.NewWriter(w)
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Select("event_id", "type", "version", "payload").
+		From(b.eventsTable()).
+		Where(sq.Or{
+			sq.Eq{"build_id": b.id},
+			sq.Eq{"build_id_old": b.id},
+		}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	encoder := json.NewEncoder(compressed)
+	for rows.Next() {
+		var id int
+		var t, v, p string
+		err := rows.Scan(&id, &t, &v, &p)
+		if err != nil {
+			return err
+		}
+
+		data := json.RawMessage(p)
+
+		err = encoder.Encode(event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+			EventID: strconv.Itoa(id),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	return compressed.Close()
+}
+
+func newArchivedBuildEventSource(ctx context.Context, buildID int, store BuildEventStore, from uint) (*archivedBuildEventSource, error) {
+	blob, found, err := store.Download(ctx, buildEventArchiveKey(buildID))
+	if err != nil {
+		return nil, fmt.Errorf("download archived events: %w", err)
+	}
+
+	if !found {
+		return &archivedBuildEventSource{}, nil
+	}
+
+	decompressed, err := buildEventArchiveCompression.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return nil, fmt.Errorf("decompress archived events: %w", err)
+	}
+
+	return &archivedBuildEventSource{
+		blob:         blob,
+		decompressed: decompressed,
+		decoder:      json.NewDecoder(bufio.NewReader(decompressed)),
+		from:         from,
+	}, nil
+}
+
+// archivedBuildEventSource reads the events of a build back from the
+// archive. As archived builds have finished, the stream ends once all of its
+// events have been read.
+type archivedBuildEventSource struct {
+	blob         io.Closer
+	decompressed io.Closer
+	decoder      *json.Decoder
+	from         uint
+	closed       bool
+}
+
+func (source *archivedBuildEventSource) Next() (event.Envelope, error) {
+	if source.closed {
+		return event.Envelope{}, ErrBuildEventStreamClosed
+	}
+
+	if source.decoder == nil {
+		return event.Envelope{}, ErrEndOfBuildEventStream
+	}
+
+	for {
+		var envelope event.Envelope
+		err := source.decoder.Decode(&envelope)
+		if err != nil {
+			if err == io.EOF {
+				return event.Envelope{}, ErrEndOfBuildEventStream
+			}
+
+			return event.Envelope{}, err
+		}
+
+		id, err := strconv.Atoi(envelope.EventID)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		if id >= int(source.from) {
+			return envelope, nil
+		}
+	}
+}
+
+func (source *archivedBuildEventSource) Close() error {
+	if source.closed {
+		return nil
+	}
+
+	source.closed = true
+
+	if source.decompressed != nil {
+		source.decompressed.Close()
+	}
+
+	if source.blob != nil {
+		return source.blob.Close()
+	}
+
+	return nil
+}
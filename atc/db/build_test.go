@@ -1,11 +1,15 @@
 package db_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"strconv"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/dummy"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/tracing"
@@ -1102,6 +1107,379 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Start when the team has a concurrent builds quota", func() {
+		BeforeEach(func() {
+			err := team.UpdateQuotas(atc.TeamQuotas{MaxConcurrentBuilds: 1})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts the build while the team is within its quota", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Context("when the team is running as many builds as its quota allows", func() {
+			BeforeEach(func() {
+				_, err := team.CreateStartedBuild(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("leaves the build pending", func() {
+				started, err := build.Start(atc.Plan{})
+				Expect(err).To(Equal(db.BuildsQuotaReachedError{MaxConcurrentBuilds: 1}))
+				Expect(started).To(BeFalse())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			})
+
+			It("saves a waiting for team quota event only once", func() {
+				_, err := build.Start(atc.Plan{})
+				Expect(err).To(HaveOccurred())
+
+				_, err = build.Start(atc.Plan{})
+				Expect(err).To(HaveOccurred())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev.Event).To(Equal(event.EventTypeWaitingForTeamQuota))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("does not count check builds", func() {
+				_, err := dbConn.Exec("UPDATE builds SET name = $1 WHERE status = 'started'", db.CheckBuildName)
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+		})
+
+		Context("when the build starts the builds of its matrix", func() {
+			BeforeEach(func() {
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("counts the matrix builds in place of the build", func() {
+				_, err := build.StartMatrixBuild(0, atc.MatrixValues{"platform": "linux"}, atc.Plan{ID: "some-plan"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = build.StartMatrixBuild(1, atc.MatrixValues{"platform": "windows"}, atc.Plan{ID: "some-plan"})
+				Expect(err).To(Equal(db.BuildsQuotaReachedError{MaxConcurrentBuilds: 1}))
+
+				_, found, err := job.Build(build.Name() + "-2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("StartMatrixBuild", func() {
+		var matrixBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			matrixBuild, err = build.StartMatrixBuild(0, atc.MatrixValues{"platform": "linux"}, atc.Plan{ID: "some-plan"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts a build of the job belonging to the build", func() {
+			Expect(matrixBuild.Name()).To(Equal(build.Name() + "-1"))
+			Expect(matrixBuild.JobID()).To(Equal(job.ID()))
+			Expect(matrixBuild.ParentBuildID()).To(Equal(build.ID()))
+			Expect(matrixBuild.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(matrixBuild.ID()).To(BeNumerically(">", build.ID()))
+		})
+
+		It("returns the existing build when started again", func() {
+			again, err := build.StartMatrixBuild(0, atc.MatrixValues{"platform": "linux"}, atc.Plan{ID: "some-plan"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again.ID()).To(Equal(matrixBuild.ID()))
+		})
+
+		It("leaves the matrix build out of the job's builds", func() {
+			builds, _, err := job.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(build.ID()))
+
+			latest, found, err := job.Build("latest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latest.ID()).To(Equal(build.ID()))
+		})
+
+		It("lists the matrix build among the build's matrix builds", func() {
+			matrixBuilds, err := build.MatrixBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matrixBuilds).To(HaveLen(1))
+			Expect(matrixBuilds[0].ID()).To(Equal(matrixBuild.ID()))
+		})
+
+		Context("when the matrix build and then the build finish", func() {
+			BeforeEach(func() {
+				err := matrixBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("makes the build the job's finished build", func() {
+				finished, next, err := job.FinishedAndNextBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finished.ID()).To(Equal(build.ID()))
+				Expect(finished.Status()).To(Equal(db.BuildStatusFailed))
+				Expect(next).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Approvals", func() {
+		BeforeEach(func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.SetWaitingForApproval("some-plan", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SetWaitingForApproval("some-other-plan", true)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the recorded approvals in order", func() {
+			err := build.SaveApproval(db.BuildApproval{
+				PlanID:   "some-plan",
+				UserName: "some-user",
+				Approved: true,
+				Comment:  "lgtm",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveApproval(db.BuildApproval{
+				PlanID:   "some-plan",
+				UserName: "some-other-user",
+				Approved: false,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			approvals, err := build.Approvals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(HaveLen(2))
+			Expect(approvals[0].PlanID).To(Equal(atc.PlanID("some-plan")))
+			Expect(approvals[0].UserName).To(Equal("some-user"))
+			Expect(approvals[0].Approved).To(BeTrue())
+			Expect(approvals[0].Comment).To(Equal("lgtm"))
+			Expect(approvals[0].CreatedAt).NotTo(BeZero())
+			Expect(approvals[1].UserName).To(Equal("some-other-user"))
+			Expect(approvals[1].Approved).To(BeFalse())
+		})
+
+		It("does not allow a user to decide twice on the same step", func() {
+			err := build.SaveApproval(db.BuildApproval{PlanID: "some-plan", UserName: "some-user", Approved: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveApproval(db.BuildApproval{PlanID: "some-plan", UserName: "some-user", Approved: false})
+			Expect(err).To(Equal(db.ErrBuildApprovalAlreadyRecorded))
+
+			err = build.SaveApproval(db.BuildApproval{PlanID: "some-other-plan", UserName: "some-user", Approved: true})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not accept approvals for a step that is not waiting", func() {
+			err := build.SaveApproval(db.BuildApproval{PlanID: "some-unknown-plan", UserName: "some-user", Approved: true})
+			Expect(err).To(Equal(db.ErrBuildNotWaitingForApproval))
+
+			err = build.SetWaitingForApproval("some-plan", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveApproval(db.BuildApproval{PlanID: "some-plan", UserName: "some-user", Approved: true})
+			Expect(err).To(Equal(db.ErrBuildNotWaitingForApproval))
+
+			approvals, err := build.Approvals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(BeEmpty())
+		})
+
+		Context("when the build is not running", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusAborted)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not accept approvals", func() {
+				err := build.SaveApproval(db.BuildApproval{PlanID: "some-plan", UserName: "some-user", Approved: true})
+				Expect(err).To(Equal(db.ErrBuildNotWaitingForApproval))
+			})
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				err := build.SaveApproval(db.BuildApproval{PlanID: "some-plan", UserName: "some-user", Approved: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				deleted, err := build.Delete()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeTrue())
+			})
+
+			It("keeps the recorded approvals as an audit trail", func() {
+				var count int
+				err := dbConn.QueryRow(`SELECT COUNT(*) FROM build_approvals WHERE build_id IS NULL AND user_name = 'some-user'`).Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("ArchiveEvents", func() {
+		var (
+			fakeStore *dbfakes.FakeBuildEventStore
+			blobs     map[string][]byte
+		)
+
+		BeforeEach(func() {
+			blobs = map[string][]byte{}
+
+			fakeStore = new(dbfakes.FakeBuildEventStore)
+			fakeStore.UploadStub = func(_ context.Context, key string, contents io.Reader) error {
+				payload, err := ioutil.ReadAll(contents)
+				if err != nil {
+					return err
+				}
+
+				blobs[key] = payload
+				return nil
+			}
+			fakeStore.DownloadStub = func(_ context.Context, key string) (io.ReadCloser, bool, error) {
+				payload, found := blobs[key]
+				if !found {
+					return nil, false, nil
+				}
+
+				return ioutil.NopCloser(bytes.NewBuffer(payload)), true, nil
+			}
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("uploads the events and marks them as archived", func() {
+			Expect(build.EventsArchived()).To(BeFalse())
+
+			err := build.ArchiveEvents(context.TODO(), fakeStore)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(blobs).To(HaveKey(fmt.Sprintf("build-events/%d.json.zst", build.ID())))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.EventsArchived()).To(BeTrue())
+		})
+
+		It("reads the events back from the archive once they are deleted", func() {
+			err := build.ArchiveEvents(context.TODO(), fakeStore)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, found, err := build.Pipeline()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = pipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			archivedBuild, found, err := db.NewBuildFactory(db.WithBuildEventArchive(dbConn, fakeStore), lockFactory, 0, 0).Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := archivedBuild.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some log"}, "1")))
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			}, "2")))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("reads the events back for builds loaded through their job", func() {
+			err := build.ArchiveEvents(context.TODO(), fakeStore)
+			Expect(err).NotTo(HaveOccurred())
+
+			archivingTeam := db.NewTeamFactory(db.WithBuildEventArchive(dbConn, fakeStore), lockFactory).GetByID(build.TeamID())
+
+			pipeline, found, err := archivingTeam.Pipeline(atc.PipelineRef{Name: "some-build-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = pipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			archivedBuild, found, err := job.Build(build.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := archivedBuild.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some log"}, "1")))
+		})
+
+		Context("when the upload fails", func() {
+			BeforeEach(func() {
+				fakeStore.UploadStub = nil
+				fakeStore.UploadReturns(errors.New("nope"))
+			})
+
+			It("does not mark the events as archived", func() {
+				err := build.ArchiveEvents(context.TODO(), fakeStore)
+				Expect(err).To(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.EventsArchived()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		It("saves and propagates events correctly", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -74,6 +75,30 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalsStub        func() ([]db.BuildApproval, error)
+	approvalsMutex       sync.RWMutex
+	approvalsArgsForCall []struct {
+	}
+	approvalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	approvalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	ArchiveEventsStub        func(context.Context, db.BuildEventStore) error
+	archiveEventsMutex       sync.RWMutex
+	archiveEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.BuildEventStore
+	}
+	archiveEventsReturns struct {
+		result1 error
+	}
+	archiveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -99,6 +124,26 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	BuildVarsStub        func() vars.StaticVariables
+	buildVarsMutex       sync.RWMutex
+	buildVarsArgsForCall []struct {
+	}
+	buildVarsReturns struct {
+		result1 vars.StaticVariables
+	}
+	buildVarsReturnsOnCall map[int]struct {
+		result1 vars.StaticVariables
+	}
+	ConcurrencyKeyStub        func() string
+	concurrencyKeyMutex       sync.RWMutex
+	concurrencyKeyArgsForCall []struct {
+	}
+	concurrencyKeyReturns struct {
+		result1 string
+	}
+	concurrencyKeyReturnsOnCall map[int]struct {
+		result1 string
+	}
 	CreateTimeStub        func() time.Time
 	createTimeMutex       sync.RWMutex
 	createTimeArgsForCall []struct {
@@ -154,6 +199,16 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsArchivedStub        func() bool
+	eventsArchivedMutex       sync.RWMutex
+	eventsArchivedArgsForCall []struct {
+	}
+	eventsArchivedReturns struct {
+		result1 bool
+	}
+	eventsArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -318,6 +373,28 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MatrixBuildsStub        func() ([]db.Build, error)
+	matrixBuildsMutex       sync.RWMutex
+	matrixBuildsArgsForCall []struct {
+	}
+	matrixBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	matrixBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	MatrixValuesStub        func() atc.MatrixValues
+	matrixValuesMutex       sync.RWMutex
+	matrixValuesArgsForCall []struct {
+	}
+	matrixValuesReturns struct {
+		result1 atc.MatrixValues
+	}
+	matrixValuesReturnsOnCall map[int]struct {
+		result1 atc.MatrixValues
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -328,6 +405,16 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ParentBuildIDStub        func() int
+	parentBuildIDMutex       sync.RWMutex
+	parentBuildIDArgsForCall []struct {
+	}
+	parentBuildIDReturns struct {
+		result1 int
+	}
+	parentBuildIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -426,6 +513,16 @@ type FakeBuild struct {
 	reapTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ReasonStub        func() string
+	reasonMutex       sync.RWMutex
+	reasonArgsForCall []struct {
+	}
+	reasonReturns struct {
+		result1 string
+	}
+	reasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -534,6 +631,28 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	SaveApprovalStub        func(db.BuildApproval) error
+	saveApprovalMutex       sync.RWMutex
+	saveApprovalArgsForCall []struct {
+		arg1 db.BuildApproval
+	}
+	saveApprovalReturns struct {
+		result1 error
+	}
+	saveApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveConcurrencyKeyStub        func(string) error
+	saveConcurrencyKeyMutex       sync.RWMutex
+	saveConcurrencyKeyArgsForCall []struct {
+		arg1 string
+	}
+	saveConcurrencyKeyReturns struct {
+		result1 error
+	}
+	saveConcurrencyKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -624,6 +743,18 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetWaitingForApprovalStub        func(atc.PlanID, bool) error
+	setWaitingForApprovalMutex       sync.RWMutex
+	setWaitingForApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+	}
+	setWaitingForApprovalReturns struct {
+		result1 error
+	}
+	setWaitingForApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SpanContextStub        func() propagation.TextMapCarrier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -647,6 +778,21 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	StartMatrixBuildStub        func(int, atc.MatrixValues, atc.Plan) (db.Build, error)
+	startMatrixBuildMutex       sync.RWMutex
+	startMatrixBuildArgsForCall []struct {
+		arg1 int
+		arg2 atc.MatrixValues
+		arg3 atc.Plan
+	}
+	startMatrixBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	startMatrixBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -969,6 +1115,124 @@ func (fake *FakeBuild) AdoptRerunInputsAndPipesReturnsOnCall(i int, result1 []db
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Approvals() ([]db.BuildApproval, error) {
+	fake.approvalsMutex.Lock()
+	ret, specificReturn := fake.approvalsReturnsOnCall[len(fake.approvalsArgsForCall)]
+	fake.approvalsArgsForCall = append(fake.approvalsArgsForCall, struct {
+	}{})
+	stub := fake.ApprovalsStub
+	fakeReturns := fake.approvalsReturns
+	fake.recordInvocation("Approvals", []interface{}{})
+	fake.approvalsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalsCallCount() int {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	return len(fake.approvalsArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalsCalls(stub func() ([]db.BuildApproval, error)) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = stub
+}
+
+func (fake *FakeBuild) ApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	fake.approvalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	if fake.approvalsReturnsOnCall == nil {
+		fake.approvalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.approvalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchiveEvents(arg1 context.Context, arg2 db.BuildEventStore) error {
+	fake.archiveEventsMutex.Lock()
+	ret, specificReturn := fake.archiveEventsReturnsOnCall[len(fake.archiveEventsArgsForCall)]
+	fake.archiveEventsArgsForCall = append(fake.archiveEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.BuildEventStore
+	}{arg1, arg2})
+	stub := fake.ArchiveEventsStub
+	fakeReturns := fake.archiveEventsReturns
+	fake.recordInvocation("ArchiveEvents", []interface{}{arg1, arg2})
+	fake.archiveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ArchiveEventsCallCount() int {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	return len(fake.archiveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchiveEventsCalls(stub func(context.Context, db.BuildEventStore) error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = stub
+}
+
+func (fake *FakeBuild) ArchiveEventsArgsForCall(i int) (context.Context, db.BuildEventStore) {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	argsForCall := fake.archiveEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) ArchiveEventsReturns(result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	fake.archiveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEventsReturnsOnCall(i int, result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	if fake.archiveEventsReturnsOnCall == nil {
+		fake.archiveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -1089,6 +1353,112 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) BuildVars() vars.StaticVariables {
+	fake.buildVarsMutex.Lock()
+	ret, specificReturn := fake.buildVarsReturnsOnCall[len(fake.buildVarsArgsForCall)]
+	fake.buildVarsArgsForCall = append(fake.buildVarsArgsForCall, struct {
+	}{})
+	stub := fake.BuildVarsStub
+	fakeReturns := fake.buildVarsReturns
+	fake.recordInvocation("BuildVars", []interface{}{})
+	fake.buildVarsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) BuildVarsCallCount() int {
+	fake.buildVarsMutex.RLock()
+	defer fake.buildVarsMutex.RUnlock()
+	return len(fake.buildVarsArgsForCall)
+}
+
+func (fake *FakeBuild) BuildVarsCalls(stub func() vars.StaticVariables) {
+	fake.buildVarsMutex.Lock()
+	defer fake.buildVarsMutex.Unlock()
+	fake.BuildVarsStub = stub
+}
+
+func (fake *FakeBuild) BuildVarsReturns(result1 vars.StaticVariables) {
+	fake.buildVarsMutex.Lock()
+	defer fake.buildVarsMutex.Unlock()
+	fake.BuildVarsStub = nil
+	fake.buildVarsReturns = struct {
+		result1 vars.StaticVariables
+	}{result1}
+}
+
+func (fake *FakeBuild) BuildVarsReturnsOnCall(i int, result1 vars.StaticVariables) {
+	fake.buildVarsMutex.Lock()
+	defer fake.buildVarsMutex.Unlock()
+	fake.BuildVarsStub = nil
+	if fake.buildVarsReturnsOnCall == nil {
+		fake.buildVarsReturnsOnCall = make(map[int]struct {
+			result1 vars.StaticVariables
+		})
+	}
+	fake.buildVarsReturnsOnCall[i] = struct {
+		result1 vars.StaticVariables
+	}{result1}
+}
+
+func (fake *FakeBuild) ConcurrencyKey() string {
+	fake.concurrencyKeyMutex.Lock()
+	ret, specificReturn := fake.concurrencyKeyReturnsOnCall[len(fake.concurrencyKeyArgsForCall)]
+	fake.concurrencyKeyArgsForCall = append(fake.concurrencyKeyArgsForCall, struct {
+	}{})
+	stub := fake.ConcurrencyKeyStub
+	fakeReturns := fake.concurrencyKeyReturns
+	fake.recordInvocation("ConcurrencyKey", []interface{}{})
+	fake.concurrencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ConcurrencyKeyCallCount() int {
+	fake.concurrencyKeyMutex.RLock()
+	defer fake.concurrencyKeyMutex.RUnlock()
+	return len(fake.concurrencyKeyArgsForCall)
+}
+
+func (fake *FakeBuild) ConcurrencyKeyCalls(stub func() string) {
+	fake.concurrencyKeyMutex.Lock()
+	defer fake.concurrencyKeyMutex.Unlock()
+	fake.ConcurrencyKeyStub = stub
+}
+
+func (fake *FakeBuild) ConcurrencyKeyReturns(result1 string) {
+	fake.concurrencyKeyMutex.Lock()
+	defer fake.concurrencyKeyMutex.Unlock()
+	fake.ConcurrencyKeyStub = nil
+	fake.concurrencyKeyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ConcurrencyKeyReturnsOnCall(i int, result1 string) {
+	fake.concurrencyKeyMutex.Lock()
+	defer fake.concurrencyKeyMutex.Unlock()
+	fake.ConcurrencyKeyStub = nil
+	if fake.concurrencyKeyReturnsOnCall == nil {
+		fake.concurrencyKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.concurrencyKeyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) CreateTime() time.Time {
 	fake.createTimeMutex.Lock()
 	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
@@ -1368,6 +1738,59 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) EventsArchived() bool {
+	fake.eventsArchivedMutex.Lock()
+	ret, specificReturn := fake.eventsArchivedReturnsOnCall[len(fake.eventsArchivedArgsForCall)]
+	fake.eventsArchivedArgsForCall = append(fake.eventsArchivedArgsForCall, struct {
+	}{})
+	stub := fake.EventsArchivedStub
+	fakeReturns := fake.eventsArchivedReturns
+	fake.recordInvocation("EventsArchived", []interface{}{})
+	fake.eventsArchivedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) EventsArchivedCallCount() int {
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
+	return len(fake.eventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArchivedCalls(stub func() bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) EventsArchivedReturns(result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	fake.eventsArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) EventsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	if fake.eventsArchivedReturnsOnCall == nil {
+		fake.eventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.eventsArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -2235,6 +2658,115 @@ func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) MatrixBuilds() ([]db.Build, error) {
+	fake.matrixBuildsMutex.Lock()
+	ret, specificReturn := fake.matrixBuildsReturnsOnCall[len(fake.matrixBuildsArgsForCall)]
+	fake.matrixBuildsArgsForCall = append(fake.matrixBuildsArgsForCall, struct {
+	}{})
+	stub := fake.MatrixBuildsStub
+	fakeReturns := fake.matrixBuildsReturns
+	fake.recordInvocation("MatrixBuilds", []interface{}{})
+	fake.matrixBuildsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) MatrixBuildsCallCount() int {
+	fake.matrixBuildsMutex.RLock()
+	defer fake.matrixBuildsMutex.RUnlock()
+	return len(fake.matrixBuildsArgsForCall)
+}
+
+func (fake *FakeBuild) MatrixBuildsCalls(stub func() ([]db.Build, error)) {
+	fake.matrixBuildsMutex.Lock()
+	defer fake.matrixBuildsMutex.Unlock()
+	fake.MatrixBuildsStub = stub
+}
+
+func (fake *FakeBuild) MatrixBuildsReturns(result1 []db.Build, result2 error) {
+	fake.matrixBuildsMutex.Lock()
+	defer fake.matrixBuildsMutex.Unlock()
+	fake.MatrixBuildsStub = nil
+	fake.matrixBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) MatrixBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.matrixBuildsMutex.Lock()
+	defer fake.matrixBuildsMutex.Unlock()
+	fake.MatrixBuildsStub = nil
+	if fake.matrixBuildsReturnsOnCall == nil {
+		fake.matrixBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.matrixBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) MatrixValues() atc.MatrixValues {
+	fake.matrixValuesMutex.Lock()
+	ret, specificReturn := fake.matrixValuesReturnsOnCall[len(fake.matrixValuesArgsForCall)]
+	fake.matrixValuesArgsForCall = append(fake.matrixValuesArgsForCall, struct {
+	}{})
+	stub := fake.MatrixValuesStub
+	fakeReturns := fake.matrixValuesReturns
+	fake.recordInvocation("MatrixValues", []interface{}{})
+	fake.matrixValuesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MatrixValuesCallCount() int {
+	fake.matrixValuesMutex.RLock()
+	defer fake.matrixValuesMutex.RUnlock()
+	return len(fake.matrixValuesArgsForCall)
+}
+
+func (fake *FakeBuild) MatrixValuesCalls(stub func() atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = stub
+}
+
+func (fake *FakeBuild) MatrixValuesReturns(result1 atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = nil
+	fake.matrixValuesReturns = struct {
+		result1 atc.MatrixValues
+	}{result1}
+}
+
+func (fake *FakeBuild) MatrixValuesReturnsOnCall(i int, result1 atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = nil
+	if fake.matrixValuesReturnsOnCall == nil {
+		fake.matrixValuesReturnsOnCall = make(map[int]struct {
+			result1 atc.MatrixValues
+		})
+	}
+	fake.matrixValuesReturnsOnCall[i] = struct {
+		result1 atc.MatrixValues
+	}{result1}
+}
+
 func (fake *FakeBuild) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2288,6 +2820,59 @@ func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ParentBuildID() int {
+	fake.parentBuildIDMutex.Lock()
+	ret, specificReturn := fake.parentBuildIDReturnsOnCall[len(fake.parentBuildIDArgsForCall)]
+	fake.parentBuildIDArgsForCall = append(fake.parentBuildIDArgsForCall, struct {
+	}{})
+	stub := fake.ParentBuildIDStub
+	fakeReturns := fake.parentBuildIDReturns
+	fake.recordInvocation("ParentBuildID", []interface{}{})
+	fake.parentBuildIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ParentBuildIDCallCount() int {
+	fake.parentBuildIDMutex.RLock()
+	defer fake.parentBuildIDMutex.RUnlock()
+	return len(fake.parentBuildIDArgsForCall)
+}
+
+func (fake *FakeBuild) ParentBuildIDCalls(stub func() int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = stub
+}
+
+func (fake *FakeBuild) ParentBuildIDReturns(result1 int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = nil
+	fake.parentBuildIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ParentBuildIDReturnsOnCall(i int, result1 int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = nil
+	if fake.parentBuildIDReturnsOnCall == nil {
+		fake.parentBuildIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.parentBuildIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2777,6 +3362,59 @@ func (fake *FakeBuild) ReapTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Reason() string {
+	fake.reasonMutex.Lock()
+	ret, specificReturn := fake.reasonReturnsOnCall[len(fake.reasonArgsForCall)]
+	fake.reasonArgsForCall = append(fake.reasonArgsForCall, struct {
+	}{})
+	stub := fake.ReasonStub
+	fakeReturns := fake.reasonReturns
+	fake.recordInvocation("Reason", []interface{}{})
+	fake.reasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReasonCallCount() int {
+	fake.reasonMutex.RLock()
+	defer fake.reasonMutex.RUnlock()
+	return len(fake.reasonArgsForCall)
+}
+
+func (fake *FakeBuild) ReasonCalls(stub func() string) {
+	fake.reasonMutex.Lock()
+	defer fake.reasonMutex.Unlock()
+	fake.ReasonStub = stub
+}
+
+func (fake *FakeBuild) ReasonReturns(result1 string) {
+	fake.reasonMutex.Lock()
+	defer fake.reasonMutex.Unlock()
+	fake.ReasonStub = nil
+	fake.reasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ReasonReturnsOnCall(i int, result1 string) {
+	fake.reasonMutex.Lock()
+	defer fake.reasonMutex.Unlock()
+	fake.ReasonStub = nil
+	if fake.reasonReturnsOnCall == nil {
+		fake.reasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.reasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -3319,6 +3957,128 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) SaveApproval(arg1 db.BuildApproval) error {
+	fake.saveApprovalMutex.Lock()
+	ret, specificReturn := fake.saveApprovalReturnsOnCall[len(fake.saveApprovalArgsForCall)]
+	fake.saveApprovalArgsForCall = append(fake.saveApprovalArgsForCall, struct {
+		arg1 db.BuildApproval
+	}{arg1})
+	stub := fake.SaveApprovalStub
+	fakeReturns := fake.saveApprovalReturns
+	fake.recordInvocation("SaveApproval", []interface{}{arg1})
+	fake.saveApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveApprovalCallCount() int {
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
+	return len(fake.saveApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) SaveApprovalCalls(stub func(db.BuildApproval) error) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = stub
+}
+
+func (fake *FakeBuild) SaveApprovalArgsForCall(i int) db.BuildApproval {
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
+	argsForCall := fake.saveApprovalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveApprovalReturns(result1 error) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = nil
+	fake.saveApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveApprovalReturnsOnCall(i int, result1 error) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = nil
+	if fake.saveApprovalReturnsOnCall == nil {
+		fake.saveApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveConcurrencyKey(arg1 string) error {
+	fake.saveConcurrencyKeyMutex.Lock()
+	ret, specificReturn := fake.saveConcurrencyKeyReturnsOnCall[len(fake.saveConcurrencyKeyArgsForCall)]
+	fake.saveConcurrencyKeyArgsForCall = append(fake.saveConcurrencyKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SaveConcurrencyKeyStub
+	fakeReturns := fake.saveConcurrencyKeyReturns
+	fake.recordInvocation("SaveConcurrencyKey", []interface{}{arg1})
+	fake.saveConcurrencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveConcurrencyKeyCallCount() int {
+	fake.saveConcurrencyKeyMutex.RLock()
+	defer fake.saveConcurrencyKeyMutex.RUnlock()
+	return len(fake.saveConcurrencyKeyArgsForCall)
+}
+
+func (fake *FakeBuild) SaveConcurrencyKeyCalls(stub func(string) error) {
+	fake.saveConcurrencyKeyMutex.Lock()
+	defer fake.saveConcurrencyKeyMutex.Unlock()
+	fake.SaveConcurrencyKeyStub = stub
+}
+
+func (fake *FakeBuild) SaveConcurrencyKeyArgsForCall(i int) string {
+	fake.saveConcurrencyKeyMutex.RLock()
+	defer fake.saveConcurrencyKeyMutex.RUnlock()
+	argsForCall := fake.saveConcurrencyKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveConcurrencyKeyReturns(result1 error) {
+	fake.saveConcurrencyKeyMutex.Lock()
+	defer fake.saveConcurrencyKeyMutex.Unlock()
+	fake.SaveConcurrencyKeyStub = nil
+	fake.saveConcurrencyKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveConcurrencyKeyReturnsOnCall(i int, result1 error) {
+	fake.saveConcurrencyKeyMutex.Lock()
+	defer fake.saveConcurrencyKeyMutex.Unlock()
+	fake.SaveConcurrencyKeyStub = nil
+	if fake.saveConcurrencyKeyReturnsOnCall == nil {
+		fake.saveConcurrencyKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveConcurrencyKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -3754,6 +4514,68 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetWaitingForApproval(arg1 atc.PlanID, arg2 bool) error {
+	fake.setWaitingForApprovalMutex.Lock()
+	ret, specificReturn := fake.setWaitingForApprovalReturnsOnCall[len(fake.setWaitingForApprovalArgsForCall)]
+	fake.setWaitingForApprovalArgsForCall = append(fake.setWaitingForApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.SetWaitingForApprovalStub
+	fakeReturns := fake.setWaitingForApprovalReturns
+	fake.recordInvocation("SetWaitingForApproval", []interface{}{arg1, arg2})
+	fake.setWaitingForApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetWaitingForApprovalCallCount() int {
+	fake.setWaitingForApprovalMutex.RLock()
+	defer fake.setWaitingForApprovalMutex.RUnlock()
+	return len(fake.setWaitingForApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) SetWaitingForApprovalCalls(stub func(atc.PlanID, bool) error) {
+	fake.setWaitingForApprovalMutex.Lock()
+	defer fake.setWaitingForApprovalMutex.Unlock()
+	fake.SetWaitingForApprovalStub = stub
+}
+
+func (fake *FakeBuild) SetWaitingForApprovalArgsForCall(i int) (atc.PlanID, bool) {
+	fake.setWaitingForApprovalMutex.RLock()
+	defer fake.setWaitingForApprovalMutex.RUnlock()
+	argsForCall := fake.setWaitingForApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SetWaitingForApprovalReturns(result1 error) {
+	fake.setWaitingForApprovalMutex.Lock()
+	defer fake.setWaitingForApprovalMutex.Unlock()
+	fake.SetWaitingForApprovalStub = nil
+	fake.setWaitingForApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForApprovalReturnsOnCall(i int, result1 error) {
+	fake.setWaitingForApprovalMutex.Lock()
+	defer fake.setWaitingForApprovalMutex.Unlock()
+	fake.SetWaitingForApprovalStub = nil
+	if fake.setWaitingForApprovalReturnsOnCall == nil {
+		fake.setWaitingForApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWaitingForApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SpanContext() propagation.TextMapCarrier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -3871,6 +4693,72 @@ func (fake *FakeBuild) StartReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) StartMatrixBuild(arg1 int, arg2 atc.MatrixValues, arg3 atc.Plan) (db.Build, error) {
+	fake.startMatrixBuildMutex.Lock()
+	ret, specificReturn := fake.startMatrixBuildReturnsOnCall[len(fake.startMatrixBuildArgsForCall)]
+	fake.startMatrixBuildArgsForCall = append(fake.startMatrixBuildArgsForCall, struct {
+		arg1 int
+		arg2 atc.MatrixValues
+		arg3 atc.Plan
+	}{arg1, arg2, arg3})
+	stub := fake.StartMatrixBuildStub
+	fakeReturns := fake.startMatrixBuildReturns
+	fake.recordInvocation("StartMatrixBuild", []interface{}{arg1, arg2, arg3})
+	fake.startMatrixBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StartMatrixBuildCallCount() int {
+	fake.startMatrixBuildMutex.RLock()
+	defer fake.startMatrixBuildMutex.RUnlock()
+	return len(fake.startMatrixBuildArgsForCall)
+}
+
+func (fake *FakeBuild) StartMatrixBuildCalls(stub func(int, atc.MatrixValues, atc.Plan) (db.Build, error)) {
+	fake.startMatrixBuildMutex.Lock()
+	defer fake.startMatrixBuildMutex.Unlock()
+	fake.StartMatrixBuildStub = stub
+}
+
+func (fake *FakeBuild) StartMatrixBuildArgsForCall(i int) (int, atc.MatrixValues, atc.Plan) {
+	fake.startMatrixBuildMutex.RLock()
+	defer fake.startMatrixBuildMutex.RUnlock()
+	argsForCall := fake.startMatrixBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) StartMatrixBuildReturns(result1 db.Build, result2 error) {
+	fake.startMatrixBuildMutex.Lock()
+	defer fake.startMatrixBuildMutex.Unlock()
+	fake.StartMatrixBuildStub = nil
+	fake.startMatrixBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StartMatrixBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.startMatrixBuildMutex.Lock()
+	defer fake.startMatrixBuildMutex.Unlock()
+	fake.StartMatrixBuildStub = nil
+	if fake.startMatrixBuildReturnsOnCall == nil {
+		fake.startMatrixBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.startMatrixBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -4274,10 +5162,18 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
 	defer fake.adoptRerunInputsAndPipesMutex.RUnlock()
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.buildVarsMutex.RLock()
+	defer fake.buildVarsMutex.RUnlock()
+	fake.concurrencyKeyMutex.RLock()
+	defer fake.concurrencyKeyMutex.RUnlock()
 	fake.createTimeMutex.RLock()
 	defer fake.createTimeMutex.RUnlock()
 	fake.createdByMutex.RLock()
@@ -4288,6 +5184,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
@@ -4320,8 +5218,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.lagerDataMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
+	fake.matrixBuildsMutex.RLock()
+	defer fake.matrixBuildsMutex.RUnlock()
+	fake.matrixValuesMutex.RLock()
+	defer fake.matrixValuesMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.parentBuildIDMutex.RLock()
+	defer fake.parentBuildIDMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -4340,6 +5244,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
+	fake.reasonMutex.RLock()
+	defer fake.reasonMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
@@ -4360,6 +5266,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
+	fake.saveConcurrencyKeyMutex.RLock()
+	defer fake.saveConcurrencyKeyMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
@@ -4374,10 +5284,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setWaitingForApprovalMutex.RLock()
+	defer fake.setWaitingForApprovalMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
+	fake.startMatrixBuildMutex.RLock()
+	defer fake.startMatrixBuildMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
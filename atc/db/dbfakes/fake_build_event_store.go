@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildEventStore struct {
+	DownloadStub        func(context.Context, string) (io.ReadCloser, bool, error)
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	downloadReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	downloadReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	UploadStub        func(context.Context, string, io.Reader) error
+	uploadMutex       sync.RWMutex
+	uploadArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}
+	uploadReturns struct {
+		result1 error
+	}
+	uploadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildEventStore) Download(arg1 context.Context, arg2 string) (io.ReadCloser, bool, error) {
+	fake.downloadMutex.Lock()
+	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadStub
+	fakeReturns := fake.downloadReturns
+	fake.recordInvocation("Download", []interface{}{arg1, arg2})
+	fake.downloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuildEventStore) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) DownloadCalls(stub func(context.Context, string) (io.ReadCloser, bool, error)) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = stub
+}
+
+func (fake *FakeBuildEventStore) DownloadArgsForCall(i int) (context.Context, string) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	argsForCall := fake.downloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildEventStore) DownloadReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildEventStore) DownloadReturnsOnCall(i int, result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	if fake.downloadReturnsOnCall == nil {
+		fake.downloadReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 bool
+			result3 error
+		})
+	}
+	fake.downloadReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildEventStore) Upload(arg1 context.Context, arg2 string, arg3 io.Reader) error {
+	fake.uploadMutex.Lock()
+	ret, specificReturn := fake.uploadReturnsOnCall[len(fake.uploadArgsForCall)]
+	fake.uploadArgsForCall = append(fake.uploadArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.UploadStub
+	fakeReturns := fake.uploadReturns
+	fake.recordInvocation("Upload", []interface{}{arg1, arg2, arg3})
+	fake.uploadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildEventStore) UploadCallCount() int {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return len(fake.uploadArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) UploadCalls(stub func(context.Context, string, io.Reader) error) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = stub
+}
+
+func (fake *FakeBuildEventStore) UploadArgsForCall(i int) (context.Context, string, io.Reader) {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	argsForCall := fake.uploadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildEventStore) UploadReturns(result1 error) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = nil
+	fake.uploadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventStore) UploadReturnsOnCall(i int, result1 error) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = nil
+	if fake.uploadReturnsOnCall == nil {
+		fake.uploadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildEventStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildEventStore = new(FakeBuildEventStore)
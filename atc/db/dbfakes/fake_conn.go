@@ -39,6 +39,16 @@ type FakeConn struct {
 		result1 db.Tx
 		result2 error
 	}
+	BuildEventArchiveStub        func() db.BuildEventStore
+	buildEventArchiveMutex       sync.RWMutex
+	buildEventArchiveArgsForCall []struct {
+	}
+	buildEventArchiveReturns struct {
+		result1 db.BuildEventStore
+	}
+	buildEventArchiveReturnsOnCall map[int]struct {
+		result1 db.BuildEventStore
+	}
 	BusStub        func() db.NotificationsBus
 	busMutex       sync.RWMutex
 	busArgsForCall []struct {
@@ -354,6 +364,59 @@ func (fake *FakeConn) BeginTxReturnsOnCall(i int, result1 db.Tx, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeConn) BuildEventArchive() db.BuildEventStore {
+	fake.buildEventArchiveMutex.Lock()
+	ret, specificReturn := fake.buildEventArchiveReturnsOnCall[len(fake.buildEventArchiveArgsForCall)]
+	fake.buildEventArchiveArgsForCall = append(fake.buildEventArchiveArgsForCall, struct {
+	}{})
+	stub := fake.BuildEventArchiveStub
+	fakeReturns := fake.buildEventArchiveReturns
+	fake.recordInvocation("BuildEventArchive", []interface{}{})
+	fake.buildEventArchiveMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) BuildEventArchiveCallCount() int {
+	fake.buildEventArchiveMutex.RLock()
+	defer fake.buildEventArchiveMutex.RUnlock()
+	return len(fake.buildEventArchiveArgsForCall)
+}
+
+func (fake *FakeConn) BuildEventArchiveCalls(stub func() db.BuildEventStore) {
+	fake.buildEventArchiveMutex.Lock()
+	defer fake.buildEventArchiveMutex.Unlock()
+	fake.BuildEventArchiveStub = stub
+}
+
+func (fake *FakeConn) BuildEventArchiveReturns(result1 db.BuildEventStore) {
+	fake.buildEventArchiveMutex.Lock()
+	defer fake.buildEventArchiveMutex.Unlock()
+	fake.BuildEventArchiveStub = nil
+	fake.buildEventArchiveReturns = struct {
+		result1 db.BuildEventStore
+	}{result1}
+}
+
+func (fake *FakeConn) BuildEventArchiveReturnsOnCall(i int, result1 db.BuildEventStore) {
+	fake.buildEventArchiveMutex.Lock()
+	defer fake.buildEventArchiveMutex.Unlock()
+	fake.BuildEventArchiveStub = nil
+	if fake.buildEventArchiveReturnsOnCall == nil {
+		fake.buildEventArchiveReturnsOnCall = make(map[int]struct {
+			result1 db.BuildEventStore
+		})
+	}
+	fake.buildEventArchiveReturnsOnCall[i] = struct {
+		result1 db.BuildEventStore
+	}{result1}
+}
+
 func (fake *FakeConn) Bus() db.NotificationsBus {
 	fake.busMutex.Lock()
 	ret, specificReturn := fake.busReturnsOnCall[len(fake.busArgsForCall)]
@@ -1312,6 +1375,8 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.beginMutex.RUnlock()
 	fake.beginTxMutex.RLock()
 	defer fake.beginTxMutex.RUnlock()
+	fake.buildEventArchiveMutex.RLock()
+	defer fake.buildEventArchiveMutex.RUnlock()
 	fake.busMutex.RLock()
 	defer fake.busMutex.RUnlock()
 	fake.closeMutex.RLock()
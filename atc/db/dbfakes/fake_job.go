@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/vars"
 )
 
 type FakeJob struct {
@@ -124,6 +125,20 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithVarsStub        func(string, vars.StaticVariables) (db.Build, error)
+	createBuildWithVarsMutex       sync.RWMutex
+	createBuildWithVarsArgsForCall []struct {
+		arg1 string
+		arg2 vars.StaticVariables
+	}
+	createBuildWithVarsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithVarsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -145,6 +160,18 @@ type FakeJob struct {
 	ensurePendingBuildExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EnsureScheduledBuildExistsStub        func(string, time.Time) error
+	ensureScheduledBuildExistsMutex       sync.RWMutex
+	ensureScheduledBuildExistsArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+	}
+	ensureScheduledBuildExistsReturns struct {
+		result1 error
+	}
+	ensureScheduledBuildExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	FinishedAndNextBuildStub        func() (db.Build, db.Build, error)
 	finishedAndNextBuildMutex       sync.RWMutex
 	finishedAndNextBuildArgsForCall []struct {
@@ -259,6 +286,16 @@ type FakeJob struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NextCronTriggerStub        func() time.Time
+	nextCronTriggerMutex       sync.RWMutex
+	nextCronTriggerArgsForCall []struct {
+	}
+	nextCronTriggerReturns struct {
+		result1 time.Time
+	}
+	nextCronTriggerReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	OutputsStub        func() ([]atc.JobOutput, error)
 	outputsMutex       sync.RWMutex
 	outputsArgsForCall []struct {
@@ -391,6 +428,19 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	RunningBuildsWithConcurrencyKeyStub        func(string) ([]db.Build, error)
+	runningBuildsWithConcurrencyKeyMutex       sync.RWMutex
+	runningBuildsWithConcurrencyKeyArgsForCall []struct {
+		arg1 string
+	}
+	runningBuildsWithConcurrencyKeyReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	runningBuildsWithConcurrencyKeyReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	SaveNextInputMappingStub        func(db.InputMapping, bool) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -1012,6 +1062,71 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithVars(arg1 string, arg2 vars.StaticVariables) (db.Build, error) {
+	fake.createBuildWithVarsMutex.Lock()
+	ret, specificReturn := fake.createBuildWithVarsReturnsOnCall[len(fake.createBuildWithVarsArgsForCall)]
+	fake.createBuildWithVarsArgsForCall = append(fake.createBuildWithVarsArgsForCall, struct {
+		arg1 string
+		arg2 vars.StaticVariables
+	}{arg1, arg2})
+	stub := fake.CreateBuildWithVarsStub
+	fakeReturns := fake.createBuildWithVarsReturns
+	fake.recordInvocation("CreateBuildWithVars", []interface{}{arg1, arg2})
+	fake.createBuildWithVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithVarsCallCount() int {
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
+	return len(fake.createBuildWithVarsArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithVarsCalls(stub func(string, vars.StaticVariables) (db.Build, error)) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithVarsArgsForCall(i int) (string, vars.StaticVariables) {
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
+	argsForCall := fake.createBuildWithVarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) CreateBuildWithVarsReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = nil
+	fake.createBuildWithVarsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithVarsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = nil
+	if fake.createBuildWithVarsReturnsOnCall == nil {
+		fake.createBuildWithVarsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithVarsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -1126,6 +1241,68 @@ func (fake *FakeJob) EnsurePendingBuildExistsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeJob) EnsureScheduledBuildExists(arg1 string, arg2 time.Time) error {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	ret, specificReturn := fake.ensureScheduledBuildExistsReturnsOnCall[len(fake.ensureScheduledBuildExistsArgsForCall)]
+	fake.ensureScheduledBuildExistsArgsForCall = append(fake.ensureScheduledBuildExistsArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.EnsureScheduledBuildExistsStub
+	fakeReturns := fake.ensureScheduledBuildExistsReturns
+	fake.recordInvocation("EnsureScheduledBuildExists", []interface{}{arg1, arg2})
+	fake.ensureScheduledBuildExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsCallCount() int {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return len(fake.ensureScheduledBuildExistsArgsForCall)
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsCalls(stub func(string, time.Time) error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = stub
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsArgsForCall(i int) (string, time.Time) {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	argsForCall := fake.ensureScheduledBuildExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsReturns(result1 error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = nil
+	fake.ensureScheduledBuildExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsReturnsOnCall(i int, result1 error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = nil
+	if fake.ensureScheduledBuildExistsReturnsOnCall == nil {
+		fake.ensureScheduledBuildExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureScheduledBuildExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) FinishedAndNextBuild() (db.Build, db.Build, error) {
 	fake.finishedAndNextBuildMutex.Lock()
 	ret, specificReturn := fake.finishedAndNextBuildReturnsOnCall[len(fake.finishedAndNextBuildArgsForCall)]
@@ -1677,6 +1854,59 @@ func (fake *FakeJob) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) NextCronTrigger() time.Time {
+	fake.nextCronTriggerMutex.Lock()
+	ret, specificReturn := fake.nextCronTriggerReturnsOnCall[len(fake.nextCronTriggerArgsForCall)]
+	fake.nextCronTriggerArgsForCall = append(fake.nextCronTriggerArgsForCall, struct {
+	}{})
+	stub := fake.NextCronTriggerStub
+	fakeReturns := fake.nextCronTriggerReturns
+	fake.recordInvocation("NextCronTrigger", []interface{}{})
+	fake.nextCronTriggerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) NextCronTriggerCallCount() int {
+	fake.nextCronTriggerMutex.RLock()
+	defer fake.nextCronTriggerMutex.RUnlock()
+	return len(fake.nextCronTriggerArgsForCall)
+}
+
+func (fake *FakeJob) NextCronTriggerCalls(stub func() time.Time) {
+	fake.nextCronTriggerMutex.Lock()
+	defer fake.nextCronTriggerMutex.Unlock()
+	fake.NextCronTriggerStub = stub
+}
+
+func (fake *FakeJob) NextCronTriggerReturns(result1 time.Time) {
+	fake.nextCronTriggerMutex.Lock()
+	defer fake.nextCronTriggerMutex.Unlock()
+	fake.NextCronTriggerStub = nil
+	fake.nextCronTriggerReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) NextCronTriggerReturnsOnCall(i int, result1 time.Time) {
+	fake.nextCronTriggerMutex.Lock()
+	defer fake.nextCronTriggerMutex.Unlock()
+	fake.NextCronTriggerStub = nil
+	if fake.nextCronTriggerReturnsOnCall == nil {
+		fake.nextCronTriggerReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextCronTriggerReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) Outputs() ([]atc.JobOutput, error) {
 	fake.outputsMutex.Lock()
 	ret, specificReturn := fake.outputsReturnsOnCall[len(fake.outputsArgsForCall)]
@@ -2337,6 +2567,70 @@ func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RunningBuildsWithConcurrencyKey(arg1 string) ([]db.Build, error) {
+	fake.runningBuildsWithConcurrencyKeyMutex.Lock()
+	ret, specificReturn := fake.runningBuildsWithConcurrencyKeyReturnsOnCall[len(fake.runningBuildsWithConcurrencyKeyArgsForCall)]
+	fake.runningBuildsWithConcurrencyKeyArgsForCall = append(fake.runningBuildsWithConcurrencyKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RunningBuildsWithConcurrencyKeyStub
+	fakeReturns := fake.runningBuildsWithConcurrencyKeyReturns
+	fake.recordInvocation("RunningBuildsWithConcurrencyKey", []interface{}{arg1})
+	fake.runningBuildsWithConcurrencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RunningBuildsWithConcurrencyKeyCallCount() int {
+	fake.runningBuildsWithConcurrencyKeyMutex.RLock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.RUnlock()
+	return len(fake.runningBuildsWithConcurrencyKeyArgsForCall)
+}
+
+func (fake *FakeJob) RunningBuildsWithConcurrencyKeyCalls(stub func(string) ([]db.Build, error)) {
+	fake.runningBuildsWithConcurrencyKeyMutex.Lock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.Unlock()
+	fake.RunningBuildsWithConcurrencyKeyStub = stub
+}
+
+func (fake *FakeJob) RunningBuildsWithConcurrencyKeyArgsForCall(i int) string {
+	fake.runningBuildsWithConcurrencyKeyMutex.RLock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.RUnlock()
+	argsForCall := fake.runningBuildsWithConcurrencyKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) RunningBuildsWithConcurrencyKeyReturns(result1 []db.Build, result2 error) {
+	fake.runningBuildsWithConcurrencyKeyMutex.Lock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.Unlock()
+	fake.RunningBuildsWithConcurrencyKeyStub = nil
+	fake.runningBuildsWithConcurrencyKeyReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RunningBuildsWithConcurrencyKeyReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.runningBuildsWithConcurrencyKeyMutex.Lock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.Unlock()
+	fake.RunningBuildsWithConcurrencyKeyStub = nil
+	if fake.runningBuildsWithConcurrencyKeyReturnsOnCall == nil {
+		fake.runningBuildsWithConcurrencyKeyReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.runningBuildsWithConcurrencyKeyReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveNextInputMapping(arg1 db.InputMapping, arg2 bool) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -2930,10 +3224,14 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
 	fake.disableManualTriggerMutex.RLock()
 	defer fake.disableManualTriggerMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
 	fake.finishedAndNextBuildMutex.RLock()
 	defer fake.finishedAndNextBuildMutex.RUnlock()
 	fake.firstLoggedBuildIDMutex.RLock()
@@ -2954,6 +3252,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.maxInFlightMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.nextCronTriggerMutex.RLock()
+	defer fake.nextCronTriggerMutex.RUnlock()
 	fake.outputsMutex.RLock()
 	defer fake.outputsMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -2978,6 +3278,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.requestScheduleMutex.RUnlock()
 	fake.rerunBuildMutex.RLock()
 	defer fake.rerunBuildMutex.RUnlock()
+	fake.runningBuildsWithConcurrencyKeyMutex.RLock()
+	defer fake.runningBuildsWithConcurrencyKeyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
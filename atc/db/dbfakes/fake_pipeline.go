@@ -100,6 +100,18 @@ type FakePipeline struct {
 		result1 atc.Config
 		result2 error
 	}
+	ConfigHistoryStub        func() ([]db.PipelineConfigVersion, error)
+	configHistoryMutex       sync.RWMutex
+	configHistoryArgsForCall []struct {
+	}
+	configHistoryReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	configHistoryReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -246,6 +258,16 @@ type FakePipeline struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ImportsStub        func() atc.ImportConfigs
+	importsMutex       sync.RWMutex
+	importsArgsForCall []struct {
+	}
+	importsReturns struct {
+		result1 atc.ImportConfigs
+	}
+	importsReturnsOnCall map[int]struct {
+		result1 atc.ImportConfigs
+	}
 	InstanceVarsStub        func() atc.InstanceVars
 	instanceVarsMutex       sync.RWMutex
 	instanceVarsArgsForCall []struct {
@@ -963,6 +985,62 @@ func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigHistory() ([]db.PipelineConfigVersion, error) {
+	fake.configHistoryMutex.Lock()
+	ret, specificReturn := fake.configHistoryReturnsOnCall[len(fake.configHistoryArgsForCall)]
+	fake.configHistoryArgsForCall = append(fake.configHistoryArgsForCall, struct {
+	}{})
+	stub := fake.ConfigHistoryStub
+	fakeReturns := fake.configHistoryReturns
+	fake.recordInvocation("ConfigHistory", []interface{}{})
+	fake.configHistoryMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigHistoryCallCount() int {
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	return len(fake.configHistoryArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigHistoryCalls(stub func() ([]db.PipelineConfigVersion, error)) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = stub
+}
+
+func (fake *FakePipeline) ConfigHistoryReturns(result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	fake.configHistoryReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigHistoryReturnsOnCall(i int, result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	if fake.configHistoryReturnsOnCall == nil {
+		fake.configHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfigVersion
+			result2 error
+		})
+	}
+	fake.configHistoryReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -1706,6 +1784,59 @@ func (fake *FakePipeline) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Imports() atc.ImportConfigs {
+	fake.importsMutex.Lock()
+	ret, specificReturn := fake.importsReturnsOnCall[len(fake.importsArgsForCall)]
+	fake.importsArgsForCall = append(fake.importsArgsForCall, struct {
+	}{})
+	stub := fake.ImportsStub
+	fakeReturns := fake.importsReturns
+	fake.recordInvocation("Imports", []interface{}{})
+	fake.importsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ImportsCallCount() int {
+	fake.importsMutex.RLock()
+	defer fake.importsMutex.RUnlock()
+	return len(fake.importsArgsForCall)
+}
+
+func (fake *FakePipeline) ImportsCalls(stub func() atc.ImportConfigs) {
+	fake.importsMutex.Lock()
+	defer fake.importsMutex.Unlock()
+	fake.ImportsStub = stub
+}
+
+func (fake *FakePipeline) ImportsReturns(result1 atc.ImportConfigs) {
+	fake.importsMutex.Lock()
+	defer fake.importsMutex.Unlock()
+	fake.ImportsStub = nil
+	fake.importsReturns = struct {
+		result1 atc.ImportConfigs
+	}{result1}
+}
+
+func (fake *FakePipeline) ImportsReturnsOnCall(i int, result1 atc.ImportConfigs) {
+	fake.importsMutex.Lock()
+	defer fake.importsMutex.Unlock()
+	fake.ImportsStub = nil
+	if fake.importsReturnsOnCall == nil {
+		fake.importsReturnsOnCall = make(map[int]struct {
+			result1 atc.ImportConfigs
+		})
+	}
+	fake.importsReturnsOnCall[i] = struct {
+		result1 atc.ImportConfigs
+	}{result1}
+}
+
 func (fake *FakePipeline) InstanceVars() atc.InstanceVars {
 	fake.instanceVarsMutex.Lock()
 	ret, specificReturn := fake.instanceVarsReturnsOnCall[len(fake.instanceVarsArgsForCall)]
@@ -3169,6 +3300,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.checkPausedMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
@@ -3195,6 +3328,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.hideMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.importsMutex.RLock()
+	defer fake.importsMutex.RUnlock()
 	fake.instanceVarsMutex.RLock()
 	defer fake.instanceVarsMutex.RUnlock()
 	fake.jobMutex.RLock()
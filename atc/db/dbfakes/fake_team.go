@@ -314,6 +314,44 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	PipelineLibraryStub        func(string, int) (db.PipelineLibrary, bool, error)
+	pipelineLibraryMutex       sync.RWMutex
+	pipelineLibraryArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	pipelineLibraryReturns struct {
+		result1 db.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	pipelineLibraryReturnsOnCall map[int]struct {
+		result1 db.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	PipelineSyncStub        func() atc.PipelineSyncConfig
+	pipelineSyncMutex       sync.RWMutex
+	pipelineSyncArgsForCall []struct {
+	}
+	pipelineSyncReturns struct {
+		result1 atc.PipelineSyncConfig
+	}
+	pipelineSyncReturnsOnCall map[int]struct {
+		result1 atc.PipelineSyncConfig
+	}
+	PipelineSyncStatusesStub        func() ([]db.PipelineSyncStatus, error)
+	pipelineSyncStatusesMutex       sync.RWMutex
+	pipelineSyncStatusesArgsForCall []struct {
+	}
+	pipelineSyncStatusesReturns struct {
+		result1 []db.PipelineSyncStatus
+		result2 error
+	}
+	pipelineSyncStatusesReturnsOnCall map[int]struct {
+		result1 []db.PipelineSyncStatus
+		result2 error
+	}
 	PipelinesStub        func() ([]db.Pipeline, error)
 	pipelinesMutex       sync.RWMutex
 	pipelinesArgsForCall []struct {
@@ -353,6 +391,16 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	QuotasStub        func() atc.TeamQuotas
+	quotasMutex       sync.RWMutex
+	quotasArgsForCall []struct {
+	}
+	quotasReturns struct {
+		result1 atc.TeamQuotas
+	}
+	quotasReturnsOnCall map[int]struct {
+		result1 atc.TeamQuotas
+	}
 	RenameStub        func(string) error
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
@@ -396,6 +444,50 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SavePipelineAsStub        func(string, atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	savePipelineAsMutex       sync.RWMutex
+	savePipelineAsArgsForCall []struct {
+		arg1 string
+		arg2 atc.PipelineRef
+		arg3 atc.Config
+		arg4 db.ConfigVersion
+		arg5 bool
+	}
+	savePipelineAsReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineAsReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	SavePipelineLibraryStub        func(string, []byte) (db.PipelineLibrary, error)
+	savePipelineLibraryMutex       sync.RWMutex
+	savePipelineLibraryArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	savePipelineLibraryReturns struct {
+		result1 db.PipelineLibrary
+		result2 error
+	}
+	savePipelineLibraryReturnsOnCall map[int]struct {
+		result1 db.PipelineLibrary
+		result2 error
+	}
+	SavePipelineSyncStatusStub        func(db.PipelineSyncStatus) error
+	savePipelineSyncStatusMutex       sync.RWMutex
+	savePipelineSyncStatusArgsForCall []struct {
+		arg1 db.PipelineSyncStatus
+	}
+	savePipelineSyncStatusReturns struct {
+		result1 error
+	}
+	savePipelineSyncStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -410,6 +502,17 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	UpdatePipelineSyncStub        func(atc.PipelineSyncConfig) error
+	updatePipelineSyncMutex       sync.RWMutex
+	updatePipelineSyncArgsForCall []struct {
+		arg1 atc.PipelineSyncConfig
+	}
+	updatePipelineSyncReturns struct {
+		result1 error
+	}
+	updatePipelineSyncReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -421,6 +524,30 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateQuotasStub        func(atc.TeamQuotas) error
+	updateQuotasMutex       sync.RWMutex
+	updateQuotasArgsForCall []struct {
+		arg1 atc.TeamQuotas
+	}
+	updateQuotasReturns struct {
+		result1 error
+	}
+	updateQuotasReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UsageStub        func(int) (db.TeamUsage, error)
+	usageMutex       sync.RWMutex
+	usageArgsForCall []struct {
+		arg1 int
+	}
+	usageReturns struct {
+		result1 db.TeamUsage
+		result2 error
+	}
+	usageReturnsOnCall map[int]struct {
+		result1 db.TeamUsage
+		result2 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -1875,6 +2002,183 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PipelineLibrary(arg1 string, arg2 int) (db.PipelineLibrary, bool, error) {
+	fake.pipelineLibraryMutex.Lock()
+	ret, specificReturn := fake.pipelineLibraryReturnsOnCall[len(fake.pipelineLibraryArgsForCall)]
+	fake.pipelineLibraryArgsForCall = append(fake.pipelineLibraryArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.PipelineLibraryStub
+	fakeReturns := fake.pipelineLibraryReturns
+	fake.recordInvocation("PipelineLibrary", []interface{}{arg1, arg2})
+	fake.pipelineLibraryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineLibraryCallCount() int {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	return len(fake.pipelineLibraryArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineLibraryCalls(stub func(string, int) (db.PipelineLibrary, bool, error)) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = stub
+}
+
+func (fake *FakeTeam) PipelineLibraryArgsForCall(i int) (string, int) {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	argsForCall := fake.pipelineLibraryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineLibraryReturns(result1 db.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	fake.pipelineLibraryReturns = struct {
+		result1 db.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineLibraryReturnsOnCall(i int, result1 db.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	if fake.pipelineLibraryReturnsOnCall == nil {
+		fake.pipelineLibraryReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineLibrary
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineLibraryReturnsOnCall[i] = struct {
+		result1 db.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineSync() atc.PipelineSyncConfig {
+	fake.pipelineSyncMutex.Lock()
+	ret, specificReturn := fake.pipelineSyncReturnsOnCall[len(fake.pipelineSyncArgsForCall)]
+	fake.pipelineSyncArgsForCall = append(fake.pipelineSyncArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSyncStub
+	fakeReturns := fake.pipelineSyncReturns
+	fake.recordInvocation("PipelineSync", []interface{}{})
+	fake.pipelineSyncMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PipelineSyncCallCount() int {
+	fake.pipelineSyncMutex.RLock()
+	defer fake.pipelineSyncMutex.RUnlock()
+	return len(fake.pipelineSyncArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSyncCalls(stub func() atc.PipelineSyncConfig) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = stub
+}
+
+func (fake *FakeTeam) PipelineSyncReturns(result1 atc.PipelineSyncConfig) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = nil
+	fake.pipelineSyncReturns = struct {
+		result1 atc.PipelineSyncConfig
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineSyncReturnsOnCall(i int, result1 atc.PipelineSyncConfig) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = nil
+	if fake.pipelineSyncReturnsOnCall == nil {
+		fake.pipelineSyncReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSyncConfig
+		})
+	}
+	fake.pipelineSyncReturnsOnCall[i] = struct {
+		result1 atc.PipelineSyncConfig
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineSyncStatuses() ([]db.PipelineSyncStatus, error) {
+	fake.pipelineSyncStatusesMutex.Lock()
+	ret, specificReturn := fake.pipelineSyncStatusesReturnsOnCall[len(fake.pipelineSyncStatusesArgsForCall)]
+	fake.pipelineSyncStatusesArgsForCall = append(fake.pipelineSyncStatusesArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSyncStatusesStub
+	fakeReturns := fake.pipelineSyncStatusesReturns
+	fake.recordInvocation("PipelineSyncStatuses", []interface{}{})
+	fake.pipelineSyncStatusesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PipelineSyncStatusesCallCount() int {
+	fake.pipelineSyncStatusesMutex.RLock()
+	defer fake.pipelineSyncStatusesMutex.RUnlock()
+	return len(fake.pipelineSyncStatusesArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSyncStatusesCalls(stub func() ([]db.PipelineSyncStatus, error)) {
+	fake.pipelineSyncStatusesMutex.Lock()
+	defer fake.pipelineSyncStatusesMutex.Unlock()
+	fake.PipelineSyncStatusesStub = stub
+}
+
+func (fake *FakeTeam) PipelineSyncStatusesReturns(result1 []db.PipelineSyncStatus, result2 error) {
+	fake.pipelineSyncStatusesMutex.Lock()
+	defer fake.pipelineSyncStatusesMutex.Unlock()
+	fake.PipelineSyncStatusesStub = nil
+	fake.pipelineSyncStatusesReturns = struct {
+		result1 []db.PipelineSyncStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PipelineSyncStatusesReturnsOnCall(i int, result1 []db.PipelineSyncStatus, result2 error) {
+	fake.pipelineSyncStatusesMutex.Lock()
+	defer fake.pipelineSyncStatusesMutex.Unlock()
+	fake.PipelineSyncStatusesStub = nil
+	if fake.pipelineSyncStatusesReturnsOnCall == nil {
+		fake.pipelineSyncStatusesReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineSyncStatus
+			result2 error
+		})
+	}
+	fake.pipelineSyncStatusesReturnsOnCall[i] = struct {
+		result1 []db.PipelineSyncStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
 	fake.pipelinesMutex.Lock()
 	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
@@ -2054,6 +2358,59 @@ func (fake *FakeTeam) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) Quotas() atc.TeamQuotas {
+	fake.quotasMutex.Lock()
+	ret, specificReturn := fake.quotasReturnsOnCall[len(fake.quotasArgsForCall)]
+	fake.quotasArgsForCall = append(fake.quotasArgsForCall, struct {
+	}{})
+	stub := fake.QuotasStub
+	fakeReturns := fake.quotasReturns
+	fake.recordInvocation("Quotas", []interface{}{})
+	fake.quotasMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) QuotasCallCount() int {
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
+	return len(fake.quotasArgsForCall)
+}
+
+func (fake *FakeTeam) QuotasCalls(stub func() atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = stub
+}
+
+func (fake *FakeTeam) QuotasReturns(result1 atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	fake.quotasReturns = struct {
+		result1 atc.TeamQuotas
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotasReturnsOnCall(i int, result1 atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	if fake.quotasReturnsOnCall == nil {
+		fake.quotasReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuotas
+		})
+	}
+	fake.quotasReturnsOnCall[i] = struct {
+		result1 atc.TeamQuotas
+	}{result1}
+}
+
 func (fake *FakeTeam) Rename(arg1 string) error {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
@@ -2250,6 +2607,208 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SavePipelineAs(arg1 string, arg2 atc.PipelineRef, arg3 atc.Config, arg4 db.ConfigVersion, arg5 bool) (db.Pipeline, bool, error) {
+	fake.savePipelineAsMutex.Lock()
+	ret, specificReturn := fake.savePipelineAsReturnsOnCall[len(fake.savePipelineAsArgsForCall)]
+	fake.savePipelineAsArgsForCall = append(fake.savePipelineAsArgsForCall, struct {
+		arg1 string
+		arg2 atc.PipelineRef
+		arg3 atc.Config
+		arg4 db.ConfigVersion
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SavePipelineAsStub
+	fakeReturns := fake.savePipelineAsReturns
+	fake.recordInvocation("SavePipelineAs", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.savePipelineAsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineAsCallCount() int {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	return len(fake.savePipelineAsArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineAsCalls(stub func(string, atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineAsArgsForCall(i int) (string, atc.PipelineRef, atc.Config, db.ConfigVersion, bool) {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	argsForCall := fake.savePipelineAsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeTeam) SavePipelineAsReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = nil
+	fake.savePipelineAsReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineAsReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = nil
+	if fake.savePipelineAsReturnsOnCall == nil {
+		fake.savePipelineAsReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineAsReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineLibrary(arg1 string, arg2 []byte) (db.PipelineLibrary, error) {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.savePipelineLibraryMutex.Lock()
+	ret, specificReturn := fake.savePipelineLibraryReturnsOnCall[len(fake.savePipelineLibraryArgsForCall)]
+	fake.savePipelineLibraryArgsForCall = append(fake.savePipelineLibraryArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.SavePipelineLibraryStub
+	fakeReturns := fake.savePipelineLibraryReturns
+	fake.recordInvocation("SavePipelineLibrary", []interface{}{arg1, arg2Copy})
+	fake.savePipelineLibraryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SavePipelineLibraryCallCount() int {
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
+	return len(fake.savePipelineLibraryArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineLibraryCalls(stub func(string, []byte) (db.PipelineLibrary, error)) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineLibraryArgsForCall(i int) (string, []byte) {
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
+	argsForCall := fake.savePipelineLibraryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SavePipelineLibraryReturns(result1 db.PipelineLibrary, result2 error) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = nil
+	fake.savePipelineLibraryReturns = struct {
+		result1 db.PipelineLibrary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipelineLibraryReturnsOnCall(i int, result1 db.PipelineLibrary, result2 error) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = nil
+	if fake.savePipelineLibraryReturnsOnCall == nil {
+		fake.savePipelineLibraryReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineLibrary
+			result2 error
+		})
+	}
+	fake.savePipelineLibraryReturnsOnCall[i] = struct {
+		result1 db.PipelineLibrary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatus(arg1 db.PipelineSyncStatus) error {
+	fake.savePipelineSyncStatusMutex.Lock()
+	ret, specificReturn := fake.savePipelineSyncStatusReturnsOnCall[len(fake.savePipelineSyncStatusArgsForCall)]
+	fake.savePipelineSyncStatusArgsForCall = append(fake.savePipelineSyncStatusArgsForCall, struct {
+		arg1 db.PipelineSyncStatus
+	}{arg1})
+	stub := fake.SavePipelineSyncStatusStub
+	fakeReturns := fake.savePipelineSyncStatusReturns
+	fake.recordInvocation("SavePipelineSyncStatus", []interface{}{arg1})
+	fake.savePipelineSyncStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatusCallCount() int {
+	fake.savePipelineSyncStatusMutex.RLock()
+	defer fake.savePipelineSyncStatusMutex.RUnlock()
+	return len(fake.savePipelineSyncStatusArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatusCalls(stub func(db.PipelineSyncStatus) error) {
+	fake.savePipelineSyncStatusMutex.Lock()
+	defer fake.savePipelineSyncStatusMutex.Unlock()
+	fake.SavePipelineSyncStatusStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatusArgsForCall(i int) db.PipelineSyncStatus {
+	fake.savePipelineSyncStatusMutex.RLock()
+	defer fake.savePipelineSyncStatusMutex.RUnlock()
+	argsForCall := fake.savePipelineSyncStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatusReturns(result1 error) {
+	fake.savePipelineSyncStatusMutex.Lock()
+	defer fake.savePipelineSyncStatusMutex.Unlock()
+	fake.SavePipelineSyncStatusStub = nil
+	fake.savePipelineSyncStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SavePipelineSyncStatusReturnsOnCall(i int, result1 error) {
+	fake.savePipelineSyncStatusMutex.Lock()
+	defer fake.savePipelineSyncStatusMutex.Unlock()
+	fake.SavePipelineSyncStatusStub = nil
+	if fake.savePipelineSyncStatusReturnsOnCall == nil {
+		fake.savePipelineSyncStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.savePipelineSyncStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -2315,6 +2874,67 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UpdatePipelineSync(arg1 atc.PipelineSyncConfig) error {
+	fake.updatePipelineSyncMutex.Lock()
+	ret, specificReturn := fake.updatePipelineSyncReturnsOnCall[len(fake.updatePipelineSyncArgsForCall)]
+	fake.updatePipelineSyncArgsForCall = append(fake.updatePipelineSyncArgsForCall, struct {
+		arg1 atc.PipelineSyncConfig
+	}{arg1})
+	stub := fake.UpdatePipelineSyncStub
+	fakeReturns := fake.updatePipelineSyncReturns
+	fake.recordInvocation("UpdatePipelineSync", []interface{}{arg1})
+	fake.updatePipelineSyncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelineSyncCallCount() int {
+	fake.updatePipelineSyncMutex.RLock()
+	defer fake.updatePipelineSyncMutex.RUnlock()
+	return len(fake.updatePipelineSyncArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelineSyncCalls(stub func(atc.PipelineSyncConfig) error) {
+	fake.updatePipelineSyncMutex.Lock()
+	defer fake.updatePipelineSyncMutex.Unlock()
+	fake.UpdatePipelineSyncStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelineSyncArgsForCall(i int) atc.PipelineSyncConfig {
+	fake.updatePipelineSyncMutex.RLock()
+	defer fake.updatePipelineSyncMutex.RUnlock()
+	argsForCall := fake.updatePipelineSyncArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePipelineSyncReturns(result1 error) {
+	fake.updatePipelineSyncMutex.Lock()
+	defer fake.updatePipelineSyncMutex.Unlock()
+	fake.UpdatePipelineSyncStub = nil
+	fake.updatePipelineSyncReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineSyncReturnsOnCall(i int, result1 error) {
+	fake.updatePipelineSyncMutex.Lock()
+	defer fake.updatePipelineSyncMutex.Unlock()
+	fake.UpdatePipelineSyncStub = nil
+	if fake.updatePipelineSyncReturnsOnCall == nil {
+		fake.updatePipelineSyncReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelineSyncReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2376,6 +2996,131 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateQuotas(arg1 atc.TeamQuotas) error {
+	fake.updateQuotasMutex.Lock()
+	ret, specificReturn := fake.updateQuotasReturnsOnCall[len(fake.updateQuotasArgsForCall)]
+	fake.updateQuotasArgsForCall = append(fake.updateQuotasArgsForCall, struct {
+		arg1 atc.TeamQuotas
+	}{arg1})
+	stub := fake.UpdateQuotasStub
+	fakeReturns := fake.updateQuotasReturns
+	fake.recordInvocation("UpdateQuotas", []interface{}{arg1})
+	fake.updateQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateQuotasCallCount() int {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	return len(fake.updateQuotasArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateQuotasCalls(stub func(atc.TeamQuotas) error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = stub
+}
+
+func (fake *FakeTeam) UpdateQuotasArgsForCall(i int) atc.TeamQuotas {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	argsForCall := fake.updateQuotasArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateQuotasReturns(result1 error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = nil
+	fake.updateQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuotasReturnsOnCall(i int, result1 error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = nil
+	if fake.updateQuotasReturnsOnCall == nil {
+		fake.updateQuotasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuotasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) Usage(arg1 int) (db.TeamUsage, error) {
+	fake.usageMutex.Lock()
+	ret, specificReturn := fake.usageReturnsOnCall[len(fake.usageArgsForCall)]
+	fake.usageArgsForCall = append(fake.usageArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.UsageStub
+	fakeReturns := fake.usageReturns
+	fake.recordInvocation("Usage", []interface{}{arg1})
+	fake.usageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UsageCallCount() int {
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
+	return len(fake.usageArgsForCall)
+}
+
+func (fake *FakeTeam) UsageCalls(stub func(int) (db.TeamUsage, error)) {
+	fake.usageMutex.Lock()
+	defer fake.usageMutex.Unlock()
+	fake.UsageStub = stub
+}
+
+func (fake *FakeTeam) UsageArgsForCall(i int) int {
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
+	argsForCall := fake.usageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UsageReturns(result1 db.TeamUsage, result2 error) {
+	fake.usageMutex.Lock()
+	defer fake.usageMutex.Unlock()
+	fake.UsageStub = nil
+	fake.usageReturns = struct {
+		result1 db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UsageReturnsOnCall(i int, result1 db.TeamUsage, result2 error) {
+	fake.usageMutex.Lock()
+	defer fake.usageMutex.Unlock()
+	fake.UsageStub = nil
+	if fake.usageReturnsOnCall == nil {
+		fake.usageReturnsOnCall = make(map[int]struct {
+			result1 db.TeamUsage
+			result2 error
+		})
+	}
+	fake.usageReturnsOnCall[i] = struct {
+		result1 db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2481,22 +3226,42 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.orderPipelinesWithinGroupMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	fake.pipelineSyncMutex.RLock()
+	defer fake.pipelineSyncMutex.RUnlock()
+	fake.pipelineSyncStatusesMutex.RLock()
+	defer fake.pipelineSyncStatusesMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
+	fake.savePipelineSyncStatusMutex.RLock()
+	defer fake.savePipelineSyncStatusMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.updatePipelineSyncMutex.RLock()
+	defer fake.updatePipelineSyncMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	fake.usageMutex.RLock()
+	defer fake.usageMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -82,6 +82,16 @@ type FakeTeamFactory struct {
 	notifyCacherReturnsOnCall map[int]struct {
 		result1 error
 	}
+	NotifyPipelineSyncerStub        func() error
+	notifyPipelineSyncerMutex       sync.RWMutex
+	notifyPipelineSyncerArgsForCall []struct {
+	}
+	notifyPipelineSyncerReturns struct {
+		result1 error
+	}
+	notifyPipelineSyncerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NotifyResourceScannerStub        func() error
 	notifyResourceScannerMutex       sync.RWMutex
 	notifyResourceScannerArgsForCall []struct {
@@ -453,6 +463,59 @@ func (fake *FakeTeamFactory) NotifyCacherReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeamFactory) NotifyPipelineSyncer() error {
+	fake.notifyPipelineSyncerMutex.Lock()
+	ret, specificReturn := fake.notifyPipelineSyncerReturnsOnCall[len(fake.notifyPipelineSyncerArgsForCall)]
+	fake.notifyPipelineSyncerArgsForCall = append(fake.notifyPipelineSyncerArgsForCall, struct {
+	}{})
+	stub := fake.NotifyPipelineSyncerStub
+	fakeReturns := fake.notifyPipelineSyncerReturns
+	fake.recordInvocation("NotifyPipelineSyncer", []interface{}{})
+	fake.notifyPipelineSyncerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeamFactory) NotifyPipelineSyncerCallCount() int {
+	fake.notifyPipelineSyncerMutex.RLock()
+	defer fake.notifyPipelineSyncerMutex.RUnlock()
+	return len(fake.notifyPipelineSyncerArgsForCall)
+}
+
+func (fake *FakeTeamFactory) NotifyPipelineSyncerCalls(stub func() error) {
+	fake.notifyPipelineSyncerMutex.Lock()
+	defer fake.notifyPipelineSyncerMutex.Unlock()
+	fake.NotifyPipelineSyncerStub = stub
+}
+
+func (fake *FakeTeamFactory) NotifyPipelineSyncerReturns(result1 error) {
+	fake.notifyPipelineSyncerMutex.Lock()
+	defer fake.notifyPipelineSyncerMutex.Unlock()
+	fake.NotifyPipelineSyncerStub = nil
+	fake.notifyPipelineSyncerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamFactory) NotifyPipelineSyncerReturnsOnCall(i int, result1 error) {
+	fake.notifyPipelineSyncerMutex.Lock()
+	defer fake.notifyPipelineSyncerMutex.Unlock()
+	fake.NotifyPipelineSyncerStub = nil
+	if fake.notifyPipelineSyncerReturnsOnCall == nil {
+		fake.notifyPipelineSyncerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyPipelineSyncerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeamFactory) NotifyResourceScanner() error {
 	fake.notifyResourceScannerMutex.Lock()
 	ret, specificReturn := fake.notifyResourceScannerReturnsOnCall[len(fake.notifyResourceScannerArgsForCall)]
@@ -521,6 +584,8 @@ func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	defer fake.getTeamsMutex.RUnlock()
 	fake.notifyCacherMutex.RLock()
 	defer fake.notifyCacherMutex.RUnlock()
+	fake.notifyPipelineSyncerMutex.RLock()
+	defer fake.notifyPipelineSyncerMutex.RUnlock()
 	fake.notifyResourceScannerMutex.RLock()
 	defer fake.notifyResourceScannerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
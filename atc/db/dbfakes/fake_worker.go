@@ -195,6 +195,16 @@ type FakeWorker struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NetworkPoliciesStub        func() bool
+	networkPoliciesMutex       sync.RWMutex
+	networkPoliciesArgsForCall []struct {
+	}
+	networkPoliciesReturns struct {
+		result1 bool
+	}
+	networkPoliciesReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	NoProxyStub        func() string
 	noProxyMutex       sync.RWMutex
 	noProxyArgsForCall []struct {
@@ -271,6 +281,26 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RootlessStub        func() bool
+	rootlessMutex       sync.RWMutex
+	rootlessArgsForCall []struct {
+	}
+	rootlessReturns struct {
+		result1 bool
+	}
+	rootlessReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	RuntimeClassesStub        func() []string
+	runtimeClassesMutex       sync.RWMutex
+	runtimeClassesArgsForCall []struct {
+	}
+	runtimeClassesReturns struct {
+		result1 []string
+	}
+	runtimeClassesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -1271,6 +1301,59 @@ func (fake *FakeWorker) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) NetworkPolicies() bool {
+	fake.networkPoliciesMutex.Lock()
+	ret, specificReturn := fake.networkPoliciesReturnsOnCall[len(fake.networkPoliciesArgsForCall)]
+	fake.networkPoliciesArgsForCall = append(fake.networkPoliciesArgsForCall, struct {
+	}{})
+	stub := fake.NetworkPoliciesStub
+	fakeReturns := fake.networkPoliciesReturns
+	fake.recordInvocation("NetworkPolicies", []interface{}{})
+	fake.networkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) NetworkPoliciesCallCount() int {
+	fake.networkPoliciesMutex.RLock()
+	defer fake.networkPoliciesMutex.RUnlock()
+	return len(fake.networkPoliciesArgsForCall)
+}
+
+func (fake *FakeWorker) NetworkPoliciesCalls(stub func() bool) {
+	fake.networkPoliciesMutex.Lock()
+	defer fake.networkPoliciesMutex.Unlock()
+	fake.NetworkPoliciesStub = stub
+}
+
+func (fake *FakeWorker) NetworkPoliciesReturns(result1 bool) {
+	fake.networkPoliciesMutex.Lock()
+	defer fake.networkPoliciesMutex.Unlock()
+	fake.NetworkPoliciesStub = nil
+	fake.networkPoliciesReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) NetworkPoliciesReturnsOnCall(i int, result1 bool) {
+	fake.networkPoliciesMutex.Lock()
+	defer fake.networkPoliciesMutex.Unlock()
+	fake.NetworkPoliciesStub = nil
+	if fake.networkPoliciesReturnsOnCall == nil {
+		fake.networkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.networkPoliciesReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) NoProxy() string {
 	fake.noProxyMutex.Lock()
 	ret, specificReturn := fake.noProxyReturnsOnCall[len(fake.noProxyArgsForCall)]
@@ -1651,6 +1734,112 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Rootless() bool {
+	fake.rootlessMutex.Lock()
+	ret, specificReturn := fake.rootlessReturnsOnCall[len(fake.rootlessArgsForCall)]
+	fake.rootlessArgsForCall = append(fake.rootlessArgsForCall, struct {
+	}{})
+	stub := fake.RootlessStub
+	fakeReturns := fake.rootlessReturns
+	fake.recordInvocation("Rootless", []interface{}{})
+	fake.rootlessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RootlessCallCount() int {
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	return len(fake.rootlessArgsForCall)
+}
+
+func (fake *FakeWorker) RootlessCalls(stub func() bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = stub
+}
+
+func (fake *FakeWorker) RootlessReturns(result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	fake.rootlessReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RootlessReturnsOnCall(i int, result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	if fake.rootlessReturnsOnCall == nil {
+		fake.rootlessReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.rootlessReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeClasses() []string {
+	fake.runtimeClassesMutex.Lock()
+	ret, specificReturn := fake.runtimeClassesReturnsOnCall[len(fake.runtimeClassesArgsForCall)]
+	fake.runtimeClassesArgsForCall = append(fake.runtimeClassesArgsForCall, struct {
+	}{})
+	stub := fake.RuntimeClassesStub
+	fakeReturns := fake.runtimeClassesReturns
+	fake.recordInvocation("RuntimeClasses", []interface{}{})
+	fake.runtimeClassesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeClassesCallCount() int {
+	fake.runtimeClassesMutex.RLock()
+	defer fake.runtimeClassesMutex.RUnlock()
+	return len(fake.runtimeClassesArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeClassesCalls(stub func() []string) {
+	fake.runtimeClassesMutex.Lock()
+	defer fake.runtimeClassesMutex.Unlock()
+	fake.RuntimeClassesStub = stub
+}
+
+func (fake *FakeWorker) RuntimeClassesReturns(result1 []string) {
+	fake.runtimeClassesMutex.Lock()
+	defer fake.runtimeClassesMutex.Unlock()
+	fake.RuntimeClassesStub = nil
+	fake.runtimeClassesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeClassesReturnsOnCall(i int, result1 []string) {
+	fake.runtimeClassesMutex.Lock()
+	defer fake.runtimeClassesMutex.Unlock()
+	fake.RuntimeClassesStub = nil
+	if fake.runtimeClassesReturnsOnCall == nil {
+		fake.runtimeClassesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.runtimeClassesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -2006,6 +2195,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.landMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.networkPoliciesMutex.RLock()
+	defer fake.networkPoliciesMutex.RUnlock()
 	fake.noProxyMutex.RLock()
 	defer fake.noProxyMutex.RUnlock()
 	fake.platformMutex.RLock()
@@ -2020,6 +2211,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	fake.runtimeClassesMutex.RLock()
+	defer fake.runtimeClassesMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
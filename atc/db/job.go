@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
 	"github.com/lib/pq"
 )
 
@@ -66,6 +67,7 @@ type Job interface {
 	ScheduleRequestedTime() time.Time
 	MaxInFlight() int
 	DisableManualTrigger() bool
+	NextCronTrigger() time.Time
 
 	Config() (atc.JobConfig, error)
 	Inputs() ([]atc.JobInput, error)
@@ -79,6 +81,7 @@ type Job interface {
 
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
+	CreateBuildWithVars(createdBy string, buildVars vars.StaticVariables) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
 
 	RequestSchedule() error
@@ -90,7 +93,9 @@ type Job interface {
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists(context.Context) error
+	EnsureScheduledBuildExists(reason string, nextCronTrigger time.Time) error
 	GetPendingBuilds() ([]Build, error)
+	RunningBuildsWithConcurrencyKey(key string) ([]Build, error)
 
 	GetNextBuildInputs() ([]BuildInput, error)
 	GetFullNextBuildInputs() ([]BuildInput, bool, error)
@@ -104,7 +109,7 @@ type Job interface {
 	HasNewInputs() bool
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger", "j.next_cron_trigger").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -134,6 +139,7 @@ type job struct {
 	scheduleRequestedTime time.Time
 	maxInFlight           int
 	disableManualTrigger  bool
+	nextCronTrigger       time.Time
 
 	config    *atc.JobConfig
 	rawConfig *string
@@ -195,6 +201,7 @@ func (j *job) HasNewInputs() bool               { return j.hasNewInputs }
 func (j *job) ScheduleRequestedTime() time.Time { return j.scheduleRequestedTime }
 func (j *job) MaxInFlight() int                 { return j.maxInFlight }
 func (j *job) DisableManualTrigger() bool       { return j.disableManualTrigger }
+func (j *job) NextCronTrigger() time.Time       { return j.nextCronTrigger }
 
 func (j *job) Config() (atc.JobConfig, error) {
 	if j.config != nil {
@@ -475,24 +482,34 @@ func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	return nil
 }
 
+// the builds of a matrix are listed by their parent build, so they are left
+// out of the job's builds
 func (j *job) BuildsWithTime(page Page) ([]Build, Pagination, error) {
-	newBuildsQuery := buildsQuery.Where(sq.Eq{"j.id": j.id})
+	newBuildsQuery := buildsQuery.Where(sq.Eq{
+		"j.id":              j.id,
+		"b.parent_build_id": nil,
+	})
 	newMinMaxIdQuery := minMaxIdQuery.
 		Join("jobs j ON b.job_id = j.id").
 		Where(sq.Eq{
-			"j.name":        j.name,
-			"j.pipeline_id": j.pipelineID,
+			"j.name":            j.name,
+			"j.pipeline_id":     j.pipelineID,
+			"b.parent_build_id": nil,
 		})
 	return getBuildsWithDates(newBuildsQuery, newMinMaxIdQuery, page, j.conn, j.lockFactory)
 }
 
 func (j *job) Builds(page Page) ([]Build, Pagination, error) {
-	newBuildsQuery := buildsQuery.Where(sq.Eq{"j.id": j.id})
+	newBuildsQuery := buildsQuery.Where(sq.Eq{
+		"j.id":              j.id,
+		"b.parent_build_id": nil,
+	})
 	newMinMaxIdQuery := minMaxIdQuery.
 		Join("jobs j ON b.job_id = j.id").
 		Where(sq.Eq{
-			"j.name":        j.name,
-			"j.pipeline_id": j.pipelineID,
+			"j.name":            j.name,
+			"j.pipeline_id":     j.pipelineID,
+			"b.parent_build_id": nil,
 		})
 
 	return getBuildsWithPagination(newBuildsQuery, newMinMaxIdQuery, page, j.conn, j.lockFactory)
@@ -503,7 +520,10 @@ func (j *job) Build(name string) (Build, bool, error) {
 
 	if name == "latest" {
 		query = buildsQuery.
-			Where(sq.Eq{"b.job_id": j.id}).
+			Where(sq.Eq{
+				"b.job_id":          j.id,
+				"b.parent_build_id": nil,
+			}).
 			OrderBy("b.id DESC").
 			Limit(1)
 	} else {
@@ -729,6 +749,123 @@ func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 	return nil
 }
 
+// EnsureScheduledBuildExists creates a pending build recording the given
+// reason, unless the job already has a pending build, and advances the job's
+// next cron trigger.
+func (j *job) EnsureScheduledBuildExists(reason string, nextCronTrigger time.Time) error {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	// advancing the trigger locks the job, so that no other pending build can
+	// be created before the check below
+	_, err = psql.Update("jobs").
+		Set("next_cron_trigger", nextCronTrigger).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	var pending bool
+	err = psql.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"status": BuildStatusPending,
+		}).
+		Suffix(")").
+		RunWith(tx).
+		QueryRow().
+		Scan(&pending)
+	if err != nil {
+		return err
+	}
+
+	// only take a build number when a build is actually created
+	if !pending {
+		buildName, err := j.getNewBuildName(tx)
+		if err != nil {
+			return err
+		}
+
+		var buildID int
+		err = psql.Insert("builds").
+			Columns("name", "job_id", "pipeline_id", "team_id", "status", "needs_v6_migration", "reason").
+			Values(buildName, j.id, j.pipelineID, j.teamID, BuildStatusPending, false, reason).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&buildID)
+		if err != nil {
+			return err
+		}
+
+		err = createBuildEventSeq(tx, buildID)
+		if err != nil {
+			return err
+		}
+
+		latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
+		if err != nil {
+			return err
+		}
+
+		err = updateNextBuildForJob(tx, j.id, latestNonRerunID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	j.nextCronTrigger = nextCronTrigger
+
+	return nil
+}
+
+// RunningBuildsWithConcurrencyKey returns the job's started builds which
+// were saved with the given concurrency key and have not been aborted yet.
+func (j *job) RunningBuildsWithConcurrencyKey(key string) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id":          j.id,
+			"b.concurrency_key": key,
+			"b.status":          BuildStatusStarted,
+			"b.completed":       false,
+			"b.aborted":         false,
+		}).
+		OrderBy("b.id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := []Build{}
+	for rows.Next() {
+		build := newEmptyBuild(j.conn, j.lockFactory)
+		err = scanBuild(build, rows, j.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
+
 func (j *job) GetPendingBuilds() ([]Build, error) {
 	builds := []Build{}
 
@@ -772,6 +909,12 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild(createdBy string) (Build, error) {
+	return j.CreateBuildWithVars(createdBy, nil)
+}
+
+// CreateBuildWithVars creates a manually triggered build which exposes the
+// given vars to its steps, e.g. fields extracted from a webhook payload.
+func (j *job) CreateBuildWithVars(createdBy string, buildVars vars.StaticVariables) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -784,8 +927,7 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		return nil, err
 	}
 
-	build := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, build, map[string]interface{}{
+	buildVals := map[string]interface{}{
 		"name":               buildName,
 		"job_id":             j.id,
 		"pipeline_id":        j.pipelineID,
@@ -793,7 +935,19 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
 		"created_by":         createdBy,
-	})
+	}
+
+	if buildVars != nil {
+		marshaled, err := json.Marshal(buildVars)
+		if err != nil {
+			return nil, err
+		}
+
+		buildVals["vars"] = string(marshaled)
+	}
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, build, buildVals)
 	if err != nil {
 		return nil, err
 	}
@@ -854,8 +1008,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		return nil, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]interface{}{
+	rerunVals := map[string]interface{}{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -864,7 +1017,29 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
 		"created_by":   createdBy,
-	})
+	}
+
+	// a rerun of a single matrix build only reruns its own combination
+	if buildToRerun.MatrixValues() != nil {
+		matrixValues, err := json.Marshal(buildToRerun.MatrixValues())
+		if err != nil {
+			return nil, err
+		}
+
+		rerunVals["matrix_values"] = string(matrixValues)
+	}
+
+	if buildToRerun.BuildVars() != nil {
+		buildVars, err := json.Marshal(buildToRerun.BuildVars())
+		if err != nil {
+			return nil, err
+		}
+
+		rerunVals["vars"] = string(buildVars)
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, rerunVals)
 	if err != nil {
 		return nil, err
 	}
@@ -1378,13 +1553,16 @@ func scanJob(j *job, row scannable) error {
 		config               sql.NullString
 		nonce                sql.NullString
 		pipelineInstanceVars sql.NullString
+		nextCronTrigger      pq.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &nextCronTrigger)
 	if err != nil {
 		return err
 	}
 
+	j.nextCronTrigger = nextCronTrigger.Time
+
 	if nonce.Valid {
 		j.nonce = &nonce.String
 	}
@@ -83,7 +83,10 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	defer tx.Rollback()
 
 	rows, err := jobsQuery.
-		Where(sq.Expr("j.schedule_requested > j.last_scheduled")).
+		Where(sq.Or{
+			sq.Expr("j.schedule_requested > j.last_scheduled"),
+			sq.Expr("j.next_cron_trigger <= now()"),
+		}).
 		Where(sq.Eq{
 			"j.active": true,
 			"j.paused": false,
@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -1983,6 +1984,173 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("EnsureScheduledBuildExists", func() {
+		var nextTrigger time.Time
+
+		BeforeEach(func() {
+			nextTrigger = time.Now().Add(time.Hour).Truncate(time.Second)
+		})
+
+		It("creates a pending build with the reason and advances the next cron trigger", func() {
+			err := job.EnsureScheduledBuildExists("some-reason", nextTrigger)
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].Reason()).To(Equal("some-reason"))
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.NextCronTrigger()).To(BeTemporally("==", nextTrigger))
+		})
+
+		It("doesn't create another build when one is already pending", func() {
+			err := job.EnsurePendingBuildExists(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job.EnsureScheduledBuildExists("some-reason", nextTrigger)
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].Reason()).To(BeEmpty())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.NextCronTrigger()).To(BeTemporally("==", nextTrigger))
+		})
+
+		It("doesn't use up a build number when one is already pending", func() {
+			pendingBuild, err := job.CreateBuild("some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job.EnsureScheduledBuildExists("some-reason", nextTrigger)
+			Expect(err).NotTo(HaveOccurred())
+
+			nextBuild, err := job.CreateBuild("some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingName, err := strconv.Atoi(pendingBuild.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextBuild.Name()).To(Equal(strconv.Itoa(pendingName + 1)))
+		})
+	})
+
+	Describe("NextCronTrigger", func() {
+		var (
+			configVersion db.ConfigVersion
+			nextTrigger   time.Time
+		)
+
+		saveJob := func(schedule *atc.ScheduleConfig) db.Job {
+			config := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "scheduled-job",
+						Schedule: schedule,
+					},
+				},
+			}
+
+			scheduled, _, err := team.SavePipeline(atc.PipelineRef{Name: "scheduled-pipeline"}, config, configVersion, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			configVersion = scheduled.ConfigVersion()
+
+			scheduledJob, found, err := scheduled.Job("scheduled-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			return scheduledJob
+		}
+
+		BeforeEach(func() {
+			configVersion = 0
+
+			scheduledJob := saveJob(&atc.ScheduleConfig{Cron: "0 0 * * *"})
+
+			// a trigger which is due, as if the scheduler has not run yet
+			nextTrigger = time.Now().Add(-time.Minute).Truncate(time.Second)
+			_, err := dbConn.Exec("UPDATE jobs SET next_cron_trigger = $1 WHERE id = $2", nextTrigger, scheduledJob.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is kept when the pipeline is saved with the same schedule", func() {
+			scheduledJob := saveJob(&atc.ScheduleConfig{Cron: "0 0 * * *"})
+			Expect(scheduledJob.NextCronTrigger()).To(BeTemporally("==", nextTrigger))
+		})
+
+		It("is recomputed when the schedule changes", func() {
+			scheduledJob := saveJob(&atc.ScheduleConfig{Cron: "0 12 * * *"})
+			Expect(scheduledJob.NextCronTrigger()).To(BeTemporally(">", time.Now()))
+		})
+
+		It("is cleared when the schedule is removed", func() {
+			scheduledJob := saveJob(nil)
+			Expect(scheduledJob.NextCronTrigger().IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("RunningBuildsWithConcurrencyKey", func() {
+		var runningBuild, otherKeyBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+
+			start := func(key string) db.Build {
+				build, err := job.CreateBuild("some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveConcurrencyKey(key)
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				return build
+			}
+
+			runningBuild = start("some-key")
+			otherKeyBuild = start("some-other-key")
+
+			abortedBuild := start("some-key")
+			err = abortedBuild.MarkAsAborted()
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuild, err := job.CreateBuild("some-user")
+			Expect(err).NotTo(HaveOccurred())
+			err = pendingBuild.SaveConcurrencyKey("some-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the started builds with the key which have not been aborted", func() {
+			builds, err := job.RunningBuildsWithConcurrencyKey("some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(runningBuild.ID()))
+			Expect(builds[0].ConcurrencyKey()).To(Equal("some-key"))
+
+			builds, err = job.RunningBuildsWithConcurrencyKey("some-other-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(otherKeyBuild.ID()))
+		})
+
+		It("does not return finished builds", func() {
+			err := runningBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := job.RunningBuildsWithConcurrencyKey("some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
 	Describe("Clear task cache", func() {
 		Context("when task cache exists", func() {
 			var (
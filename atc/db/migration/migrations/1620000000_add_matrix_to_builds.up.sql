@@ -0,0 +1,5 @@
+ALTER TABLE builds
+  ADD COLUMN parent_build_id bigint REFERENCES builds (id) ON DELETE CASCADE,
+  ADD COLUMN matrix_values jsonb;
+
+CREATE INDEX builds_parent_build_id_idx ON builds (parent_build_id);
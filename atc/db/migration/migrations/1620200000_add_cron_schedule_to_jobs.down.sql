@@ -0,0 +1,3 @@
+ALTER TABLE builds DROP COLUMN reason;
+
+ALTER TABLE jobs DROP COLUMN next_cron_trigger;
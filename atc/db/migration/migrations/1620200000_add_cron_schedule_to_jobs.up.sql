@@ -0,0 +1,3 @@
+ALTER TABLE jobs ADD COLUMN next_cron_trigger timestamp with time zone;
+
+ALTER TABLE builds ADD COLUMN reason text;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS build_waiting_approvals;
+DROP TABLE IF EXISTS build_approvals;
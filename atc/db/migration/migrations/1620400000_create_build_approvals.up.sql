@@ -0,0 +1,16 @@
+CREATE TABLE build_approvals (
+  id serial PRIMARY KEY,
+  build_id bigint REFERENCES builds (id) ON DELETE SET NULL,
+  plan_id text NOT NULL,
+  user_name text NOT NULL,
+  approved boolean NOT NULL,
+  comment text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  UNIQUE (build_id, plan_id, user_name)
+);
+
+CREATE TABLE build_waiting_approvals (
+  build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+  plan_id text NOT NULL,
+  PRIMARY KEY (build_id, plan_id)
+);
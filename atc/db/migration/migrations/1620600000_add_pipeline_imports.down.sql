@@ -0,0 +1,3 @@
+DROP TABLE pipeline_libraries;
+
+ALTER TABLE pipelines DROP COLUMN imports;
@@ -0,0 +1,12 @@
+ALTER TABLE pipelines ADD COLUMN imports json;
+
+CREATE TABLE pipeline_libraries (
+  id serial PRIMARY KEY,
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  name text NOT NULL,
+  version integer NOT NULL,
+  config text NOT NULL,
+  digest text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  UNIQUE (team_id, name, version)
+);
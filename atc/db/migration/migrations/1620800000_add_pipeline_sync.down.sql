@@ -0,0 +1,3 @@
+DROP TABLE pipeline_sync_statuses;
+
+ALTER TABLE teams DROP COLUMN pipeline_sync;
@@ -0,0 +1,14 @@
+ALTER TABLE teams ADD COLUMN pipeline_sync json;
+
+CREATE TABLE pipeline_sync_statuses (
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  pipeline_name text NOT NULL,
+  file text NOT NULL,
+  commit text,
+  config_version bigint,
+  status text NOT NULL,
+  drifted boolean NOT NULL DEFAULT false,
+  error text,
+  synced_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (team_id, pipeline_name)
+);
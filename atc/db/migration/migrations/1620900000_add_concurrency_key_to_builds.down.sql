@@ -0,0 +1,3 @@
+DROP INDEX builds_job_id_concurrency_key_idx;
+
+ALTER TABLE builds DROP COLUMN concurrency_key;
@@ -0,0 +1,3 @@
+ALTER TABLE builds ADD COLUMN concurrency_key text;
+
+CREATE INDEX builds_job_id_concurrency_key_idx ON builds (job_id, concurrency_key) WHERE concurrency_key IS NOT NULL AND completed = false;
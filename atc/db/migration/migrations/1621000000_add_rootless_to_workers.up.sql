@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN rootless boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN runtime_classes text;
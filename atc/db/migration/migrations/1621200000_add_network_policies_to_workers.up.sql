@@ -0,0 +1 @@
+ALTER TABLE workers ADD COLUMN network_policies boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE builds DROP COLUMN waiting_for_quota;
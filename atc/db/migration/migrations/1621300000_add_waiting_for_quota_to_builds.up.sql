@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN waiting_for_quota boolean NOT NULL DEFAULT false;
@@ -23,6 +23,10 @@ type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
 
+	// BuildEventArchive is the store that the events of archived builds are
+	// read back from. If it is nil, archived builds have no events.
+	BuildEventArchive() BuildEventStore
+
 	Ping() error
 	Driver() driver.Driver
 
@@ -130,6 +134,10 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+func (db *db) BuildEventArchive() BuildEventStore {
+	return nil
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
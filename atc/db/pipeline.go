@@ -45,8 +45,10 @@ type Pipeline interface {
 	Groups() atc.GroupConfigs
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	Imports() atc.ImportConfigs
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
+	ConfigHistory() ([]PipelineConfigVersion, error)
 	Public() bool
 	Paused() bool
 	Archived() bool
@@ -109,6 +111,7 @@ type pipeline struct {
 	groups        atc.GroupConfigs
 	varSources    atc.VarSourceConfigs
 	display       *atc.DisplayConfig
+	imports       atc.ImportConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
@@ -128,6 +131,7 @@ var pipelinesQuery = psql.Select(`
 		p.groups,
 		p.var_sources,
 		p.display,
+		p.imports,
 		p.nonce,
 		p.version,
 		p.team_id,
@@ -161,6 +165,7 @@ func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
 
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
+func (p *pipeline) Imports() atc.ImportConfigs       { return p.imports }
 func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
 func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
@@ -275,6 +280,7 @@ func (p *pipeline) Config() (atc.Config, error) {
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobConfigs,
 		Display:       p.Display(),
+		Imports:       p.Imports(),
 	}
 
 	return config, nil
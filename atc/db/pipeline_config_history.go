@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// PipelineConfigHistoryLimit is how many of a pipeline's configs are kept.
+// Once a pipeline has more, its oldest configs are removed.
+const PipelineConfigHistoryLimit = 25
+
+// PipelineConfigVersion is a config which a pipeline was set to.
+type PipelineConfigVersion struct {
+	Version   ConfigVersion
+	Config    atc.Config
+	Author    string
+	CreatedAt time.Time
+}
+
+// recordPipelineConfig saves the config which the pipeline has just been set
+// to, and removes the configs which no longer fit in the history.
+func recordPipelineConfig(tx Tx, pipelineID int, config atc.Config, author string) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_history (pipeline_id, version, config, nonce, author)
+		SELECT id, version, $2, $3, $4 FROM pipelines WHERE id = $1
+	`, pipelineID, encryptedPayload, nonce, author)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("pipeline_config_history").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		Where(sq.Expr(`id NOT IN (
+			SELECT id FROM pipeline_config_history
+			WHERE pipeline_id = ?
+			ORDER BY version DESC
+			LIMIT ?
+		)`, pipelineID, PipelineConfigHistoryLimit)).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
+// ConfigHistory returns the configs which the pipeline has been set to, most
+// recent first.
+func (p *pipeline) ConfigHistory() ([]PipelineConfigVersion, error) {
+	rows, err := psql.Select("version", "config", "nonce", "author", "created_at").
+		From("pipeline_config_history").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	es := p.conn.EncryptionStrategy()
+
+	history := []PipelineConfigVersion{}
+	for rows.Next() {
+		var (
+			entry  PipelineConfigVersion
+			config string
+			nonce  sql.NullString
+		)
+
+		err = rows.Scan(&entry.Version, &config, &nonce, &entry.Author, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := es.Decrypt(config, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(decryptedConfig, &entry.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// PipelineLibrary is a version of a config which the team's pipelines may
+// import.
+type PipelineLibrary struct {
+	TeamName  string
+	Name      string
+	Version   int
+	Config    []byte
+	Digest    string
+	CreatedAt time.Time
+}
+
+// SavePipelineLibrary saves the config as the next version of the library. If
+// the config is the same as the latest version, the latest version is
+// returned instead.
+func (t *team) SavePipelineLibrary(name string, config []byte) (PipelineLibrary, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return PipelineLibrary{}, err
+	}
+
+	defer Rollback(tx)
+
+	// lock the team so that concurrent saves are numbered one after the
+	// other. NO KEY UPDATE leaves other tables free to reference the team.
+	_, err = psql.Select("1").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		Suffix("FOR NO KEY UPDATE").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return PipelineLibrary{}, err
+	}
+
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(config))
+
+	latest, found, err := t.pipelineLibrary(tx, name, 0)
+	if err != nil {
+		return PipelineLibrary{}, err
+	}
+
+	if found && latest.Digest == digest {
+		return latest, nil
+	}
+
+	library := PipelineLibrary{
+		TeamName: t.name,
+		Name:     name,
+		Version:  latest.Version + 1,
+		Config:   config,
+		Digest:   digest,
+	}
+
+	err = psql.Insert("pipeline_libraries").
+		Columns("team_id", "name", "version", "config", "digest").
+		Values(t.id, name, library.Version, string(config), digest).
+		Suffix("RETURNING created_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&library.CreatedAt)
+	if err != nil {
+		return PipelineLibrary{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return PipelineLibrary{}, err
+	}
+
+	return library, nil
+}
+
+// PipelineLibrary returns the given version of the library, or its latest
+// version if the version is 0.
+func (t *team) PipelineLibrary(name string, version int) (PipelineLibrary, bool, error) {
+	return t.pipelineLibrary(t.conn, name, version)
+}
+
+func (t *team) pipelineLibrary(runner sq.BaseRunner, name string, version int) (PipelineLibrary, bool, error) {
+	query := psql.Select("version", "config", "digest", "created_at").
+		From("pipeline_libraries").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		})
+
+	if version != 0 {
+		query = query.Where(sq.Eq{"version": version})
+	}
+
+	library := PipelineLibrary{
+		TeamName: t.name,
+		Name:     name,
+	}
+
+	var config string
+	err := query.
+		OrderBy("version DESC").
+		Limit(1).
+		RunWith(runner).
+		QueryRow().
+		Scan(&library.Version, &config, &library.Digest, &library.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineLibrary{}, false, nil
+		}
+
+		return PipelineLibrary{}, false, err
+	}
+
+	library.Config = []byte(config)
+
+	return library, true, nil
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// PipelineSyncStatus is the result of the last sync of one of a team's
+// pipelines from its git repository.
+type PipelineSyncStatus struct {
+	PipelineName string
+	File         string
+	Commit       string
+
+	// ConfigVersion is the version of the pipeline's config once it was
+	// synced. If the pipeline's config version is different by the next sync,
+	// the pipeline has been set from somewhere else.
+	ConfigVersion ConfigVersion
+
+	Status   string
+	Drifted  bool
+	Error    string
+	SyncedAt time.Time
+}
+
+func (t *team) UpdatePipelineSync(config atc.PipelineSyncConfig) error {
+	var payload []byte
+	if !config.IsZero() {
+		var err error
+		payload, err = json.Marshal(config)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := psql.Update("teams").
+		Set("pipeline_sync", payload).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.pipelineSync = config
+
+	return nil
+}
+
+// PipelineSyncStatuses returns the status of each pipeline which has been
+// synced, ordered by name.
+func (t *team) PipelineSyncStatuses() ([]PipelineSyncStatus, error) {
+	rows, err := psql.Select("pipeline_name", "file", "commit", "config_version", "status", "drifted", "error", "synced_at").
+		From("pipeline_sync_statuses").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("pipeline_name ASC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	statuses := []PipelineSyncStatus{}
+	for rows.Next() {
+		var (
+			status          PipelineSyncStatus
+			commit, message sql.NullString
+			configVersion   sql.NullInt64
+		)
+
+		err = rows.Scan(&status.PipelineName, &status.File, &commit, &configVersion, &status.Status, &status.Drifted, &message, &status.SyncedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		status.Commit = commit.String
+		status.ConfigVersion = ConfigVersion(configVersion.Int64)
+		status.Error = message.String
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (t *team) SavePipelineSyncStatus(status PipelineSyncStatus) error {
+	_, err := psql.Insert("pipeline_sync_statuses").
+		Columns("team_id", "pipeline_name", "file", "commit", "config_version", "status", "drifted", "error", "synced_at").
+		Values(
+			t.id,
+			status.PipelineName,
+			status.File,
+			sql.NullString{String: status.Commit, Valid: status.Commit != ""},
+			sql.NullInt64{Int64: int64(status.ConfigVersion), Valid: status.ConfigVersion != 0},
+			status.Status,
+			status.Drifted,
+			sql.NullString{String: status.Error, Valid: status.Error != ""},
+			sq.Expr("now()"),
+		).
+		Suffix(`
+			ON CONFLICT (team_id, pipeline_name) DO UPDATE SET
+				file = EXCLUDED.file,
+				commit = EXCLUDED.commit,
+				config_version = EXCLUDED.config_version,
+				status = EXCLUDED.status,
+				drifted = EXCLUDED.drifted,
+				error = EXCLUDED.error,
+				synced_at = EXCLUDED.synced_at
+		`).
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
@@ -2109,6 +2109,74 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("ConfigHistory", func() {
+		It("records the config the pipeline was created with", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+			Expect(history[0].Config.Groups).To(Equal(pipelineConfig.Groups))
+			Expect(history[0].Config.Jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+			Expect(history[0].Author).To(BeEmpty())
+		})
+
+		Context("when the pipeline is set again", func() {
+			var newConfig atc.Config
+
+			BeforeEach(func() {
+				newConfig = pipelineConfig
+				newConfig.Groups = nil
+
+				var err error
+				pipeline, _, err = team.SavePipelineAs("some-user", atc.PipelineRef{Name: "fake-pipeline"}, newConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the new config and its author, most recent first", func() {
+				history, err := pipeline.ConfigHistory()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(2))
+				Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+				Expect(history[0].Config.Groups).To(BeEmpty())
+				Expect(history[0].Author).To(Equal("some-user"))
+				Expect(history[1].Config.Groups).To(Equal(pipelineConfig.Groups))
+			})
+		})
+
+		Context("when the pipeline is set by a build", func() {
+			BeforeEach(func() {
+				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, _, err = build.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, team.ID(), pipelineConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the build as the author", func() {
+				history, err := pipeline.ConfigHistory()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history[0].Author).To(Equal("default-pipeline/branch:master/some-job #1"))
+			})
+		})
+
+		Context("when the pipeline has been set more times than are kept", func() {
+			BeforeEach(func() {
+				for i := 0; i < db.PipelineConfigHistoryLimit; i++ {
+					var err error
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("removes the oldest configs", func() {
+				history, err := pipeline.ConfigHistory()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(db.PipelineConfigHistoryLimit))
+				Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+			})
+		})
+	})
+
 	Describe("SetParentIDs", func() {
 		It("sets the parent_job_id and parent_build_id fields", func() {
 			jobID := 123
@@ -44,6 +44,13 @@ type Team interface {
 		from ConfigVersion,
 		initiallyPaused bool,
 	) (Pipeline, bool, error)
+	SavePipelineAs(
+		author string,
+		pipelineRef atc.PipelineRef,
+		config atc.Config,
+		from ConfigVersion,
+		initiallyPaused bool,
+	) (Pipeline, bool, error)
 	RenamePipeline(oldName string, newName string) (bool, error)
 
 	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
@@ -76,6 +83,18 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	Quotas() atc.TeamQuotas
+	UpdateQuotas(atc.TeamQuotas) error
+	Usage(buildID int) (TeamUsage, error)
+
+	SavePipelineLibrary(name string, config []byte) (PipelineLibrary, error)
+	PipelineLibrary(name string, version int) (PipelineLibrary, bool, error)
+
+	PipelineSync() atc.PipelineSyncConfig
+	UpdatePipelineSync(atc.PipelineSyncConfig) error
+	PipelineSyncStatuses() ([]PipelineSyncStatus, error)
+	SavePipelineSyncStatus(PipelineSyncStatus) error
 }
 
 type team struct {
@@ -86,14 +105,18 @@ type team struct {
 	name  string
 	admin bool
 
-	auth atc.TeamAuth
+	auth         atc.TeamAuth
+	quotas       atc.TeamQuotas
+	pipelineSync atc.PipelineSyncConfig
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
-func (t *team) Auth() atc.TeamAuth { return t.auth }
+func (t *team) Auth() atc.TeamAuth                   { return t.auth }
+func (t *team) Quotas() atc.TeamQuotas               { return t.quotas }
+func (t *team) PipelineSync() atc.PipelineSyncConfig { return t.pipelineSync }
 
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
@@ -374,6 +397,7 @@ func savePipeline(
 	teamID int,
 	jobID sql.NullInt64,
 	buildID sql.NullInt64,
+	author string,
 ) (int, bool, error) {
 
 	var instanceVars sql.NullString
@@ -423,6 +447,14 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	var importsPayload []byte
+	if len(config.Imports) > 0 {
+		importsPayload, err = json.Marshal(config.Imports)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]interface{}{
@@ -430,6 +462,7 @@ func savePipeline(
 			"groups":          groupsPayload,
 			"var_sources":     encryptedVarSourcesPayload,
 			"display":         displayPayload,
+			"imports":         importsPayload,
 			"nonce":           nonce,
 			"version":         sq.Expr("nextval('config_version_seq')"),
 			"paused":          initiallyPaused,
@@ -475,6 +508,7 @@ func savePipeline(
 			Set("groups", groupsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("imports", importsPayload).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -572,6 +606,11 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = recordPipelineConfig(tx, pipelineID, config, author)
+	if err != nil {
+		return 0, false, err
+	}
+
 	return pipelineID, !existingConfig, nil
 }
 
@@ -580,6 +619,18 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
+) (Pipeline, bool, error) {
+	return t.SavePipelineAs("", pipelineRef, config, from, initiallyPaused)
+}
+
+// SavePipelineAs saves the pipeline, recording the author of the config in
+// the pipeline's config history.
+func (t *team) SavePipelineAs(
+	author string,
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	from ConfigVersion,
+	initiallyPaused bool,
 ) (Pipeline, bool, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -589,7 +640,7 @@ func (t *team) SavePipeline(
 	defer Rollback(tx)
 
 	nullID := sql.NullInt64{Valid: false}
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID, author)
 	if err != nil {
 		return nil, false, err
 	}
@@ -928,7 +979,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, quotas, pipeline_sync
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -1211,11 +1262,16 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 		return 0, err
 	}
 
+	nextCronTrigger, err := jobNextCronTrigger(tx, job, pipelineID)
+	if err != nil {
+		return 0, err
+	}
+
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags", "next_cron_trigger").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups), nextCronTrigger).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags, next_cron_trigger = EXCLUDED.next_cron_trigger").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -1227,6 +1283,60 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 	return jobID, nil
 }
 
+// jobNextCronTrigger returns when the job's schedule next fires. The stored
+// trigger is kept if the schedule has not changed, so that saving the
+// pipeline again does not skip a trigger which is due.
+func jobNextCronTrigger(tx Tx, job atc.JobConfig, pipelineID int) (*time.Time, error) {
+	if job.Schedule == nil {
+		return nil, nil
+	}
+
+	var rawConfig sql.NullString
+	var nonce sql.NullString
+	var stored pq.NullTime
+	err := psql.Select("config", "nonce", "next_cron_trigger").
+		From("jobs").
+		Where(sq.Eq{
+			"name":        job.Name,
+			"pipeline_id": pipelineID,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&rawConfig, &nonce, &stored)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if err == nil && stored.Valid && rawConfig.Valid {
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := tx.EncryptionStrategy().Decrypt(rawConfig.String, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		var existing atc.JobConfig
+		err = json.Unmarshal(decryptedConfig, &existing)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing.Schedule != nil && *existing.Schedule == *job.Schedule {
+			return &stored.Time, nil
+		}
+	}
+
+	next, err := job.Schedule.Next(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
 func registerSerialGroup(tx Tx, serialGroup string, jobID int) error {
 	_, err := psql.Insert("jobs_serial_groups").
 		Columns("serial_group", "job_id").
@@ -1359,6 +1469,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		groups        sql.NullString
 		varSources    sql.NullString
 		display       sql.NullString
+		imports       sql.NullString
 		nonce         sql.NullString
 		nonceStr      *string
 		lastUpdated   pq.NullTime
@@ -1366,7 +1477,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		parentBuildID sql.NullInt64
 		instanceVars  sql.NullString
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &imports, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
 	if err != nil {
 		return err
 	}
@@ -1399,6 +1510,13 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.display = displayConfig
 	}
 
+	if imports.Valid {
+		err = json.Unmarshal([]byte(imports.String), &p.imports)
+		if err != nil {
+			return err
+		}
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -1470,7 +1588,7 @@ func scanContainers(rows *sql.Rows, conn Conn, initContainers []Container) ([]Co
 }
 
 func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, quotas, pipelineSync sql.NullString
 
 	err := tx.QueryRow(query, params...).Scan(
 		&t.id,
@@ -1478,11 +1596,31 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&quotas,
+		&pipelineSync,
 	)
 	if err != nil {
 		return err
 	}
 
+	if quotas.Valid {
+		var teamQuotas atc.TeamQuotas
+		err = json.Unmarshal([]byte(quotas.String), &teamQuotas)
+		if err != nil {
+			return err
+		}
+		t.quotas = teamQuotas
+	}
+
+	if pipelineSync.Valid {
+		var teamPipelineSync atc.PipelineSyncConfig
+		err = json.Unmarshal([]byte(pipelineSync.String), &teamPipelineSync)
+		if err != nil {
+			return err
+		}
+		t.pipelineSync = teamPipelineSync
+	}
+
 	if providerAuth.Valid {
 		var auth atc.TeamAuth
 		err = json.Unmarshal([]byte(providerAuth.String), &auth)
@@ -19,6 +19,7 @@ type TeamFactory interface {
 	GetByID(teamID int) Team
 	CreateDefaultTeamIfNotExists() (Team, error)
 	NotifyResourceScanner() error
+	NotifyPipelineSyncer() error
 	NotifyCacher() error
 }
 
@@ -51,10 +52,26 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var quotas []byte
+	if t.Quotas != nil {
+		quotas, err = json.Marshal(t.Quotas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pipelineSync []byte
+	if t.PipelineSync != nil && !t.PipelineSync.IsZero() {
+		pipelineSync, err = json.Marshal(t.PipelineSync)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, quotas, pipeline_sync").
+		Values(t.Name, auth, admin, quotas, pipelineSync).
+		Suffix("RETURNING id, name, admin, auth, quotas, pipeline_sync").
 		RunWith(tx).
 		QueryRow()
 
@@ -90,7 +107,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, quotas, pipeline_sync").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +126,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, quotas, pipeline_sync").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -170,18 +187,24 @@ func (factory *teamFactory) NotifyResourceScanner() error {
 	return factory.conn.Bus().Notify(atc.ComponentLidarScanner)
 }
 
+func (factory *teamFactory) NotifyPipelineSyncer() error {
+	return factory.conn.Bus().Notify(atc.ComponentPipelineSyncer)
+}
+
 func (factory *teamFactory) NotifyCacher() error {
 	return factory.conn.Bus().Notify(atc.TeamCacheChannel)
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, quotas, pipelineSync sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&quotas,
+		&pipelineSync,
 	)
 
 	if providerAuth.Valid {
@@ -191,5 +214,19 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if quotas.Valid {
+		err = json.Unmarshal([]byte(quotas.String), &t.quotas)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelineSync.Valid {
+		err = json.Unmarshal([]byte(pipelineSync.String), &t.pipelineSync)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
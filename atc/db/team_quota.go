@@ -0,0 +1,238 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BuildsQuotaReachedError is returned when a build cannot be started because
+// its team is already running as many builds as its quota allows.
+type BuildsQuotaReachedError struct {
+	MaxConcurrentBuilds int
+}
+
+func (err BuildsQuotaReachedError) Error() string {
+	return fmt.Sprintf("team has reached its quota of %s", err.Quota())
+}
+
+// Quota describes the quota which was reached.
+func (err BuildsQuotaReachedError) Quota() string {
+	return fmt.Sprintf("max %d concurrent builds", err.MaxConcurrentBuilds)
+}
+
+// TeamUsage is how much of its quotas a team is currently using.
+type TeamUsage struct {
+	Quotas atc.TeamQuotas
+
+	RunningBuilds int
+	Containers    int
+	Volumes       int
+}
+
+// BuildsQuotaReached returns true if the team may not start any more builds.
+func (usage TeamUsage) BuildsQuotaReached() bool {
+	return usage.Quotas.MaxConcurrentBuilds > 0 && usage.RunningBuilds >= usage.Quotas.MaxConcurrentBuilds
+}
+
+// ContainersQuotaReached returns true if the team may not create any more
+// containers.
+func (usage TeamUsage) ContainersQuotaReached() bool {
+	return usage.Quotas.MaxContainers > 0 && usage.Containers >= usage.Quotas.MaxContainers
+}
+
+// VolumesQuotaReached returns true if the team may not create any more
+// volumes.
+func (usage TeamUsage) VolumesQuotaReached() bool {
+	return usage.Quotas.MaxVolumes > 0 && usage.Volumes >= usage.Quotas.MaxVolumes
+}
+
+func (t *team) UpdateQuotas(quotas atc.TeamQuotas) error {
+	var payload []byte
+	if !quotas.IsZero() {
+		var err error
+		payload, err = json.Marshal(quotas)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := psql.Update("teams").
+		Set("quotas", payload).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.quotas = quotas
+
+	return nil
+}
+
+// Usage loads the team's quotas along with its usage of each resource which
+// has a quota. Usage of resources without a quota is not counted.
+//
+// The containers and volumes of the given build are not counted either, so
+// that a build which needs more of them than the quota allows, or builds which
+// have filled the quota between them, are never left waiting on themselves.
+func (t *team) Usage(buildID int) (TeamUsage, error) {
+	quotas, err := teamQuotas(t.conn, t.id, false)
+	if err != nil {
+		return TeamUsage{}, fmt.Errorf("get team quotas: %w", err)
+	}
+
+	usage := TeamUsage{Quotas: quotas}
+
+	if quotas.MaxConcurrentBuilds > 0 {
+		usage.RunningBuilds, err = runningBuilds(t.conn, t.id, 0)
+		if err != nil {
+			return TeamUsage{}, fmt.Errorf("count running builds: %w", err)
+		}
+	}
+
+	if quotas.MaxContainers > 0 {
+		query := psql.Select("COUNT(*)").
+			From("containers").
+			Where(sq.Eq{
+				"team_id": t.id,
+				"state":   []string{atc.ContainerStateCreating, atc.ContainerStateCreated},
+			})
+
+		if buildID != 0 {
+			query = query.Where(sq.Or{
+				sq.Eq{"build_id": nil},
+				sq.NotEq{"build_id": buildID},
+			})
+		}
+
+		err = query.
+			RunWith(t.conn).
+			QueryRow().
+			Scan(&usage.Containers)
+		if err != nil {
+			return TeamUsage{}, fmt.Errorf("count containers: %w", err)
+		}
+	}
+
+	if quotas.MaxVolumes > 0 {
+		query := psql.Select("COUNT(*)").
+			From("volumes v").
+			Where(sq.Eq{
+				"v.team_id": t.id,
+				"v.state":   []string{string(VolumeStateCreating), string(VolumeStateCreated)},
+			})
+
+		if buildID != 0 {
+			query = query.Where(sq.Expr("NOT EXISTS (SELECT 1 FROM containers c WHERE c.id = v.container_id AND c.build_id = ?)", buildID))
+		}
+
+		err = query.
+			RunWith(t.conn).
+			QueryRow().
+			Scan(&usage.Volumes)
+		if err != nil {
+			return TeamUsage{}, fmt.Errorf("count volumes: %w", err)
+		}
+	}
+
+	t.quotas = usage.Quotas
+
+	return usage, nil
+}
+
+// teamQuotas loads the team's quotas. If lock is true, the team's row is
+// locked until the end of the transaction, so that builds of the team are
+// started one at a time while its quota is checked.
+func teamQuotas(runner sq.BaseRunner, teamID int, lock bool) (atc.TeamQuotas, error) {
+	query := psql.Select("quotas").
+		From("teams").
+		Where(sq.Eq{"id": teamID})
+
+	if lock {
+		query = query.Suffix("FOR NO KEY UPDATE")
+	}
+
+	var payload sql.NullString
+	err := query.RunWith(runner).QueryRow().Scan(&payload)
+	if err != nil {
+		return atc.TeamQuotas{}, err
+	}
+
+	var quotas atc.TeamQuotas
+	if payload.Valid {
+		err = json.Unmarshal([]byte(payload.String), &quotas)
+		if err != nil {
+			return atc.TeamQuotas{}, fmt.Errorf("unmarshal quotas: %w", err)
+		}
+	}
+
+	return quotas, nil
+}
+
+// runningBuilds counts the team's builds which count towards its quota of
+// concurrent builds. Check builds don't count, and a matrix build counts as
+// the builds of its matrix rather than as a build of its own once any of them
+// has been started. The matrix build which is starting one of its builds is
+// given as parentBuildID, as it must make way for the build it starts.
+func runningBuilds(runner sq.BaseRunner, teamID int, parentBuildID int) (int, error) {
+	query := psql.Select("COUNT(*)").
+		From("builds b").
+		Where(sq.Eq{
+			"b.team_id": teamID,
+			"b.status":  BuildStatusStarted,
+		}).
+		Where(sq.NotEq{"b.name": CheckBuildName}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds m WHERE m.parent_build_id = b.id)"))
+
+	if parentBuildID != 0 {
+		query = query.Where(sq.NotEq{"b.id": parentBuildID})
+	}
+
+	var count int
+	err := query.RunWith(runner).QueryRow().Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// reserveBuildSlot checks, within the transaction starting a build of the
+// team, that the team is within its quota of concurrent builds. If the quota
+// has been reached, a BuildsQuotaReachedError is returned.
+func reserveBuildSlot(tx Tx, teamID int, parentBuildID int) error {
+	// most teams have no quota, so check for one before taking the lock
+	quotas, err := teamQuotas(tx, teamID, false)
+	if err != nil {
+		return err
+	}
+
+	if quotas.MaxConcurrentBuilds == 0 {
+		return nil
+	}
+
+	quotas, err = teamQuotas(tx, teamID, true)
+	if err != nil {
+		return err
+	}
+
+	if quotas.MaxConcurrentBuilds == 0 {
+		return nil
+	}
+
+	running, err := runningBuilds(tx, teamID, parentBuildID)
+	if err != nil {
+		return err
+	}
+
+	if running >= quotas.MaxConcurrentBuilds {
+		return BuildsQuotaReachedError{MaxConcurrentBuilds: quotas.MaxConcurrentBuilds}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -1043,6 +1044,285 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("Quotas", func() {
+		It("has no quotas by default", func() {
+			Expect(team.Quotas()).To(BeZero())
+		})
+
+		Describe("UpdateQuotas", func() {
+			quotas := atc.TeamQuotas{MaxConcurrentBuilds: 2, MaxVolumes: 10}
+
+			It("saves the quotas", func() {
+				err := team.UpdateQuotas(quotas)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.Quotas()).To(Equal(quotas))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.Quotas()).To(Equal(quotas))
+			})
+
+			It("clears the quotas when none are limited", func() {
+				err := team.UpdateQuotas(quotas)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateQuotas(atc.TeamQuotas{})
+				Expect(err).ToNot(HaveOccurred())
+
+				var saved sql.NullString
+				err = dbConn.QueryRow("SELECT quotas FROM teams WHERE id = $1", team.ID()).Scan(&saved)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved.Valid).To(BeFalse())
+			})
+		})
+
+		Describe("Usage", func() {
+			BeforeEach(func() {
+				err := team.UpdateQuotas(atc.TeamQuotas{MaxConcurrentBuilds: 1})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the quotas", func() {
+				usage, err := team.Usage(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usage.Quotas).To(Equal(atc.TeamQuotas{MaxConcurrentBuilds: 1}))
+				Expect(usage.BuildsQuotaReached()).To(BeFalse())
+			})
+
+			Context("when the team has a started build", func() {
+				BeforeEach(func() {
+					_, err := team.CreateStartedBuild(atc.Plan{})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = otherTeam.CreateStartedBuild(atc.Plan{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("counts the team's running builds", func() {
+					usage, err := team.Usage(0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(usage.RunningBuilds).To(Equal(1))
+					Expect(usage.BuildsQuotaReached()).To(BeTrue())
+				})
+			})
+
+			Context("when a build has more containers than the team's quota", func() {
+				var build db.Build
+
+				BeforeEach(func() {
+					err := team.UpdateQuotas(atc.TeamQuotas{MaxContainers: 1})
+					Expect(err).ToNot(HaveOccurred())
+
+					build, err = team.CreateStartedBuild(atc.Plan{})
+					Expect(err).ToNot(HaveOccurred())
+
+					for _, planID := range []atc.PlanID{"some-plan", "some-other-plan"} {
+						_, err = defaultWorker.CreateContainer(
+							db.NewBuildStepContainerOwner(build.ID(), planID, team.ID()),
+							db.ContainerMetadata{},
+						)
+						Expect(err).ToNot(HaveOccurred())
+					}
+				})
+
+				It("counts the containers towards the quota", func() {
+					usage, err := team.Usage(0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(usage.Containers).To(Equal(2))
+					Expect(usage.ContainersQuotaReached()).To(BeTrue())
+				})
+
+				It("does not count them for the build itself", func() {
+					usage, err := team.Usage(build.ID())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(usage.Containers).To(Equal(0))
+					Expect(usage.ContainersQuotaReached()).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("PipelineSync", func() {
+		config := atc.PipelineSyncConfig{
+			URI:          "https://example.com/pipelines.git",
+			Paths:        "ci/*.yml",
+			WebhookToken: "some-token",
+		}
+
+		It("does not sync by default", func() {
+			Expect(team.PipelineSync()).To(BeZero())
+		})
+
+		It("saves the pipeline sync", func() {
+			err := team.UpdatePipelineSync(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(team.PipelineSync()).To(Equal(config))
+
+			reloaded, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.PipelineSync()).To(Equal(config))
+		})
+
+		It("clears the pipeline sync when it is empty", func() {
+			err := team.UpdatePipelineSync(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.UpdatePipelineSync(atc.PipelineSyncConfig{})
+			Expect(err).ToNot(HaveOccurred())
+
+			var saved sql.NullString
+			err = dbConn.QueryRow("SELECT pipeline_sync FROM teams WHERE id = $1", team.ID()).Scan(&saved)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved.Valid).To(BeFalse())
+		})
+
+		Describe("SavePipelineSyncStatus", func() {
+			It("saves the latest status of each pipeline", func() {
+				err := team.SavePipelineSyncStatus(db.PipelineSyncStatus{
+					PipelineName:  "some-pipeline",
+					File:          "ci/some-pipeline.yml",
+					Commit:        "abcdef",
+					ConfigVersion: 2,
+					Status:        atc.PipelineSyncStatusSynced,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.SavePipelineSyncStatus(db.PipelineSyncStatus{
+					PipelineName: "other-pipeline",
+					File:         "ci/other-pipeline.yml",
+					Status:       atc.PipelineSyncStatusErrored,
+					Error:        "failed to fetch: nope",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.SavePipelineSyncStatus(db.PipelineSyncStatus{
+					PipelineName:  "some-pipeline",
+					File:          "ci/some-pipeline.yml",
+					Commit:        "012345",
+					ConfigVersion: 3,
+					Status:        atc.PipelineSyncStatusSynced,
+					Drifted:       true,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				statuses, err := team.PipelineSyncStatuses()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statuses).To(HaveLen(2))
+
+				Expect(statuses[0].PipelineName).To(Equal("other-pipeline"))
+				Expect(statuses[0].Status).To(Equal(atc.PipelineSyncStatusErrored))
+				Expect(statuses[0].Error).To(Equal("failed to fetch: nope"))
+				Expect(statuses[0].ConfigVersion).To(BeZero())
+
+				Expect(statuses[1].PipelineName).To(Equal("some-pipeline"))
+				Expect(statuses[1].Commit).To(Equal("012345"))
+				Expect(statuses[1].ConfigVersion).To(Equal(db.ConfigVersion(3)))
+				Expect(statuses[1].Drifted).To(BeTrue())
+				Expect(statuses[1].SyncedAt).ToNot(BeZero())
+			})
+
+			It("does not return the statuses of other teams", func() {
+				err := otherTeam.SavePipelineSyncStatus(db.PipelineSyncStatus{
+					PipelineName: "some-pipeline",
+					File:         "ci/some-pipeline.yml",
+					Status:       atc.PipelineSyncStatusSynced,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				statuses, err := team.PipelineSyncStatuses()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statuses).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PipelineLibrary", func() {
+		It("returns not found when the library does not exist", func() {
+			_, found, err := team.PipelineLibrary("some-library", 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when versions of the library have been saved", func() {
+			var first, second db.PipelineLibrary
+
+			BeforeEach(func() {
+				var err error
+				first, err = team.SavePipelineLibrary("some-library", []byte("jobs: []"))
+				Expect(err).ToNot(HaveOccurred())
+
+				second, err = team.SavePipelineLibrary("some-library", []byte("resources: []"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("numbers the versions", func() {
+				Expect(first.Version).To(Equal(1))
+				Expect(second.Version).To(Equal(2))
+				Expect(second.Digest).To(HavePrefix("sha256:"))
+			})
+
+			It("returns the latest version by default", func() {
+				library, found, err := team.PipelineLibrary("some-library", 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(library.Version).To(Equal(2))
+				Expect(string(library.Config)).To(Equal("resources: []"))
+			})
+
+			It("returns the given version", func() {
+				library, found, err := team.PipelineLibrary("some-library", 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(string(library.Config)).To(Equal("jobs: []"))
+			})
+
+			It("does not save a new version when the config has not changed", func() {
+				library, err := team.SavePipelineLibrary("some-library", []byte("resources: []"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(library.Version).To(Equal(2))
+			})
+
+			It("is not visible to other teams", func() {
+				_, found, err := otherTeam.PipelineLibrary("some-library", 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when versions are saved concurrently", func() {
+			It("numbers each of them", func() {
+				versions := make(chan int, 10)
+
+				wg := new(sync.WaitGroup)
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+
+					go func(i int) {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						library, err := team.SavePipelineLibrary("some-library", []byte(fmt.Sprintf("jobs: [{name: job-%d}]", i)))
+						Expect(err).ToNot(HaveOccurred())
+
+						versions <- library.Version
+					}(i)
+				}
+
+				wg.Wait()
+				close(versions)
+
+				var saved []int
+				for version := range versions {
+					saved = append(saved, version)
+				}
+
+				Expect(saved).To(ConsistOf(1, 2, 3, 4, 5, 6, 7, 8, 9, 10))
+			})
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -1972,6 +2252,34 @@ var _ = Describe("Team", func() {
 			Expect(pipeline.Paused()).To(BeFalse())
 		})
 
+		It("saves the resolved imports", func() {
+			config.Imports = atc.ImportConfigs{
+				{
+					Library: "some-library",
+					Vars:    atc.Params{"some": "var"},
+					Resolved: &atc.ResolvedImport{
+						Version: 2,
+						Digest:  "sha256:some-digest",
+						Jobs:    []string{"job-1"},
+					},
+				},
+			}
+
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.Imports()).To(Equal(config.Imports))
+
+			savedConfig, err := savedPipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedConfig.Imports).To(Equal(config.Imports))
+
+			config.Imports = nil
+
+			savedPipeline, _, err = team.SavePipeline(pipelineRef, config, savedPipeline.ConfigVersion(), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.Imports()).To(BeEmpty())
+		})
+
 		It("is not archived by default", func() {
 			_, _, err := team.SavePipeline(pipelineRef, config, 0, true)
 			Expect(err).ToNot(HaveOccurred())
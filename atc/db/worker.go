@@ -68,6 +68,9 @@ type Worker interface {
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Rootless() bool
+	RuntimeClasses() []string
+	NetworkPolicies() bool
 
 	Reload() (bool, error)
 
@@ -107,6 +110,9 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	rootless         bool
+	runtimeClasses   []string
+	networkPolicies  bool
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -127,6 +133,9 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Rootless() bool                          { return worker.rootless }
+func (worker *worker) RuntimeClasses() []string                { return worker.runtimeClasses }
+func (worker *worker) NetworkPolicies() bool                   { return worker.networkPolicies }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
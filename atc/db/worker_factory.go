@@ -53,7 +53,10 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.rootless,
+		w.runtime_classes,
+		w.network_policies
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -139,6 +142,8 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+
+		runtimeClasses []byte
 	)
 
 	err := row.Scan(
@@ -161,6 +166,9 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&worker.rootless,
+		&runtimeClasses,
+		&worker.networkPolicies,
 	)
 	if err != nil {
 		return err
@@ -219,6 +227,14 @@ func scanWorker(worker *worker, row scannable) error {
 		return err
 	}
 
+	// workers registered before runtime classes existed have none
+	if runtimeClasses != nil {
+		err = json.Unmarshal(runtimeClasses, &worker.runtimeClasses)
+		if err != nil {
+			return err
+		}
+	}
+
 	return json.Unmarshal(tags, &worker.tags)
 }
 
@@ -374,6 +390,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	runtimeClasses, err := json.Marshal(atcWorker.RuntimeClasses)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -410,6 +431,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.Rootless,
+		runtimeClasses,
+		atcWorker.NetworkPolicies,
 	}
 
 	conflictValues := values
@@ -441,6 +465,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"rootless",
+			"runtime_classes",
+			"network_policies",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -465,7 +492,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				rootless = ?,
+				runtime_classes = ?,
+				network_policies = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -508,6 +538,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		rootless:         atcWorker.Rootless,
+		runtimeClasses:   atcWorker.RuntimeClasses,
+		networkPolicies:  atcWorker.NetworkPolicies,
 		conn:             conn,
 	}
 
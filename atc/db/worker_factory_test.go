@@ -27,6 +27,9 @@ var _ = Describe("WorkerFactory", func() {
 			HTTPSProxyURL:    "some-https-proxy-url",
 			NoProxy:          "some-no-proxy",
 			Ephemeral:        true,
+			Rootless:         true,
+			RuntimeClasses:   []string{"kata", "runsc"},
+			NetworkPolicies:  true,
 			ActiveContainers: 140,
 			ActiveVolumes:    550,
 			ResourceTypes: []atc.WorkerResourceType{
@@ -226,6 +229,9 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
 				Expect(foundWorker.Ephemeral()).To(Equal(true))
+				Expect(foundWorker.Rootless()).To(Equal(true))
+				Expect(foundWorker.RuntimeClasses()).To(Equal([]string{"kata", "runsc"}))
+				Expect(foundWorker.NetworkPolicies()).To(BeTrue())
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
 				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+func NewApproveStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+) *approveStepDelegate {
+	return &approveStepDelegate{
+		buildStepDelegate{
+			build:  newRedactingBuild(build, state),
+			planID: planID,
+			clock:  clock,
+			state:  state,
+			stdout: nil,
+			stderr: nil,
+		},
+	}
+}
+
+type approveStepDelegate struct {
+	buildStepDelegate
+}
+
+// WaitingForApprovals records whether the step is waiting for approvals, so
+// that approvals are only accepted while it is.
+func (delegate *approveStepDelegate) WaitingForApprovals(logger lager.Logger, waiting bool) error {
+	err := delegate.build.SetWaitingForApproval(delegate.planID, waiting)
+	if err != nil {
+		logger.Error("failed-to-set-waiting-for-approval", err, lager.Data{"waiting": waiting})
+		return err
+	}
+
+	return nil
+}
+
+// Approvals returns the approvals and rejections recorded for the step.
+func (delegate *approveStepDelegate) Approvals(logger lager.Logger) ([]db.BuildApproval, error) {
+	approvals, err := delegate.build.Approvals()
+	if err != nil {
+		logger.Error("failed-to-get-approvals", err)
+		return nil, err
+	}
+
+	stepApprovals := []db.BuildApproval{}
+	for _, approval := range approvals {
+		if approval.PlanID == delegate.planID {
+			stepApprovals = append(stepApprovals, approval)
+		}
+	}
+
+	return stepApprovals, nil
+}
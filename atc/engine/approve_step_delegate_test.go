@@ -0,0 +1,105 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("ApproveStepDelegate", func() {
+	var (
+		logger    *lagertest.TestLogger
+		fakeBuild *dbfakes.FakeBuild
+		fakeClock *fakeclock.FakeClock
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.ApproveStepDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, true)
+
+		delegate = engine.NewApproveStepDelegate(fakeBuild, "some-plan-id", state, fakeClock)
+	})
+
+	Describe("WaitingForApprovals", func() {
+		It("records whether the step is waiting", func() {
+			err := delegate.WaitingForApprovals(logger, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SetWaitingForApprovalCallCount()).To(Equal(1))
+			planID, waiting := fakeBuild.SetWaitingForApprovalArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(waiting).To(BeTrue())
+		})
+
+		Context("when recording fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SetWaitingForApprovalReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				err := delegate.WaitingForApprovals(logger, true)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("Approvals", func() {
+		var (
+			approvals []db.BuildApproval
+			err       error
+		)
+
+		JustBeforeEach(func() {
+			approvals, err = delegate.Approvals(logger)
+		})
+
+		BeforeEach(func() {
+			fakeBuild.ApprovalsReturns([]db.BuildApproval{
+				{PlanID: "some-plan-id", UserName: "some-user", Approved: true},
+				{PlanID: "some-other-plan-id", UserName: "some-user", Approved: false},
+				{PlanID: "some-plan-id", UserName: "some-other-user", Approved: false},
+			}, nil)
+		})
+
+		It("returns the approvals of the step", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(approvals).To(Equal([]db.BuildApproval{
+				{PlanID: "some-plan-id", UserName: "some-user", Approved: true},
+				{PlanID: "some-plan-id", UserName: "some-other-user", Approved: false},
+			}))
+		})
+
+		Context("when getting the approvals fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.ApprovalsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
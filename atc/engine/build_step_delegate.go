@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -42,7 +41,7 @@ func NewBuildStepDelegate(
 	artifactSourcer worker.ArtifactSourcer,
 ) *buildStepDelegate {
 	return &buildStepDelegate{
-		build:           build,
+		build:           newRedactingBuild(build, state),
 		planID:          planID,
 		clock:           clock,
 		state:           state,
@@ -66,20 +65,6 @@ func (delegate *buildStepDelegate) StartSpan(
 	return tracing.StartSpan(ctx, component, attrs)
 }
 
-type credVarsIterator struct {
-	line string
-}
-
-func (it *credVarsIterator) YieldCred(name, value string) {
-	for _, lineValue := range strings.Split(value, "\n") {
-		lineValue = strings.TrimSpace(lineValue)
-		// Don't consider a single char as a secret.
-		if len(lineValue) > 1 {
-			it.line = strings.Replace(it.line, lineValue, "((redacted))", -1)
-		}
-	}
-}
-
 func (delegate *buildStepDelegate) Stdout() io.Writer {
 	if delegate.stdout != nil {
 		return delegate.stdout
@@ -92,7 +77,6 @@ func (delegate *buildStepDelegate) Stdout() io.Writer {
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
-			delegate.buildOutputFilter,
 		)
 	} else {
 		delegate.stdout = newDBEventWriter(
@@ -119,7 +103,6 @@ func (delegate *buildStepDelegate) Stderr() io.Writer {
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
-			delegate.buildOutputFilter,
 		)
 	} else {
 		delegate.stderr = newDBEventWriter(
@@ -197,6 +180,20 @@ func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger) {
 	}
 }
 
+func (delegate *buildStepDelegate) WaitingForTeamQuota(logger lager.Logger, quota string) {
+	err := delegate.build.SaveEvent(event.WaitingForTeamQuota{
+		Time: time.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Quota: quota,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-team-quota-event", err)
+		return
+	}
+}
+
 func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker string) {
 	err := delegate.build.SaveEvent(event.SelectedWorker{
 		Time: time.Now().Unix(),
@@ -386,9 +383,7 @@ func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, pri
 }
 
 func (delegate *buildStepDelegate) buildOutputFilter(str string) string {
-	it := &credVarsIterator{line: str}
-	delegate.state.IterateInterpolatedCreds(it)
-	return it.line
+	return delegate.state.Redactor().Redact(str)
 }
 
 func (delegate *buildStepDelegate) redactImageSource(source atc.Source) (atc.Source, error) {
@@ -58,6 +58,11 @@ var _ = Describe("BuildStepDelegate", func() {
 
 		runState = new(execfakes.FakeRunState)
 		runState.RedactionEnabledReturns(true)
+		runState.RedactorStub = func() *vars.Redactor {
+			redactor := vars.NewRedactor()
+			runState.IterateInterpolatedCreds(redactor)
+			return redactor
+		}
 
 		repo := build.NewRepository()
 		runState.ArtifactRepositoryReturns(repo)
@@ -747,6 +752,27 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 
 		Context("Stdout", func() {
+			Context("encoded secret", func() {
+				JustBeforeEach(func() {
+					writer = delegate.Stdout()
+					writtenBytes, writeErr = writer.Write([]byte("ok c3VwZXItc2VjcmV0LXNvdXJjZQ== ok\n"))
+					writer.(io.Closer).Close()
+				})
+
+				It("should be redacted", func() {
+					Expect(writeErr).To(BeNil())
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+						Time:    now.Unix(),
+						Payload: "ok ((redacted)) ok\n",
+						Origin: event.Origin{
+							Source: event.OriginSourceStdout,
+							ID:     "some-plan-id",
+						},
+					}))
+				})
+			})
+
 			Context("single-line secret", func() {
 				JustBeforeEach(func() {
 					writer = delegate.Stdout()
@@ -824,6 +850,23 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 
+		Context("Errored", func() {
+			JustBeforeEach(func() {
+				delegate.Errored(logger, "failed to authenticate with super-secret-source")
+			})
+
+			It("redacts the message", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+					Message: "failed to authenticate with ((redacted))",
+					Origin: event.Origin{
+						ID: event.OriginID(planID),
+					},
+					Time: now.Unix(),
+				}))
+			})
+		})
+
 		Context("Stderr", func() {
 			Context("single-line secret", func() {
 				JustBeforeEach(func() {
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -94,6 +95,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildAcrossStep(build, plan)
 	}
 
+	if plan.Matrix != nil {
+		return factory.buildMatrixStep(build, plan)
+	}
+
 	if plan.Do != nil {
 		return factory.buildDoStep(build, plan)
 	}
@@ -138,6 +143,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildLoadVarStep(build, plan)
 	}
 
+	if plan.Approve != nil {
+		return factory.buildApproveStep(build, plan)
+	}
+
 	if plan.Check != nil {
 		return factory.buildCheckStep(build, plan)
 	}
@@ -202,6 +211,38 @@ func (factory *stepperFactory) buildAcrossStep(build db.Build, plan atc.Plan) ex
 	)
 }
 
+func (factory *stepperFactory) buildMatrixStep(build db.Build, plan atc.Plan) exec.Step {
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return exec.NewMatrixStep(
+		plan.ID,
+		*plan.Matrix,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+		clock.NewClock(),
+	)
+}
+
+func (factory *stepperFactory) buildApproveStep(build db.Build, plan atc.Plan) exec.Step {
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return exec.NewApproveStep(
+		plan.ID,
+		*plan.Approve,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+		clock.NewClock(),
+	)
+}
+
 func (factory *stepperFactory) buildDoStep(build db.Build, plan atc.Plan) exec.Step {
 	var step exec.Step = exec.IdentityStep{}
 
@@ -44,3 +44,11 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
 	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
 }
+
+func (delegate DelegateFactory) ApproveStepDelegate(state exec.RunState) exec.ApproveStepDelegate {
+	return NewApproveStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
+}
+
+func (delegate DelegateFactory) MatrixStepDelegate(state exec.RunState) exec.MatrixStepDelegate {
+	return NewMatrixStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
+}
@@ -323,7 +323,9 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if err != nil {
 		return nil, err
 	}
-	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets))
+	newState := exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets)
+	exec.BuildVars(b.build.BuildVars()).AddTo(newState)
+	state, _ := b.trackedStates.LoadOrStore(id, newState)
 	return state.(exec.RunState), nil
 }
 
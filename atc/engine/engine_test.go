@@ -190,6 +190,21 @@ var _ = Describe("Engine", func() {
 									Expect(val).To(Equal("bar"))
 								})
 
+								Context("when the build has build vars", func() {
+									BeforeEach(func() {
+										fakeBuild.BuildVarsReturns(vars.StaticVariables{"branch": "master"})
+									})
+
+									It("exposes them as local vars", func() {
+										state := <-invokedState
+
+										val, found, err := state.Get(vars.Reference{Source: ".", Path: "branch"})
+										Expect(err).ToNot(HaveOccurred())
+										Expect(found).To(BeTrue())
+										Expect(val).To(Equal("master"))
+									})
+								})
+
 								Context("when the build is released", func() {
 									BeforeEach(func() {
 										readyToRelease := make(chan bool)
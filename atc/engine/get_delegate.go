@@ -27,7 +27,7 @@ func NewGetDelegate(
 		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
+		build:       newRedactingBuild(build, state),
 		clock:       clock,
 	}
 }
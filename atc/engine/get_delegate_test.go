@@ -78,6 +78,31 @@ var _ = Describe("GetDelegate", func() {
 				FetchedMetadata: info.Metadata,
 			}))
 		})
+
+		Context("when the metadata contains interpolated creds", func() {
+			BeforeEach(func() {
+				state.Get(vars.Reference{Path: "source-param"})
+
+				info.Metadata = []atc.MetadataField{
+					{Name: "url", Value: "https://example.com/?token=super-secret-source"},
+					{Name: "auth", Value: "c3VwZXItc2VjcmV0LXNvdXJjZQ=="},
+				}
+			})
+
+			It("redacts them before saving the event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishGet{
+					Origin:         event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:           now.Unix(),
+					ExitStatus:     int(exitStatus),
+					FetchedVersion: info.Version,
+					FetchedMetadata: []atc.MetadataField{
+						{Name: "url", Value: "https://example.com/?token=((redacted))"},
+						{Name: "auth", Value: "((redacted))"},
+					},
+				}))
+			})
+		})
 	})
 
 	Describe("UpdateVersion", func() {
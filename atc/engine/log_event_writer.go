@@ -8,7 +8,6 @@ import (
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
-	"github.com/concourse/concourse/atc/exec"
 )
 
 func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.WriteCloser {
@@ -65,20 +64,21 @@ func (writer *dbEventWriter) Close() error {
 	return nil
 }
 
-func newDBEventWriterWithSecretRedaction(build db.Build, origin event.Origin, clock clock.Clock, filter exec.BuildOutputFilter) io.Writer {
+// newDBEventWriterWithSecretRedaction returns a writer which only saves whole
+// lines, so that a secret is never split across log events. The secrets are
+// redacted by the build that the events are saved to.
+func newDBEventWriterWithSecretRedaction(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
 	return &dbEventWriterWithSecretRedaction{
 		dbEventWriter: dbEventWriter{
 			build:  build,
 			origin: origin,
 			clock:  clock,
 		},
-		filter: filter,
 	}
 }
 
 type dbEventWriterWithSecretRedaction struct {
 	dbEventWriter
-	filter exec.BuildOutputFilter
 }
 
 func (writer *dbEventWriterWithSecretRedaction) Write(data []byte) (int, error) {
@@ -111,7 +111,6 @@ func (writer *dbEventWriterWithSecretRedaction) Write(data []byte) (int, error)
 		}
 	}
 
-	payload = writer.filter(payload)
 	err := writer.saveLog(payload)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+func NewMatrixStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+) *matrixStepDelegate {
+	return &matrixStepDelegate{
+		buildStepDelegate{
+			build:  newRedactingBuild(build, state),
+			planID: planID,
+			clock:  clock,
+			state:  state,
+			stdout: nil,
+			stderr: nil,
+		},
+	}
+}
+
+type matrixStepDelegate struct {
+	buildStepDelegate
+}
+
+func (delegate *matrixStepDelegate) StartMatrixBuild(logger lager.Logger, index int, plan atc.MatrixBuildPlan) (db.Build, error) {
+	build, err := delegate.build.StartMatrixBuild(index, plan.Values, plan.Plan)
+	if _, ok := err.(db.BuildsQuotaReachedError); ok {
+		return nil, err
+	}
+
+	if err != nil {
+		logger.Error("failed-to-start-matrix-build", err)
+		return nil, err
+	}
+
+	logger.Debug("started-matrix-build", lager.Data{"matrix-build": build.Name()})
+
+	return build, nil
+}
@@ -0,0 +1,93 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("MatrixStepDelegate", func() {
+	var (
+		logger    *lagertest.TestLogger
+		fakeBuild *dbfakes.FakeBuild
+		fakeClock *fakeclock.FakeClock
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.MatrixStepDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, true)
+
+		delegate = engine.NewMatrixStepDelegate(fakeBuild, "some-plan-id", state, fakeClock)
+	})
+
+	Describe("StartMatrixBuild", func() {
+		var (
+			buildPlan atc.MatrixBuildPlan
+
+			matrixBuild db.Build
+			startErr    error
+		)
+
+		BeforeEach(func() {
+			buildPlan = atc.MatrixBuildPlan{
+				Values: atc.MatrixValues{"os": "linux"},
+				Plan:   atc.Plan{ID: "some-linux-plan"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			matrixBuild, startErr = delegate.StartMatrixBuild(logger, 1, buildPlan)
+		})
+
+		Context("when the build is started", func() {
+			var fakeMatrixBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				fakeMatrixBuild = new(dbfakes.FakeBuild)
+				fakeBuild.StartMatrixBuildReturns(fakeMatrixBuild, nil)
+			})
+
+			It("starts the build with the matrix values and plan", func() {
+				Expect(startErr).ToNot(HaveOccurred())
+				Expect(matrixBuild).To(Equal(fakeMatrixBuild))
+
+				Expect(fakeBuild.StartMatrixBuildCallCount()).To(Equal(1))
+				index, values, plan := fakeBuild.StartMatrixBuildArgsForCall(0)
+				Expect(index).To(Equal(1))
+				Expect(values).To(Equal(atc.MatrixValues{"os": "linux"}))
+				Expect(plan).To(Equal(atc.Plan{ID: "some-linux-plan"}))
+			})
+		})
+
+		Context("when starting the build fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.StartMatrixBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(startErr).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+// redactingBuild redacts the creds that have been interpolated into the
+// build from every event before it is saved, when redaction is enabled. This
+// is the only place events are redacted.
+type redactingBuild struct {
+	db.Build
+
+	state exec.RunState
+}
+
+func newRedactingBuild(build db.Build, state exec.RunState) db.Build {
+	if _, ok := build.(redactingBuild); ok {
+		return build
+	}
+
+	return redactingBuild{
+		Build: build,
+		state: state,
+	}
+}
+
+func (build redactingBuild) SaveEvent(ev atc.Event) error {
+	if build.state.RedactionEnabled() {
+		redactor := build.state.Redactor()
+		if !redactor.Empty() {
+			ev = event.Redact(ev, redactor.Redact)
+		}
+	}
+
+	return build.Build.SaveEvent(ev)
+}
@@ -17,7 +17,7 @@ func NewSetPipelineStepDelegate(
 ) *setPipelineStepDelegate {
 	return &setPipelineStepDelegate{
 		buildStepDelegate{
-			build:  build,
+			build:  newRedactingBuild(build, state),
 			planID: planID,
 			clock:  clock,
 			state:  state,
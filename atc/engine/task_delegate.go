@@ -28,7 +28,7 @@ func NewTaskDelegate(
 		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
+		build:       newRedactingBuild(build, state),
 		clock:       clock,
 
 		dbWorkerFactory: dbWorkerFactory,
@@ -85,15 +85,17 @@ func (d *taskDelegate) Finished(
 	exitStatus exec.ExitStatus,
 	strategy worker.ContainerPlacementStrategy,
 	chosenWorker worker.Client,
+	resourceUsage *atc.ResourceUsage,
 ) {
 	// PR#4398: close to flush stdout and stderr
 	d.Stdout().(io.Closer).Close()
 	d.Stderr().(io.Closer).Close()
 
 	err := d.build.SaveEvent(event.FinishTask{
-		ExitStatus: int(exitStatus),
-		Time:       d.clock.Now().Unix(),
-		Origin:     d.eventOrigin,
+		ExitStatus:    int(exitStatus),
+		Time:          d.clock.Now().Unix(),
+		Origin:        d.eventOrigin,
+		ResourceUsage: resourceUsage,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-finish-event", err)
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -136,14 +137,16 @@ var _ = Describe("TaskDelegate", func() {
 	Describe("Finished", func() {
 		var fakeClient *workerfakes.FakeClient
 		var fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+		var resourceUsage *atc.ResourceUsage
 
 		BeforeEach(func() {
 			fakeClient = new(workerfakes.FakeClient)
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			resourceUsage = nil
 		})
 
 		JustBeforeEach(func() {
-			delegate.Finished(logger, exitStatus, fakeStrategy, fakeClient)
+			delegate.Finished(logger, exitStatus, fakeStrategy, fakeClient, resourceUsage)
 		})
 
 		It("saves an event", func() {
@@ -151,6 +154,22 @@ var _ = Describe("TaskDelegate", func() {
 			event := fakeBuild.SaveEventArgsForCall(0)
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
+
+		Context("when the resource usage was sampled", func() {
+			BeforeEach(func() {
+				resourceUsage = &atc.ResourceUsage{
+					PeakMemoryBytes: 1024,
+					CPUSeconds:      1.5,
+					DiskIO:          &atc.DiskIOUsage{ReadBytes: 10, WriteBytes: 20},
+				}
+			})
+
+			It("saves it with the event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				e := fakeBuild.SaveEventArgsForCall(0)
+				Expect(e.(event.FinishTask).ResourceUsage).To(Equal(resourceUsage))
+			})
+		})
 	})
 })
 
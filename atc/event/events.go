@@ -16,9 +16,10 @@ func (Error) EventType() atc.EventType  { return EventTypeError }
 func (Error) Version() atc.EventVersion { return "4.1" }
 
 type FinishTask struct {
-	Time       int64  `json:"time"`
-	ExitStatus int    `json:"exit_status"`
-	Origin     Origin `json:"origin"`
+	Time          int64              `json:"time"`
+	ExitStatus    int                `json:"exit_status"`
+	Origin        Origin             `json:"origin"`
+	ResourceUsage *atc.ResourceUsage `json:"resource_usage,omitempty"`
 }
 
 func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
@@ -100,6 +101,18 @@ type WaitingForWorker struct {
 func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
 func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
 
+// WaitingForTeamQuota is saved when a build cannot be started, or a step
+// cannot be placed on a worker, because its team has reached a quota. The
+// origin is empty for builds.
+type WaitingForTeamQuota struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Quota  string `json:"quota"`
+}
+
+func (WaitingForTeamQuota) EventType() atc.EventType  { return EventTypeWaitingForTeamQuota }
+func (WaitingForTeamQuota) Version() atc.EventVersion { return "1.0" }
+
 type SelectedWorker struct {
 	Time       int64  `json:"time"`
 	Origin     Origin `json:"origin"`
@@ -46,6 +46,7 @@ func init() {
 	RegisterEvent(SetPipelineChanged{})
 	RegisterEvent(Status{})
 	RegisterEvent(WaitingForWorker{})
+	RegisterEvent(WaitingForTeamQuota{})
 	RegisterEvent(SelectedWorker{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
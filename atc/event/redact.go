@@ -0,0 +1,138 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var (
+	originType     = reflect.TypeOf(Origin{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	numberType     = reflect.TypeOf(json.Number(""))
+)
+
+// Redact returns a copy of the event with the filter applied to every string
+// it contains, including strings nested in maps, slices and raw JSON.
+//
+// The event's origin is left as-is, as it identifies the step rather than
+// carrying any output of it.
+func Redact(ev atc.Event, filter func(string) string) atc.Event {
+	redacted := redactValue(reflect.ValueOf(ev), filter)
+	return redacted.Interface().(atc.Event)
+}
+
+func redactValue(val reflect.Value, filter func(string) string) reflect.Value {
+	switch val.Kind() {
+	case reflect.String:
+		if val.Type() == numberType {
+			return val
+		}
+
+		return reflect.ValueOf(filter(val.String())).Convert(val.Type())
+
+	case reflect.Struct:
+		if val.Type() == originType {
+			return val
+		}
+
+		redacted := reflect.New(val.Type()).Elem()
+		redacted.Set(val)
+
+		for i := 0; i < val.NumField(); i++ {
+			if val.Type().Field(i).PkgPath != "" {
+				// unexported
+				continue
+			}
+
+			redacted.Field(i).Set(redactValue(val.Field(i), filter))
+		}
+
+		return redacted
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			return val
+		}
+
+		redacted := reflect.New(val.Type().Elem())
+		redacted.Elem().Set(redactValue(val.Elem(), filter))
+		return redacted
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return val
+		}
+
+		redacted := reflect.New(val.Type()).Elem()
+		redacted.Set(redactValue(val.Elem(), filter))
+		return redacted
+
+	case reflect.Map:
+		if val.IsNil() {
+			return val
+		}
+
+		redacted := reflect.MakeMapWithSize(val.Type(), val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			redacted.SetMapIndex(iter.Key(), redactValue(iter.Value(), filter))
+		}
+
+		return redacted
+
+	case reflect.Slice:
+		if val.IsNil() {
+			return val
+		}
+
+		if val.Type() == rawMessageType {
+			return reflect.ValueOf(redactJSON(val.Interface().(json.RawMessage), filter))
+		}
+
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return val
+		}
+
+		redacted := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		for i := 0; i < val.Len(); i++ {
+			redacted.Index(i).Set(redactValue(val.Index(i), filter))
+		}
+
+		return redacted
+
+	case reflect.Array:
+		redacted := reflect.New(val.Type()).Elem()
+		for i := 0; i < val.Len(); i++ {
+			redacted.Index(i).Set(redactValue(val.Index(i), filter))
+		}
+
+		return redacted
+
+	default:
+		return val
+	}
+}
+
+// redactJSON redacts the strings within a JSON document. The document is
+// decoded first so that escaped strings are matched against the filter in
+// their original form.
+func redactJSON(payload json.RawMessage, filter func(string) string) json.RawMessage {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return json.RawMessage(filter(string(payload)))
+	}
+
+	redacted, err := json.Marshal(redactValue(reflect.ValueOf(&decoded).Elem(), filter).Interface())
+	if err != nil {
+		return json.RawMessage(filter(string(payload)))
+	}
+
+	return redacted
+}
@@ -0,0 +1,76 @@
+package event_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redact", func() {
+	filter := func(s string) string {
+		return strings.Replace(s, "secret", "((redacted))", -1)
+	}
+
+	It("redacts resource metadata and versions", func() {
+		ev := event.FinishGet{
+			Origin:         event.Origin{ID: "secret-plan"},
+			Time:           123,
+			ExitStatus:     0,
+			FetchedVersion: atc.Version{"ref": "secret"},
+			FetchedMetadata: []atc.MetadataField{
+				{Name: "message", Value: "the secret is out"},
+			},
+		}
+
+		Expect(event.Redact(ev, filter)).To(Equal(event.FinishGet{
+			Origin:         event.Origin{ID: "secret-plan"},
+			Time:           123,
+			ExitStatus:     0,
+			FetchedVersion: atc.Version{"ref": "((redacted))"},
+			FetchedMetadata: []atc.MetadataField{
+				{Name: "message", Value: "the ((redacted)) is out"},
+			},
+		}))
+	})
+
+	It("does not modify the original event", func() {
+		ev := event.FinishPut{
+			CreatedVersion: atc.Version{"ref": "secret"},
+		}
+
+		event.Redact(ev, filter)
+
+		Expect(ev.CreatedVersion).To(Equal(atc.Version{"ref": "secret"}))
+	})
+
+	It("preserves nil fields", func() {
+		ev := event.FinishPut{}
+		Expect(event.Redact(ev, filter)).To(Equal(ev))
+	})
+
+	It("redacts task configs", func() {
+		ev := event.InitializeTask{
+			TaskConfig: event.TaskConfig{
+				Run: event.TaskRunConfig{
+					Path: "sh",
+					Args: []string{"-c", "echo secret"},
+				},
+			},
+		}
+
+		redacted := event.Redact(ev, filter).(event.InitializeTask)
+		Expect(redacted.TaskConfig.Run.Args).To(Equal([]string{"-c", "echo ((redacted))"}))
+	})
+
+	It("redacts strings within raw JSON", func() {
+		plan := json.RawMessage(`{"get":{"name":"image","source":{"password":"secret","port":5432}}}`)
+		ev := event.ImageGet{PublicPlan: &plan}
+
+		redacted := event.Redact(ev, filter).(event.ImageGet)
+		Expect(*redacted.PublicPlan).To(MatchJSON(`{"get":{"name":"image","source":{"password":"((redacted))","port":5432}}}`))
+	})
+})
@@ -12,6 +12,9 @@ const (
 	// a step (get/put/task) is waiting for a worker
 	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
 
+	// a build or step is waiting for its team to be within its quotas
+	EventTypeWaitingForTeamQuota atc.EventType = "waiting-for-team-quota"
+
 	// a step (get/put/task) selected worker
 	EventTypeSelectedWorker atc.EventType = "selected-worker"
 
@@ -0,0 +1,152 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ApprovalPollInterval is how often the approvals of an approve step are
+// reloaded while it is waiting.
+var ApprovalPollInterval = 5 * time.Second
+
+//counterfeiter:generate . ApproveStepDelegateFactory
+type ApproveStepDelegateFactory interface {
+	ApproveStepDelegate(state RunState) ApproveStepDelegate
+}
+
+//counterfeiter:generate . ApproveStepDelegate
+type ApproveStepDelegate interface {
+	BuildStepDelegate
+
+	WaitingForApprovals(lager.Logger, bool) error
+	Approvals(lager.Logger) ([]db.BuildApproval, error)
+}
+
+// ApproveStep pauses the build until enough users have approved it, or until
+// any user rejects it. The step succeeds once the required number of
+// approvals has been recorded and fails as soon as it is rejected.
+type ApproveStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovePlan
+	metadata        StepMetadata
+	delegateFactory ApproveStepDelegateFactory
+	clock           clock.Clock
+}
+
+func NewApproveStep(
+	planID atc.PlanID,
+	plan atc.ApprovePlan,
+	metadata StepMetadata,
+	delegateFactory ApproveStepDelegateFactory,
+	clock clock.Clock,
+) Step {
+	return &ApproveStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		clock:           clock,
+	}
+}
+
+func (step *ApproveStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.ApproveStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approve", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApproveStep) run(ctx context.Context, delegate ApproveStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approve-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	delegate.Starting(logger)
+
+	err := delegate.WaitingForApprovals(logger, true)
+	if err != nil {
+		return false, fmt.Errorf("wait for approvals: %w", err)
+	}
+
+	defer func() {
+		// approvals are no longer accepted once the step has stopped waiting
+		_ = delegate.WaitingForApprovals(logger, false)
+	}()
+
+	stdout := delegate.Stdout()
+
+	required := step.plan.Required
+	if required < 1 {
+		required = 1
+	}
+
+	fmt.Fprintf(stdout, "waiting for %d approval(s)\n", required)
+
+	ticker := step.clock.NewTicker(ApprovalPollInterval)
+	defer ticker.Stop()
+
+	seen := map[string]bool{}
+	approved := 0
+	for {
+		approvals, err := delegate.Approvals(logger)
+		if err != nil {
+			return false, fmt.Errorf("get approvals: %w", err)
+		}
+
+		for _, approval := range approvals {
+			if seen[approval.UserName] {
+				continue
+			}
+
+			seen[approval.UserName] = true
+
+			if approval.Approved {
+				approved++
+				fmt.Fprintf(stdout, "approved by %s (%d/%d)%s\n", approval.UserName, approved, required, formatApprovalComment(approval.Comment))
+				continue
+			}
+
+			fmt.Fprintf(stdout, "rejected by %s%s\n", approval.UserName, formatApprovalComment(approval.Comment))
+
+			delegate.Finished(logger, false)
+
+			return false, nil
+		}
+
+		if approved >= required {
+			delegate.Finished(logger, true)
+
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+func formatApprovalComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+
+	return ": " + comment
+}
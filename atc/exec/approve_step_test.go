@@ -0,0 +1,202 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ApproveStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeClock           *fakeclock.FakeClock
+		fakeDelegate        *execfakes.FakeApproveStepDelegate
+		fakeDelegateFactory *execfakes.FakeApproveStepDelegateFactory
+
+		approvePlan atc.ApprovePlan
+		state       exec.RunState
+		stdout      *gbytes.Buffer
+
+		step exec.Step
+
+		stepOk  bool
+		stepErr error
+		done    chan struct{}
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeApproveStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+		fakeDelegate.ApprovalsReturns([]db.BuildApproval{}, nil)
+
+		fakeDelegateFactory = new(execfakes.FakeApproveStepDelegateFactory)
+		fakeDelegateFactory.ApproveStepDelegateReturns(fakeDelegate)
+
+		approvePlan = atc.ApprovePlan{
+			Name:     "deploy",
+			Required: 2,
+		}
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApproveStep(
+			"some-plan-id",
+			approvePlan,
+			exec.StepMetadata{BuildID: 42, BuildName: "42"},
+			fakeDelegateFactory,
+			fakeClock,
+		)
+
+		done = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+
+			stepOk, stepErr = step.Run(ctx, state)
+		}()
+	})
+
+	It("waits for the required approvals", func() {
+		Eventually(stdout).Should(gbytes.Say(`waiting for 2 approval\(s\)`))
+		Consistently(done).ShouldNot(BeClosed())
+
+		fakeDelegate.ApprovalsReturns([]db.BuildApproval{
+			{PlanID: "some-plan-id", UserName: "some-user", Approved: true, Comment: "lgtm"},
+		}, nil)
+		fakeClock.WaitForWatcherAndIncrement(exec.ApprovalPollInterval)
+
+		Eventually(stdout).Should(gbytes.Say(`approved by some-user \(1/2\): lgtm`))
+		Consistently(done).ShouldNot(BeClosed())
+
+		fakeDelegate.ApprovalsReturns([]db.BuildApproval{
+			{PlanID: "some-plan-id", UserName: "some-user", Approved: true, Comment: "lgtm"},
+			{PlanID: "some-plan-id", UserName: "some-other-user", Approved: true},
+		}, nil)
+		fakeClock.WaitForWatcherAndIncrement(exec.ApprovalPollInterval)
+
+		Eventually(done).Should(BeClosed())
+		Expect(stdout).To(gbytes.Say(`approved by some-other-user \(2/2\)\n`))
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	It("accepts approvals only while it is waiting", func() {
+		Eventually(fakeDelegate.ApprovalsCallCount).Should(Equal(1))
+		Expect(fakeDelegate.WaitingForApprovalsCallCount()).To(Equal(1))
+		_, waiting := fakeDelegate.WaitingForApprovalsArgsForCall(0)
+		Expect(waiting).To(BeTrue())
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+
+		Expect(fakeDelegate.WaitingForApprovalsCallCount()).To(Equal(2))
+		_, waiting = fakeDelegate.WaitingForApprovalsArgsForCall(1)
+		Expect(waiting).To(BeFalse())
+	})
+
+	Context("when the step is rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.ApprovalsReturns([]db.BuildApproval{
+				{PlanID: "some-plan-id", UserName: "some-user", Approved: true},
+				{PlanID: "some-plan-id", UserName: "some-other-user", Approved: false, Comment: "not today"},
+			}, nil)
+		})
+
+		It("fails", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stdout).To(gbytes.Say(`rejected by some-other-user: not today`))
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when no required count is configured", func() {
+		BeforeEach(func() {
+			approvePlan.Required = 0
+			fakeDelegate.ApprovalsReturns([]db.BuildApproval{
+				{PlanID: "some-plan-id", UserName: "some-user", Approved: true},
+			}, nil)
+		})
+
+		It("requires a single approval", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the step is aborted or times out", func() {
+		It("stops waiting", func() {
+			Eventually(fakeDelegate.ApprovalsCallCount).Should(Equal(1))
+			cancel()
+
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when recording that the step is waiting fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.WaitingForApprovalsReturns(disaster)
+		})
+
+		It("errors without waiting", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+			Expect(fakeDelegate.ApprovalsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the approvals fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.ApprovalsReturns(nil, disaster)
+		})
+
+		It("errors", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+		})
+	})
+})
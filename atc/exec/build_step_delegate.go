@@ -32,6 +32,7 @@ type BuildStepDelegate interface {
 	Errored(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
+	WaitingForTeamQuota(lager.Logger, string)
 	SelectedWorker(lager.Logger, string)
 }
 
@@ -80,3 +80,15 @@ func (b *buildVariables) AddLocalVar(name string, val interface{}, redact bool)
 func (b *buildVariables) RedactionEnabled() bool {
 	return b.tracker.Enabled
 }
+
+// BuildVars are vars which were provided to a build when it was created, e.g.
+// fields extracted from a webhook payload. They are exposed to every step of
+// the build as local vars, i.e. ((.:name)).
+type BuildVars vars.StaticVariables
+
+// AddTo declares each of the build vars as a local var in the given state.
+func (buildVars BuildVars) AddTo(state RunState) {
+	for name, val := range buildVars {
+		state.AddLocalVar(name, val, false)
+	}
+}
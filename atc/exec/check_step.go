@@ -242,12 +242,15 @@ func (step *CheckStep) runCheck(
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
 		TeamID:       step.metadata.TeamID,
+		BuildID:      step.metadata.BuildID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
+		workerSpec.Privileged = resourceType.Privileged
+
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
 			Type:    resourceType.Type,
@@ -492,6 +492,7 @@ var _ = Describe("CheckStep", func() {
 
 						Expect(workerSpec).To(Equal(worker.WorkerSpec{
 							TeamID:       stepMetadata.TeamID,
+							BuildID:      stepMetadata.BuildID,
 							ResourceType: "registry-image",
 						}))
 					})
@@ -0,0 +1,779 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type FakeApproveStepDelegate struct {
+	ApprovalsStub        func(lager.Logger) ([]db.BuildApproval, error)
+	approvalsMutex       sync.RWMutex
+	approvalsArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	approvalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	approvalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	ErroredStub        func(lager.Logger, string)
+	erroredMutex       sync.RWMutex
+	erroredArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	FetchImageStub        func(context.Context, atc.ImageResource, atc.VersionedResourceTypes, bool) (worker.ImageSpec, error)
+	fetchImageMutex       sync.RWMutex
+	fetchImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 atc.ImageResource
+		arg3 atc.VersionedResourceTypes
+		arg4 bool
+	}
+	fetchImageReturns struct {
+		result1 worker.ImageSpec
+		result2 error
+	}
+	fetchImageReturnsOnCall map[int]struct {
+		result1 worker.ImageSpec
+		result2 error
+	}
+	FinishedStub        func(lager.Logger, bool)
+	finishedMutex       sync.RWMutex
+	finishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 bool
+	}
+	InitializingStub        func(lager.Logger)
+	initializingMutex       sync.RWMutex
+	initializingArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	SelectedWorkerStub        func(lager.Logger, string)
+	selectedWorkerMutex       sync.RWMutex
+	selectedWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
+	startSpanMutex       sync.RWMutex
+	startSpanArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 tracing.Attrs
+	}
+	startSpanReturns struct {
+		result1 context.Context
+		result2 trace.Span
+	}
+	startSpanReturnsOnCall map[int]struct {
+		result1 context.Context
+		result2 trace.Span
+	}
+	StartingStub        func(lager.Logger)
+	startingMutex       sync.RWMutex
+	startingArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	StderrStub        func() io.Writer
+	stderrMutex       sync.RWMutex
+	stderrArgsForCall []struct {
+	}
+	stderrReturns struct {
+		result1 io.Writer
+	}
+	stderrReturnsOnCall map[int]struct {
+		result1 io.Writer
+	}
+	StdoutStub        func() io.Writer
+	stdoutMutex       sync.RWMutex
+	stdoutArgsForCall []struct {
+	}
+	stdoutReturns struct {
+		result1 io.Writer
+	}
+	stdoutReturnsOnCall map[int]struct {
+		result1 io.Writer
+	}
+	WaitingForApprovalsStub        func(lager.Logger, bool) error
+	waitingForApprovalsMutex       sync.RWMutex
+	waitingForApprovalsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 bool
+	}
+	waitingForApprovalsReturns struct {
+		result1 error
+	}
+	waitingForApprovalsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitingForTeamQuotaStub        func(lager.Logger, string)
+	waitingForTeamQuotaMutex       sync.RWMutex
+	waitingForTeamQuotaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	WaitingForWorkerStub        func(lager.Logger)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApproveStepDelegate) Approvals(arg1 lager.Logger) ([]db.BuildApproval, error) {
+	fake.approvalsMutex.Lock()
+	ret, specificReturn := fake.approvalsReturnsOnCall[len(fake.approvalsArgsForCall)]
+	fake.approvalsArgsForCall = append(fake.approvalsArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.ApprovalsStub
+	fakeReturns := fake.approvalsReturns
+	fake.recordInvocation("Approvals", []interface{}{arg1})
+	fake.approvalsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApproveStepDelegate) ApprovalsCallCount() int {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	return len(fake.approvalsArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) ApprovalsCalls(stub func(lager.Logger) ([]db.BuildApproval, error)) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) ApprovalsArgsForCall(i int) lager.Logger {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	argsForCall := fake.approvalsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApproveStepDelegate) ApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	fake.approvalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) ApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	if fake.approvalsReturnsOnCall == nil {
+		fake.approvalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.approvalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) Errored(arg1 lager.Logger, arg2 string) {
+	fake.erroredMutex.Lock()
+	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ErroredStub
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+	fake.erroredMutex.Unlock()
+	if stub != nil {
+		fake.ErroredStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) ErroredCallCount() int {
+	fake.erroredMutex.RLock()
+	defer fake.erroredMutex.RUnlock()
+	return len(fake.erroredArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) ErroredCalls(stub func(lager.Logger, string)) {
+	fake.erroredMutex.Lock()
+	defer fake.erroredMutex.Unlock()
+	fake.ErroredStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+	fake.erroredMutex.RLock()
+	defer fake.erroredMutex.RUnlock()
+	argsForCall := fake.erroredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApproveStepDelegate) FetchImage(arg1 context.Context, arg2 atc.ImageResource, arg3 atc.VersionedResourceTypes, arg4 bool) (worker.ImageSpec, error) {
+	fake.fetchImageMutex.Lock()
+	ret, specificReturn := fake.fetchImageReturnsOnCall[len(fake.fetchImageArgsForCall)]
+	fake.fetchImageArgsForCall = append(fake.fetchImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 atc.ImageResource
+		arg3 atc.VersionedResourceTypes
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.FetchImageStub
+	fakeReturns := fake.fetchImageReturns
+	fake.recordInvocation("FetchImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.fetchImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApproveStepDelegate) FetchImageCallCount() int {
+	fake.fetchImageMutex.RLock()
+	defer fake.fetchImageMutex.RUnlock()
+	return len(fake.fetchImageArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) FetchImageCalls(stub func(context.Context, atc.ImageResource, atc.VersionedResourceTypes, bool) (worker.ImageSpec, error)) {
+	fake.fetchImageMutex.Lock()
+	defer fake.fetchImageMutex.Unlock()
+	fake.FetchImageStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) FetchImageArgsForCall(i int) (context.Context, atc.ImageResource, atc.VersionedResourceTypes, bool) {
+	fake.fetchImageMutex.RLock()
+	defer fake.fetchImageMutex.RUnlock()
+	argsForCall := fake.fetchImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeApproveStepDelegate) FetchImageReturns(result1 worker.ImageSpec, result2 error) {
+	fake.fetchImageMutex.Lock()
+	defer fake.fetchImageMutex.Unlock()
+	fake.FetchImageStub = nil
+	fake.fetchImageReturns = struct {
+		result1 worker.ImageSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) FetchImageReturnsOnCall(i int, result1 worker.ImageSpec, result2 error) {
+	fake.fetchImageMutex.Lock()
+	defer fake.fetchImageMutex.Unlock()
+	fake.FetchImageStub = nil
+	if fake.fetchImageReturnsOnCall == nil {
+		fake.fetchImageReturnsOnCall = make(map[int]struct {
+			result1 worker.ImageSpec
+			result2 error
+		})
+	}
+	fake.fetchImageReturnsOnCall[i] = struct {
+		result1 worker.ImageSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) Finished(arg1 lager.Logger, arg2 bool) {
+	fake.finishedMutex.Lock()
+	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.FinishedStub
+	fake.recordInvocation("Finished", []interface{}{arg1, arg2})
+	fake.finishedMutex.Unlock()
+	if stub != nil {
+		fake.FinishedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) FinishedCallCount() int {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return len(fake.finishedArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) FinishedCalls(stub func(lager.Logger, bool)) {
+	fake.finishedMutex.Lock()
+	defer fake.finishedMutex.Unlock()
+	fake.FinishedStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) FinishedArgsForCall(i int) (lager.Logger, bool) {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	argsForCall := fake.finishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApproveStepDelegate) Initializing(arg1 lager.Logger) {
+	fake.initializingMutex.Lock()
+	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.InitializingStub
+	fake.recordInvocation("Initializing", []interface{}{arg1})
+	fake.initializingMutex.Unlock()
+	if stub != nil {
+		fake.InitializingStub(arg1)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) InitializingCallCount() int {
+	fake.initializingMutex.RLock()
+	defer fake.initializingMutex.RUnlock()
+	return len(fake.initializingArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) InitializingCalls(stub func(lager.Logger)) {
+	fake.initializingMutex.Lock()
+	defer fake.initializingMutex.Unlock()
+	fake.InitializingStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) InitializingArgsForCall(i int) lager.Logger {
+	fake.initializingMutex.RLock()
+	defer fake.initializingMutex.RUnlock()
+	argsForCall := fake.initializingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApproveStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
+	fake.selectedWorkerMutex.Lock()
+	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SelectedWorkerStub
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2})
+	fake.selectedWorkerMutex.Unlock()
+	if stub != nil {
+		fake.SelectedWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) SelectedWorkerCallCount() int {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	return len(fake.selectedWorkerArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) SelectedWorkerCalls(stub func(lager.Logger, string)) {
+	fake.selectedWorkerMutex.Lock()
+	defer fake.selectedWorkerMutex.Unlock()
+	fake.SelectedWorkerStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string) {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	argsForCall := fake.selectedWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApproveStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
+	fake.startSpanMutex.Lock()
+	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
+	fake.startSpanArgsForCall = append(fake.startSpanArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 tracing.Attrs
+	}{arg1, arg2, arg3})
+	stub := fake.StartSpanStub
+	fakeReturns := fake.startSpanReturns
+	fake.recordInvocation("StartSpan", []interface{}{arg1, arg2, arg3})
+	fake.startSpanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApproveStepDelegate) StartSpanCallCount() int {
+	fake.startSpanMutex.RLock()
+	defer fake.startSpanMutex.RUnlock()
+	return len(fake.startSpanArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) StartSpanCalls(stub func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)) {
+	fake.startSpanMutex.Lock()
+	defer fake.startSpanMutex.Unlock()
+	fake.StartSpanStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) StartSpanArgsForCall(i int) (context.Context, string, tracing.Attrs) {
+	fake.startSpanMutex.RLock()
+	defer fake.startSpanMutex.RUnlock()
+	argsForCall := fake.startSpanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeApproveStepDelegate) StartSpanReturns(result1 context.Context, result2 trace.Span) {
+	fake.startSpanMutex.Lock()
+	defer fake.startSpanMutex.Unlock()
+	fake.StartSpanStub = nil
+	fake.startSpanReturns = struct {
+		result1 context.Context
+		result2 trace.Span
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) StartSpanReturnsOnCall(i int, result1 context.Context, result2 trace.Span) {
+	fake.startSpanMutex.Lock()
+	defer fake.startSpanMutex.Unlock()
+	fake.StartSpanStub = nil
+	if fake.startSpanReturnsOnCall == nil {
+		fake.startSpanReturnsOnCall = make(map[int]struct {
+			result1 context.Context
+			result2 trace.Span
+		})
+	}
+	fake.startSpanReturnsOnCall[i] = struct {
+		result1 context.Context
+		result2 trace.Span
+	}{result1, result2}
+}
+
+func (fake *FakeApproveStepDelegate) Starting(arg1 lager.Logger) {
+	fake.startingMutex.Lock()
+	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.StartingStub
+	fake.recordInvocation("Starting", []interface{}{arg1})
+	fake.startingMutex.Unlock()
+	if stub != nil {
+		fake.StartingStub(arg1)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) StartingCallCount() int {
+	fake.startingMutex.RLock()
+	defer fake.startingMutex.RUnlock()
+	return len(fake.startingArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) StartingCalls(stub func(lager.Logger)) {
+	fake.startingMutex.Lock()
+	defer fake.startingMutex.Unlock()
+	fake.StartingStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) StartingArgsForCall(i int) lager.Logger {
+	fake.startingMutex.RLock()
+	defer fake.startingMutex.RUnlock()
+	argsForCall := fake.startingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApproveStepDelegate) Stderr() io.Writer {
+	fake.stderrMutex.Lock()
+	ret, specificReturn := fake.stderrReturnsOnCall[len(fake.stderrArgsForCall)]
+	fake.stderrArgsForCall = append(fake.stderrArgsForCall, struct {
+	}{})
+	stub := fake.StderrStub
+	fakeReturns := fake.stderrReturns
+	fake.recordInvocation("Stderr", []interface{}{})
+	fake.stderrMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApproveStepDelegate) StderrCallCount() int {
+	fake.stderrMutex.RLock()
+	defer fake.stderrMutex.RUnlock()
+	return len(fake.stderrArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) StderrCalls(stub func() io.Writer) {
+	fake.stderrMutex.Lock()
+	defer fake.stderrMutex.Unlock()
+	fake.StderrStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) StderrReturns(result1 io.Writer) {
+	fake.stderrMutex.Lock()
+	defer fake.stderrMutex.Unlock()
+	fake.StderrStub = nil
+	fake.stderrReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) StderrReturnsOnCall(i int, result1 io.Writer) {
+	fake.stderrMutex.Lock()
+	defer fake.stderrMutex.Unlock()
+	fake.StderrStub = nil
+	if fake.stderrReturnsOnCall == nil {
+		fake.stderrReturnsOnCall = make(map[int]struct {
+			result1 io.Writer
+		})
+	}
+	fake.stderrReturnsOnCall[i] = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) Stdout() io.Writer {
+	fake.stdoutMutex.Lock()
+	ret, specificReturn := fake.stdoutReturnsOnCall[len(fake.stdoutArgsForCall)]
+	fake.stdoutArgsForCall = append(fake.stdoutArgsForCall, struct {
+	}{})
+	stub := fake.StdoutStub
+	fakeReturns := fake.stdoutReturns
+	fake.recordInvocation("Stdout", []interface{}{})
+	fake.stdoutMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApproveStepDelegate) StdoutCallCount() int {
+	fake.stdoutMutex.RLock()
+	defer fake.stdoutMutex.RUnlock()
+	return len(fake.stdoutArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) StdoutCalls(stub func() io.Writer) {
+	fake.stdoutMutex.Lock()
+	defer fake.stdoutMutex.Unlock()
+	fake.StdoutStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) StdoutReturns(result1 io.Writer) {
+	fake.stdoutMutex.Lock()
+	defer fake.stdoutMutex.Unlock()
+	fake.StdoutStub = nil
+	fake.stdoutReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
+	fake.stdoutMutex.Lock()
+	defer fake.stdoutMutex.Unlock()
+	fake.StdoutStub = nil
+	if fake.stdoutReturnsOnCall == nil {
+		fake.stdoutReturnsOnCall = make(map[int]struct {
+			result1 io.Writer
+		})
+	}
+	fake.stdoutReturnsOnCall[i] = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovals(arg1 lager.Logger, arg2 bool) error {
+	fake.waitingForApprovalsMutex.Lock()
+	ret, specificReturn := fake.waitingForApprovalsReturnsOnCall[len(fake.waitingForApprovalsArgsForCall)]
+	fake.waitingForApprovalsArgsForCall = append(fake.waitingForApprovalsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.WaitingForApprovalsStub
+	fakeReturns := fake.waitingForApprovalsReturns
+	fake.recordInvocation("WaitingForApprovals", []interface{}{arg1, arg2})
+	fake.waitingForApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovalsCallCount() int {
+	fake.waitingForApprovalsMutex.RLock()
+	defer fake.waitingForApprovalsMutex.RUnlock()
+	return len(fake.waitingForApprovalsArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovalsCalls(stub func(lager.Logger, bool) error) {
+	fake.waitingForApprovalsMutex.Lock()
+	defer fake.waitingForApprovalsMutex.Unlock()
+	fake.WaitingForApprovalsStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovalsArgsForCall(i int) (lager.Logger, bool) {
+	fake.waitingForApprovalsMutex.RLock()
+	defer fake.waitingForApprovalsMutex.RUnlock()
+	argsForCall := fake.waitingForApprovalsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovalsReturns(result1 error) {
+	fake.waitingForApprovalsMutex.Lock()
+	defer fake.waitingForApprovalsMutex.Unlock()
+	fake.WaitingForApprovalsStub = nil
+	fake.waitingForApprovalsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForApprovalsReturnsOnCall(i int, result1 error) {
+	fake.waitingForApprovalsMutex.Lock()
+	defer fake.waitingForApprovalsMutex.Unlock()
+	fake.WaitingForApprovalsStub = nil
+	if fake.waitingForApprovalsReturnsOnCall == nil {
+		fake.waitingForApprovalsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitingForApprovalsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForTeamQuota(arg1 lager.Logger, arg2 string) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	fake.waitingForTeamQuotaArgsForCall = append(fake.waitingForTeamQuotaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WaitingForTeamQuotaStub
+	fake.recordInvocation("WaitingForTeamQuota", []interface{}{arg1, arg2})
+	fake.waitingForTeamQuotaMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForTeamQuotaStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForTeamQuotaCallCount() int {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	return len(fake.waitingForTeamQuotaArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForTeamQuotaCalls(stub func(lager.Logger, string)) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	defer fake.waitingForTeamQuotaMutex.Unlock()
+	fake.WaitingForTeamQuotaStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForTeamQuotaArgsForCall(i int) (lager.Logger, string) {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	argsForCall := fake.waitingForTeamQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForWorker(arg1 lager.Logger) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.WaitingForWorkerStub
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1})
+	fake.waitingForWorkerMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForWorkerStub(arg1)
+	}
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForWorkerCalls(stub func(lager.Logger)) {
+	fake.waitingForWorkerMutex.Lock()
+	defer fake.waitingForWorkerMutex.Unlock()
+	fake.WaitingForWorkerStub = stub
+}
+
+func (fake *FakeApproveStepDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	argsForCall := fake.waitingForWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApproveStepDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	fake.erroredMutex.RLock()
+	defer fake.erroredMutex.RUnlock()
+	fake.fetchImageMutex.RLock()
+	defer fake.fetchImageMutex.RUnlock()
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	fake.initializingMutex.RLock()
+	defer fake.initializingMutex.RUnlock()
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	fake.startSpanMutex.RLock()
+	defer fake.startSpanMutex.RUnlock()
+	fake.startingMutex.RLock()
+	defer fake.startingMutex.RUnlock()
+	fake.stderrMutex.RLock()
+	defer fake.stderrMutex.RUnlock()
+	fake.stdoutMutex.RLock()
+	defer fake.stdoutMutex.RUnlock()
+	fake.waitingForApprovalsMutex.RLock()
+	defer fake.waitingForApprovalsMutex.RUnlock()
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeApproveStepDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ApproveStepDelegate = new(FakeApproveStepDelegate)
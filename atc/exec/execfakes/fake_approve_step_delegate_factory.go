@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeApproveStepDelegateFactory struct {
+	ApproveStepDelegateStub        func(exec.RunState) exec.ApproveStepDelegate
+	approveStepDelegateMutex       sync.RWMutex
+	approveStepDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	approveStepDelegateReturns struct {
+		result1 exec.ApproveStepDelegate
+	}
+	approveStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.ApproveStepDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegate(arg1 exec.RunState) exec.ApproveStepDelegate {
+	fake.approveStepDelegateMutex.Lock()
+	ret, specificReturn := fake.approveStepDelegateReturnsOnCall[len(fake.approveStepDelegateArgsForCall)]
+	fake.approveStepDelegateArgsForCall = append(fake.approveStepDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.ApproveStepDelegateStub
+	fakeReturns := fake.approveStepDelegateReturns
+	fake.recordInvocation("ApproveStepDelegate", []interface{}{arg1})
+	fake.approveStepDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegateCallCount() int {
+	fake.approveStepDelegateMutex.RLock()
+	defer fake.approveStepDelegateMutex.RUnlock()
+	return len(fake.approveStepDelegateArgsForCall)
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegateCalls(stub func(exec.RunState) exec.ApproveStepDelegate) {
+	fake.approveStepDelegateMutex.Lock()
+	defer fake.approveStepDelegateMutex.Unlock()
+	fake.ApproveStepDelegateStub = stub
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegateArgsForCall(i int) exec.RunState {
+	fake.approveStepDelegateMutex.RLock()
+	defer fake.approveStepDelegateMutex.RUnlock()
+	argsForCall := fake.approveStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegateReturns(result1 exec.ApproveStepDelegate) {
+	fake.approveStepDelegateMutex.Lock()
+	defer fake.approveStepDelegateMutex.Unlock()
+	fake.ApproveStepDelegateStub = nil
+	fake.approveStepDelegateReturns = struct {
+		result1 exec.ApproveStepDelegate
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegateFactory) ApproveStepDelegateReturnsOnCall(i int, result1 exec.ApproveStepDelegate) {
+	fake.approveStepDelegateMutex.Lock()
+	defer fake.approveStepDelegateMutex.Unlock()
+	fake.ApproveStepDelegateStub = nil
+	if fake.approveStepDelegateReturnsOnCall == nil {
+		fake.approveStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ApproveStepDelegate
+		})
+	}
+	fake.approveStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.ApproveStepDelegate
+	}{result1}
+}
+
+func (fake *FakeApproveStepDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.approveStepDelegateMutex.RLock()
+	defer fake.approveStepDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeApproveStepDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ApproveStepDelegateFactory = new(FakeApproveStepDelegateFactory)
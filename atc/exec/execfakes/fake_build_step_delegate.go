@@ -94,6 +94,12 @@ type FakeBuildStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WaitingForTeamQuotaStub        func(lager.Logger, string)
+	waitingForTeamQuotaMutex       sync.RWMutex
+	waitingForTeamQuotaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -505,6 +511,39 @@ func (fake *FakeBuildStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) WaitingForTeamQuota(arg1 lager.Logger, arg2 string) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	fake.waitingForTeamQuotaArgsForCall = append(fake.waitingForTeamQuotaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WaitingForTeamQuotaStub
+	fake.recordInvocation("WaitingForTeamQuota", []interface{}{arg1, arg2})
+	fake.waitingForTeamQuotaMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForTeamQuotaStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForTeamQuotaCallCount() int {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	return len(fake.waitingForTeamQuotaArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForTeamQuotaCalls(stub func(lager.Logger, string)) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	defer fake.waitingForTeamQuotaMutex.Unlock()
+	fake.WaitingForTeamQuotaStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForTeamQuotaArgsForCall(i int) (lager.Logger, string) {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	argsForCall := fake.waitingForTeamQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -558,6 +597,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
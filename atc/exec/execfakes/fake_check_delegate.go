@@ -136,6 +136,12 @@ type FakeCheckDelegate struct {
 		result2 bool
 		result3 error
 	}
+	WaitingForTeamQuotaStub        func(lager.Logger, string)
+	waitingForTeamQuotaMutex       sync.RWMutex
+	waitingForTeamQuotaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -740,6 +746,39 @@ func (fake *FakeCheckDelegate) WaitToRunReturnsOnCall(i int, result1 lock.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCheckDelegate) WaitingForTeamQuota(arg1 lager.Logger, arg2 string) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	fake.waitingForTeamQuotaArgsForCall = append(fake.waitingForTeamQuotaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WaitingForTeamQuotaStub
+	fake.recordInvocation("WaitingForTeamQuota", []interface{}{arg1, arg2})
+	fake.waitingForTeamQuotaMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForTeamQuotaStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) WaitingForTeamQuotaCallCount() int {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	return len(fake.waitingForTeamQuotaArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) WaitingForTeamQuotaCalls(stub func(lager.Logger, string)) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	defer fake.waitingForTeamQuotaMutex.Unlock()
+	fake.WaitingForTeamQuotaStub = stub
+}
+
+func (fake *FakeCheckDelegate) WaitingForTeamQuotaArgsForCall(i int) (lager.Logger, string) {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	argsForCall := fake.waitingForTeamQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -799,6 +838,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.waitToRunMutex.RLock()
 	defer fake.waitToRunMutex.RUnlock()
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
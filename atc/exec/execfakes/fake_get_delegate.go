@@ -103,6 +103,12 @@ type FakeGetDelegate struct {
 		arg2 atc.GetPlan
 		arg3 runtime.VersionResult
 	}
+	WaitingForTeamQuotaStub        func(lager.Logger, string)
+	waitingForTeamQuotaMutex       sync.RWMutex
+	waitingForTeamQuotaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -549,6 +555,39 @@ func (fake *FakeGetDelegate) UpdateVersionArgsForCall(i int) (lager.Logger, atc.
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeGetDelegate) WaitingForTeamQuota(arg1 lager.Logger, arg2 string) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	fake.waitingForTeamQuotaArgsForCall = append(fake.waitingForTeamQuotaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WaitingForTeamQuotaStub
+	fake.recordInvocation("WaitingForTeamQuota", []interface{}{arg1, arg2})
+	fake.waitingForTeamQuotaMutex.Unlock()
+	if stub != nil {
+		fake.WaitingForTeamQuotaStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) WaitingForTeamQuotaCallCount() int {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	return len(fake.waitingForTeamQuotaArgsForCall)
+}
+
+func (fake *FakeGetDelegate) WaitingForTeamQuotaCalls(stub func(lager.Logger, string)) {
+	fake.waitingForTeamQuotaMutex.Lock()
+	defer fake.waitingForTeamQuotaMutex.Unlock()
+	fake.WaitingForTeamQuotaStub = stub
+}
+
+func (fake *FakeGetDelegate) WaitingForTeamQuotaArgsForCall(i int) (lager.Logger, string) {
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
+	argsForCall := fake.waitingForTeamQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -604,6 +643,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.updateVersionMutex.RLock()
 	defer fake.updateVersionMutex.RUnlock()
+	fake.waitingForTeamQuotaMutex.RLock()
+	defer fake.waitingForTeamQuotaMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
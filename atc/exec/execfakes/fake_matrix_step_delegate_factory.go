@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeMatrixStepDelegateFactory struct {
+	MatrixStepDelegateStub        func(exec.RunState) exec.MatrixStepDelegate
+	matrixStepDelegateMutex       sync.RWMutex
+	matrixStepDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	matrixStepDelegateReturns struct {
+		result1 exec.MatrixStepDelegate
+	}
+	matrixStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.MatrixStepDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegate(arg1 exec.RunState) exec.MatrixStepDelegate {
+	fake.matrixStepDelegateMutex.Lock()
+	ret, specificReturn := fake.matrixStepDelegateReturnsOnCall[len(fake.matrixStepDelegateArgsForCall)]
+	fake.matrixStepDelegateArgsForCall = append(fake.matrixStepDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.MatrixStepDelegateStub
+	fakeReturns := fake.matrixStepDelegateReturns
+	fake.recordInvocation("MatrixStepDelegate", []interface{}{arg1})
+	fake.matrixStepDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegateCallCount() int {
+	fake.matrixStepDelegateMutex.RLock()
+	defer fake.matrixStepDelegateMutex.RUnlock()
+	return len(fake.matrixStepDelegateArgsForCall)
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegateCalls(stub func(exec.RunState) exec.MatrixStepDelegate) {
+	fake.matrixStepDelegateMutex.Lock()
+	defer fake.matrixStepDelegateMutex.Unlock()
+	fake.MatrixStepDelegateStub = stub
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegateArgsForCall(i int) exec.RunState {
+	fake.matrixStepDelegateMutex.RLock()
+	defer fake.matrixStepDelegateMutex.RUnlock()
+	argsForCall := fake.matrixStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegateReturns(result1 exec.MatrixStepDelegate) {
+	fake.matrixStepDelegateMutex.Lock()
+	defer fake.matrixStepDelegateMutex.Unlock()
+	fake.MatrixStepDelegateStub = nil
+	fake.matrixStepDelegateReturns = struct {
+		result1 exec.MatrixStepDelegate
+	}{result1}
+}
+
+func (fake *FakeMatrixStepDelegateFactory) MatrixStepDelegateReturnsOnCall(i int, result1 exec.MatrixStepDelegate) {
+	fake.matrixStepDelegateMutex.Lock()
+	defer fake.matrixStepDelegateMutex.Unlock()
+	fake.MatrixStepDelegateStub = nil
+	if fake.matrixStepDelegateReturnsOnCall == nil {
+		fake.matrixStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.MatrixStepDelegate
+		})
+	}
+	fake.matrixStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.MatrixStepDelegate
+	}{result1}
+}
+
+func (fake *FakeMatrixStepDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.matrixStepDelegateMutex.RLock()
+	defer fake.matrixStepDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMatrixStepDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.MatrixStepDelegateFactory = new(FakeMatrixStepDelegateFactory)
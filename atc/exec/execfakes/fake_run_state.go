@@ -91,6 +91,16 @@ type FakeRunState struct {
 	redactionEnabledReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RedactorStub        func() *vars.Redactor
+	redactorMutex       sync.RWMutex
+	redactorArgsForCall []struct {
+	}
+	redactorReturns struct {
+		result1 *vars.Redactor
+	}
+	redactorReturnsOnCall map[int]struct {
+		result1 *vars.Redactor
+	}
 	ResultStub        func(atc.PlanID, interface{}) bool
 	resultMutex       sync.RWMutex
 	resultArgsForCall []struct {
@@ -528,6 +538,59 @@ func (fake *FakeRunState) RedactionEnabledReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRunState) Redactor() *vars.Redactor {
+	fake.redactorMutex.Lock()
+	ret, specificReturn := fake.redactorReturnsOnCall[len(fake.redactorArgsForCall)]
+	fake.redactorArgsForCall = append(fake.redactorArgsForCall, struct {
+	}{})
+	stub := fake.RedactorStub
+	fakeReturns := fake.redactorReturns
+	fake.recordInvocation("Redactor", []interface{}{})
+	fake.redactorMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) RedactorCallCount() int {
+	fake.redactorMutex.RLock()
+	defer fake.redactorMutex.RUnlock()
+	return len(fake.redactorArgsForCall)
+}
+
+func (fake *FakeRunState) RedactorCalls(stub func() *vars.Redactor) {
+	fake.redactorMutex.Lock()
+	defer fake.redactorMutex.Unlock()
+	fake.RedactorStub = stub
+}
+
+func (fake *FakeRunState) RedactorReturns(result1 *vars.Redactor) {
+	fake.redactorMutex.Lock()
+	defer fake.redactorMutex.Unlock()
+	fake.RedactorStub = nil
+	fake.redactorReturns = struct {
+		result1 *vars.Redactor
+	}{result1}
+}
+
+func (fake *FakeRunState) RedactorReturnsOnCall(i int, result1 *vars.Redactor) {
+	fake.redactorMutex.Lock()
+	defer fake.redactorMutex.Unlock()
+	fake.RedactorStub = nil
+	if fake.redactorReturnsOnCall == nil {
+		fake.redactorReturnsOnCall = make(map[int]struct {
+			result1 *vars.Redactor
+		})
+	}
+	fake.redactorReturnsOnCall[i] = struct {
+		result1 *vars.Redactor
+	}{result1}
+}
+
 func (fake *FakeRunState) Result(arg1 atc.PlanID, arg2 interface{}) bool {
 	fake.resultMutex.Lock()
 	ret, specificReturn := fake.resultReturnsOnCall[len(fake.resultArgsForCall)]
@@ -707,6 +770,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.parentMutex.RUnlock()
 	fake.redactionEnabledMutex.RLock()
 	defer fake.redactionEnabledMutex.RUnlock()
+	fake.redactorMutex.RLock()
+	defer fake.redactorMutex.RUnlock()
 	fake.resultMutex.RLock()
 	defer fake.resultMutex.RUnlock()
 	fake.runMutex.RLock()
@@ -56,6 +56,7 @@ type GetDelegate interface {
 	Errored(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
+	WaitingForTeamQuota(lager.Logger, string)
 	SelectedWorker(lager.Logger, string)
 
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
@@ -133,12 +134,15 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
 		TeamID:       step.metadata.TeamID,
+		BuildID:      step.metadata.BuildID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
+		workerSpec.Privileged = resourceType.Privileged
+
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
 			Type:    resourceType.Type,
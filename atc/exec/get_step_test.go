@@ -383,6 +383,7 @@ var _ = Describe("GetStep", func() {
 				worker.WorkerSpec{
 					ResourceType: "some-base-type",
 					TeamID:       stepMetadata.TeamID,
+					BuildID:      stepMetadata.BuildID,
 				},
 			))
 		})
@@ -552,6 +553,7 @@ var _ = Describe("GetStep", func() {
 			Expect(workerSpec).To(Equal(
 				worker.WorkerSpec{
 					TeamID:       stepMetadata.TeamID,
+					BuildID:      stepMetadata.BuildID,
 					ResourceType: "registry-image",
 				},
 			))
@@ -571,6 +573,12 @@ var _ = Describe("GetStep", func() {
 				_, _, _, privileged := fakeDelegate.FetchImageArgsForCall(0)
 				Expect(privileged).To(BeTrue())
 			})
+
+			It("selects a worker that can run privileged containers", func() {
+				Expect(fakePool.SelectWorkerCallCount()).To(Equal(1))
+				_, _, _, workerSpec, _, _ := fakePool.SelectWorkerArgsForCall(0)
+				Expect(workerSpec.Privileged).To(BeTrue())
+			})
 		})
 	})
 
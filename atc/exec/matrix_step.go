@@ -0,0 +1,206 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// MatrixBuildPollInterval is how often the builds of a matrix are reloaded to
+// determine whether they have finished.
+var MatrixBuildPollInterval = 5 * time.Second
+
+//counterfeiter:generate . MatrixStepDelegateFactory
+type MatrixStepDelegateFactory interface {
+	MatrixStepDelegate(state RunState) MatrixStepDelegate
+}
+
+//counterfeiter:generate . MatrixStepDelegate
+type MatrixStepDelegate interface {
+	BuildStepDelegate
+
+	// StartMatrixBuild returns a db.BuildsQuotaReachedError if the build
+	// can't be started yet because of its team's quota.
+	StartMatrixBuild(lager.Logger, int, atc.MatrixBuildPlan) (db.Build, error)
+}
+
+// MatrixBuildsErroredError is returned when any of the builds of a matrix
+// errored or was aborted, as opposed to simply failing.
+type MatrixBuildsErroredError struct {
+	Builds []string
+}
+
+func (err MatrixBuildsErroredError) Error() string {
+	return fmt.Sprintf("matrix builds did not complete: %s", strings.Join(err.Builds, ", "))
+}
+
+// MatrixStep starts a build for each combination of a job's matrix vars and
+// waits for all of them to finish. The step succeeds only if every build of
+// the matrix succeeds.
+type MatrixStep struct {
+	planID          atc.PlanID
+	plan            atc.MatrixPlan
+	metadata        StepMetadata
+	delegateFactory MatrixStepDelegateFactory
+	clock           clock.Clock
+}
+
+func NewMatrixStep(
+	planID atc.PlanID,
+	plan atc.MatrixPlan,
+	metadata StepMetadata,
+	delegateFactory MatrixStepDelegateFactory,
+	clock clock.Clock,
+) Step {
+	return &MatrixStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		clock:           clock,
+	}
+}
+
+func (step *MatrixStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("matrix-step", lager.Data{
+		"job-id": step.metadata.JobID,
+	})
+
+	delegate := step.delegateFactory.MatrixStepDelegate(state)
+
+	delegate.Initializing(logger)
+	delegate.Starting(logger)
+
+	builds := make([]db.Build, len(step.plan.Builds))
+	err := step.runBuilds(ctx, logger, delegate, builds)
+	if err != nil {
+		return false, err
+	}
+
+	succeeded := true
+	var errored []string
+	for _, build := range builds {
+		switch build.Status() {
+		case db.BuildStatusSucceeded:
+		case db.BuildStatusFailed:
+			succeeded = false
+		default:
+			errored = append(errored, build.Name())
+		}
+	}
+
+	// finish the step before erroring, so that the build's events show
+	// the step as done
+	delegate.Finished(logger, succeeded && len(errored) == 0)
+
+	if len(errored) > 0 {
+		return false, MatrixBuildsErroredError{Builds: errored}
+	}
+
+	return succeeded, nil
+}
+
+// runBuilds starts the builds of the matrix and waits for them to finish.
+// Builds which can't be started yet because of the team's quota are started
+// as soon as the team's other builds make way for them.
+func (step *MatrixStep) runBuilds(ctx context.Context, logger lager.Logger, delegate MatrixStepDelegate, builds []db.Build) error {
+	running := map[int]db.Build{}
+	started := 0
+	waitingForQuota := false
+
+	ticker := step.clock.NewTicker(MatrixBuildPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for i, buildPlan := range step.plan.Builds {
+			if builds[i] != nil {
+				continue
+			}
+
+			build, err := delegate.StartMatrixBuild(logger, i, buildPlan)
+			if quotaErr, ok := err.(db.BuildsQuotaReachedError); ok {
+				if !waitingForQuota {
+					delegate.WaitingForTeamQuota(logger, quotaErr.Quota())
+					waitingForQuota = true
+				}
+
+				// start the builds in order, so that later builds don't take
+				// the place of earlier ones
+				break
+			}
+
+			if err != nil {
+				return fmt.Errorf("start matrix build: %w", err)
+			}
+
+			fmt.Fprintf(delegate.Stdout(), "started build %s (%s)\n", build.Name(), formatMatrixValues(buildPlan.Values))
+
+			builds[i] = build
+			running[i] = build
+			started++
+		}
+
+		for i := range builds {
+			build, isRunning := running[i]
+			if !isRunning {
+				continue
+			}
+
+			found, err := build.Reload()
+			if err != nil {
+				return fmt.Errorf("reload matrix build: %w", err)
+			}
+
+			if !found {
+				return fmt.Errorf("matrix build %s disappeared", build.Name())
+			}
+
+			if build.IsCompleted() {
+				fmt.Fprintf(delegate.Stdout(), "build %s (%s) %s\n", build.Name(), formatMatrixValues(step.plan.Builds[i].Values), build.Status())
+				delete(running, i)
+			}
+		}
+
+		if len(running) == 0 && started == len(builds) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, build := range running {
+				err := build.MarkAsAborted()
+				if err != nil {
+					logger.Error("failed-to-abort-matrix-build", err, lager.Data{"build": build.Name()})
+				}
+			}
+
+			return ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+func formatMatrixValues(values atc.MatrixValues) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s: %v", name, values[name])
+	}
+
+	return strings.Join(pairs, ", ")
+}
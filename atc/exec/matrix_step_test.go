@@ -0,0 +1,284 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("MatrixStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeClock           *fakeclock.FakeClock
+		fakeDelegate        *execfakes.FakeMatrixStepDelegate
+		fakeDelegateFactory *execfakes.FakeMatrixStepDelegateFactory
+
+		linuxBuild   *dbfakes.FakeBuild
+		windowsBuild *dbfakes.FakeBuild
+
+		matrixPlan atc.MatrixPlan
+		state      exec.RunState
+		stdout     *gbytes.Buffer
+
+		step exec.Step
+
+		stepOk  bool
+		stepErr error
+		done    chan struct{}
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeMatrixStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+
+		fakeDelegateFactory = new(execfakes.FakeMatrixStepDelegateFactory)
+		fakeDelegateFactory.MatrixStepDelegateReturns(fakeDelegate)
+
+		linuxBuild = new(dbfakes.FakeBuild)
+		linuxBuild.NameReturns("42-1")
+		linuxBuild.ReloadReturns(true, nil)
+
+		windowsBuild = new(dbfakes.FakeBuild)
+		windowsBuild.NameReturns("42-2")
+		windowsBuild.ReloadReturns(true, nil)
+
+		fakeDelegate.StartMatrixBuildStub = func(_ lager.Logger, i int, _ atc.MatrixBuildPlan) (db.Build, error) {
+			return []db.Build{linuxBuild, windowsBuild}[i], nil
+		}
+
+		matrixPlan = atc.MatrixPlan{
+			Builds: []atc.MatrixBuildPlan{
+				{
+					Values: atc.MatrixValues{"os": "linux"},
+					Plan:   atc.Plan{ID: "some-linux-plan"},
+				},
+				{
+					Values: atc.MatrixValues{"os": "windows"},
+					Plan:   atc.Plan{ID: "some-windows-plan"},
+				},
+			},
+		}
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewMatrixStep(
+			"some-plan-id",
+			matrixPlan,
+			exec.StepMetadata{BuildID: 42, BuildName: "42"},
+			fakeDelegateFactory,
+			fakeClock,
+		)
+
+		done = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+
+			stepOk, stepErr = step.Run(ctx, state)
+		}()
+	})
+
+	It("starts a build for each combination", func() {
+		Eventually(fakeDelegate.StartMatrixBuildCallCount).Should(Equal(2))
+
+		_, index, buildPlan := fakeDelegate.StartMatrixBuildArgsForCall(0)
+		Expect(index).To(Equal(0))
+		Expect(buildPlan).To(Equal(matrixPlan.Builds[0]))
+
+		_, index, buildPlan = fakeDelegate.StartMatrixBuildArgsForCall(1)
+		Expect(index).To(Equal(1))
+		Expect(buildPlan).To(Equal(matrixPlan.Builds[1]))
+
+		Eventually(stdout).Should(gbytes.Say(`started build 42-1 \(os: linux\)`))
+		Eventually(stdout).Should(gbytes.Say(`started build 42-2 \(os: windows\)`))
+	})
+
+	Context("when all of the builds succeed", func() {
+		BeforeEach(func() {
+			linuxBuild.IsCompletedReturns(true)
+			linuxBuild.StatusReturns(db.BuildStatusSucceeded)
+			windowsBuild.IsCompletedReturns(true)
+			windowsBuild.StatusReturns(db.BuildStatusSucceeded)
+		})
+
+		It("succeeds", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("reports the status of each build", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stdout).To(gbytes.Say(`build 42-1 \(os: linux\) succeeded`))
+			Expect(stdout).To(gbytes.Say(`build 42-2 \(os: windows\) succeeded`))
+		})
+	})
+
+	Context("when one of the builds fails", func() {
+		BeforeEach(func() {
+			linuxBuild.IsCompletedReturns(true)
+			linuxBuild.StatusReturns(db.BuildStatusFailed)
+			windowsBuild.IsCompletedReturns(true)
+			windowsBuild.StatusReturns(db.BuildStatusSucceeded)
+		})
+
+		It("fails", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when one of the builds errors", func() {
+		BeforeEach(func() {
+			linuxBuild.IsCompletedReturns(true)
+			linuxBuild.StatusReturns(db.BuildStatusErrored)
+			windowsBuild.IsCompletedReturns(true)
+			windowsBuild.StatusReturns(db.BuildStatusFailed)
+		})
+
+		It("errors", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).To(Equal(exec.MatrixBuildsErroredError{Builds: []string{"42-1"}}))
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("finishes the step as failed", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when a build is still running", func() {
+		BeforeEach(func() {
+			linuxBuild.IsCompletedReturns(true)
+			linuxBuild.StatusReturns(db.BuildStatusSucceeded)
+			windowsBuild.IsCompletedReturns(false)
+		})
+
+		It("waits for it to finish", func() {
+			Consistently(done).ShouldNot(BeClosed())
+
+			windowsBuild.IsCompletedReturns(true)
+			windowsBuild.StatusReturns(db.BuildStatusSucceeded)
+			fakeClock.WaitForWatcherAndIncrement(exec.MatrixBuildPollInterval)
+
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("only reloads the builds which are still running", func() {
+			fakeClock.WaitForWatcherAndIncrement(exec.MatrixBuildPollInterval)
+			Eventually(windowsBuild.ReloadCallCount).Should(Equal(2))
+			Expect(linuxBuild.ReloadCallCount()).To(Equal(1))
+		})
+
+		Context("when the step is aborted", func() {
+			It("aborts the running builds", func() {
+				Eventually(windowsBuild.ReloadCallCount).Should(Equal(1))
+				cancel()
+
+				Eventually(done).Should(BeClosed())
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(windowsBuild.MarkAsAbortedCallCount()).To(Equal(1))
+				Expect(linuxBuild.MarkAsAbortedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the team's quota is reached", func() {
+		BeforeEach(func() {
+			// the windows build can only start once the linux build is done
+			fakeDelegate.StartMatrixBuildStub = func(_ lager.Logger, i int, _ atc.MatrixBuildPlan) (db.Build, error) {
+				if i == 1 && !linuxBuild.IsCompleted() {
+					return nil, db.BuildsQuotaReachedError{MaxConcurrentBuilds: 1}
+				}
+
+				return []db.Build{linuxBuild, windowsBuild}[i], nil
+			}
+
+			windowsBuild.IsCompletedReturns(true)
+			windowsBuild.StatusReturns(db.BuildStatusSucceeded)
+		})
+
+		It("waits for the quota before starting the build", func() {
+			Eventually(fakeDelegate.WaitingForTeamQuotaCallCount).Should(Equal(1))
+			_, quota := fakeDelegate.WaitingForTeamQuotaArgsForCall(0)
+			Expect(quota).To(Equal("max 1 concurrent builds"))
+
+			Consistently(done).ShouldNot(BeClosed())
+
+			linuxBuild.IsCompletedReturns(true)
+			linuxBuild.StatusReturns(db.BuildStatusSucceeded)
+			fakeClock.WaitForWatcherAndIncrement(exec.MatrixBuildPollInterval)
+
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			Expect(fakeDelegate.WaitingForTeamQuotaCallCount()).To(Equal(1))
+			Expect(stdout).To(gbytes.Say(`started build 42-2 \(os: windows\)`))
+		})
+	})
+
+	Context("when starting a build fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.StartMatrixBuildStub = nil
+			fakeDelegate.StartMatrixBuildReturns(nil, disaster)
+		})
+
+		It("errors", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+		})
+	})
+
+	Context("when a build disappears", func() {
+		BeforeEach(func() {
+			linuxBuild.ReloadReturns(false, nil)
+		})
+
+		It("errors", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).To(MatchError("matrix build 42-1 disappeared"))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// SavePipelineFunc saves a pipeline's new config in place of the given
+// version of its config.
+type SavePipelineFunc func(from db.ConfigVersion) (db.Pipeline, error)
+
+// PipelineSetter sets a team's pipeline to a config the way the set_pipeline
+// step does. It is also used to set pipelines from outside of builds.
+type PipelineSetter struct {
+	PolicyChecker policy.Checker
+
+	// DryRun stops Set short of saving the config, once it has shown the
+	// difference and checked the policy.
+	DryRun bool
+}
+
+// Set prints the difference between the pipeline's current config and the
+// given config to stdout. If there is a difference, and the config is allowed
+// by policy, it is saved with the given function. The config must already
+// have been validated.
+//
+// Set returns the pipeline, which is nil if the pipeline did not exist and was
+// not saved, and whether the pipeline's config changed (or, for a dry run,
+// would have changed).
+func (setter PipelineSetter) Set(
+	logger lager.Logger,
+	stdout io.Writer,
+	team db.Team,
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	save SavePipelineFunc,
+) (db.Pipeline, bool, error) {
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fromVersion := db.ConfigVersion(0)
+	var existingConfig atc.Config
+	if !found {
+		existingConfig = atc.Config{}
+	} else {
+		fromVersion = pipeline.ConfigVersion()
+		existingConfig, err = pipeline.Config()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	diffExists := existingConfig.Diff(stdout, config)
+	if !diffExists {
+		logger.Debug("no-diff")
+
+		fmt.Fprintf(stdout, "no changes to apply.\n")
+
+		if !found {
+			return nil, false, nil
+		}
+
+		return pipeline, false, nil
+	}
+
+	// conditionally check step
+	if setter.PolicyChecker != nil && setter.PolicyChecker.ShouldCheckAction(ActionRunSetPipeline) {
+		input := policy.PolicyCheckInput{
+			Action:   ActionRunSetPipeline,
+			Team:     team.Name(),
+			Pipeline: pipelineRef.Name,
+			Data:     &config,
+		}
+		result, err := setter.PolicyChecker.Check(input)
+		if err != nil {
+			return nil, false, fmt.Errorf("error checking policy enforcement")
+		}
+		if !result.Allowed {
+			return nil, false, fmt.Errorf("policy check failed for set_pipeline: %s", strings.Join(result.Reasons, ", "))
+		}
+		logger.Debug("policy check passed for set_pipeline")
+	}
+
+	if setter.DryRun {
+		fmt.Fprintf(stdout, "dry run: not setting pipeline: %s\n", pipelineRef.String())
+		return pipeline, true, nil
+	}
+
+	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
+
+	pipeline, err = save(fromVersion)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pipeline, true, nil
+}
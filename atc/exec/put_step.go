@@ -37,6 +37,7 @@ type PutDelegate interface {
 	Errored(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
+	WaitingForTeamQuota(lager.Logger, string)
 	SelectedWorker(lager.Logger, string)
 
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
@@ -156,12 +157,15 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
 		TeamID:       step.metadata.TeamID,
+		BuildID:      step.metadata.BuildID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
+		workerSpec.Privileged = resourceType.Privileged
+
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
 			Type:    resourceType.Type,
@@ -273,6 +273,7 @@ var _ = Describe("PutStep", func() {
 				worker.WorkerSpec{
 					ResourceType: "some-resource-type",
 					TeamID:       stepMetadata.TeamID,
+					BuildID:      stepMetadata.BuildID,
 				},
 			))
 		})
@@ -500,6 +501,7 @@ var _ = Describe("PutStep", func() {
 
 			Expect(workerSpec).To(Equal(worker.WorkerSpec{
 				TeamID:       stepMetadata.TeamID,
+				BuildID:      stepMetadata.BuildID,
 				ResourceType: "registry-image",
 			}))
 		})
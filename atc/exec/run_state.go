@@ -13,7 +13,8 @@ import (
 type runState struct {
 	stepper Stepper
 
-	vars *buildVariables
+	vars     *buildVariables
+	redactor *vars.Redactor
 
 	artifacts *build.Repository
 	results   *sync.Map
@@ -31,7 +32,8 @@ func NewRunState(
 	return &runState{
 		stepper: stepper,
 
-		vars: newBuildVariables(credVars, enableRedaction),
+		vars:     newBuildVariables(credVars, enableRedaction),
+		redactor: vars.NewRedactor(),
 
 		artifacts: build.NewRepository(),
 		results:   &sync.Map{},
@@ -92,6 +94,14 @@ func (state *runState) RedactionEnabled() bool {
 	return state.vars.RedactionEnabled()
 }
 
+// Redactor returns the redactor of the build, with the creds interpolated so
+// far. It is shared by every scope of the build, so that the encodings of a
+// cred are only computed once per build.
+func (state *runState) Redactor() *vars.Redactor {
+	state.vars.IterateInterpolatedCreds(state.redactor)
+	return state.redactor
+}
+
 func (state *runState) Run(ctx context.Context, plan atc.Plan) (bool, error) {
 	return state.stepper(plan).Run(ctx, state)
 }
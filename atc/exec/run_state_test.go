@@ -288,6 +288,28 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("Redactor", func() {
+		BeforeEach(func() {
+			state = exec.NewRunState(stepper, credVars, true)
+		})
+
+		It("redacts the creds interpolated so far", func() {
+			state.Get(vars.Reference{Path: "k1"})
+			Expect(state.Redactor().Redact("v1 v2")).To(Equal("((redacted)) v2"))
+
+			state.Get(vars.Reference{Path: "k2"})
+			Expect(state.Redactor().Redact("v1 v2")).To(Equal("((redacted)) ((redacted))"))
+		})
+
+		It("is shared with local scopes", func() {
+			scope := state.NewLocalScope()
+			scope.AddLocalVar("foo", "bar", true)
+
+			Expect(scope.Redactor()).To(BeIdenticalTo(state.Redactor()))
+			Expect(state.Redactor().Redact("bar")).To(Equal("((redacted))"))
+		})
+	})
+
 	Describe("NewLocalScope", func() {
 		It("maintains a reference to the parent", func() {
 			Expect(state.NewLocalScope().Parent()).To(Equal(state))
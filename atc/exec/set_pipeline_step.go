@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path"
 	"strings"
 
 	"code.cloudfoundry.org/lager"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configimport"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -129,22 +131,6 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 	delegate.Starting(logger)
 
-	warnings, errors := configvalidate.Validate(atcConfig)
-	for _, warning := range warnings {
-		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
-	}
-
-	if len(errors) > 0 {
-		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
-
-		for _, e := range errors {
-			fmt.Fprintf(stderr, "- %s", e)
-		}
-
-		delegate.Finished(logger, false)
-		return false, nil
-	}
-
 	var team db.Team
 	if step.plan.Team == "" {
 		team = step.teamFactory.GetByID(step.metadata.TeamID)
@@ -187,76 +173,58 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		team = targetTeam
 	}
 
-	pipelineRef := atc.PipelineRef{
-		Name:         step.plan.Name,
-		InstanceVars: step.plan.InstanceVars,
-	}
-	pipeline, found, err := team.Pipeline(pipelineRef)
-	if err != nil {
-		return false, err
-	}
+	if len(atcConfig.Imports) > 0 {
+		resolver := configimport.Resolver{
+			Files:     source,
+			Libraries: configimport.TeamLibraries{Team: team},
+		}
 
-	fromVersion := db.ConfigVersion(0)
-	var existingConfig atc.Config
-	if !found {
-		existingConfig = atc.Config{}
-	} else {
-		fromVersion = pipeline.ConfigVersion()
-		existingConfig, err = pipeline.Config()
+		err = resolver.Resolve(&atcConfig)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	diffExists := existingConfig.Diff(stdout, atcConfig)
-	if !diffExists {
-		logger.Debug("no-diff")
+	warnings, errors := configvalidate.Validate(atcConfig)
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
 
-		fmt.Fprintf(stdout, "no changes to apply.\n")
+	if len(errors) > 0 {
+		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
 
-		if found {
-			err := pipeline.SetParentIDs(step.metadata.JobID, step.metadata.BuildID)
-			if err != nil {
-				return false, err
-			}
+		for _, e := range errors {
+			fmt.Fprintf(stderr, "- %s", e)
 		}
 
-		delegate.SetPipelineChanged(logger, false)
-		delegate.Finished(logger, true)
-		return true, nil
+		delegate.Finished(logger, false)
+		return false, nil
 	}
 
-	// conditionally check step
-	if step.policyChecker != nil && step.policyChecker.ShouldCheckAction(ActionRunSetPipeline) {
-		input := policy.PolicyCheckInput{
-			Action:   ActionRunSetPipeline,
-			Team:     team.Name(),
-			Pipeline: step.plan.Name,
-			Data:     &atcConfig,
-		}
-		result, err := step.policyChecker.Check(input)
-		if err != nil {
-			return false, fmt.Errorf("error checking policy enforcement")
-		}
-		if !result.Allowed {
-			return false, fmt.Errorf("policy check failed for set_pipeline: %s", strings.Join(result.Reasons, ", "))
-		}
-		logger.Debug("policy check passed for set_pipeline")
+	pipelineRef := atc.PipelineRef{
+		Name:         step.plan.Name,
+		InstanceVars: step.plan.InstanceVars,
 	}
 
-	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
-	delegate.SetPipelineChanged(logger, true)
-
-	parentBuild, found, err := step.buildFactory.Build(step.metadata.BuildID)
-	if err != nil {
-		return false, err
+	setter := PipelineSetter{
+		PolicyChecker: step.policyChecker,
+		DryRun:        step.plan.DryRun,
 	}
+	pipeline, changed, err := setter.Set(logger, stdout, team, pipelineRef, atcConfig, func(from db.ConfigVersion) (db.Pipeline, error) {
+		delegate.SetPipelineChanged(logger, true)
 
-	if !found {
-		return false, fmt.Errorf("set_pipeline step not attached to a buildID")
-	}
+		parentBuild, found, err := step.buildFactory.Build(step.metadata.BuildID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, fmt.Errorf("set_pipeline step not attached to a buildID")
+		}
 
-	pipeline, _, err = parentBuild.SavePipeline(pipelineRef, team.ID(), atcConfig, fromVersion, false)
+		pipeline, _, err := parentBuild.SavePipeline(pipelineRef, team.ID(), atcConfig, from, false)
+		return pipeline, err
+	})
 	if err != nil {
 		if err == db.ErrSetByNewerBuild {
 			fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the pipeline was not saved because it was already saved by a newer build\x1b[0m")
@@ -266,6 +234,24 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
+	if step.plan.DryRun {
+		delegate.Finished(logger, true)
+		return true, nil
+	}
+
+	if !changed {
+		if pipeline != nil {
+			err := pipeline.SetParentIDs(step.metadata.JobID, step.metadata.BuildID)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		delegate.SetPipelineChanged(logger, false)
+		delegate.Finished(logger, true)
+		return true, nil
+	}
+
 	fmt.Fprintf(stdout, "done\n")
 	logger.Info("saved-pipeline", lager.Data{"team": team.Name(), "pipeline": pipeline.Name()})
 	delegate.Finished(logger, true)
@@ -344,6 +330,16 @@ func (s setPipelineSource) FetchPipelineConfig() (atc.Config, error) {
 	return atcConfig, nil
 }
 
+// ReadFile reads a file imported by the pipeline config, relative to the
+// config file.
+func (s setPipelineSource) ReadFile(file string) ([]byte, error) {
+	if path.IsAbs(file) {
+		return nil, fmt.Errorf("cannot import absolute path '%s'", file)
+	}
+
+	return s.fetchPipelineBits(path.Join(path.Dir(s.step.plan.File), file))
+}
+
 func (s setPipelineSource) fetchPipelineBits(path string) ([]byte, error) {
 	segs := strings.SplitN(path, "/", 2)
 	if len(segs) != 2 {
@@ -20,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -286,6 +287,68 @@ jobs:
 			})
 		})
 
+		Context("when the pipeline imports a file and a library", func() {
+			BeforeEach(func() {
+				files := map[string]string{
+					"ci/pipeline.yml": `
+imports:
+- file: snippets/resources.yml
+- library: some-library
+jobs:
+- name: some-job
+  plan:
+  - get: some-repo
+`,
+					"ci/snippets/resources.yml": "resources:\n- name: some-repo\n  type: git\n",
+				}
+
+				spPlan.File = "some-resource/ci/pipeline.yml"
+				fakeArtifactStreamer.StreamFileFromArtifactStub = func(_ context.Context, _ runtime.Artifact, file string) (io.ReadCloser, error) {
+					content, found := files[file]
+					if !found {
+						return nil, errors.New("file not found")
+					}
+
+					return &fakeReadCloser{str: content}, nil
+				}
+
+				fakeTeam.PipelineLibraryReturns(db.PipelineLibrary{
+					Name:    "some-library",
+					Version: 2,
+					Config:  []byte("jobs:\n- name: some-library-job\n  plan: []\n"),
+					Digest:  "sha256:abc",
+				}, true, nil)
+
+				fakeTeam.PipelineReturns(nil, false, nil)
+				fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+			})
+
+			It("imports the library of the team", func() {
+				Expect(fakeTeam.PipelineLibraryCallCount()).To(Equal(1))
+				name, version := fakeTeam.PipelineLibraryArgsForCall(0)
+				Expect(name).To(Equal("some-library"))
+				Expect(version).To(BeZero())
+			})
+
+			It("saves the pipeline with the imports resolved", func() {
+				Expect(stepErr).NotTo(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+
+				Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+				_, _, config, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+				Expect(config.Resources).To(HaveLen(1))
+				Expect(config.Resources[0].Name).To(Equal("some-repo"))
+				Expect(config.Jobs).To(HaveLen(2))
+				Expect(config.Jobs[1].Name).To(Equal("some-library-job"))
+				Expect(config.Imports[0].Resolved).ToNot(BeNil())
+				Expect(config.Imports[1].Resolved).To(Equal(&atc.ResolvedImport{
+					Version: 2,
+					Digest:  "sha256:abc",
+					Jobs:    []string{"some-library-job"},
+				}))
+			})
+		})
+
 		Context("when pipeline file is good", func() {
 			BeforeEach(func() {
 				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: pipelineContent}, nil)
@@ -606,6 +669,81 @@ jobs:
 					})
 				})
 			})
+
+			Context("when doing a dry run", func() {
+				BeforeEach(func() {
+					spPlan.DryRun = true
+				})
+
+				Context("when there are some diff", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.ConfigReturns(atc.Config{}, nil)
+					})
+
+					It("should log diff", func() {
+						Expect(stdout).To(gbytes.Say("job some-job has been added:"))
+						Expect(stdout).To(gbytes.Say("dry run: not setting pipeline: some-pipeline"))
+					})
+
+					It("should not save the pipeline", func() {
+						Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+						Expect(fakeDelegate.SetPipelineChangedCallCount()).To(BeZero())
+					})
+
+					It("should not update the job and build id", func() {
+						Expect(fakePipeline.SetParentIDsCallCount()).To(BeZero())
+					})
+
+					It("should finish successfully", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+						Expect(succeeded).To(BeTrue())
+					})
+				})
+
+				Context("when no diff", func() {
+					BeforeEach(func() {
+						var existingConfig atc.Config
+						err := atc.UnmarshalConfig([]byte(pipelineContent), &existingConfig)
+						Expect(err).NotTo(HaveOccurred())
+
+						fakeTeam.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.ConfigReturns(existingConfig, nil)
+					})
+
+					It("should not update the job and build id", func() {
+						Expect(stdout).To(gbytes.Say("no changes to apply."))
+						Expect(fakePipeline.SetParentIDsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the policy check fails", func() {
+					BeforeEach(func() {
+						result := policy.FailedPolicyCheck()
+						result.Reasons = append(result.Reasons, "foo")
+						fakeAgent.CheckReturns(result, nil)
+					})
+
+					It("should return error", func() {
+						Expect(stepErr).To(HaveOccurred())
+						Expect(stepErr.Error()).To(Equal("policy check failed for set_pipeline: foo"))
+					})
+				})
+
+				Context("when the pipeline is invalid", func() {
+					BeforeEach(func() {
+						fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: badPipelineContentWithInvalidSyntax}, nil)
+					})
+
+					It("should finish unsuccessfully", func() {
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+						Expect(succeeded).To(BeFalse())
+					})
+				})
+			})
 		})
 	})
 })
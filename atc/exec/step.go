@@ -43,6 +43,7 @@ type RunState interface {
 
 	IterateInterpolatedCreds(vars.TrackedVarsIterator)
 	RedactionEnabled() bool
+	Redactor() *vars.Redactor
 
 	ArtifactRepository() *build.Repository
 
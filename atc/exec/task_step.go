@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -69,10 +70,11 @@ type TaskDelegate interface {
 
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
-	Finished(lager.Logger, ExitStatus, worker.ContainerPlacementStrategy, worker.Client)
+	Finished(lager.Logger, ExitStatus, worker.ContainerPlacementStrategy, worker.Client, *atc.ResourceUsage)
 	Errored(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
+	WaitingForTeamQuota(lager.Logger, string)
 	SelectedWorker(lager.Logger, string)
 }
 
@@ -293,7 +295,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
-		if err := step.registerCaches(logger, repository, config, result.VolumeMounts, step.containerMetadata); err != nil {
+		if err := step.registerCaches(ctx, logger, repository, config, result.VolumeMounts, step.containerMetadata); err != nil {
 			return false, err
 		}
 	}
@@ -307,7 +309,18 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, runErr
 	}
 
-	delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, chosenWorker)
+	delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, chosenWorker, result.ResourceUsage)
+
+	if result.ResourceUsage != nil {
+		metric.StepResourceUsage{
+			TeamName:     step.metadata.TeamName,
+			PipelineName: step.metadata.PipelineName,
+			JobName:      step.metadata.JobName,
+			BuildName:    step.metadata.BuildName,
+			StepName:     step.plan.Name,
+			Usage:        *result.ResourceUsage,
+		}.Emit(logger)
+	}
 
 	return result.ExitStatus == 0, nil
 }
@@ -418,10 +431,12 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		TeamID:    step.metadata.TeamID,
 		Type:      metadata.Type,
 
-		Dir:    metadata.WorkingDirectory,
-		Env:    config.Params.Env(),
-		Limits: limits,
-		User:   config.Run.User,
+		Dir:          metadata.WorkingDirectory,
+		Env:          config.Params.Env(),
+		Limits:       limits,
+		User:         config.Run.User,
+		RuntimeClass: step.plan.RuntimeClass,
+		Network:      step.plan.Network,
 
 		Outputs: worker.OutputPaths{},
 	}
@@ -442,9 +457,13 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 
 func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.WorkerSpec {
 	return worker.WorkerSpec{
-		Platform: config.Platform,
-		Tags:     step.plan.Tags,
-		TeamID:   step.metadata.TeamID,
+		Platform:      config.Platform,
+		Tags:          step.plan.Tags,
+		TeamID:        step.metadata.TeamID,
+		BuildID:       step.metadata.BuildID,
+		Privileged:    bool(step.plan.Privileged),
+		RuntimeClass:  step.plan.RuntimeClass,
+		NetworkPolicy: step.plan.Network != nil,
 	}
 }
 
@@ -470,7 +489,7 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Rep
 	}
 }
 
-func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+func (step *TaskStep) registerCaches(ctx context.Context, logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	for _, cacheConfig := range config.Caches {
 		for _, volumeMount := range volumeMounts {
 			if volumeMount.MountPath == filepath.Join(metadata.WorkingDirectory, cacheConfig.Path) {
@@ -479,6 +498,7 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 				})
 
 				err := volumeMount.Volume.InitializeTaskCache(
+					ctx,
 					logger,
 					step.metadata.JobID,
 					step.plan.Name,
@@ -234,6 +234,50 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			Context("when the task is privileged", func() {
+				BeforeEach(func() {
+					taskPlan.Privileged = true
+				})
+
+				It("creates a privileged worker spec", func() {
+					Expect(workerSpec.Privileged).To(BeTrue())
+				})
+			})
+
+			Context("when a runtime class is configured", func() {
+				BeforeEach(func() {
+					taskPlan.RuntimeClass = "runsc"
+				})
+
+				It("creates a worker spec with the runtime class", func() {
+					Expect(workerSpec.RuntimeClass).To(Equal("runsc"))
+				})
+
+				It("creates a container spec with the runtime class", func() {
+					Expect(containerSpec.RuntimeClass).To(Equal("runsc"))
+				})
+			})
+
+			Context("when a network is configured", func() {
+				BeforeEach(func() {
+					taskPlan.Network = &atc.NetworkConfig{None: true}
+				})
+
+				It("creates a worker spec requiring network policies", func() {
+					Expect(workerSpec.NetworkPolicy).To(BeTrue())
+				})
+
+				It("creates a container spec with the network", func() {
+					Expect(containerSpec.Network).To(Equal(&atc.NetworkConfig{None: true}))
+				})
+			})
+
+			Context("when no network is configured", func() {
+				It("creates a worker spec not requiring network policies", func() {
+					Expect(workerSpec.NetworkPolicy).To(BeFalse())
+				})
+			})
+
 			Context("when selecting a worker fails", func() {
 				BeforeEach(func() {
 					fakePool.SelectWorkerReturns(nil, 0, errors.New("nope"))
@@ -583,14 +627,14 @@ var _ = Describe("TaskStep", func() {
 			itRegistersCaches := func() {
 				It("registers cache volumes as task caches", func() {
 					Expect(fakeVolume1.InitializeTaskCacheCallCount()).To(Equal(1))
-					_, jID, stepName, cachePath, p := fakeVolume1.InitializeTaskCacheArgsForCall(0)
+					_, _, jID, stepName, cachePath, p := fakeVolume1.InitializeTaskCacheArgsForCall(0)
 					Expect(jID).To(Equal(stepMetadata.JobID))
 					Expect(stepName).To(Equal("some-task"))
 					Expect(cachePath).To(Equal("some-path-1"))
 					Expect(p).To(Equal(bool(taskPlan.Privileged)))
 
 					Expect(fakeVolume2.InitializeTaskCacheCallCount()).To(Equal(1))
-					_, jID, stepName, cachePath, p = fakeVolume2.InitializeTaskCacheArgsForCall(0)
+					_, _, jID, stepName, cachePath, p = fakeVolume2.InitializeTaskCacheArgsForCall(0)
 					Expect(jID).To(Equal(stepMetadata.JobID))
 					Expect(stepName).To(Equal("some-task"))
 					Expect(cachePath).To(Equal("some-path-2"))
@@ -988,8 +1032,32 @@ var _ = Describe("TaskStep", func() {
 				})
 				It("finishes the task via the delegate", func() {
 					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
-					_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+					_, status, _, _, usage := fakeDelegate.FinishedArgsForCall(0)
 					Expect(status).To(Equal(exec.ExitStatus(taskStepStatus)))
+					Expect(usage).To(BeNil())
+				})
+
+				Context("when the worker sampled the resource usage", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(worker.TaskResult{
+							ExitStatus: taskStepStatus,
+							ResourceUsage: &atc.ResourceUsage{
+								PeakMemoryBytes: 1024,
+								CPUSeconds:      1.5,
+								DiskIO:          &atc.DiskIOUsage{ReadBytes: 10, WriteBytes: 20},
+							},
+						}, nil)
+					})
+
+					It("finishes the task with the resource usage", func() {
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, _, _, _, usage := fakeDelegate.FinishedArgsForCall(0)
+						Expect(usage).To(Equal(&atc.ResourceUsage{
+							PeakMemoryBytes: 1024,
+							CPUSeconds:      1.5,
+							DiskIO:          &atc.DiskIOUsage{ReadBytes: 10, WriteBytes: 20},
+						}))
+					})
 				})
 
 				It("returns successfully", func() {
@@ -1077,7 +1145,7 @@ var _ = Describe("TaskStep", func() {
 				})
 				It("finishes the task via the delegate", func() {
 					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
-					_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+					_, status, _, _, _ := fakeDelegate.FinishedArgsForCall(0)
 					Expect(status).To(Equal(exec.ExitStatus(taskStepStatus)))
 				})
 
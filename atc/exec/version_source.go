@@ -39,13 +39,21 @@ type PutStepVersionSource struct {
 	planID atc.PlanID
 }
 
+// Version returns the version produced by the step, which is either a put
+// step or a check step, as is the case for one-off builds which get the
+// latest version of a resource.
 func (p *PutStepVersionSource) Version(state RunState) (atc.Version, error) {
 	var info runtime.VersionResult
-	if !state.Result(p.planID, &info) {
-		return atc.Version{}, ErrPutStepVersionMissing
+	if state.Result(p.planID, &info) {
+		return info.Version, nil
 	}
 
-	return info.Version, nil
+	var checked atc.Version
+	if state.Result(p.planID, &checked) {
+		return checked, nil
+	}
+
+	return atc.Version{}, ErrPutStepVersionMissing
 }
 
 type EmptyVersionSource struct{}
@@ -0,0 +1,64 @@
+package exec_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PutStepVersionSource", func() {
+	var (
+		state         exec.RunState
+		versionSource exec.VersionSource
+
+		version    atc.Version
+		versionErr error
+	)
+
+	BeforeEach(func() {
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		planID := atc.PlanID("some-plan")
+		versionSource = exec.NewVersionSourceFromPlan(&atc.GetPlan{
+			VersionFrom: &planID,
+		})
+	})
+
+	JustBeforeEach(func() {
+		version, versionErr = versionSource.Version(state)
+	})
+
+	Context("when the step stored a put result", func() {
+		BeforeEach(func() {
+			state.StoreResult("some-plan", runtime.VersionResult{
+				Version: atc.Version{"some": "version"},
+			})
+		})
+
+		It("returns the put version", func() {
+			Expect(versionErr).ToNot(HaveOccurred())
+			Expect(version).To(Equal(atc.Version{"some": "version"}))
+		})
+	})
+
+	Context("when the step stored a check result", func() {
+		BeforeEach(func() {
+			state.StoreResult("some-plan", atc.Version{"some": "checked-version"})
+		})
+
+		It("returns the checked version", func() {
+			Expect(versionErr).ToNot(HaveOccurred())
+			Expect(version).To(Equal(atc.Version{"some": "checked-version"}))
+		})
+	})
+
+	Context("when the step stored no result", func() {
+		It("returns an error", func() {
+			Expect(versionErr).To(Equal(exec.ErrPutStepVersionMissing))
+		})
+	})
+})
@@ -17,6 +17,7 @@ type buildLogCollector struct {
 	batchSize                   int
 	drainerConfigured           bool
 	buildLogRetentionCalculator BuildLogRetentionCalculator
+	eventStore                  db.BuildEventStore
 }
 
 func NewBuildLogCollector(
@@ -25,6 +26,7 @@ func NewBuildLogCollector(
 	batchSize int,
 	buildLogRetentionCalculator BuildLogRetentionCalculator,
 	drainerConfigured bool,
+	eventStore db.BuildEventStore,
 ) *buildLogCollector {
 	return &buildLogCollector{
 		pipelineFactory:             pipelineFactory,
@@ -32,6 +34,7 @@ func NewBuildLogCollector(
 		batchSize:                   batchSize,
 		drainerConfigured:           drainerConfigured,
 		buildLogRetentionCalculator: buildLogRetentionCalculator,
+		eventStore:                  eventStore,
 	}
 }
 
@@ -69,7 +72,7 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 				continue
 			}
 
-			err = br.reapLogsOfJob(pipeline, job, logger)
+			err = br.reapLogsOfJob(ctx, pipeline, job, logger)
 			if err != nil {
 				return err
 			}
@@ -79,7 +82,8 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 	return nil
 }
 
-func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
+func (br *buildLogCollector) reapLogsOfJob(ctx context.Context,
+	pipeline db.Pipeline,
 	job db.Job,
 	logger lager.Logger) error {
 
@@ -202,11 +206,65 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 		}
 	}
 
+	buildsByID := map[int]db.Build{}
+	for _, build := range buildsToConsiderDeleting {
+		buildsByID[build.ID()] = build
+	}
+
+	// the builds of a matrix are left out of the job's builds, so they are
+	// reaped along with their parent build
+	matrixBuildsByID := map[int][]db.Build{}
+	for _, buildID := range buildIDsToDelete {
+		matrixBuilds, err := buildsByID[buildID].MatrixBuilds()
+		if err != nil {
+			logger.Error("failed-to-get-matrix-builds", err)
+			return err
+		}
+
+		matrixBuildsByID[buildID] = matrixBuilds
+	}
+
+	if br.eventStore != nil {
+		archivedBuildIDs := []int{}
+		for _, buildID := range buildIDsToDelete {
+			build := buildsByID[buildID]
+
+			err = br.archiveEvents(ctx, append([]db.Build{build}, matrixBuildsByID[buildID]...))
+			if err != nil {
+				// keep the events of the build, and keep it logged so
+				// that the upload is retried on the next run
+				logger.Error("failed-to-archive-build-events", err, build.LagerData())
+
+				if firstLoggedBuildID > build.ID() {
+					firstLoggedBuildID = build.ID()
+				}
+
+				continue
+			}
+
+			archivedBuildIDs = append(archivedBuildIDs, buildID)
+		}
+
+		logger.Debug("archived-builds", lager.Data{
+			"build_ids": archivedBuildIDs,
+		})
+
+		buildIDsToDelete = archivedBuildIDs
+	}
+
+	buildIDsToReap := []int{}
+	for _, buildID := range buildIDsToDelete {
+		buildIDsToReap = append(buildIDsToReap, buildID)
+		for _, matrixBuild := range matrixBuildsByID[buildID] {
+			buildIDsToReap = append(buildIDsToReap, matrixBuild.ID())
+		}
+	}
+
 	logger.Debug("reaping-builds", lager.Data{
-		"build_ids": buildIDsToDelete,
+		"build_ids": buildIDsToReap,
 	})
 
-	err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToReap)
 	if err != nil {
 		logger.Error("failed-to-delete-build-events", err)
 		return err
@@ -222,3 +280,18 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 
 	return nil
 }
+
+func (br *buildLogCollector) archiveEvents(ctx context.Context, builds []db.Build) error {
+	for _, build := range builds {
+		if build.EventsArchived() {
+			continue
+		}
+
+		err := build.ArchiveEvents(ctx, br.eventStore)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ var _ = Describe("BuildLogCollector", func() {
 		fakePipelineLifecycle *dbfakes.FakePipelineLifecycle
 		batchSize             int
 		buildLogRetainCalc    BuildLogRetentionCalculator
+		eventStore            db.BuildEventStore
 	)
 
 	BeforeEach(func() {
@@ -29,6 +30,7 @@ var _ = Describe("BuildLogCollector", func() {
 		fakePipelineLifecycle = new(dbfakes.FakePipelineLifecycle)
 		batchSize = 5
 		buildLogRetainCalc = NewBuildLogRetentionCalculator(0, 0, 0, 0)
+		eventStore = nil
 	})
 
 	JustBeforeEach(func() {
@@ -38,6 +40,7 @@ var _ = Describe("BuildLogCollector", func() {
 			batchSize,
 			buildLogRetainCalc,
 			false,
+			eventStore,
 		)
 	})
 
@@ -104,6 +107,7 @@ var _ = Describe("BuildLogCollector", func() {
 						batchSize,
 						buildLogRetainCalc,
 						true,
+						nil,
 					)
 				})
 				BeforeEach(func() {
@@ -157,6 +161,7 @@ var _ = Describe("BuildLogCollector", func() {
 						batchSize,
 						buildLogRetainCalc,
 						false,
+						nil,
 					)
 					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
 						if *page.From == 5 {
@@ -184,6 +189,156 @@ var _ = Describe("BuildLogCollector", func() {
 				})
 			})
 
+			Context("when a build to reap has matrix builds", func() {
+				BeforeEach(func() {
+					buildLogCollector = NewBuildLogCollector(
+						fakePipelineFactory,
+						fakePipelineLifecycle,
+						batchSize,
+						buildLogRetainCalc,
+						false,
+						nil,
+					)
+
+					parentBuild := new(dbfakes.FakeBuild)
+					parentBuild.IDReturns(5)
+					parentBuild.MatrixBuildsReturns([]db.Build{sb(6), sb(7)}, nil)
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if *page.From == 5 {
+							return []db.Build{sb(10), sb(9), parentBuild}, db.Pagination{}, nil
+						}
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						return []db.Build{}, db.Pagination{}, nil
+					}
+				})
+
+				It("reaps the matrix builds along with their parent", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(5, 6, 7))
+				})
+			})
+
+			Context("when an event store is configured", func() {
+				var (
+					fakeEventStore *dbfakes.FakeBuildEventStore
+					builds         []*dbfakes.FakeBuild
+				)
+
+				BeforeEach(func() {
+					fakeEventStore = new(dbfakes.FakeBuildEventStore)
+					eventStore = fakeEventStore
+
+					builds = []*dbfakes.FakeBuild{}
+					for _, id := range []int{8, 7, 6, 5} {
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(id)
+						builds = append(builds, build)
+					}
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if *page.From == 5 {
+							return []db.Build{builds[0], builds[1], builds[2], builds[3]}, db.Pagination{}, nil
+						}
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						return []db.Build{}, db.Pagination{}, nil
+					}
+				})
+
+				It("archives the events of the builds before reaping them", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(builds[0].ArchiveEventsCallCount()).To(Equal(0))
+					Expect(builds[1].ArchiveEventsCallCount()).To(Equal(0))
+
+					Expect(builds[2].ArchiveEventsCallCount()).To(Equal(1))
+					_, store := builds[2].ArchiveEventsArgsForCall(0)
+					Expect(store).To(Equal(fakeEventStore))
+
+					Expect(builds[3].ArchiveEventsCallCount()).To(Equal(1))
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(5, 6))
+				})
+
+				Context("when a build has already been archived", func() {
+					BeforeEach(func() {
+						builds[3].EventsArchivedReturns(true)
+					})
+
+					It("does not archive it again", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(builds[3].ArchiveEventsCallCount()).To(Equal(0))
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(5, 6))
+					})
+				})
+
+				Context("when a build has matrix builds", func() {
+					var matrixBuild *dbfakes.FakeBuild
+
+					BeforeEach(func() {
+						matrixBuild = new(dbfakes.FakeBuild)
+						matrixBuild.IDReturns(9)
+						builds[3].MatrixBuildsReturns([]db.Build{matrixBuild}, nil)
+					})
+
+					It("archives the events of the matrix builds", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(matrixBuild.ArchiveEventsCallCount()).To(Equal(1))
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(5, 6, 9))
+					})
+
+					Context("when archiving a matrix build fails", func() {
+						BeforeEach(func() {
+							matrixBuild.ArchiveEventsReturns(errors.New("bucket on fire"))
+						})
+
+						It("keeps the parent build and its matrix builds", func() {
+							err := buildLogCollector.Run(context.TODO())
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6))
+							Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when archiving fails", func() {
+					var disaster error
+
+					BeforeEach(func() {
+						disaster = errors.New("bucket on fire")
+						builds[2].ArchiveEventsReturns(disaster)
+					})
+
+					It("reaps the other builds", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(builds[3].ArchiveEventsCallCount()).To(Equal(1))
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(5))
+					})
+
+					It("keeps the build logged so that it is archived again", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+						Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(6))
+					})
+				})
+			})
+
 			Context("when deleting build events fails", func() {
 				var disaster error
 
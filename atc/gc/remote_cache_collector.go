@@ -0,0 +1,128 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type remoteCacheCollector struct {
+	remoteCache          worker.RemoteCache
+	taskCacheFactory     db.TaskCacheFactory
+	resourceCacheFactory db.ResourceCacheFactory
+}
+
+// NewRemoteCacheCollector returns a collector which deletes the blobs of task
+// caches and resource caches which no longer exist from the remote cache.
+func NewRemoteCacheCollector(
+	remoteCache worker.RemoteCache,
+	taskCacheFactory db.TaskCacheFactory,
+	resourceCacheFactory db.ResourceCacheFactory,
+) *remoteCacheCollector {
+	return &remoteCacheCollector{
+		remoteCache:          remoteCache,
+		taskCacheFactory:     taskCacheFactory,
+		resourceCacheFactory: resourceCacheFactory,
+	}
+}
+
+func (c *remoteCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("remote-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := c.collectTaskCaches(ctx, logger)
+	if err != nil {
+		logger.Error("failed-to-collect-task-caches", err)
+		return err
+	}
+
+	err = c.collectResourceCaches(ctx, logger)
+	if err != nil {
+		logger.Error("failed-to-collect-resource-caches", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *remoteCacheCollector) collectTaskCaches(ctx context.Context, logger lager.Logger) error {
+	keys, err := c.remoteCache.List(ctx, worker.TaskCachesPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		jobID, stepName, path, ok := worker.ParseTaskCacheKey(key)
+		if !ok {
+			continue
+		}
+
+		// task caches are removed along with their job
+		_, found, err := c.taskCacheFactory.Find(jobID, stepName, path)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			continue
+		}
+
+		err = c.delete(ctx, logger, key)
+		if err != nil {
+			return err
+		}
+
+		err = c.delete(ctx, logger, worker.DigestsPrefix+key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *remoteCacheCollector) collectResourceCaches(ctx context.Context, logger lager.Logger) error {
+	keys, err := c.remoteCache.List(ctx, worker.ResourceCachesPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		id, ok := worker.ParseResourceCacheKey(key)
+		if !ok {
+			continue
+		}
+
+		_, found, err := c.resourceCacheFactory.FindResourceCacheByID(id)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			continue
+		}
+
+		err = c.delete(ctx, logger, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *remoteCacheCollector) delete(ctx context.Context, logger lager.Logger, key string) error {
+	err := c.remoteCache.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("deleted", lager.Data{"key": key})
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteCacheCollector", func() {
+	var (
+		collector GcCollector
+
+		fakeRemoteCache          *workerfakes.FakeRemoteCache
+		fakeTaskCacheFactory     *dbfakes.FakeTaskCacheFactory
+		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
+
+		deletedKeys []string
+	)
+
+	BeforeEach(func() {
+		fakeRemoteCache = new(workerfakes.FakeRemoteCache)
+		fakeTaskCacheFactory = new(dbfakes.FakeTaskCacheFactory)
+		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+
+		fakeRemoteCache.ListStub = func(_ context.Context, prefix string) ([]string, error) {
+			switch prefix {
+			case "task-caches/":
+				return []string{
+					"task-caches/1/some-task/some-path",
+					"task-caches/2/some-task/some-path",
+					"task-caches/unknown",
+				}, nil
+			case "resource-caches/":
+				return []string{
+					"resource-caches/1",
+					"resource-caches/2",
+				}, nil
+			default:
+				return nil, nil
+			}
+		}
+
+		fakeTaskCacheFactory.FindStub = func(jobID int, _ string, _ string) (db.UsedTaskCache, bool, error) {
+			return nil, jobID == 1, nil
+		}
+
+		fakeResourceCacheFactory.FindResourceCacheByIDStub = func(id int) (db.UsedResourceCache, bool, error) {
+			return nil, id == 1, nil
+		}
+
+		deletedKeys = nil
+		fakeRemoteCache.DeleteStub = func(_ context.Context, key string) error {
+			deletedKeys = append(deletedKeys, key)
+			return nil
+		}
+
+		collector = gc.NewRemoteCacheCollector(fakeRemoteCache, fakeTaskCacheFactory, fakeResourceCacheFactory)
+	})
+
+	Describe("Run", func() {
+		It("deletes the blobs of caches which no longer exist", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deletedKeys).To(ConsistOf(
+				"task-caches/2/some-task/some-path",
+				"digests/task-caches/2/some-task/some-path",
+				"resource-caches/2",
+			))
+		})
+
+		It("looks up task caches by the key", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTaskCacheFactory.FindCallCount()).To(Equal(2))
+			jobID, stepName, path := fakeTaskCacheFactory.FindArgsForCall(0)
+			Expect(jobID).To(Equal(1))
+			Expect(stepName).To(Equal("some-task"))
+			Expect(path).To(Equal("some-path"))
+		})
+
+		Context("when looking up a cache fails", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeResourceCacheFactory.FindResourceCacheByIDStub = nil
+				fakeResourceCacheFactory.FindResourceCacheByIDReturns(nil, false, disaster)
+			})
+
+			It("returns the error without deleting the blob", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(Equal(disaster))
+
+				Expect(deletedKeys).ToNot(ContainElement("resource-caches/1"))
+			})
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package atc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ImportConfig pulls in jobs, resources, and resource types from a file or
+// from a pipeline library stored on the ATC. The imported config is a template
+// evaluated with the import's vars.
+//
+// Imports are resolved by fly set-pipeline, the set_pipeline step and pipeline
+// sync before the config is saved. The ATC records how each import was
+// resolved.
+type ImportConfig struct {
+	// File is the path to a config to import, relative to the pipeline's
+	// config file.
+	File string `json:"file,omitempty"`
+
+	// Library is the name of a pipeline library to import. The latest version
+	// is imported unless Version is given.
+	Library string `json:"library,omitempty"`
+	Version int    `json:"version,omitempty"`
+
+	Vars Params `json:"vars,omitempty"`
+
+	// Resolved is set once the import has been resolved.
+	Resolved *ResolvedImport `json:"resolved,omitempty"`
+}
+
+// ResolvedImport records what an import resolved to.
+type ResolvedImport struct {
+	// Version is the version of the library that was imported.
+	Version int `json:"version,omitempty"`
+
+	// Digest is the SHA256 digest of the imported config, prior to evaluating
+	// it with the import's vars.
+	Digest string `json:"digest"`
+
+	Jobs          []string `json:"jobs,omitempty"`
+	Resources     []string `json:"resources,omitempty"`
+	ResourceTypes []string `json:"resource_types,omitempty"`
+}
+
+type ImportConfigs []ImportConfig
+
+// PipelineLibrary is a versioned config which pipelines may import.
+type PipelineLibrary struct {
+	Name      string `json:"name"`
+	TeamName  string `json:"team_name"`
+	Version   int    `json:"version"`
+	Config    string `json:"config,omitempty"`
+	Digest    string `json:"digest"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ImportableFields are the top-level fields of a config which may be imported.
+var ImportableFields = []string{"jobs", "resources", "resource_types"}
+
+// ValidatePipelineLibrary checks that the config only has importable fields.
+// The config is a template, so it is not unmarshalled any further than that.
+func ValidatePipelineLibrary(config []byte) error {
+	var fields map[string]interface{}
+	err := yaml.Unmarshal(config, &fields)
+	if err != nil {
+		return fmt.Errorf("malformed config: %s", err)
+	}
+
+	var invalid []string
+	for field := range fields {
+		if !importable(field) {
+			invalid = append(invalid, field)
+		}
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("only %s may be imported, not %s", strings.Join(ImportableFields, ", "), strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+func importable(field string) bool {
+	for _, f := range ImportableFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
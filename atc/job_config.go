@@ -1,5 +1,14 @@
 package atc
 
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/vars"
+	"github.com/robfig/cron/v3"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -20,9 +29,187 @@ type JobConfig struct {
 	OnError   *Step `json:"on_error,omitempty"`
 	Ensure    *Step `json:"ensure,omitempty"`
 
+	Matrix []MatrixVarConfig `json:"matrix,omitempty"`
+
+	Schedule *ScheduleConfig `json:"schedule,omitempty"`
+
+	Concurrency *ConcurrencyConfig `json:"concurrency,omitempty"`
+
 	PlanSequence []Step `json:"plan"`
 }
 
+// MatrixVarConfig declares a single dimension of a job's build matrix. Each
+// value becomes available to the job's plan as the local var `((.:<var>))`.
+type MatrixVarConfig struct {
+	Var    string        `json:"var"`
+	Values []interface{} `json:"values,omitempty"`
+}
+
+func (config *MatrixVarConfig) UnmarshalJSON(data []byte) error {
+	// Used to avoid infinite recursion when unmarshalling.
+	type target MatrixVarConfig
+
+	var t target
+	if err := unmarshalStrict(data, &t); err != nil {
+		return err
+	}
+
+	*config = MatrixVarConfig(t)
+	return nil
+}
+
+// MatrixValues maps each matrix var of a job to the value used for a single
+// build of the matrix.
+type MatrixValues map[string]interface{}
+
+// ScheduleConfig triggers builds of a job at the times described by a cron
+// expression, evaluated in the given location (UTC by default).
+type ScheduleConfig struct {
+	Cron     string `json:"cron"`
+	Location string `json:"location,omitempty"`
+}
+
+func (config *ScheduleConfig) UnmarshalJSON(data []byte) error {
+	// Used to avoid infinite recursion when unmarshalling.
+	type target ScheduleConfig
+
+	var t target
+	if err := unmarshalStrict(data, &t); err != nil {
+		return err
+	}
+
+	*config = ScheduleConfig(t)
+	return nil
+}
+
+// Validate returns an error if the cron expression or location is invalid.
+func (config ScheduleConfig) Validate() error {
+	_, _, err := config.parse()
+	return err
+}
+
+// Next returns the first time the schedule fires after the given time.
+func (config ScheduleConfig) Next(after time.Time) (time.Time, error) {
+	schedule, location, err := config.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(after.In(location)), nil
+}
+
+func (config ScheduleConfig) parse() (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(config.Cron)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cron '%s': %w", config.Cron, err)
+	}
+
+	location := time.UTC
+	if config.Location != "" {
+		location, err = time.LoadLocation(config.Location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid location '%s': %w", config.Location, err)
+		}
+	}
+
+	return schedule, location, nil
+}
+
+// ConcurrencyConfig makes a new build of a job supersede the job's older
+// builds with the same key. Superseded builds which are still pending are
+// skipped, and superseded builds which are running are aborted if
+// CancelInProgress is set.
+//
+// The key is interpolated with the versions of the build's inputs, with each
+// version field referred to as ((<input>.<field>)), e.g.
+// "pr-((pull-request.number))".
+//
+// Pending builds are only skipped when they would all run with the same
+// inputs. If any input uses `version: every`, every pending build runs with
+// its own version and only running builds are superseded.
+type ConcurrencyConfig struct {
+	Key              string `json:"key"`
+	CancelInProgress bool   `json:"cancel_in_progress,omitempty"`
+}
+
+func (config *ConcurrencyConfig) UnmarshalJSON(data []byte) error {
+	// Used to avoid infinite recursion when unmarshalling.
+	type target ConcurrencyConfig
+
+	var t target
+	if err := unmarshalStrict(data, &t); err != nil {
+		return err
+	}
+
+	*config = ConcurrencyConfig(t)
+	return nil
+}
+
+// Validate returns an error if the key is empty or refers to anything other
+// than a field of one of the given inputs.
+func (config ConcurrencyConfig) Validate(inputNames []string) error {
+	if config.Key == "" {
+		return fmt.Errorf("key is empty")
+	}
+
+	inputs := map[string]bool{}
+	for _, name := range inputNames {
+		inputs[name] = true
+	}
+
+	refs, err := config.refs()
+	if err != nil {
+		return err
+	}
+
+	for name, ref := range refs {
+		if !inputs[ref.Path] {
+			return fmt.Errorf("key refers to unknown input '%s' in '((%s))'", ref.Path, name)
+		}
+	}
+
+	return nil
+}
+
+// KeyFor interpolates the key with the versions of a build's inputs, keyed by
+// input name. It returns an error if any field the key refers to is missing.
+func (config ConcurrencyConfig) KeyFor(versions map[string]Version) (string, error) {
+	refs, err := config.refs()
+	if err != nil {
+		return "", err
+	}
+
+	key := config.Key
+	for name, ref := range refs {
+		value, found := versions[ref.Path][ref.Fields[0]]
+		if !found {
+			return "", fmt.Errorf("version of input '%s' has no field '%s'", ref.Path, ref.Fields[0])
+		}
+
+		key = strings.Replace(key, "(("+name+"))", value, -1)
+	}
+
+	return key, nil
+}
+
+func (config ConcurrencyConfig) refs() (map[string]vars.Reference, error) {
+	refs := map[string]vars.Reference{}
+	for _, name := range vars.NewTemplate([]byte(config.Key)).ExtraVarNames() {
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.Source != "" || len(ref.Fields) != 1 {
+			return nil, fmt.Errorf("invalid reference '((%s))' in key, must be ((<input>.<field>))", name)
+		}
+
+		refs[name] = ref
+	}
+
+	return refs, nil
+}
+
 type BuildLogRetention struct {
 	Builds                 int `json:"builds,omitempty"`
 	MinimumSucceededBuilds int `json:"minimum_succeeded_builds,omitempty"`
@@ -76,6 +263,53 @@ func (config JobConfig) StepConfig() StepConfig {
 	return step
 }
 
+// MatrixCombinations returns every combination of the job's matrix vars, in
+// the order in which the matrix builds should be created. It returns nil if
+// the job has no matrix configured.
+func (config JobConfig) MatrixCombinations() []MatrixValues {
+	if len(config.Matrix) == 0 {
+		return nil
+	}
+
+	combinations := []MatrixValues{{}}
+	for _, v := range config.Matrix {
+		var product []MatrixValues
+		for _, combination := range combinations {
+			for _, val := range v.Values {
+				values := MatrixValues{}
+				for k, existing := range combination {
+					values[k] = existing
+				}
+
+				values[v.Var] = val
+				product = append(product, values)
+			}
+		}
+
+		combinations = product
+	}
+
+	return combinations
+}
+
+// MatrixStepConfig returns the job's step config scoped to a single
+// combination of matrix values. The values are exposed as local vars by
+// wrapping the plan in an across step with a single value for each var.
+func (config JobConfig) MatrixStepConfig(values MatrixValues) StepConfig {
+	vars := make([]AcrossVarConfig, len(config.Matrix))
+	for i, v := range config.Matrix {
+		vars[i] = AcrossVarConfig{
+			Var:    v.Var,
+			Values: []interface{}{values[v.Var]},
+		}
+	}
+
+	return &AcrossStep{
+		Step: config.StepConfig(),
+		Vars: vars,
+	}
+}
+
 func (config JobConfig) MaxInFlight() int {
 	if config.Serial || len(config.SerialGroups) > 0 {
 		return 1
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -62,6 +64,109 @@ var _ = Describe("JobConfig", func() {
 		})
 	})
 
+	Describe("MatrixCombinations", func() {
+		It("returns nil if there is no matrix", func() {
+			jobConfig := atc.JobConfig{}
+
+			Expect(jobConfig.MatrixCombinations()).To(BeNil())
+		})
+
+		It("returns every combination of the matrix vars", func() {
+			jobConfig := atc.JobConfig{
+				Matrix: []atc.MatrixVarConfig{
+					{Var: "os", Values: []interface{}{"linux", "windows"}},
+					{Var: "go", Values: []interface{}{"1.15", "1.16"}},
+				},
+			}
+
+			Expect(jobConfig.MatrixCombinations()).To(Equal([]atc.MatrixValues{
+				{"os": "linux", "go": "1.15"},
+				{"os": "linux", "go": "1.16"},
+				{"os": "windows", "go": "1.15"},
+				{"os": "windows", "go": "1.16"},
+			}))
+		})
+	})
+
+	Describe("MatrixStepConfig", func() {
+		It("wraps the plan in an across step with a single value per var", func() {
+			jobConfig := atc.JobConfig{
+				Matrix: []atc.MatrixVarConfig{
+					{Var: "os", Values: []interface{}{"linux", "windows"}},
+				},
+				PlanSequence: []atc.Step{
+					{Config: &atc.GetStep{Name: "some-get"}},
+				},
+			}
+
+			Expect(jobConfig.MatrixStepConfig(atc.MatrixValues{"os": "windows"})).To(Equal(&atc.AcrossStep{
+				Step: jobConfig.StepConfig(),
+				Vars: []atc.AcrossVarConfig{
+					{Var: "os", Values: []interface{}{"windows"}},
+				},
+			}))
+		})
+	})
+
+	Describe("ScheduleConfig", func() {
+		It("returns the next time the schedule fires", func() {
+			schedule := atc.ScheduleConfig{Cron: "30 9 * * *"}
+
+			next, err := schedule.Next(time.Date(2021, 5, 3, 10, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2021, 5, 4, 9, 30, 0, 0, time.UTC)))
+		})
+
+		It("evaluates the schedule in the configured location", func() {
+			schedule := atc.ScheduleConfig{Cron: "30 9 * * *", Location: "America/Toronto"}
+
+			next, err := schedule.Next(time.Date(2021, 5, 3, 10, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2021, 5, 3, 13, 30, 0, 0, time.UTC)))
+		})
+
+		It("returns an error for an invalid cron expression", func() {
+			schedule := atc.ScheduleConfig{Cron: "bogus"}
+
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("invalid cron 'bogus'")))
+		})
+	})
+
+	Describe("ConcurrencyConfig", func() {
+		It("interpolates the key with the versions of the inputs", func() {
+			concurrency := atc.ConcurrencyConfig{Key: "pr-((pull-request.number))-((pull-request.number))"}
+
+			key, err := concurrency.KeyFor(map[string]atc.Version{
+				"pull-request": {"number": "42", "ref": "abcdef"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(Equal("pr-42-42"))
+		})
+
+		It("returns the key as-is if it refers to nothing", func() {
+			concurrency := atc.ConcurrencyConfig{Key: "main"}
+
+			key, err := concurrency.KeyFor(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(Equal("main"))
+		})
+
+		It("returns an error if the version has no such field", func() {
+			concurrency := atc.ConcurrencyConfig{Key: "((pull-request.branch))"}
+
+			_, err := concurrency.KeyFor(map[string]atc.Version{
+				"pull-request": {"number": "42"},
+			})
+			Expect(err).To(MatchError("version of input 'pull-request' has no field 'branch'"))
+		})
+
+		It("returns an error for a key referring to a var source", func() {
+			concurrency := atc.ConcurrencyConfig{Key: "((vault:some.secret))"}
+
+			Expect(concurrency.Validate([]string{"some"})).To(MatchError(ContainSubstring("invalid reference '((vault:some.secret))'")))
+		})
+	})
+
 	Describe("Inputs", func() {
 		var (
 			jobConfig atc.JobConfig
@@ -86,6 +86,18 @@ var _ = Describe("Scanner", func() {
 				})
 			})
 
+			Context("when the resource uses the time resource type", func() {
+				BeforeEach(func() {
+					fakeResource.TypeReturns("time")
+					fakeResource.PipelineIDReturns(1)
+					fakeCheckFactory.ResourceTypesReturns([]db.ResourceType{}, nil)
+				})
+
+				It("checks the resource", func() {
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("when fetching resources types succeeds", func() {
 				var fakeResourceType *dbfakes.FakeResourceType
 
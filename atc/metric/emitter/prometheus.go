@@ -35,6 +35,11 @@ type PrometheusEmitter struct {
 	stepsWaiting         *prometheus.GaugeVec
 	stepsWaitingDuration *prometheus.HistogramVec
 
+	stepMemoryPeak *prometheus.HistogramVec
+	stepCPU        *prometheus.HistogramVec
+	stepDiskRead   *prometheus.HistogramVec
+	stepDiskWrite  *prometheus.HistogramVec
+
 	buildDurationsVec *prometheus.HistogramVec
 	buildsAborted     prometheus.Counter
 	buildsErrored     prometheus.Counter
@@ -222,6 +227,42 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	}, []string{"platform", "teamId", "type", "workerTags"})
 	prometheus.MustRegister(stepsWaitingDuration)
 
+	stepMemoryPeak := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "steps",
+		Name:      "memory_peak_bytes",
+		Help:      "Peak memory usage of a task step's container",
+		Buckets:   prometheus.ExponentialBuckets(16*1024*1024, 2, 10),
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(stepMemoryPeak)
+
+	stepCPU := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "steps",
+		Name:      "cpu_seconds",
+		Help:      "CPU time used by a task step's container",
+		Buckets:   []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200, 18000},
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(stepCPU)
+
+	stepDiskRead := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "steps",
+		Name:      "disk_read_bytes",
+		Help:      "Bytes read from disk by a task step's container",
+		Buckets:   prometheus.ExponentialBuckets(16*1024*1024, 2, 10),
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(stepDiskRead)
+
+	stepDiskWrite := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "concourse",
+		Subsystem: "steps",
+		Name:      "disk_write_bytes",
+		Help:      "Bytes written to disk by a task step's container",
+		Buckets:   prometheus.ExponentialBuckets(16*1024*1024, 2, 10),
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(stepDiskWrite)
+
 	buildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
 		Subsystem: "builds",
@@ -507,6 +548,11 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		stepsWaiting:         stepsWaiting,
 		stepsWaitingDuration: stepsWaitingDuration,
 
+		stepMemoryPeak: stepMemoryPeak,
+		stepCPU:        stepCPU,
+		stepDiskRead:   stepDiskRead,
+		stepDiskWrite:  stepDiskWrite,
+
 		buildDurationsVec: buildDurationsVec,
 		buildsAborted:     buildsAborted,
 		buildsErrored:     buildsErrored,
@@ -604,6 +650,14 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 				event.Attributes["type"],
 				event.Attributes["workerTags"],
 			).Observe(event.Value)
+	case "step memory peak (bytes)":
+		emitter.stepResourceUsageMetric(emitter.stepMemoryPeak, event)
+	case "step cpu (seconds)":
+		emitter.stepResourceUsageMetric(emitter.stepCPU, event)
+	case "step disk read (bytes)":
+		emitter.stepResourceUsageMetric(emitter.stepDiskRead, event)
+	case "step disk write (bytes)":
+		emitter.stepResourceUsageMetric(emitter.stepDiskWrite, event)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
 	case "check build finished":
@@ -721,6 +775,16 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
 }
 
+func (emitter *PrometheusEmitter) stepResourceUsageMetric(histogram *prometheus.HistogramVec, event metric.Event) {
+	// the build is left out of the labels to keep their cardinality bounded
+	histogram.WithLabelValues(
+		event.Attributes["team_name"],
+		event.Attributes["pipeline"],
+		event.Attributes["job"],
+		event.Attributes["step"],
+	).Observe(event.Value)
+}
+
 func (emitter *PrometheusEmitter) checkBuildFinishedMetrics(logger lager.Logger, event metric.Event) {
 	// concourse_builds_finished_total
 	emitter.checkBuildsFinished.Inc()
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -494,6 +495,65 @@ func (event CheckBuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepResourceUsage struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildName    string
+	StepName     string
+	Usage        atc.ResourceUsage
+}
+
+func (event StepResourceUsage) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"team_name": event.TeamName,
+		"pipeline":  event.PipelineName,
+		"job":       event.JobName,
+		"build":     event.BuildName,
+		"step":      event.StepName,
+	}
+
+	logger = logger.Session("step-resource-usage")
+
+	Metrics.emit(
+		logger,
+		Event{
+			Name:       "step memory peak (bytes)",
+			Value:      float64(event.Usage.PeakMemoryBytes),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger,
+		Event{
+			Name:       "step cpu (seconds)",
+			Value:      event.Usage.CPUSeconds,
+			Attributes: attributes,
+		},
+	)
+
+	if event.Usage.DiskIO != nil {
+		Metrics.emit(
+			logger,
+			Event{
+				Name:       "step disk read (bytes)",
+				Value:      float64(event.Usage.DiskIO.ReadBytes),
+				Attributes: attributes,
+			},
+		)
+
+		Metrics.emit(
+			logger,
+			Event{
+				Name:       "step disk write (bytes)",
+				Value:      float64(event.Usage.DiskIO.WriteBytes),
+				Attributes: attributes,
+			},
+		)
+	}
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
@@ -0,0 +1,92 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+)
+
+const NetworkNone = "none"
+
+var hostnameRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// A NetworkConfig restricts the network traffic of a task's container to the
+// allowed destinations, or cuts the container off from the network entirely.
+//
+// Only IPv4 destinations are supported. Hostnames are resolved once, when the
+// container is created, and only their IPv4 addresses are allowed; a task
+// won't be able to reach a host whose addresses change while it is running.
+type NetworkConfig struct {
+	None  bool
+	Allow []string
+}
+
+type networkAllowConfig struct {
+	Allow []string `json:"allow"`
+}
+
+func (c *NetworkConfig) UnmarshalJSON(network []byte) error {
+	var data interface{}
+
+	err := json.Unmarshal(network, &data)
+	if err != nil {
+		return err
+	}
+
+	switch actual := data.(type) {
+	case string:
+		if actual != NetworkNone {
+			return fmt.Errorf("unknown network '%s'", actual)
+		}
+
+		c.None = true
+	case map[string]interface{}:
+		var allow networkAllowConfig
+		err := unmarshalStrict(network, &allow)
+		if err != nil {
+			return err
+		}
+
+		c.Allow = allow.Allow
+	default:
+		return errors.New("unknown type for network")
+	}
+
+	return nil
+}
+
+func (c NetworkConfig) MarshalJSON() ([]byte, error) {
+	if c.None {
+		return json.Marshal(NetworkNone)
+	}
+
+	return json.Marshal(networkAllowConfig{Allow: c.Allow})
+}
+
+// ValidateNetworkDestination checks that a destination allowed by a network
+// config is an IPv4 CIDR, an IPv4 address or a hostname.
+func ValidateNetworkDestination(destination string) error {
+	if ip, _, err := net.ParseCIDR(destination); err == nil {
+		if ip.To4() == nil {
+			return fmt.Errorf("'%s' is an IPv6 CIDR, which is not supported", destination)
+		}
+
+		return nil
+	}
+
+	if ip := net.ParseIP(destination); ip != nil {
+		if ip.To4() == nil {
+			return fmt.Errorf("'%s' is an IPv6 address, which is not supported", destination)
+		}
+
+		return nil
+	}
+
+	if len(destination) <= 253 && hostnameRegex.MatchString(destination) {
+		return nil
+	}
+
+	return fmt.Errorf("'%s' is not a CIDR, IP address or hostname", destination)
+}
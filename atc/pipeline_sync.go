@@ -0,0 +1,144 @@
+package atc
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrPipelineSyncURIMissing   = errors.New("pipeline sync must specify a uri")
+	ErrPipelineSyncURIInvalid   = errors.New("pipeline sync uri must be an https, ssh or git url")
+	ErrPipelineSyncPathsMissing = errors.New("pipeline sync must specify the paths of the pipeline configs")
+	ErrPipelineSyncPathsInvalid = errors.New("pipeline sync paths must be a valid glob")
+)
+
+// PipelineSyncProtocols are the git transports pipelines can be synced over.
+// Local repositories are not allowed, as they would give teams access to the
+// web node's filesystem.
+var PipelineSyncProtocols = []string{"https", "ssh", "git"}
+
+// scpLikeURI matches git's scp-like syntax for ssh, e.g.
+// git@github.com:org/repo.git
+var scpLikeURI = regexp.MustCompile(`^(?:[^@/:]+@)?([^@/:]+):`)
+
+const (
+	PipelineSyncStatusSynced   = "synced"
+	PipelineSyncStatusErrored  = "errored"
+	PipelineSyncStatusArchived = "archived"
+)
+
+// PipelineSyncConfig is a git repository which a team's pipelines are kept in
+// sync with. Each file in the repository which matches Paths is the config of
+// the pipeline named after the file, without its extension.
+type PipelineSyncConfig struct {
+	URI string `json:"uri,omitempty"`
+
+	// Branch is the branch to sync from. If it is empty, the repository's
+	// default branch is used.
+	Branch string `json:"branch,omitempty"`
+
+	// Paths is a glob, relative to the root of the repository, of the files
+	// which configure pipelines.
+	Paths string `json:"paths,omitempty"`
+
+	// WebhookToken, if set, allows the git host to request a sync by calling
+	// the team's pipeline sync webhook with the token.
+	WebhookToken string `json:"webhook_token,omitempty"`
+}
+
+func (config PipelineSyncConfig) Validate() error {
+	if config.URI == "" {
+		return ErrPipelineSyncURIMissing
+	}
+
+	protocol, err := config.Protocol()
+	if err != nil {
+		return err
+	}
+
+	if !contains(PipelineSyncProtocols, protocol) {
+		return ErrPipelineSyncURIInvalid
+	}
+
+	if config.Paths == "" {
+		return ErrPipelineSyncPathsMissing
+	}
+
+	if _, err := path.Match(config.Paths, ""); err != nil {
+		return ErrPipelineSyncPathsInvalid
+	}
+
+	return nil
+}
+
+// Protocol is the git transport the URI is fetched over: the scheme of a URL,
+// ssh for the scp-like syntax and file for a local path. URIs which git would
+// read as an option or hand to a remote helper are invalid.
+func (config PipelineSyncConfig) Protocol() (string, error) {
+	uri := config.URI
+
+	if strings.HasPrefix(uri, "-") || strings.Contains(uri, "::") {
+		return "", ErrPipelineSyncURIInvalid
+	}
+
+	if strings.Contains(uri, "://") {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return "", ErrPipelineSyncURIInvalid
+		}
+
+		if strings.HasPrefix(u.Hostname(), "-") {
+			return "", ErrPipelineSyncURIInvalid
+		}
+
+		return strings.ToLower(u.Scheme), nil
+	}
+
+	if match := scpLikeURI.FindStringSubmatch(uri); match != nil {
+		if strings.HasPrefix(match[1], "-") {
+			return "", ErrPipelineSyncURIInvalid
+		}
+
+		return "ssh", nil
+	}
+
+	return "file", nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsZero returns true if the team's pipelines are not synced.
+func (config PipelineSyncConfig) IsZero() bool {
+	return config == PipelineSyncConfig{}
+}
+
+// PipelineSyncStatus is the result of the last sync of a pipeline.
+type PipelineSyncStatus struct {
+	Pipeline string `json:"pipeline"`
+	File     string `json:"file"`
+	Commit   string `json:"commit,omitempty"`
+	Status   string `json:"status"`
+
+	// Drifted is true if the pipeline had been set from somewhere other than
+	// the repository since it was last synced, and was set back.
+	Drifted bool `json:"drifted,omitempty"`
+
+	Error    string `json:"error,omitempty"`
+	SyncedAt int64  `json:"synced_at"`
+}
+
+type PipelineSyncResponse struct {
+	Config    PipelineSyncConfig   `json:"config"`
+	Pipelines []PipelineSyncStatus `json:"pipelines"`
+}
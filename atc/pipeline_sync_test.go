@@ -0,0 +1,35 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineSyncConfig", func() {
+	DescribeTable("validating the uri",
+		func(uri string, expectedErr error) {
+			err := atc.PipelineSyncConfig{URI: uri, Paths: "ci/*.yml"}.Validate()
+			if expectedErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(Equal(expectedErr))
+			}
+		},
+		Entry("https", "https://example.com/pipelines.git", nil),
+		Entry("ssh", "ssh://git@example.com/pipelines.git", nil),
+		Entry("scp-like ssh", "git@example.com:org/pipelines.git", nil),
+		Entry("git", "git://example.com/pipelines.git", nil),
+		Entry("missing", "", atc.ErrPipelineSyncURIMissing),
+		Entry("http", "http://example.com/pipelines.git", atc.ErrPipelineSyncURIInvalid),
+		Entry("file url", "file:///var/lib/concourse/1", atc.ErrPipelineSyncURIInvalid),
+		Entry("local path", "/var/lib/concourse/1", atc.ErrPipelineSyncURIInvalid),
+		Entry("relative path", "../1", atc.ErrPipelineSyncURIInvalid),
+		Entry("option", "--upload-pack=touch /tmp/pwned", atc.ErrPipelineSyncURIInvalid),
+		Entry("remote helper", "ext::sh -c touch% /tmp/pwned", atc.ErrPipelineSyncURIInvalid),
+		Entry("option as ssh host", "ssh://-oProxyCommand=touch/pipelines.git", atc.ErrPipelineSyncURIInvalid),
+		Entry("option as scp-like host", "-oProxyCommand=touch:pipelines.git", atc.ErrPipelineSyncURIInvalid),
+	)
+})
@@ -0,0 +1,153 @@
+package pipelinesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Fetcher
+
+// Fetcher fetches the pipeline configs from a team's git repository.
+type Fetcher interface {
+	Fetch(ctx context.Context, teamID int, config atc.PipelineSyncConfig) (Snapshot, error)
+}
+
+// Snapshot is the pipeline configs at a commit of a repository, keyed by their
+// path in the repository.
+type Snapshot struct {
+	Commit string
+	Files  map[string][]byte
+}
+
+// syncRef is where the fetched branch is kept in each team's repository.
+const syncRef = "refs/pipeline-sync"
+
+// GitFetcher fetches with the git CLI. Each team's repository is kept as a
+// bare repository in the directory, so that each fetch only downloads what
+// changed since the last one.
+type GitFetcher struct {
+	Dir string
+
+	// Protocols are the git transports repositories may be fetched over.
+	// Defaults to atc.PipelineSyncProtocols.
+	Protocols []string
+}
+
+func (fetcher GitFetcher) Fetch(ctx context.Context, teamID int, config atc.PipelineSyncConfig) (Snapshot, error) {
+	// the config may have been saved before its uri was validated
+	protocol, err := config.Protocol()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if !allowed(fetcher.protocols(), protocol) {
+		return Snapshot{}, fmt.Errorf("git protocol not allowed: %s", protocol)
+	}
+
+	repo := filepath.Join(fetcher.Dir, strconv.Itoa(teamID))
+
+	_, err = os.Stat(repo)
+	if os.IsNotExist(err) {
+		_, err = fetcher.git(ctx, "", "init", "--quiet", "--bare", repo)
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	branch := "HEAD"
+	if config.Branch != "" {
+		branch = "refs/heads/" + config.Branch
+	}
+
+	_, err = fetcher.git(ctx, repo, "fetch", "--quiet", "--depth", "1", "--no-tags", "--", config.URI, "+"+branch+":"+syncRef)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	commit, err := fetcher.git(ctx, repo, "rev-parse", syncRef)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	paths, err := fetcher.git(ctx, repo, "ls-tree", "-r", "-z", "--name-only", syncRef)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Commit: strings.TrimSpace(string(commit)),
+		Files:  map[string][]byte{},
+	}
+
+	for _, p := range strings.Split(string(paths), "\x00") {
+		matched, err := path.Match(config.Paths, p)
+		if err != nil {
+			return Snapshot{}, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		snapshot.Files[p], err = fetcher.git(ctx, repo, "cat-file", "blob", syncRef+":"+p)
+		if err != nil {
+			return Snapshot{}, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+func allowed(protocols []string, protocol string) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fetcher GitFetcher) protocols() []string {
+	if len(fetcher.Protocols) == 0 {
+		return atc.PipelineSyncProtocols
+	}
+
+	return fetcher.Protocols
+}
+
+func (fetcher GitFetcher) git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	cmd.Env = append(
+		os.Environ(),
+		// never wait for credentials to be typed in
+		"GIT_TERMINAL_PROMPT=0",
+		// git enforces the allowed protocols itself too, e.g. on redirects
+		"GIT_ALLOW_PROTOCOL="+strings.Join(fetcher.protocols(), ":"),
+	)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,185 @@
+package pipelinesync_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/pipelinesync"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitFetcher", func() {
+	var (
+		tmpDir   string
+		bareRepo string
+		workTree string
+
+		fetcher pipelinesync.GitFetcher
+		config  atc.PipelineSyncConfig
+
+		snapshot pipelinesync.Snapshot
+		fetchErr error
+	)
+
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(
+			os.Environ(),
+			"GIT_AUTHOR_NAME=some-author",
+			"GIT_AUTHOR_EMAIL=author@example.com",
+			"GIT_COMMITTER_NAME=some-author",
+			"GIT_COMMITTER_EMAIL=author@example.com",
+		)
+
+		output, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		return strings.TrimSpace(string(output))
+	}
+
+	commit := func(files map[string]string) string {
+		for path, contents := range files {
+			Expect(os.MkdirAll(filepath.Join(workTree, filepath.Dir(path)), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(workTree, path), []byte(contents), 0644)).To(Succeed())
+		}
+
+		git(workTree, "add", "-A")
+		git(workTree, "commit", "--quiet", "--allow-empty", "-m", "some-commit")
+		git(workTree, "push", "--quiet", "origin", "HEAD:refs/heads/master")
+
+		return git(workTree, "rev-parse", "HEAD")
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not installed")
+		}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pipeline-sync")
+		Expect(err).NotTo(HaveOccurred())
+
+		bareRepo = filepath.Join(tmpDir, "repo.git")
+		workTree = filepath.Join(tmpDir, "work")
+
+		git(tmpDir, "init", "--quiet", "--bare", bareRepo)
+		git(tmpDir, "init", "--quiet", workTree)
+		git(workTree, "remote", "add", "origin", bareRepo)
+
+		fetcher = pipelinesync.GitFetcher{
+			Dir:       filepath.Join(tmpDir, "cache"),
+			Protocols: []string{"file"},
+		}
+		config = atc.PipelineSyncConfig{
+			URI:    bareRepo,
+			Branch: "master",
+			Paths:  "pipelines/*.yml",
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		snapshot, fetchErr = fetcher.Fetch(context.Background(), 1, config)
+	})
+
+	Context("when the protocol of the uri is not allowed", func() {
+		BeforeEach(func() {
+			fetcher.Protocols = nil
+			commit(map[string]string{"pipelines/some-pipeline.yml": "jobs: []"})
+		})
+
+		It("does not fetch", func() {
+			Expect(fetchErr).To(MatchError("git protocol not allowed: file"))
+			Expect(filepath.Join(tmpDir, "cache", "1")).NotTo(BeADirectory())
+		})
+	})
+
+	Context("when the uri would be read as an option", func() {
+		BeforeEach(func() {
+			config.URI = "--upload-pack=touch " + filepath.Join(tmpDir, "pwned")
+		})
+
+		It("does not run git", func() {
+			Expect(fetchErr).To(Equal(atc.ErrPipelineSyncURIInvalid))
+			Expect(filepath.Join(tmpDir, "pwned")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Context("when the branch has pipeline configs", func() {
+		var sha string
+
+		BeforeEach(func() {
+			sha = commit(map[string]string{
+				"pipelines/some-pipeline.yml":  "jobs: []",
+				"pipelines/other-pipeline.yml": "resources: []",
+				"pipelines/README.md":          "not a pipeline",
+				"tasks/some-task.yml":          "platform: linux",
+			})
+		})
+
+		It("returns the files matching the paths at the branch's commit", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(snapshot.Commit).To(Equal(sha))
+			Expect(snapshot.Files).To(Equal(map[string][]byte{
+				"pipelines/some-pipeline.yml":  []byte("jobs: []"),
+				"pipelines/other-pipeline.yml": []byte("resources: []"),
+			}))
+		})
+
+		Context("when the branch moves on", func() {
+			var newSha string
+
+			JustBeforeEach(func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+
+				git(workTree, "rm", "--quiet", "pipelines/other-pipeline.yml")
+				newSha = commit(map[string]string{
+					"pipelines/some-pipeline.yml": "jobs: [{name: some-job}]",
+				})
+
+				snapshot, fetchErr = fetcher.Fetch(context.Background(), 1, config)
+			})
+
+			It("returns the files at the new commit", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(snapshot.Commit).To(Equal(newSha))
+				Expect(snapshot.Files).To(Equal(map[string][]byte{
+					"pipelines/some-pipeline.yml": []byte("jobs: [{name: some-job}]"),
+				}))
+			})
+		})
+
+		Context("when no branch is configured", func() {
+			BeforeEach(func() {
+				config.Branch = ""
+				git(bareRepo, "symbolic-ref", "HEAD", "refs/heads/master")
+			})
+
+			It("fetches the default branch", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(snapshot.Commit).To(Equal(sha))
+			})
+		})
+	})
+
+	Context("when the branch does not exist", func() {
+		BeforeEach(func() {
+			commit(map[string]string{"pipelines/some-pipeline.yml": "jobs: []"})
+			config.Branch = "bogus"
+		})
+
+		It("errors", func() {
+			Expect(fetchErr).To(MatchError(ContainSubstring("git fetch")))
+		})
+	})
+})
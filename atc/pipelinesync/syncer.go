@@ -0,0 +1,265 @@
+package pipelinesync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configimport"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// NewSyncer returns a component which keeps the pipelines of each team which
+// has a pipeline sync configured in sync with the team's git repository.
+//
+// Each matching file in the repository is set as a pipeline the same way the
+// set_pipeline step would set it. Pipelines whose file has been removed from
+// the repository are archived.
+//
+// Teams are synced one after another, each within the timeout, so that a slow
+// repository cannot hold up the other teams' syncs for long.
+func NewSyncer(
+	teamFactory db.TeamFactory,
+	fetcher Fetcher,
+	policyChecker policy.Checker,
+	timeout time.Duration,
+) *syncer {
+	return &syncer{
+		teamFactory: teamFactory,
+		fetcher:     fetcher,
+		setter:      exec.PipelineSetter{PolicyChecker: policyChecker},
+		timeout:     timeout,
+	}
+}
+
+type syncer struct {
+	teamFactory db.TeamFactory
+	fetcher     Fetcher
+	setter      exec.PipelineSetter
+	timeout     time.Duration
+}
+
+func (s *syncer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	teams, err := s.teamFactory.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return err
+	}
+
+	for _, team := range teams {
+		if team.PipelineSync().IsZero() {
+			continue
+		}
+
+		tLog := logger.Session("sync", lager.Data{"team": team.Name()})
+
+		teamCtx, cancel := context.WithTimeout(lagerctx.NewContext(ctx, tLog), s.timeout)
+		err := s.syncTeam(teamCtx, team)
+		cancel()
+		if err != nil {
+			tLog.Error("failed-to-sync", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *syncer) syncTeam(ctx context.Context, team db.Team) error {
+	logger := lagerctx.FromContext(ctx)
+
+	previousStatuses, err := team.PipelineSyncStatuses()
+	if err != nil {
+		return err
+	}
+
+	previous := map[string]db.PipelineSyncStatus{}
+	for _, status := range previousStatuses {
+		previous[status.PipelineName] = status
+	}
+
+	snapshot, err := s.fetcher.Fetch(ctx, team.ID(), team.PipelineSync())
+	if err != nil {
+		// leave the pipelines as they are, but make the failure visible
+		for _, status := range previousStatuses {
+			if status.Status == atc.PipelineSyncStatusArchived {
+				continue
+			}
+
+			status.Status = atc.PipelineSyncStatusErrored
+			status.Drifted = false
+			status.Error = fmt.Sprintf("failed to fetch: %s", err)
+
+			saveErr := team.SavePipelineSyncStatus(status)
+			if saveErr != nil {
+				logger.Error("failed-to-save-status", saveErr)
+			}
+		}
+
+		return err
+	}
+
+	files := map[string][]string{}
+	for file := range snapshot.Files {
+		name := pipelineName(file)
+		files[name] = append(files[name], file)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+		sort.Strings(files[name])
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		status := db.PipelineSyncStatus{
+			PipelineName: name,
+			File:         files[name][0],
+			Commit:       snapshot.Commit,
+			Status:       atc.PipelineSyncStatusSynced,
+		}
+
+		if len(files[name]) > 1 {
+			err = fmt.Errorf("pipeline is configured by more than one file: %s", strings.Join(files[name], ", "))
+		} else {
+			err = s.syncPipeline(logger.Session("pipeline", lager.Data{"pipeline": name}), team, snapshot, previous[name], &status)
+		}
+
+		if err != nil {
+			status.Status = atc.PipelineSyncStatusErrored
+			status.Error = err.Error()
+		}
+
+		err = team.SavePipelineSyncStatus(status)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, status := range previousStatuses {
+		if _, found := files[status.PipelineName]; found || status.Status == atc.PipelineSyncStatusArchived {
+			continue
+		}
+
+		pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: status.PipelineName})
+		if err != nil {
+			return err
+		}
+
+		if found && !pipeline.Archived() {
+			err = pipeline.Archive()
+			if err != nil {
+				return err
+			}
+
+			logger.Info("archived-pipeline", lager.Data{"pipeline": status.PipelineName})
+		}
+
+		status.Commit = snapshot.Commit
+		status.ConfigVersion = 0
+		status.Status = atc.PipelineSyncStatusArchived
+		status.Drifted = false
+		status.Error = ""
+
+		err = team.SavePipelineSyncStatus(status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *syncer) syncPipeline(
+	logger lager.Logger,
+	team db.Team,
+	snapshot Snapshot,
+	previous db.PipelineSyncStatus,
+	status *db.PipelineSyncStatus,
+) error {
+	_, err := atc.ValidateIdentifier(status.PipelineName, "pipeline")
+	if err != nil {
+		return err
+	}
+
+	var config atc.Config
+	err = atc.UnmarshalConfig(snapshot.Files[status.File], &config)
+	if err != nil {
+		return fmt.Errorf("invalid pipeline config: %w", err)
+	}
+
+	// only the pipeline configs are fetched from the repository, so only
+	// libraries can be imported
+	resolver := configimport.Resolver{
+		Libraries: configimport.TeamLibraries{Team: team},
+	}
+
+	err = resolver.Resolve(&config)
+	if err != nil {
+		return err
+	}
+
+	_, errorMessages := configvalidate.Validate(config)
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("invalid pipeline config:\n%s", strings.TrimSpace(strings.Join(errorMessages, "\n")))
+	}
+
+	pipelineRef := atc.PipelineRef{Name: status.PipelineName}
+
+	var existingVersion db.ConfigVersion
+	pipeline, changed, err := s.setter.Set(logger, ioutil.Discard, team, pipelineRef, config, func(from db.ConfigVersion) (db.Pipeline, error) {
+		existingVersion = from
+
+		pipeline, _, err := team.SavePipelineAs(
+			fmt.Sprintf("pipeline sync at %s", shortCommit(snapshot.Commit)),
+			pipelineRef,
+			config,
+			from,
+			false,
+		)
+
+		return pipeline, err
+	})
+	if err != nil {
+		return err
+	}
+
+	// the pipeline drifted if it was set from somewhere else since the last
+	// sync. setting it has put it back the way the repository says.
+	status.Drifted = changed && previous.ConfigVersion != 0 && existingVersion != previous.ConfigVersion
+
+	if pipeline != nil {
+		status.ConfigVersion = pipeline.ConfigVersion()
+	}
+
+	return nil
+}
+
+// pipelineName is the name of the pipeline configured by a file: the file's
+// name without its extension.
+func pipelineName(file string) string {
+	base := path.Base(file)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+
+	return commit
+}
@@ -0,0 +1,398 @@
+package pipelinesync_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/pipelinesync"
+	"github.com/concourse/concourse/atc/pipelinesync/pipelinesyncfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Syncer interface {
+	Run(ctx context.Context) error
+}
+
+var _ = Describe("Syncer", func() {
+	var (
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeFetcher     *pipelinesyncfakes.FakeFetcher
+		fakeTeam        *dbfakes.FakeTeam
+		fakePipeline    *dbfakes.FakePipeline
+
+		syncConfig atc.PipelineSyncConfig
+
+		syncer Syncer
+		err    error
+	)
+
+	savedStatuses := func() map[string]db.PipelineSyncStatus {
+		statuses := map[string]db.PipelineSyncStatus{}
+		for i := 0; i < fakeTeam.SavePipelineSyncStatusCallCount(); i++ {
+			status := fakeTeam.SavePipelineSyncStatusArgsForCall(i)
+			statuses[status.PipelineName] = status
+		}
+
+		return statuses
+	}
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeFetcher = new(pipelinesyncfakes.FakeFetcher)
+
+		syncConfig = atc.PipelineSyncConfig{
+			URI:   "https://example.com/pipelines.git",
+			Paths: "pipelines/*.yml",
+		}
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(42)
+		fakeTeam.NameReturns("some-team")
+		fakeTeam.PipelineSyncReturns(syncConfig)
+		fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{}, nil)
+
+		otherTeam := new(dbfakes.FakeTeam)
+		fakeTeamFactory.GetTeamsReturns([]db.Team{fakeTeam, otherTeam}, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ConfigVersionReturns(2)
+		fakeTeam.SavePipelineAsReturns(fakePipeline, true, nil)
+
+		fakeFetcher.FetchReturns(pipelinesync.Snapshot{
+			Commit: "abcdef0123456789",
+			Files: map[string][]byte{
+				"pipelines/some-pipeline.yml": []byte("jobs: [{name: some-job, plan: [{task: some-task, config: {platform: linux, run: {path: \"true\"}}}]}]"),
+			},
+		}, nil)
+
+		syncer = pipelinesync.NewSyncer(fakeTeamFactory, fakeFetcher, nil, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		err = syncer.Run(context.TODO())
+	})
+
+	It("only fetches for teams with a pipeline sync", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeFetcher.FetchCallCount()).To(Equal(1))
+
+		_, teamID, config := fakeFetcher.FetchArgsForCall(0)
+		Expect(teamID).To(Equal(42))
+		Expect(config).To(Equal(syncConfig))
+	})
+
+	It("fetches within the timeout", func() {
+		ctx, _, _ := fakeFetcher.FetchArgsForCall(0)
+
+		deadline, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+	})
+
+	Context("when getting the teams fails", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.GetTeamsReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		It("sets the pipeline, naming it after the file", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+			author, ref, config, from, paused := fakeTeam.SavePipelineAsArgsForCall(0)
+			Expect(author).To(Equal("pipeline sync at abcdef0"))
+			Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(paused).To(BeFalse())
+		})
+
+		It("records the pipeline as synced", func() {
+			Expect(savedStatuses()).To(Equal(map[string]db.PipelineSyncStatus{
+				"some-pipeline": {
+					PipelineName:  "some-pipeline",
+					File:          "pipelines/some-pipeline.yml",
+					Commit:        "abcdef0123456789",
+					ConfigVersion: 2,
+					Status:        atc.PipelineSyncStatusSynced,
+				},
+			}))
+		})
+	})
+
+	Context("when the pipeline exists", func() {
+		var existingPipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			existingPipeline = new(dbfakes.FakePipeline)
+			existingPipeline.ConfigVersionReturns(1)
+			fakeTeam.PipelineReturns(existingPipeline, true, nil)
+		})
+
+		Context("when its config is the same as the file", func() {
+			BeforeEach(func() {
+				existingPipeline.ConfigReturns(atc.Config{
+					Jobs: atc.JobConfigs{{
+						Name: "some-job",
+						PlanSequence: []atc.Step{{
+							Config: &atc.TaskStep{
+								Name: "some-task",
+								Config: &atc.TaskConfig{
+									Platform: "linux",
+									Run:      atc.TaskRunConfig{Path: "true"},
+								},
+							},
+						}},
+					}},
+				}, nil)
+
+				fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+					{PipelineName: "some-pipeline", ConfigVersion: 1},
+				}, nil)
+			})
+
+			It("does not set the pipeline", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+			})
+
+			It("records the pipeline as synced", func() {
+				status := savedStatuses()["some-pipeline"]
+				Expect(status.Status).To(Equal(atc.PipelineSyncStatusSynced))
+				Expect(status.ConfigVersion).To(Equal(db.ConfigVersion(1)))
+				Expect(status.Drifted).To(BeFalse())
+			})
+		})
+
+		Context("when the file has changed since the last sync", func() {
+			BeforeEach(func() {
+				existingPipeline.ConfigReturns(atc.Config{}, nil)
+
+				fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+					{PipelineName: "some-pipeline", ConfigVersion: 1},
+				}, nil)
+			})
+
+			It("sets the pipeline from its current config version", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				_, _, _, from, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(from).To(Equal(db.ConfigVersion(1)))
+			})
+
+			It("records the pipeline as synced without drift", func() {
+				status := savedStatuses()["some-pipeline"]
+				Expect(status.Status).To(Equal(atc.PipelineSyncStatusSynced))
+				Expect(status.ConfigVersion).To(Equal(db.ConfigVersion(2)))
+				Expect(status.Drifted).To(BeFalse())
+			})
+		})
+
+		Context("when it was set from somewhere else since the last sync", func() {
+			BeforeEach(func() {
+				existingPipeline.ConfigReturns(atc.Config{}, nil)
+
+				fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+					{PipelineName: "some-pipeline", ConfigVersion: 5},
+				}, nil)
+			})
+
+			It("sets it back and reports that it drifted", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				status := savedStatuses()["some-pipeline"]
+				Expect(status.Status).To(Equal(atc.PipelineSyncStatusSynced))
+				Expect(status.ConfigVersion).To(Equal(db.ConfigVersion(2)))
+				Expect(status.Drifted).To(BeTrue())
+			})
+		})
+
+		Context("when it has never been synced", func() {
+			BeforeEach(func() {
+				existingPipeline.ConfigReturns(atc.Config{}, nil)
+			})
+
+			It("takes it over without reporting drift", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+				Expect(savedStatuses()["some-pipeline"].Drifted).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the file is not a valid pipeline config", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchReturns(pipelinesync.Snapshot{
+				Commit: "abcdef0123456789",
+				Files: map[string][]byte{
+					"pipelines/some-pipeline.yml": []byte("jobs: [{name: some-job}, {name: some-job}]"),
+				},
+			}, nil)
+		})
+
+		It("does not set the pipeline", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+
+		It("records the pipeline as errored", func() {
+			status := savedStatuses()["some-pipeline"]
+			Expect(status.Status).To(Equal(atc.PipelineSyncStatusErrored))
+			Expect(status.Error).To(ContainSubstring("invalid pipeline config"))
+		})
+	})
+
+	Context("when the file imports a library", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchReturns(pipelinesync.Snapshot{
+				Commit: "abcdef0123456789",
+				Files: map[string][]byte{
+					"pipelines/some-pipeline.yml": []byte("imports: [{library: some-library}]\njobs: [{name: some-job, plan: []}]"),
+				},
+			}, nil)
+
+			fakeTeam.PipelineLibraryReturns(db.PipelineLibrary{
+				Name:    "some-library",
+				Version: 3,
+				Config:  []byte("jobs: [{name: some-library-job, plan: []}]"),
+				Digest:  "sha256:abc",
+			}, true, nil)
+		})
+
+		It("sets the pipeline with the library imported", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+			_, _, config, _, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+			Expect(config.Jobs).To(HaveLen(2))
+			Expect(config.Jobs[1].Name).To(Equal("some-library-job"))
+			Expect(config.Imports[0].Resolved).To(Equal(&atc.ResolvedImport{
+				Version: 3,
+				Digest:  "sha256:abc",
+				Jobs:    []string{"some-library-job"},
+			}))
+		})
+	})
+
+	Context("when the file imports another file", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchReturns(pipelinesync.Snapshot{
+				Commit: "abcdef0123456789",
+				Files: map[string][]byte{
+					"pipelines/some-pipeline.yml": []byte("imports: [{file: snippet.yml}]\njobs: [{name: some-job, plan: []}]"),
+				},
+			}, nil)
+		})
+
+		It("records the pipeline as errored", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+
+			status := savedStatuses()["some-pipeline"]
+			Expect(status.Status).To(Equal(atc.PipelineSyncStatusErrored))
+			Expect(status.Error).To(Equal("failed to resolve imports[0]: cannot import file 'snippet.yml' here"))
+		})
+	})
+
+	Context("when two files configure the same pipeline", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchReturns(pipelinesync.Snapshot{
+				Commit: "abcdef0123456789",
+				Files: map[string][]byte{
+					"pipelines/some-pipeline.yaml": []byte("jobs: []"),
+					"pipelines/some-pipeline.yml":  []byte("jobs: []"),
+				},
+			}, nil)
+		})
+
+		It("does not set the pipeline", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+
+		It("records the pipeline as errored", func() {
+			status := savedStatuses()["some-pipeline"]
+			Expect(status.Status).To(Equal(atc.PipelineSyncStatusErrored))
+			Expect(status.Error).To(Equal("pipeline is configured by more than one file: pipelines/some-pipeline.yaml, pipelines/some-pipeline.yml"))
+		})
+	})
+
+	Context("when a previously synced pipeline's file is removed", func() {
+		var removedPipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+				{PipelineName: "removed-pipeline", File: "pipelines/removed-pipeline.yml", ConfigVersion: 3, Status: atc.PipelineSyncStatusSynced},
+			}, nil)
+
+			removedPipeline = new(dbfakes.FakePipeline)
+			fakeTeam.PipelineStub = func(ref atc.PipelineRef) (db.Pipeline, bool, error) {
+				if ref.Name == "removed-pipeline" {
+					return removedPipeline, true, nil
+				}
+
+				return nil, false, nil
+			}
+		})
+
+		It("archives the pipeline", func() {
+			Expect(removedPipeline.ArchiveCallCount()).To(Equal(1))
+		})
+
+		It("records the pipeline as archived", func() {
+			Expect(savedStatuses()["removed-pipeline"]).To(Equal(db.PipelineSyncStatus{
+				PipelineName: "removed-pipeline",
+				File:         "pipelines/removed-pipeline.yml",
+				Commit:       "abcdef0123456789",
+				Status:       atc.PipelineSyncStatusArchived,
+			}))
+		})
+
+		Context("when it was already archived by a sync", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+					{PipelineName: "removed-pipeline", File: "pipelines/removed-pipeline.yml", Status: atc.PipelineSyncStatusArchived},
+				}, nil)
+			})
+
+			It("leaves it alone", func() {
+				Expect(removedPipeline.ArchiveCallCount()).To(BeZero())
+				Expect(savedStatuses()).NotTo(HaveKey("removed-pipeline"))
+			})
+		})
+	})
+
+	Context("when fetching fails", func() {
+		BeforeEach(func() {
+			fakeFetcher.FetchReturns(pipelinesync.Snapshot{}, errors.New("nope"))
+
+			fakeTeam.PipelineSyncStatusesReturns([]db.PipelineSyncStatus{
+				{PipelineName: "some-pipeline", ConfigVersion: 3, Status: atc.PipelineSyncStatusSynced},
+				{PipelineName: "removed-pipeline", Status: atc.PipelineSyncStatusArchived},
+			}, nil)
+		})
+
+		It("does not error, so that other teams are still synced", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not touch the pipelines", func() {
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+			Expect(fakeTeam.PipelineCallCount()).To(BeZero())
+		})
+
+		It("records the synced pipelines as errored", func() {
+			Expect(savedStatuses()).To(Equal(map[string]db.PipelineSyncStatus{
+				"some-pipeline": {
+					PipelineName:  "some-pipeline",
+					ConfigVersion: 3,
+					Status:        atc.PipelineSyncStatusErrored,
+					Error:         "failed to fetch: nope",
+				},
+			}))
+		})
+	})
+})
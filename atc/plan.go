@@ -10,10 +10,12 @@ type Plan struct {
 	Task        *TaskPlan        `json:"task,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approve     *ApprovePlan     `json:"approve,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
 	Across     *AcrossPlan     `json:"across,omitempty"`
+	Matrix     *MatrixPlan     `json:"matrix,omitempty"`
 
 	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
 	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
@@ -57,6 +59,13 @@ func (plan *Plan) Each(f func(*Plan)) {
 		}
 	}
 
+	if plan.Matrix != nil {
+		for i, b := range plan.Matrix.Builds {
+			b.Plan.Each(f)
+			plan.Matrix.Builds[i] = b
+		}
+	}
+
 	if plan.OnSuccess != nil {
 		plan.OnSuccess.Step.Each(f)
 		plan.OnSuccess.Next.Each(f)
@@ -170,6 +179,18 @@ type VarScopedPlan struct {
 	Values []interface{} `json:"values"`
 }
 
+// MatrixPlan fans a job out into one build per combination of its matrix
+// vars. Each build runs its own plan, and the build running the MatrixPlan
+// aggregates their results.
+type MatrixPlan struct {
+	Builds []MatrixBuildPlan `json:"builds"`
+}
+
+type MatrixBuildPlan struct {
+	Values MatrixValues `json:"values"`
+	Plan   Plan         `json:"plan"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -274,6 +295,15 @@ type TaskPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// The OCI runtime class to run the task's container with, e.g. a sandboxed
+	// runtime such as gVisor or Kata. Only workers offering the class are
+	// eligible to run the task.
+	RuntimeClass string `json:"runtime_class,omitempty"`
+
+	// The network the task's container is allowed to reach. Only workers
+	// enforcing network policies are eligible to run the task.
+	Network *NetworkConfig `json:"network,omitempty"`
+
 	// The task config to execute - either fetched from a path at runtime, or
 	// provided statically.
 	ConfigPath string      `json:"config_path,omitempty"`
@@ -319,6 +349,7 @@ type SetPipelinePlan struct {
 	Vars         map[string]interface{} `json:"vars,omitempty"`
 	VarFiles     []string               `json:"var_files,omitempty"`
 	InstanceVars map[string]interface{} `json:"instance_vars,omitempty"`
+	DryRun       bool                   `json:"dry_run,omitempty"`
 }
 
 type LoadVarPlan struct {
@@ -328,6 +359,12 @@ type LoadVarPlan struct {
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type ApprovePlan struct {
+	Name      string           `json:"name"`
+	Approvers *ApproversConfig `json:"approvers,omitempty"`
+	Required  int              `json:"required"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
@@ -29,6 +29,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.InParallel = &t
 	case AcrossPlan:
 		plan.Across = &t
+	case MatrixPlan:
+		plan.Matrix = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -41,6 +43,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case ApprovePlan:
+		plan.Approve = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
@@ -8,6 +8,7 @@ func (plan Plan) Public() *json.RawMessage {
 
 		InParallel     *json.RawMessage `json:"in_parallel,omitempty"`
 		Across         *json.RawMessage `json:"across,omitempty"`
+		Matrix         *json.RawMessage `json:"matrix,omitempty"`
 		Do             *json.RawMessage `json:"do,omitempty"`
 		Get            *json.RawMessage `json:"get,omitempty"`
 		Put            *json.RawMessage `json:"put,omitempty"`
@@ -15,6 +16,7 @@ func (plan Plan) Public() *json.RawMessage {
 		Task           *json.RawMessage `json:"task,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		Approve        *json.RawMessage `json:"approve,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
@@ -38,6 +40,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Across = plan.Across.Public()
 	}
 
+	if plan.Matrix != nil {
+		public.Matrix = plan.Matrix.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -66,6 +72,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.Approve != nil {
+		public.Approve = plan.Approve.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -156,6 +166,25 @@ func (plan AcrossPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan MatrixPlan) Public() *json.RawMessage {
+	type matrixBuild struct {
+		Values MatrixValues `json:"values"`
+	}
+
+	builds := []matrixBuild{}
+	for _, build := range plan.Builds {
+		builds = append(builds, matrixBuild{
+			Values: build.Values,
+		})
+	}
+
+	return enc(struct {
+		Builds []matrixBuild `json:"builds"`
+	}{
+		Builds: builds,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -294,6 +323,18 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ApprovePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name      string           `json:"name"`
+		Approvers *ApproversConfig `json:"approvers,omitempty"`
+		Required  int              `json:"required"`
+	}{
+		Name:      plan.Name,
+		Approvers: plan.Approvers,
+		Required:  plan.Required,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -0,0 +1,20 @@
+package atc
+
+// ResourceUsage is how much of its container's resources a step used, as
+// sampled by the worker while the step was running.
+//
+// The peak memory is the cgroup's high-water mark when the worker reports
+// one, so short spikes between samples are not missed. Disk I/O is only
+// reported by workers using the containerd runtime.
+type ResourceUsage struct {
+	PeakMemoryBytes uint64       `json:"peak_memory_bytes"`
+	CPUSeconds      float64      `json:"cpu_seconds"`
+	DiskIO          *DiskIOUsage `json:"disk_io,omitempty"`
+}
+
+// DiskIOUsage is how many bytes a step's container read from and wrote to
+// block devices.
+type DiskIOUsage struct {
+	ReadBytes  uint64 `json:"read_bytes"`
+	WriteBytes uint64 `json:"write_bytes"`
+}
@@ -9,6 +9,36 @@ type SaveConfigResponse struct {
 	Warnings []ConfigWarning `json:"warnings,omitempty"`
 }
 
+// DryRunConfigResponse is what would happen if the config were saved. The
+// config would be rejected if there are any credential errors, or if it is not
+// allowed by policy. An invalid config is rejected by the dry run itself.
+type DryRunConfigResponse struct {
+	Warnings         []ConfigWarning `json:"warnings,omitempty"`
+	CredentialErrors []string        `json:"credential_errors,omitempty"`
+	Policy           PolicyResult    `json:"policy"`
+
+	// NewPipeline is true if the pipeline does not exist yet.
+	NewPipeline bool           `json:"new_pipeline,omitempty"`
+	Changes     []ConfigChange `json:"changes"`
+}
+
+// Rejected returns true if saving the config would fail.
+func (response DryRunConfigResponse) Rejected() bool {
+	return len(response.CredentialErrors) > 0 || !response.Policy.Allowed
+}
+
+type PolicyResult struct {
+	Allowed bool     `json:"allowed"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
 type ConfigResponse struct {
 	Config Config `json:"config"`
 }
+
+type ConfigHistoryEntry struct {
+	Version   int    `json:"version"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	Config    Config `json:"config"`
+}
@@ -3,8 +3,10 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig       = "SaveConfig"
+	DryRunConfig     = "DryRunConfig"
+	GetConfig        = "GetConfig"
+	GetConfigHistory = "GetConfigHistory"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -14,6 +16,9 @@ const (
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildDiff        = "GetBuildDiff"
+	ListBuildApprovals  = "ListBuildApprovals"
+	ApproveBuild        = "ApproveBuild"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -32,13 +37,14 @@ const (
 
 	ClearTaskCache = "ClearTaskCache"
 
-	ListAllResources     = "ListAllResources"
-	ListResources        = "ListResources"
-	ListResourceTypes    = "ListResourceTypes"
-	GetResource          = "GetResource"
-	CheckResource        = "CheckResource"
-	CheckResourceWebHook = "CheckResourceWebHook"
-	CheckResourceType    = "CheckResourceType"
+	ListAllResources       = "ListAllResources"
+	ListResources          = "ListResources"
+	ListResourceTypes      = "ListResourceTypes"
+	GetResource            = "GetResource"
+	CheckResource          = "CheckResource"
+	CheckResourceWebHook   = "CheckResourceWebHook"
+	TriggerResourceWebHook = "TriggerResourceWebHook"
+	CheckResourceType      = "CheckResourceType"
 
 	ListResourceVersions          = "ListResourceVersions"
 	GetResourceVersion            = "GetResourceVersion"
@@ -101,6 +107,13 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	SavePipelineLibrary = "SavePipelineLibrary"
+	GetPipelineLibrary  = "GetPipelineLibrary"
+
+	GetPipelineSync     = "GetPipelineSync"
+	SyncPipelines       = "SyncPipelines"
+	PipelineSyncWebhook = "PipelineSyncWebhook"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
@@ -120,7 +133,9 @@ const (
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/dry_run", Method: "PUT", Name: DryRunConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/history", Method: "GET", Name: GetConfigHistory},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -132,6 +147,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/diff/:other_build_id", Method: "GET", Name: GetBuildDiff},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "GET", Name: ListBuildApprovals},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "POST", Name: ApproveBuild},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -172,6 +190,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/trigger/webhook", Method: "POST", Name: TriggerResourceWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
@@ -222,6 +241,13 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
 
+	{Path: "/api/v1/teams/:team_name/pipeline_libraries/:library_name", Method: "PUT", Name: SavePipelineLibrary},
+	{Path: "/api/v1/teams/:team_name/pipeline_libraries/:library_name", Method: "GET", Name: GetPipelineLibrary},
+
+	{Path: "/api/v1/teams/:team_name/pipeline_sync", Method: "GET", Name: GetPipelineSync},
+	{Path: "/api/v1/teams/:team_name/pipeline_sync/sync", Method: "POST", Name: SyncPipelines},
+	{Path: "/api/v1/teams/:team_name/pipeline_sync/webhook", Method: "POST", Name: PipelineSyncWebhook},
+
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
 
@@ -22,6 +22,7 @@ type BuildStarter interface {
 //counterfeiter:generate . BuildPlanner
 type BuildPlanner interface {
 	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
+	CreateMatrix(atc.JobConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
 }
 
 type Build interface {
@@ -56,11 +57,23 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 		return false, fmt.Errorf("get pending builds: %w", err)
 	}
 
+	config, err := job.Config()
+	if err != nil {
+		return false, fmt.Errorf("config: %w", err)
+	}
+
+	if config.Concurrency != nil {
+		nextPendingBuilds, err = s.supersedeBuilds(logger, job, config, nextPendingBuilds)
+		if err != nil {
+			return false, fmt.Errorf("supersede builds: %w", err)
+		}
+	}
+
 	buildsToSchedule := s.constructBuilds(job, jobInputs, nextPendingBuilds)
 
 	var needsRetry bool
 	for _, nextSchedulableBuild := range buildsToSchedule {
-		results, err := s.tryStartNextPendingBuild(logger, nextSchedulableBuild, job)
+		results, err := s.tryStartNextPendingBuild(logger, nextSchedulableBuild, job, config)
 		if err != nil {
 			return false, err
 		}
@@ -131,6 +144,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	logger lager.Logger,
 	nextPendingBuild Build,
 	job db.SchedulerJob,
+	config atc.JobConfig,
 ) (startResults, error) {
 	logger = logger.Session("try-start-next-pending-build", lager.Data{
 		"build-id":   nextPendingBuild.ID(),
@@ -191,12 +205,20 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
-	config, err := job.Config()
-	if err != nil {
-		return startResults{}, fmt.Errorf("config: %w", err)
+	if config.Concurrency != nil && nextPendingBuild.RerunOf() == 0 {
+		key, err := config.Concurrency.KeyFor(inputVersions(buildInputs))
+		if err != nil {
+			// the build still runs, it just can't be superseded
+			logger.Info("failed-to-determine-concurrency-key", lager.Data{"error": err.Error()})
+		} else {
+			err = nextPendingBuild.SaveConcurrencyKey(key)
+			if err != nil {
+				return startResults{}, fmt.Errorf("save concurrency key: %w", err)
+			}
+		}
 	}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, buildInputs)
+	plan, err := s.createPlan(config, nextPendingBuild, job, buildInputs)
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
 
@@ -212,6 +234,14 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	}
 
 	started, err := nextPendingBuild.Start(plan)
+	if _, ok := err.(db.BuildsQuotaReachedError); ok {
+		// the build stays pending until the team's running builds finish
+		logger.Debug("team-quota-reached")
+		return startResults{
+			scheduled: true,
+		}, nil
+	}
+
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-started", err)
 		return startResults{}, fmt.Errorf("start build: %w", err)
@@ -239,3 +269,142 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		finished: true,
 	}, nil
 }
+
+// supersedeBuilds makes the pending build which will be started with the
+// job's next inputs supersede the job's older builds with the same
+// concurrency key. The running builds are aborted if the job's concurrency
+// config cancels builds in progress, and older pending builds are aborted
+// and left out of the returned builds.
+//
+// Pending builds other than reruns are started one after the other, each with
+// the job's next inputs at the time. Usually every one of them gets the same
+// inputs, so the newest one gets the key of the next inputs and the older
+// ones would only run the same inputs again. With `version: every` inputs
+// each build gets the next version instead, so only the oldest pending build
+// is known to get the key of the next inputs and the other pending builds
+// are left to run with their own versions. Reruns keep the inputs of the
+// build they rerun, so they neither supersede nor get superseded.
+func (s *buildStarter) supersedeBuilds(
+	logger lager.Logger,
+	job db.SchedulerJob,
+	config atc.JobConfig,
+	pendingBuilds []db.Build,
+) ([]db.Build, error) {
+	everyVersion := hasEveryVersionInput(config)
+
+	var superseding db.Build
+	for _, build := range pendingBuilds {
+		if build.RerunOf() != 0 || build.IsAborted() {
+			continue
+		}
+
+		if superseding == nil ||
+			(everyVersion && build.ID() < superseding.ID()) ||
+			(!everyVersion && build.ID() > superseding.ID()) {
+			superseding = build
+		}
+	}
+
+	if superseding == nil {
+		return pendingBuilds, nil
+	}
+
+	inputs, determined, err := job.GetFullNextBuildInputs()
+	if err != nil {
+		return nil, fmt.Errorf("get next build inputs: %w", err)
+	}
+
+	if !determined {
+		return pendingBuilds, nil
+	}
+
+	concurrency := *config.Concurrency
+
+	key, err := concurrency.KeyFor(inputVersions(inputs))
+	if err != nil {
+		logger.Info("failed-to-determine-concurrency-key", lager.Data{"error": err.Error()})
+		return pendingBuilds, nil
+	}
+
+	logger = logger.Session("supersede", lager.Data{
+		"concurrency-key": key,
+		"superseded-by":   superseding.Name(),
+	})
+
+	var remaining []db.Build
+	for _, build := range pendingBuilds {
+		if everyVersion || build.RerunOf() != 0 || build.IsAborted() || build.ID() == superseding.ID() {
+			remaining = append(remaining, build)
+			continue
+		}
+
+		logger.Info("skipping-pending-build", lager.Data{"build": build.Name()})
+
+		// the aborted build gets finished the next time the job is scheduled
+		err := build.MarkAsAborted()
+		if err != nil {
+			return nil, fmt.Errorf("abort pending build: %w", err)
+		}
+	}
+
+	if !concurrency.CancelInProgress {
+		return remaining, nil
+	}
+
+	runningBuilds, err := job.RunningBuildsWithConcurrencyKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("get running builds: %w", err)
+	}
+
+	for _, build := range runningBuilds {
+		if build.ID() > superseding.ID() {
+			continue
+		}
+
+		logger.Info("aborting-running-build", lager.Data{"build": build.Name()})
+
+		err := build.MarkAsAborted()
+		if err != nil {
+			return nil, fmt.Errorf("abort running build: %w", err)
+		}
+	}
+
+	return remaining, nil
+}
+
+func hasEveryVersionInput(config atc.JobConfig) bool {
+	for _, input := range config.Inputs() {
+		if input.Version != nil && input.Version.Every {
+			return true
+		}
+	}
+
+	return false
+}
+
+func inputVersions(inputs []db.BuildInput) map[string]atc.Version {
+	versions := map[string]atc.Version{}
+	for _, input := range inputs {
+		versions[input.Name] = input.Version
+	}
+
+	return versions
+}
+
+func (s *buildStarter) createPlan(
+	config atc.JobConfig,
+	build Build,
+	job db.SchedulerJob,
+	buildInputs []db.BuildInput,
+) (atc.Plan, error) {
+	if build.MatrixValues() != nil {
+		// a rerun of a single build of the matrix
+		return s.planner.Create(config.MatrixStepConfig(build.MatrixValues()), job.Resources, job.ResourceTypes, buildInputs)
+	}
+
+	if len(config.Matrix) > 0 {
+		return s.planner.CreateMatrix(config, job.Resources, job.ResourceTypes, buildInputs)
+	}
+
+	return s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, buildInputs)
+}
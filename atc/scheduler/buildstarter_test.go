@@ -695,6 +695,25 @@ var _ = Describe("BuildStarter", func() {
 										})
 									})
 
+									Context("when the team has reached its concurrent builds quota", func() {
+										BeforeEach(func() {
+											pendingBuild1.StartReturns(false, db.BuildsQuotaReachedError{MaxConcurrentBuilds: 2})
+										})
+
+										It("needs to be rescheduled without an error", func() {
+											Expect(tryStartErr).NotTo(HaveOccurred())
+											Expect(needsReschedule).To(BeTrue())
+										})
+
+										It("leaves the build pending", func() {
+											Expect(pendingBuild1.FinishCallCount()).To(BeZero())
+										})
+
+										It("does not start the other builds", func() {
+											Expect(pendingBuild2.StartCallCount()).To(Equal(0))
+										})
+									})
+
 									Context("when starting the build returns false", func() {
 										BeforeEach(func() {
 											pendingBuild1.StartReturns(false, nil)
@@ -890,6 +909,258 @@ var _ = Describe("BuildStarter", func() {
 							})
 						})
 					})
+
+					Context("when the job has a build matrix", func() {
+						var matrixJobConfig atc.JobConfig
+						var matrixPlan atc.Plan
+
+						BeforeEach(func() {
+							matrixJobConfig = jobConfig
+							matrixJobConfig.Matrix = []atc.MatrixVarConfig{
+								{Var: "os", Values: []interface{}{"linux", "windows"}},
+							}
+							job.ConfigReturns(matrixJobConfig, nil)
+
+							matrixPlan = atc.Plan{
+								ID:     "some-matrix",
+								Matrix: &atc.MatrixPlan{},
+							}
+							fakePlanner.CreateMatrixReturns(matrixPlan, nil)
+							fakePlanner.CreateReturns(plannedPlan, nil)
+
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+							pendingBuild1.StartReturns(true, nil)
+							job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+						})
+
+						It("starts the build with a matrix plan", func() {
+							Expect(fakePlanner.CreateCallCount()).To(BeZero())
+							Expect(fakePlanner.CreateMatrixCallCount()).To(Equal(1))
+
+							actualConfig, actualResources, actualResourceTypes, actualBuildInputs := fakePlanner.CreateMatrixArgsForCall(0)
+							Expect(actualConfig).To(Equal(matrixJobConfig))
+							Expect(actualResources).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
+							Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
+							Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
+
+							Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+							Expect(pendingBuild1.StartArgsForCall(0)).To(Equal(matrixPlan))
+						})
+
+						Context("when the build is a rerun of a single build of the matrix", func() {
+							BeforeEach(func() {
+								rerunBuild = new(dbfakes.FakeBuild)
+								rerunBuild.IDReturns(555)
+								rerunBuild.RerunOfReturns(100)
+								rerunBuild.MatrixValuesReturns(atc.MatrixValues{"os": "windows"})
+								rerunBuild.AdoptRerunInputsAndPipesReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+								rerunBuild.StartReturns(true, nil)
+								job.GetPendingBuildsReturns([]db.Build{rerunBuild}, nil)
+							})
+
+							It("plans only the combination of the rerun build", func() {
+								Expect(fakePlanner.CreateMatrixCallCount()).To(BeZero())
+								Expect(fakePlanner.CreateCallCount()).To(Equal(1))
+
+								actualPlanConfig, _, _, _ := fakePlanner.CreateArgsForCall(0)
+								Expect(actualPlanConfig).To(Equal(matrixJobConfig.MatrixStepConfig(atc.MatrixValues{"os": "windows"})))
+
+								Expect(rerunBuild.StartCallCount()).To(Equal(1))
+								Expect(rerunBuild.StartArgsForCall(0)).To(Equal(plannedPlan))
+							})
+						})
+					})
+
+					Context("when the job has a concurrency key", func() {
+						var concurrencyJobConfig atc.JobConfig
+						var olderBuild *dbfakes.FakeBuild
+						var runningBuild *dbfakes.FakeBuild
+
+						BeforeEach(func() {
+							concurrencyJobConfig = jobConfig
+							concurrencyJobConfig.Concurrency = &atc.ConcurrencyConfig{
+								Key:              "pr-((some-input.number))",
+								CancelInProgress: true,
+							}
+							job.ConfigReturns(concurrencyJobConfig, nil)
+							fakePlanner.CreateReturns(plannedPlan, nil)
+
+							olderBuild = new(dbfakes.FakeBuild)
+							olderBuild.IDReturns(98)
+							olderBuild.NameReturns("98")
+
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.NameReturns("99")
+							pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{
+								{Name: "some-input", Version: atc.Version{"number": "43"}},
+							}, true, nil)
+							pendingBuild1.StartReturns(true, nil)
+
+							rerunBuild = new(dbfakes.FakeBuild)
+							rerunBuild.IDReturns(555)
+							rerunBuild.RerunOfReturns(50)
+							rerunBuild.AdoptRerunInputsAndPipesReturns([]db.BuildInput{
+								{Name: "some-input", Version: atc.Version{"number": "1"}},
+							}, true, nil)
+							rerunBuild.StartReturns(true, nil)
+
+							job.GetPendingBuildsReturns([]db.Build{olderBuild, pendingBuild1, rerunBuild}, nil)
+
+							job.GetFullNextBuildInputsReturns([]db.BuildInput{
+								{Name: "some-input", Version: atc.Version{"number": "42"}},
+							}, true, nil)
+
+							runningBuild = new(dbfakes.FakeBuild)
+							runningBuild.IDReturns(97)
+							job.RunningBuildsWithConcurrencyKeyReturns([]db.Build{runningBuild}, nil)
+						})
+
+						It("skips the older pending builds", func() {
+							Expect(olderBuild.MarkAsAbortedCallCount()).To(Equal(1))
+							Expect(olderBuild.StartCallCount()).To(BeZero())
+
+							Expect(job.ScheduleBuildCallCount()).To(Equal(2))
+							Expect(job.ScheduleBuildArgsForCall(0).ID()).To(Equal(pendingBuild1.ID()))
+							Expect(job.ScheduleBuildArgsForCall(1).ID()).To(Equal(rerunBuild.ID()))
+						})
+
+						It("aborts the running builds with the key of the next inputs", func() {
+							Expect(job.RunningBuildsWithConcurrencyKeyCallCount()).To(Equal(1))
+							Expect(job.RunningBuildsWithConcurrencyKeyArgsForCall(0)).To(Equal("pr-42"))
+							Expect(runningBuild.MarkAsAbortedCallCount()).To(Equal(1))
+						})
+
+						It("saves the key of the inputs each build is started with", func() {
+							Expect(pendingBuild1.SaveConcurrencyKeyCallCount()).To(Equal(1))
+							Expect(pendingBuild1.SaveConcurrencyKeyArgsForCall(0)).To(Equal("pr-43"))
+							Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+						})
+
+						It("leaves rerun builds alone", func() {
+							Expect(rerunBuild.MarkAsAbortedCallCount()).To(BeZero())
+							Expect(rerunBuild.SaveConcurrencyKeyCallCount()).To(BeZero())
+							Expect(rerunBuild.StartCallCount()).To(Equal(1))
+						})
+
+						Context("when the older pending build has already been aborted", func() {
+							BeforeEach(func() {
+								olderBuild.IsAbortedReturns(true)
+							})
+
+							It("finishes it without aborting it again", func() {
+								Expect(olderBuild.MarkAsAbortedCallCount()).To(BeZero())
+								Expect(olderBuild.FinishCallCount()).To(Equal(1))
+								Expect(olderBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+							})
+						})
+
+						Context("when builds in progress are not cancelled", func() {
+							BeforeEach(func() {
+								concurrencyJobConfig.Concurrency.CancelInProgress = false
+								job.ConfigReturns(concurrencyJobConfig, nil)
+							})
+
+							It("only skips the older pending builds", func() {
+								Expect(olderBuild.MarkAsAbortedCallCount()).To(Equal(1))
+								Expect(job.RunningBuildsWithConcurrencyKeyCallCount()).To(BeZero())
+								Expect(runningBuild.MarkAsAbortedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when an input uses every version", func() {
+							BeforeEach(func() {
+								concurrencyJobConfig.PlanSequence = []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:    "some-input",
+											Version: &atc.VersionConfig{Every: true},
+										},
+									},
+								}
+								job.ConfigReturns(concurrencyJobConfig, nil)
+
+								olderBuild.AdoptInputsAndPipesReturns([]db.BuildInput{
+									{Name: "some-input", Version: atc.Version{"number": "42"}},
+								}, true, nil)
+								olderBuild.StartReturns(true, nil)
+							})
+
+							It("starts every pending build with its own version", func() {
+								Expect(olderBuild.MarkAsAbortedCallCount()).To(BeZero())
+								Expect(job.ScheduleBuildCallCount()).To(Equal(3))
+
+								Expect(olderBuild.SaveConcurrencyKeyArgsForCall(0)).To(Equal("pr-42"))
+								Expect(pendingBuild1.SaveConcurrencyKeyArgsForCall(0)).To(Equal("pr-43"))
+							})
+
+							It("aborts the running builds with the key of the oldest pending build", func() {
+								Expect(job.RunningBuildsWithConcurrencyKeyCallCount()).To(Equal(1))
+								Expect(job.RunningBuildsWithConcurrencyKeyArgsForCall(0)).To(Equal("pr-42"))
+								Expect(runningBuild.MarkAsAbortedCallCount()).To(Equal(1))
+							})
+
+							Context("when a running build is newer than the oldest pending build", func() {
+								BeforeEach(func() {
+									runningBuild.IDReturns(100)
+								})
+
+								It("does not abort it", func() {
+									Expect(runningBuild.MarkAsAbortedCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when the next inputs have not been determined", func() {
+							BeforeEach(func() {
+								job.GetFullNextBuildInputsReturns(nil, false, nil)
+							})
+
+							It("does not supersede any builds", func() {
+								Expect(olderBuild.MarkAsAbortedCallCount()).To(BeZero())
+								Expect(runningBuild.MarkAsAbortedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the key can not be determined", func() {
+							BeforeEach(func() {
+								job.GetFullNextBuildInputsReturns([]db.BuildInput{
+									{Name: "some-input", Version: atc.Version{"ref": "abcdef"}},
+								}, true, nil)
+								pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{
+									{Name: "some-input", Version: atc.Version{"ref": "abcdef"}},
+								}, true, nil)
+							})
+
+							It("does not supersede any builds", func() {
+								Expect(olderBuild.MarkAsAbortedCallCount()).To(BeZero())
+								Expect(runningBuild.MarkAsAbortedCallCount()).To(BeZero())
+							})
+
+							Context("when the build is the only pending build", func() {
+								BeforeEach(func() {
+									job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+								})
+
+								It("still starts the build without a key", func() {
+									Expect(pendingBuild1.SaveConcurrencyKeyCallCount()).To(BeZero())
+									Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+								})
+							})
+						})
+
+						Context("when getting the next inputs fails", func() {
+							BeforeEach(func() {
+								job.GetFullNextBuildInputsReturns(nil, false, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(tryStartErr).To(MatchError(ContainSubstring("bad thing")))
+							})
+						})
+					})
 				})
 			})
 		})
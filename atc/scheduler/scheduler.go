@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
@@ -58,6 +59,11 @@ func (s *Scheduler) Schedule(
 		return false, err
 	}
 
+	err = s.ensureScheduledBuildExists(logger, job)
+	if err != nil {
+		return false, err
+	}
+
 	return s.BuildStarter.TryStartPendingBuildsForJob(logger, job, jobInputs)
 }
 
@@ -121,3 +127,43 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+func (s *Scheduler) ensureScheduledBuildExists(logger lager.Logger, job db.SchedulerJob) error {
+	nextTrigger := job.NextCronTrigger()
+	if nextTrigger.IsZero() {
+		return nil
+	}
+
+	now := time.Now()
+	if nextTrigger.After(now) {
+		return nil
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		return fmt.Errorf("job config: %w", err)
+	}
+
+	if config.Schedule == nil {
+		return nil
+	}
+
+	next, err := config.Schedule.Next(now)
+	if err != nil {
+		return fmt.Errorf("next cron trigger: %w", err)
+	}
+
+	logger.Debug("cron-triggered", lager.Data{
+		"trigger": nextTrigger,
+		"next":    next,
+	})
+
+	reason := fmt.Sprintf("schedule '%s' at %s", config.Schedule.Cron, nextTrigger.Format(time.RFC3339))
+
+	err = job.EnsureScheduledBuildExists(reason, next)
+	if err != nil {
+		return fmt.Errorf("ensure scheduled build exists: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -466,6 +467,64 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 
+		Context("when the job has a schedule", func() {
+			BeforeEach(func() {
+				fakeJob.NameReturns("some-job")
+				fakeJob.GetFullNextBuildInputsReturns(nil, true, nil)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					Schedule: &atc.ScheduleConfig{
+						Cron: "0 9 * * *",
+					},
+				}, nil)
+			})
+
+			Context("when the next cron trigger has passed", func() {
+				var nextTrigger time.Time
+
+				BeforeEach(func() {
+					nextTrigger = time.Date(2021, 5, 3, 9, 0, 0, 0, time.UTC)
+					fakeJob.NextCronTriggerReturns(nextTrigger)
+				})
+
+				It("ensures a scheduled build exists with the next trigger", func() {
+					Expect(scheduleErr).ToNot(HaveOccurred())
+					Expect(fakeJob.EnsureScheduledBuildExistsCallCount()).To(Equal(1))
+
+					reason, next := fakeJob.EnsureScheduledBuildExistsArgsForCall(0)
+					Expect(reason).To(Equal("schedule '0 9 * * *' at 2021-05-03T09:00:00Z"))
+					Expect(next).To(BeTemporally(">", time.Now()))
+					Expect(next.Hour()).To(Equal(9))
+					Expect(next.Minute()).To(BeZero())
+				})
+
+				It("starts pending builds after creating the scheduled build", func() {
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				})
+
+				Context("when creating the scheduled build fails", func() {
+					BeforeEach(func() {
+						fakeJob.EnsureScheduledBuildExistsReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(fmt.Errorf("ensure scheduled build exists: %w", disaster)))
+					})
+				})
+			})
+
+			Context("when the next cron trigger has not passed", func() {
+				BeforeEach(func() {
+					fakeJob.NextCronTriggerReturns(time.Now().Add(time.Hour))
+				})
+
+				It("does not create a scheduled build", func() {
+					Expect(scheduleErr).ToNot(HaveOccurred())
+					Expect(fakeJob.EnsureScheduledBuildExistsCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the job inputs fail to fetch", func() {
 			BeforeEach(func() {
 				fakeJob.AlgorithmInputsReturns(nil, disaster)
@@ -26,6 +26,22 @@ type FakeBuildPlanner struct {
 		result1 atc.Plan
 		result2 error
 	}
+	CreateMatrixStub        func(atc.JobConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
+	createMatrixMutex       sync.RWMutex
+	createMatrixArgsForCall []struct {
+		arg1 atc.JobConfig
+		arg2 db.SchedulerResources
+		arg3 atc.VersionedResourceTypes
+		arg4 []db.BuildInput
+	}
+	createMatrixReturns struct {
+		result1 atc.Plan
+		result2 error
+	}
+	createMatrixReturnsOnCall map[int]struct {
+		result1 atc.Plan
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -102,11 +118,85 @@ func (fake *FakeBuildPlanner) CreateReturnsOnCall(i int, result1 atc.Plan, resul
 	}{result1, result2}
 }
 
+func (fake *FakeBuildPlanner) CreateMatrix(arg1 atc.JobConfig, arg2 db.SchedulerResources, arg3 atc.VersionedResourceTypes, arg4 []db.BuildInput) (atc.Plan, error) {
+	var arg4Copy []db.BuildInput
+	if arg4 != nil {
+		arg4Copy = make([]db.BuildInput, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.createMatrixMutex.Lock()
+	ret, specificReturn := fake.createMatrixReturnsOnCall[len(fake.createMatrixArgsForCall)]
+	fake.createMatrixArgsForCall = append(fake.createMatrixArgsForCall, struct {
+		arg1 atc.JobConfig
+		arg2 db.SchedulerResources
+		arg3 atc.VersionedResourceTypes
+		arg4 []db.BuildInput
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.CreateMatrixStub
+	fakeReturns := fake.createMatrixReturns
+	fake.recordInvocation("CreateMatrix", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.createMatrixMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildPlanner) CreateMatrixCallCount() int {
+	fake.createMatrixMutex.RLock()
+	defer fake.createMatrixMutex.RUnlock()
+	return len(fake.createMatrixArgsForCall)
+}
+
+func (fake *FakeBuildPlanner) CreateMatrixCalls(stub func(atc.JobConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)) {
+	fake.createMatrixMutex.Lock()
+	defer fake.createMatrixMutex.Unlock()
+	fake.CreateMatrixStub = stub
+}
+
+func (fake *FakeBuildPlanner) CreateMatrixArgsForCall(i int) (atc.JobConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) {
+	fake.createMatrixMutex.RLock()
+	defer fake.createMatrixMutex.RUnlock()
+	argsForCall := fake.createMatrixArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBuildPlanner) CreateMatrixReturns(result1 atc.Plan, result2 error) {
+	fake.createMatrixMutex.Lock()
+	defer fake.createMatrixMutex.Unlock()
+	fake.CreateMatrixStub = nil
+	fake.createMatrixReturns = struct {
+		result1 atc.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildPlanner) CreateMatrixReturnsOnCall(i int, result1 atc.Plan, result2 error) {
+	fake.createMatrixMutex.Lock()
+	defer fake.createMatrixMutex.Unlock()
+	fake.CreateMatrixStub = nil
+	if fake.createMatrixReturnsOnCall == nil {
+		fake.createMatrixReturnsOnCall = make(map[int]struct {
+			result1 atc.Plan
+			result2 error
+		})
+	}
+	fake.createMatrixReturnsOnCall[i] = struct {
+		result1 atc.Plan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildPlanner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createMatrixMutex.RLock()
+	defer fake.createMatrixMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
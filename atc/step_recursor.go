@@ -22,6 +22,9 @@ type StepRecursor struct {
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnApprove will be invoked for any *ApproveStep present in the StepConfig.
+	OnApprove func(*ApproveStep) error
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -69,6 +72,15 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitApprove calls the OnApprove hook if configured.
+func (recursor StepRecursor) VisitApprove(step *ApproveStep) error {
+	if recursor.OnApprove != nil {
+		return recursor.OnApprove(step)
+	}
+
+	return nil
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -103,6 +103,18 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.popContext()
 	}
 
+	if plan.Network != nil {
+		validator.pushContext(".network.allow")
+
+		for _, destination := range plan.Network.Allow {
+			if err := ValidateNetworkDestination(destination); err != nil {
+				validator.recordError(err.Error())
+			}
+		}
+
+		validator.popContext()
+	}
+
 	return nil
 }
 
@@ -229,6 +241,31 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitApprove(step *ApproveStep) error {
+	validator.pushContext(".approve(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	if step.Required < 0 {
+		validator.recordError("required must not be negative")
+	}
+
+	if step.Approvers != nil && len(step.Approvers.Roles) == 0 && len(step.Approvers.Users) == 0 {
+		validator.recordError("approvers must list at least one role or user")
+	} else if step.Approvers != nil && len(step.Approvers.Roles) == 0 && len(step.Approvers.Users) < step.Required {
+		validator.recordError("requires %d approvals but only %d users are allowed to approve", step.Required, len(step.Approvers.Users))
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
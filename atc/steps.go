@@ -193,6 +193,7 @@ type StepVisitor interface {
 	VisitPut(*PutStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitApprove(*ApproveStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
@@ -273,6 +274,10 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "approve",
+		New: func() StepConfig { return &ApproveStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -345,6 +350,8 @@ type TaskStep struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	RuntimeClass      string            `json:"runtime_class,omitempty"`
+	Network           *NetworkConfig    `json:"network,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -358,6 +365,7 @@ type SetPipelineStep struct {
 	Vars         Params       `json:"vars,omitempty"`
 	VarFiles     []string     `json:"var_files,omitempty"`
 	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	DryRun       bool         `json:"dry_run,omitempty"`
 }
 
 func (step *SetPipelineStep) Visit(v StepVisitor) error {
@@ -375,6 +383,24 @@ func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+type ApproveStep struct {
+	Name      string           `json:"approve"`
+	Approvers *ApproversConfig `json:"approvers,omitempty"`
+	Required  int              `json:"required,omitempty"`
+}
+
+func (step *ApproveStep) Visit(v StepVisitor) error {
+	return v.VisitApprove(step)
+}
+
+// ApproversConfig restricts who may approve or reject an approve step. A user
+// is allowed if they have any of the listed roles in the build's team, or if
+// their user name is listed.
+type ApproversConfig struct {
+	Roles []string `json:"roles,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
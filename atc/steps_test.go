@@ -141,6 +141,50 @@ var factoryTests = []StepTest{
 			Timeout:           "1h",
 		},
 	},
+	{
+		Title: "task step with a network allow-list",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			network:
+			  allow: [10.0.0.0/8, api.example.com]
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Network: &atc.NetworkConfig{
+				Allow: []string{"10.0.0.0/8", "api.example.com"},
+			},
+		},
+	},
+	{
+		Title: "task step without network",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			network: none
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Network:    &atc.NetworkConfig{None: true},
+		},
+	},
+	{
+		Title: "task step with an unknown network",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			network: everything
+		`,
+
+		Err: `error unmarshaling JSON: while decoding JSON: malformed task step: unknown network 'everything'`,
+	},
 	{
 		Title: "task step with non-string params",
 
@@ -174,6 +218,7 @@ var factoryTests = []StepTest{
 			vars: {some: vars}
 			var_files: [file-1, file-2]
 			instance_vars: {branch: feature/foo}
+			dry_run: true
 		`,
 
 		StepConfig: &atc.SetPipelineStep{
@@ -182,6 +227,7 @@ var factoryTests = []StepTest{
 			Vars:         atc.Params{"some": "vars"},
 			VarFiles:     []string{"file-1", "file-2"},
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
+			DryRun:       true,
 		},
 	},
 	{
@@ -201,6 +247,41 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "approve step",
+
+		ConfigYAML: `
+			approve: deploy
+			approvers:
+			  roles: [owner]
+			  users: [some-user]
+			required: 2
+		`,
+
+		StepConfig: &atc.ApproveStep{
+			Name: "deploy",
+			Approvers: &atc.ApproversConfig{
+				Roles: []string{"owner"},
+				Users: []string{"some-user"},
+			},
+			Required: 2,
+		},
+	},
+	{
+		Title: "approve step with a timeout",
+
+		ConfigYAML: `
+			approve: deploy
+			timeout: 1h
+		`,
+
+		StepConfig: &atc.TimeoutStep{
+			Step: &atc.ApproveStep{
+				Name: "deploy",
+			},
+			Duration: "1h",
+		},
+	},
 	{
 		Title: "try step",
 
@@ -2,21 +2,87 @@ package atc
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
 	ErrAuthConfigEmpty   = errors.New("auth config for the team must not be empty")
 	ErrAuthConfigInvalid = errors.New("auth config for the team does not have users and groups configured")
+	ErrQuotaInvalid      = errors.New("team quotas must not be negative")
 )
 
 type Team struct {
-	ID   int      `json:"id,omitempty"`
-	Name string   `json:"name,omitempty"`
-	Auth TeamAuth `json:"auth,omitempty"`
+	ID     int         `json:"id,omitempty"`
+	Name   string      `json:"name,omitempty"`
+	Auth   TeamAuth    `json:"auth,omitempty"`
+	Quotas *TeamQuotas `json:"quotas,omitempty"`
+
+	PipelineSync *PipelineSyncConfig `json:"pipeline_sync,omitempty"`
 }
 
 func (team Team) Validate() error {
-	return team.Auth.Validate()
+	err := team.Auth.Validate()
+	if err != nil {
+		return err
+	}
+
+	if team.Quotas != nil {
+		err = team.Quotas.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	// an empty pipeline sync config stops the team's pipelines from being
+	// synced
+	if team.PipelineSync != nil && !team.PipelineSync.IsZero() {
+		return team.PipelineSync.Validate()
+	}
+
+	return nil
+}
+
+// TeamQuotas limits how much of the cluster a team may use at once. A quota
+// of 0 means no limit.
+type TeamQuotas struct {
+	MaxConcurrentBuilds int `json:"max_concurrent_builds,omitempty"`
+	MaxContainers       int `json:"max_containers,omitempty"`
+	MaxVolumes          int `json:"max_volumes,omitempty"`
+}
+
+func (quotas TeamQuotas) Validate() error {
+	if quotas.MaxConcurrentBuilds < 0 || quotas.MaxContainers < 0 || quotas.MaxVolumes < 0 {
+		return ErrQuotaInvalid
+	}
+
+	return nil
+}
+
+// IsZero returns true if none of the quotas are limited.
+func (quotas TeamQuotas) IsZero() bool {
+	return quotas == TeamQuotas{}
+}
+
+func (quotas TeamQuotas) String() string {
+	if quotas.IsZero() {
+		return "none"
+	}
+
+	var limits []string
+	if quotas.MaxConcurrentBuilds > 0 {
+		limits = append(limits, fmt.Sprintf("%d concurrent builds", quotas.MaxConcurrentBuilds))
+	}
+
+	if quotas.MaxContainers > 0 {
+		limits = append(limits, fmt.Sprintf("%d containers", quotas.MaxContainers))
+	}
+
+	if quotas.MaxVolumes > 0 {
+		limits = append(limits, fmt.Sprintf("%d volumes", quotas.MaxVolumes))
+	}
+
+	return strings.Join(limits, ", ")
 }
 
 type TeamAuth map[string]map[string][]string
@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+	"github.com/gobwas/glob"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Filter decides whether a webhook payload should trigger builds, and
+// extracts the vars which are provided to those builds.
+type Filter struct {
+	matchers []matcher
+	vars     map[string]*jsonpath.JSONPath
+}
+
+type matcher struct {
+	path     *jsonpath.JSONPath
+	patterns []glob.Glob
+	ignore   []glob.Glob
+}
+
+// NewFilter parses the JSONPath expressions and patterns of the given config.
+// A nil config results in a filter which matches every payload.
+func NewFilter(config *atc.WebhookFilter) (Filter, error) {
+	filter := Filter{
+		vars: map[string]*jsonpath.JSONPath{},
+	}
+
+	if config == nil {
+		return filter, nil
+	}
+
+	for i, match := range config.Match {
+		path, err := parsePath(match.Path)
+		if err != nil {
+			return Filter{}, fmt.Errorf("match[%d]: invalid path '%s': %w", i, match.Path, err)
+		}
+
+		patterns, err := compileGlobs(match.Patterns)
+		if err != nil {
+			return Filter{}, fmt.Errorf("match[%d]: invalid pattern: %w", i, err)
+		}
+
+		ignore, err := compileGlobs(match.Ignore)
+		if err != nil {
+			return Filter{}, fmt.Errorf("match[%d]: invalid ignore pattern: %w", i, err)
+		}
+
+		filter.matchers = append(filter.matchers, matcher{
+			path:     path,
+			patterns: patterns,
+			ignore:   ignore,
+		})
+	}
+
+	for name, expr := range config.Vars {
+		path, err := parsePath(expr)
+		if err != nil {
+			return Filter{}, fmt.Errorf("vars.%s: invalid path '%s': %w", name, expr, err)
+		}
+
+		filter.vars[name] = path
+	}
+
+	return filter, nil
+}
+
+// Matches returns true if the payload satisfies every condition of the
+// filter.
+func (filter Filter) Matches(payload interface{}) (bool, error) {
+	for _, m := range filter.matchers {
+		values, err := find(m.path, payload)
+		if err != nil {
+			return false, err
+		}
+
+		if !m.matchesAny(values) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Vars evaluates the filter's vars against the payload. A path which matches
+// a single value results in that value; a path which matches multiple values
+// results in a list. Paths which match nothing are omitted.
+func (filter Filter) Vars(payload interface{}) (vars.StaticVariables, error) {
+	buildVars := vars.StaticVariables{}
+	for name, path := range filter.vars {
+		values, err := find(path, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(values) {
+		case 0:
+		case 1:
+			buildVars[name] = values[0]
+		default:
+			buildVars[name] = values
+		}
+	}
+
+	return buildVars, nil
+}
+
+func (m matcher) matchesAny(values []interface{}) bool {
+	for _, val := range values {
+		str := fmt.Sprintf("%v", val)
+
+		if len(m.patterns) > 0 && !anyGlobMatches(m.patterns, str) {
+			continue
+		}
+
+		if anyGlobMatches(m.ignore, str) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func parsePath(expr string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(expr, "{") {
+		expr = "{" + strings.TrimPrefix(expr, "$") + "}"
+	}
+
+	path := jsonpath.New("webhook").AllowMissingKeys(true)
+
+	err := path.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return path, nil
+}
+
+func find(path *jsonpath.JSONPath, payload interface{}) ([]interface{}, error) {
+	results, err := path.FindResults(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []interface{}
+	for _, result := range results {
+		for _, val := range result {
+			values = append(values, interfaceOf(val))
+		}
+	}
+
+	return values, nil
+}
+
+func interfaceOf(val reflect.Value) interface{} {
+	if !val.IsValid() {
+		return nil
+	}
+
+	return val.Interface()
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	var globs []glob.Glob
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("'%s': %w", pattern, err)
+		}
+
+		globs = append(globs, g)
+	}
+
+	return globs, nil
+}
+
+func anyGlobMatches(globs []glob.Glob, str string) bool {
+	for _, g := range globs {
+		if g.Match(str) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,187 @@
+package webhook_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/webhook"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	var (
+		config  *atc.WebhookFilter
+		payload interface{}
+
+		filter webhook.Filter
+	)
+
+	BeforeEach(func() {
+		config = &atc.WebhookFilter{}
+
+		err := json.Unmarshal([]byte(`{
+			"ref": "refs/heads/master",
+			"head_commit": {"id": "abc123"},
+			"commits": [
+				{"modified": ["docs/index.md", "README.md"]},
+				{"modified": ["docs/guide/setup.md"]}
+			]
+		}`), &payload)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		filter, err = webhook.NewFilter(config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Matches", func() {
+		var matches bool
+
+		JustBeforeEach(func() {
+			var err error
+			matches, err = filter.Matches(payload)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there are no conditions", func() {
+			It("matches", func() {
+				Expect(matches).To(BeTrue())
+			})
+		})
+
+		Context("when the filter is nil", func() {
+			BeforeEach(func() {
+				config = nil
+			})
+
+			It("matches", func() {
+				Expect(matches).To(BeTrue())
+			})
+		})
+
+		Context("when a value matches a pattern", func() {
+			BeforeEach(func() {
+				config.Match = []atc.WebhookMatch{
+					{Path: "$.ref", Patterns: []string{"refs/heads/master"}},
+				}
+			})
+
+			It("matches", func() {
+				Expect(matches).To(BeTrue())
+			})
+		})
+
+		Context("when no value matches a pattern", func() {
+			BeforeEach(func() {
+				config.Match = []atc.WebhookMatch{
+					{Path: "{.ref}", Patterns: []string{"refs/tags/*"}},
+				}
+			})
+
+			It("does not match", func() {
+				Expect(matches).To(BeFalse())
+			})
+		})
+
+		Context("when any condition does not hold", func() {
+			BeforeEach(func() {
+				config.Match = []atc.WebhookMatch{
+					{Path: ".ref", Patterns: []string{"refs/heads/master"}},
+					{Path: ".head_commit.id", Patterns: []string{"def*"}},
+				}
+			})
+
+			It("does not match", func() {
+				Expect(matches).To(BeFalse())
+			})
+		})
+
+		Context("when the path does not exist", func() {
+			BeforeEach(func() {
+				config.Match = []atc.WebhookMatch{
+					{Path: ".pull_request.number"},
+				}
+			})
+
+			It("does not match", func() {
+				Expect(matches).To(BeFalse())
+			})
+		})
+
+		Context("when some values are ignored", func() {
+			BeforeEach(func() {
+				config.Match = []atc.WebhookMatch{
+					{Path: ".commits[*].modified[*]", Ignore: []string{"docs/**"}},
+				}
+			})
+
+			It("matches since not every changed file is ignored", func() {
+				Expect(matches).To(BeTrue())
+			})
+
+			Context("when the remaining values are ignored too", func() {
+				BeforeEach(func() {
+					config.Match[0].Ignore = append(config.Match[0].Ignore, "*.md")
+				})
+
+				It("does not match", func() {
+					Expect(matches).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("Vars", func() {
+		var buildVars vars.StaticVariables
+
+		BeforeEach(func() {
+			config.Vars = map[string]string{
+				"branch":  ".ref",
+				"commit":  ".head_commit.id",
+				"changed": ".commits[*].modified[*]",
+				"missing": ".pull_request.number",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			buildVars, err = filter.Vars(payload)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("extracts the fields from the payload", func() {
+			Expect(buildVars).To(Equal(vars.StaticVariables{
+				"branch":  "refs/heads/master",
+				"commit":  "abc123",
+				"changed": []interface{}{"docs/index.md", "README.md", "docs/guide/setup.md"},
+			}))
+		})
+	})
+
+	Describe("NewFilter", func() {
+		It("errors on an invalid path", func() {
+			_, err := webhook.NewFilter(&atc.WebhookFilter{
+				Match: []atc.WebhookMatch{{Path: "{.ref"}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("match[0]: invalid path '{.ref'")))
+		})
+
+		It("errors on an invalid pattern", func() {
+			_, err := webhook.NewFilter(&atc.WebhookFilter{
+				Match: []atc.WebhookMatch{{Path: ".ref", Patterns: []string{"refs/["}}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("match[0]: invalid pattern")))
+		})
+
+		It("errors on an invalid var path", func() {
+			_, err := webhook.NewFilter(&atc.WebhookFilter{
+				Vars: map[string]string{"branch": "{.ref"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("vars.branch: invalid path '{.ref'")))
+		})
+	})
+})
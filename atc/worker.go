@@ -30,6 +30,18 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// Rootless workers run their containers without root privileges on the
+	// host, so they cannot run privileged containers.
+	Rootless bool `json:"rootless,omitempty"`
+
+	// RuntimeClasses are the OCI runtimes, besides the default one, that
+	// tasks can choose to run their containers with.
+	RuntimeClasses []string `json:"runtime_classes,omitempty"`
+
+	// NetworkPolicies is set by workers that restrict the egress traffic of
+	// containers according to the network policy of their task.
+	NetworkPolicies bool `json:"network_policies,omitempty"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
@@ -77,8 +77,9 @@ type client struct {
 }
 
 type TaskResult struct {
-	ExitStatus   int
-	VolumeMounts []VolumeMount
+	ExitStatus    int
+	VolumeMounts  []VolumeMount
+	ResourceUsage *atc.ResourceUsage
 }
 
 type CheckResult struct {
@@ -219,6 +220,8 @@ func (client *client) RunTaskStep(
 
 	logger.Info("attached")
 
+	stopSampling := sampleResourceUsage(logger, container)
+
 	exitStatusChan := make(chan processStatus)
 
 	go func() {
@@ -236,26 +239,32 @@ func (client *client) RunTaskStep(
 
 		status := <-exitStatusChan
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: stopSampling(),
 		}, ctx.Err()
 
 	case status := <-exitStatusChan:
+		usage := stopSampling()
+
 		if status.processErr != nil {
 			return TaskResult{
-				ExitStatus: status.processStatus,
+				ExitStatus:    status.processStatus,
+				ResourceUsage: usage,
 			}, status.processErr
 		}
 
 		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", status.processStatus))
 		if err != nil {
 			return TaskResult{
-				ExitStatus: status.processStatus,
+				ExitStatus:    status.processStatus,
+				ResourceUsage: usage,
 			}, err
 		}
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: usage,
 		}, err
 	}
 }
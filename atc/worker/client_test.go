@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -661,6 +662,73 @@ var _ = Describe("Client", func() {
 						Expect(value).To(Equal("0"))
 					})
 
+					Context("when the container reports metrics", func() {
+						BeforeEach(func() {
+							fakeContainer.MetricsReturns(garden.Metrics{
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+								CPUStat:    garden.ContainerCPUStat{Usage: uint64(1500 * time.Millisecond)},
+							}, nil)
+						})
+
+						It("returns the resource usage of the task", func() {
+							Expect(taskResult.ResourceUsage).To(Equal(&atc.ResourceUsage{
+								PeakMemoryBytes: 1024,
+								CPUSeconds:      1.5,
+							}))
+						})
+
+						Context("when the worker reports the memory high-water mark and disk I/O", func() {
+							BeforeEach(func() {
+								fakeContainer.PropertyStub = func(name string) (string, error) {
+									if name == "concourse:resource-usage" {
+										return `{"memory_peak_bytes":4096,"disk_read_bytes":10,"disk_write_bytes":20}`, nil
+									}
+									return "", errors.New("unhandled property")
+								}
+							})
+
+							It("includes them in the resource usage", func() {
+								Expect(taskResult.ResourceUsage).To(Equal(&atc.ResourceUsage{
+									PeakMemoryBytes: 4096,
+									CPUSeconds:      1.5,
+									DiskIO: &atc.DiskIOUsage{
+										ReadBytes:  10,
+										WriteBytes: 20,
+									},
+								}))
+							})
+						})
+
+						Context("when the reported memory high-water mark is lower than a sample", func() {
+							BeforeEach(func() {
+								fakeContainer.PropertyStub = func(name string) (string, error) {
+									if name == "concourse:resource-usage" {
+										return `{"memory_peak_bytes":512,"disk_read_bytes":10,"disk_write_bytes":20}`, nil
+									}
+									return "", errors.New("unhandled property")
+								}
+							})
+
+							It("keeps the sampled peak", func() {
+								Expect(taskResult.ResourceUsage.PeakMemoryBytes).To(Equal(uint64(1024)))
+							})
+						})
+					})
+
+					Context("when the container fails to report metrics", func() {
+						BeforeEach(func() {
+							fakeContainer.MetricsReturns(garden.Metrics{}, errors.New("nope"))
+						})
+
+						It("returns no resource usage", func() {
+							Expect(taskResult.ResourceUsage).To(BeNil())
+						})
+
+						It("returns successfully", func() {
+							Expect(err).ToNot(HaveOccurred())
+						})
+					})
+
 					Context("when saving the exit status succeeds", func() {
 						BeforeEach(func() {
 							fakeContainer.SetPropertyReturns(nil)
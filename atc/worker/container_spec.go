@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"go.opentelemetry.io/otel/propagation"
 )
@@ -14,6 +15,20 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// BuildID is the build the container is placed for. Its own containers
+	// and volumes don't count against the team's quotas.
+	BuildID int
+
+	// Privileged steps cannot be placed on rootless workers.
+	Privileged bool
+
+	// RuntimeClass restricts placement to workers offering the runtime class.
+	RuntimeClass string
+
+	// NetworkPolicy restricts placement to workers enforcing network
+	// policies.
+	NetworkPolicy bool
 }
 
 type ContainerSpec struct {
@@ -41,6 +56,13 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional OCI runtime class to run the container with instead of the
+	// worker's default runtime.
+	RuntimeClass string
+
+	// Optional network the container is restricted to.
+	Network *atc.NetworkConfig
 }
 
 // ContainerSpec must implement propagation.TextMapCarrier so that it can be
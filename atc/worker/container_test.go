@@ -102,6 +102,7 @@ var _ = Describe("RunScript", func() {
 			fakeDBTeamFactory,
 			fakeDBWorker,
 			fakeResourceCacheFactory,
+			nil,
 			0,
 		)
 
@@ -62,6 +62,7 @@ type dbWorkerProvider struct {
 	dbVolumeRepository                db.VolumeRepository
 	dbTeamFactory                     db.TeamFactory
 	dbWorkerFactory                   db.WorkerFactory
+	remoteCache                       RemoteCache
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
 	gardenRequestTimeout              time.Duration
@@ -80,6 +81,7 @@ func NewDBWorkerProvider(
 	dbVolumeRepository db.VolumeRepository,
 	dbTeamFactory db.TeamFactory,
 	workerFactory db.WorkerFactory,
+	remoteCache RemoteCache,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout, gardenRequestTimeout time.Duration,
 ) WorkerProvider {
@@ -96,6 +98,7 @@ func NewDBWorkerProvider(
 		dbVolumeRepository:                dbVolumeRepository,
 		dbTeamFactory:                     dbTeamFactory,
 		dbWorkerFactory:                   workerFactory,
+		remoteCache:                       remoteCache,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
 		gardenRequestTimeout:              gardenRequestTimeout,
@@ -261,6 +264,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, savedWork
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbTaskCacheFactory,
 		provider.dbWorkerTaskCacheFactory,
+		provider.remoteCache,
 	)
 
 	return NewGardenWorker(
@@ -272,6 +276,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, savedWork
 		provider.dbTeamFactory,
 		savedWorker,
 		provider.dbResourceCacheFactory,
+		provider.remoteCache,
 		buildContainersCount,
 	)
 }
@@ -178,6 +178,7 @@ var _ = Describe("DBProvider", func() {
 			fakeDBVolumeRepository,
 			fakeDBTeamFactory,
 			fakeDBWorkerFactory,
+			nil,
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
 			gardenRequestTimeout,
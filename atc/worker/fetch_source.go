@@ -5,8 +5,10 @@ package worker
 
 import (
 	"context"
+	"fmt"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
@@ -35,13 +37,16 @@ type FetchSourceFactory interface {
 
 type fetchSourceFactory struct {
 	resourceCacheFactory db.ResourceCacheFactory
+	remoteCache          RemoteCache
 }
 
 func NewFetchSourceFactory(
 	resourceCacheFactory db.ResourceCacheFactory,
+	remoteCache RemoteCache,
 ) FetchSourceFactory {
 	return &fetchSourceFactory{
 		resourceCacheFactory: resourceCacheFactory,
+		remoteCache:          remoteCache,
 	}
 }
 
@@ -65,6 +70,7 @@ func (r *fetchSourceFactory) NewFetchSource(
 		processSpec:            processSpec,
 		containerMetadata:      containerMetadata,
 		dbResourceCacheFactory: r.resourceCacheFactory,
+		remoteCache:            r.remoteCache,
 	}
 }
 
@@ -78,6 +84,7 @@ type fetchSource struct {
 	processSpec            runtime.ProcessSpec
 	containerMetadata      db.ContainerMetadata
 	dbResourceCacheFactory db.ResourceCacheFactory
+	remoteCache            RemoteCache
 }
 
 func (s *fetchSource) Find() (GetResult, Volume, bool, error) {
@@ -138,6 +145,26 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return findResult, volume, nil
 	}
 
+	if s.remoteCache != nil {
+		hydrated, err := s.hydrate(ctx)
+		if err != nil {
+			// the remote cache is best-effort; fall back to fetching the
+			// resource instead
+			sLog.Error("failed-to-hydrate-resource-cache", err)
+		}
+
+		if hydrated {
+			findResult, volume, found, err := s.Find()
+			if err != nil {
+				return GetResult{}, nil, err
+			}
+
+			if found {
+				return findResult, volume, nil
+			}
+		}
+	}
+
 	s.containerSpec.BindMounts = []BindMountSource{
 		&CertsVolumeMount{Logger: s.logger},
 	}
@@ -188,6 +215,13 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	if s.remoteCache != nil {
+		err = uploadVolume(ctx, s.remoteCache, ResourceCacheKey(s.cache), volume)
+		if err != nil {
+			sLog.Error("failed-to-upload-resource-cache", err)
+		}
+	}
+
 	return GetResult{
 		ExitStatus:    0,
 		VersionResult: vr,
@@ -197,6 +231,46 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 	}, volume, nil
 }
 
+// hydrate populates a new volume with the contents of the resource cache from
+// the remote cache and initializes it as the resource cache's volume on the
+// worker. It returns false if the remote cache does not have the contents.
+func (s *fetchSource) hydrate(ctx context.Context) (bool, error) {
+	in, found, err := s.remoteCache.Download(ctx, ResourceCacheKey(s.cache))
+	if err != nil {
+		return false, fmt.Errorf("download: %w", err)
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	defer in.Close()
+
+	volume, err := s.worker.CreateVolume(
+		s.logger,
+		VolumeSpec{Strategy: baggageclaim.EmptyStrategy{}},
+		s.containerSpec.TeamID,
+		db.VolumeTypeResource,
+	)
+	if err != nil {
+		return false, fmt.Errorf("create volume: %w", err)
+	}
+
+	err = volume.StreamIn(ctx, ".", baggageclaim.GzipEncoding, in)
+	if err != nil {
+		return false, fmt.Errorf("stream in: %w", err)
+	}
+
+	err = volume.InitializeResourceCache(s.cache)
+	if err != nil {
+		return false, fmt.Errorf("initialize resource cache: %w", err)
+	}
+
+	s.logger.Debug("hydrated-resource-cache", lager.Data{"volume": volume.Handle()})
+
+	return true, nil
+}
+
 func volumeWithFetchedBits(bitsDestinationPath string, container Container) Volume {
 	for _, mount := range container.VolumeMounts() {
 		if mount.MountPath == bitsDestinationPath {
@@ -3,10 +3,14 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -31,6 +35,7 @@ var _ = Describe("FetchSource", func() {
 		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
 		fakeUsedResourceCache    *dbfakes.FakeUsedResourceCache
 		fakeResource             *resourcefakes.FakeResource
+		remoteCache              worker.RemoteCache
 		metadata                 db.ContainerMetadata
 		owner                    db.ContainerOwner
 
@@ -39,7 +44,6 @@ var _ = Describe("FetchSource", func() {
 	)
 
 	BeforeEach(func() {
-		logger := lagertest.NewTestLogger("test")
 		fakeContainer = new(workerfakes.FakeContainer)
 
 		ctx, cancel = context.WithCancel(context.Background())
@@ -87,12 +91,18 @@ var _ = Describe("FetchSource", func() {
 			{Name: "some", Value: "metadata"},
 		}, nil)
 
+		remoteCache = nil
+	})
+
+	JustBeforeEach(func() {
+		logger := lagertest.NewTestLogger("test")
+
 		getProcessSpec := runtime.ProcessSpec{
 			Path: "/opt/resource/in",
 			Args: []string{resource.ResourcesDir("get")},
 		}
 
-		fetchSourceFactory = worker.NewFetchSourceFactory(fakeResourceCacheFactory)
+		fetchSourceFactory = worker.NewFetchSourceFactory(fakeResourceCacheFactory, remoteCache)
 		fetchSource = fetchSourceFactory.NewFetchSource(
 			logger,
 			fakeWorker,
@@ -264,6 +274,118 @@ var _ = Describe("FetchSource", func() {
 				Expect(getResult.GetArtifact.VolumeHandle).To(Equal(fakeVolume.Handle()))
 				Expect(volume).ToNot(BeNil())
 			})
+
+			Context("when a remote cache is configured", func() {
+				var fakeRemoteCache *workerfakes.FakeRemoteCache
+
+				BeforeEach(func() {
+					fakeRemoteCache = new(workerfakes.FakeRemoteCache)
+					remoteCache = fakeRemoteCache
+				})
+
+				Context("when the remote cache has the resource cache", func() {
+					var hydratedVolume *workerfakes.FakeVolume
+
+					BeforeEach(func() {
+						fakeRemoteCache.DownloadReturns(ioutil.NopCloser(strings.NewReader("some-contents")), true, nil)
+
+						hydratedVolume = new(workerfakes.FakeVolume)
+						hydratedVolume.HandleReturns("hydrated-handle")
+						fakeWorker.CreateVolumeReturns(hydratedVolume, nil)
+
+						fakeWorker.FindVolumeForResourceCacheReturnsOnCall(1, hydratedVolume, true, nil)
+					})
+
+					It("downloads the resource cache", func() {
+						Expect(fakeRemoteCache.DownloadCallCount()).To(Equal(1))
+						_, key := fakeRemoteCache.DownloadArgsForCall(0)
+						Expect(key).To(Equal("resource-caches/42"))
+					})
+
+					It("streams the contents into a new volume", func() {
+						Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+						_, spec, teamID, volumeType := fakeWorker.CreateVolumeArgsForCall(0)
+						Expect(spec).To(Equal(worker.VolumeSpec{Strategy: baggageclaim.EmptyStrategy{}}))
+						Expect(teamID).To(Equal(42))
+						Expect(volumeType).To(Equal(db.VolumeTypeResource))
+
+						Expect(hydratedVolume.StreamInCallCount()).To(Equal(1))
+						_, path, encoding, reader := hydratedVolume.StreamInArgsForCall(0)
+						Expect(path).To(Equal("."))
+						Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+						Expect(ioutil.ReadAll(reader)).To(Equal([]byte("some-contents")))
+					})
+
+					It("initializes the new volume as the resource cache", func() {
+						Expect(hydratedVolume.InitializeResourceCacheCallCount()).To(Equal(1))
+						Expect(hydratedVolume.InitializeResourceCacheArgsForCall(0)).To(Equal(fakeUsedResourceCache))
+					})
+
+					It("does not fetch the resource", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(0))
+						Expect(fakeResource.GetCallCount()).To(Equal(0))
+					})
+
+					It("returns the hydrated volume", func() {
+						Expect(getResult.GetArtifact.VolumeHandle).To(Equal("hydrated-handle"))
+						Expect(volume).To(Equal(hydratedVolume))
+					})
+				})
+
+				Context("when the remote cache does not have the resource cache", func() {
+					BeforeEach(func() {
+						fakeRemoteCache.DownloadReturns(nil, false, nil)
+						fakeVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("fetched-bits")), nil)
+						fakeRemoteCache.UploadStub = func(_ context.Context, key string, contents io.Reader) error {
+							defer GinkgoRecover()
+
+							Expect(key).To(Equal("resource-caches/42"))
+							Expect(ioutil.ReadAll(contents)).To(Equal([]byte("fetched-bits")))
+							return nil
+						}
+					})
+
+					It("fetches the resource", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(0))
+						Expect(fakeResource.GetCallCount()).To(Equal(1))
+					})
+
+					It("uploads the fetched bits", func() {
+						Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+						_, path, encoding := fakeVolume.StreamOutArgsForCall(0)
+						Expect(path).To(Equal("."))
+						Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+						Expect(fakeRemoteCache.UploadCallCount()).To(Equal(1))
+					})
+
+					Context("when uploading fails", func() {
+						BeforeEach(func() {
+							fakeRemoteCache.UploadStub = nil
+							fakeRemoteCache.UploadReturns(errors.New("nope"))
+						})
+
+						It("still succeeds", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(getResult.GetArtifact.VolumeHandle).To(Equal(fakeVolume.Handle()))
+						})
+					})
+				})
+
+				Context("when downloading from the remote cache fails", func() {
+					BeforeEach(func() {
+						fakeRemoteCache.DownloadReturns(nil, false, errors.New("nope"))
+						fakeVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("fetched-bits")), nil)
+					})
+
+					It("falls back to fetching the resource", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeResource.GetCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 	})
 })
@@ -59,6 +59,7 @@ type Pool interface {
 //counterfeiter:generate . PoolCallbacks
 type PoolCallbacks interface {
 	WaitingForWorker(lager.Logger)
+	WaitingForTeamQuota(lager.Logger, string)
 }
 
 //counterfeiter:generate . VolumeFinder
@@ -67,14 +68,16 @@ type VolumeFinder interface {
 }
 
 type pool struct {
-	provider WorkerProvider
-	waker    chan bool
+	provider    WorkerProvider
+	teamFactory db.TeamFactory
+	waker       chan bool
 }
 
-func NewPool(provider WorkerProvider) Pool {
+func NewPool(provider WorkerProvider, teamFactory db.TeamFactory) Pool {
 	return &pool{
-		provider: provider,
-		waker:    make(chan bool),
+		provider:    provider,
+		teamFactory: teamFactory,
+		waker:       make(chan bool),
 	}
 }
 
@@ -169,22 +172,48 @@ func (pool *pool) findWorkerFromStrategy(
 	return nil, nil
 }
 
+// teamQuotaReached returns a description of the team's quota which prevents
+// any new containers from being placed for the build, or an empty string if
+// the team is within its quotas. The build's own containers and volumes don't
+// count against the quotas.
+func (pool *pool) teamQuotaReached(teamID int, buildID int) (string, error) {
+	if teamID == 0 {
+		return "", nil
+	}
+
+	usage, err := pool.teamFactory.GetByID(teamID).Usage(buildID)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case usage.ContainersQuotaReached():
+		return fmt.Sprintf("max %d containers", usage.Quotas.MaxContainers), nil
+	case usage.VolumesQuotaReached():
+		return fmt.Sprintf("max %d volumes", usage.Quotas.MaxVolumes), nil
+	default:
+		return "", nil
+	}
+}
+
+// findWorker returns the worker to place the container on. If no worker was
+// found because the team has reached a quota, the quota is returned instead.
 func (pool *pool) findWorker(
 	ctx context.Context,
 	containerOwner db.ContainerOwner,
 	containerSpec ContainerSpec,
 	workerSpec WorkerSpec,
 	strategy ContainerPlacementStrategy,
-) (Client, error) {
+) (Client, string, error) {
 	logger := lagerctx.FromContext(ctx)
 
 	compatibleWorkers, err := pool.allSatisfying(logger, workerSpec)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if len(compatibleWorkers) == 0 {
-		return nil, nil
+		return nil, "", nil
 	}
 
 	worker, err := pool.findWorkerWithContainer(
@@ -193,10 +222,22 @@ func (pool *pool) findWorker(
 		containerOwner,
 	)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if worker == nil {
+		// a container which already exists does not count against the team's
+		// quotas again
+		quota, err := pool.teamQuotaReached(workerSpec.TeamID, workerSpec.BuildID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if quota != "" {
+			logger.Debug("team-quota-reached", lager.Data{"quota": quota})
+			return nil, quota, nil
+		}
+
 		worker, err = pool.findWorkerFromStrategy(
 			logger,
 			compatibleWorkers,
@@ -204,15 +245,15 @@ func (pool *pool) findWorker(
 			strategy,
 		)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 
 	if worker == nil {
-		return nil, nil
+		return nil, "", nil
 	}
 
-	return NewClient(worker), nil
+	return NewClient(worker), "", nil
 }
 
 func (pool *pool) FindContainer(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
@@ -287,9 +328,11 @@ func (pool *pool) SelectWorker(
 
 	var worker Client
 	var pollingTicker *time.Ticker
+	var waitingFor string
 	for {
+		var quota string
 		var err error
-		worker, err = pool.findWorker(ctx, owner, containerSpec, workerSpec, strategy)
+		worker, quota, err = pool.findWorker(ctx, owner, containerSpec, workerSpec, strategy)
 
 		if err != nil {
 			return nil, 0, err
@@ -312,8 +355,21 @@ func (pool *pool) SelectWorker(
 
 			metric.Metrics.StepsWaiting[labels].Inc()
 			defer metric.Metrics.StepsWaiting[labels].Dec()
+		}
+
+		// only notify when the reason for waiting changes, e.g. when the
+		// team is back within its quota but there is no worker available
+		reason := "worker"
+		if quota != "" {
+			reason = "quota: " + quota
+		}
 
-			if callbacks != nil {
+		if callbacks != nil && reason != waitingFor {
+			waitingFor = reason
+
+			if quota != "" {
+				callbacks.WaitingForTeamQuota(logger, quota)
+			} else {
 				callbacks.WaitingForWorker(logger)
 			}
 		}
@@ -24,6 +24,9 @@ var _ = Describe("Pool", func() {
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
 
+		fakeTeam        *dbfakes.FakeTeam
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+
 		pool Pool
 	)
 
@@ -31,7 +34,11 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		pool = NewPool(fakeProvider, fakeTeamFactory)
 	})
 
 	Describe("FindContainer", func() {
@@ -472,6 +479,20 @@ var _ = Describe("Pool", func() {
 						})
 					})
 
+					Context("when getting the team's usage fails", func() {
+						var usageErr error
+
+						BeforeEach(func() {
+							usageErr = errors.New("usage explosion")
+							fakeTeam.UsageReturns(db.TeamUsage{}, usageErr)
+						})
+
+						It("returns an error", func() {
+							Expect(selectErr).To(Equal(usageErr))
+							Expect(fakeStrategy.OrderCallCount()).To(BeZero())
+						})
+					})
+
 					Context("when strategy returns a worker", func() {
 						BeforeEach(func() {
 							fakeStrategy.OrderReturns([]Worker{workers[0]}, nil)
@@ -484,6 +505,32 @@ var _ = Describe("Pool", func() {
 						})
 					})
 
+					Context("when the build needs more containers than the team's quota allows", func() {
+						BeforeEach(func() {
+							workerSpec.BuildID = 42
+
+							// the build's own containers fill the quota
+							fakeTeam.UsageStub = func(buildID int) (db.TeamUsage, error) {
+								usage := db.TeamUsage{Quotas: atc.TeamQuotas{MaxContainers: 3}}
+								if buildID != 42 {
+									usage.Containers = 5
+								}
+
+								return usage, nil
+							}
+
+							fakeStrategy.OrderReturns([]Worker{workers[0]}, nil)
+						})
+
+						It("does not count the build's own containers", func() {
+							Expect(fakeTeam.UsageCallCount()).To(Equal(1))
+							Expect(fakeTeam.UsageArgsForCall(0)).To(Equal(42))
+
+							Expect(selectErr).ToNot(HaveOccurred())
+							Expect(selectedWorker.Name()).To(Equal(workers[0].Name()))
+						})
+					})
+
 					Context("when strategy returns multiple workers", func() {
 						BeforeEach(func() {
 							fakeStrategy.OrderCalls(func(_ lager.Logger, workers []Worker, _ ContainerSpec) ([]Worker, error) {
@@ -577,6 +624,35 @@ var _ = Describe("Pool", func() {
 					Expect(workerFakes[0].SatisfiesCallCount()).To(Equal(2))
 				})
 			})
+
+			Context("when the team has reached its containers quota", func() {
+				BeforeEach(func() {
+					workerFakes[0].SatisfiesReturns(true)
+					fakeProvider.RunningWorkersReturns(workers, nil)
+
+					fakeTeam.UsageReturns(db.TeamUsage{
+						Quotas:     atc.TeamQuotas{MaxContainers: 3},
+						Containers: 3,
+					}, nil)
+				})
+
+				It("times out and returns an error", func() {
+					Expect(selectErr).To(Equal(selectCtx.Err()))
+					Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(4567))
+				})
+
+				It("does not place the container", func() {
+					Expect(fakeStrategy.OrderCallCount()).To(BeZero())
+				})
+
+				It("notifies that it is waiting for the team's quota once", func() {
+					Expect(fakeCallbacks.WaitingForTeamQuotaCallCount()).To(Equal(1))
+					_, quota := fakeCallbacks.WaitingForTeamQuotaArgsForCall(0)
+					Expect(quota).To(Equal("max 3 containers"))
+
+					Expect(fakeCallbacks.WaitingForWorkerCallCount()).To(BeZero())
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,260 @@
+package worker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//counterfeiter:generate . RemoteCache
+
+// RemoteCache stores the contents of task caches and resource caches in a
+// blob store, so that a worker which does not have a cache volume can hydrate
+// it rather than starting cold.
+//
+// The contents are stored as gzipped tarballs.
+type RemoteCache interface {
+	// Upload stores the contents under the given key, replacing any existing
+	// blob.
+	Upload(ctx context.Context, key string, contents io.Reader) error
+
+	// Download returns the contents stored under the given key. It returns
+	// false if nothing has been stored under the key.
+	Download(ctx context.Context, key string) (io.ReadCloser, bool, error)
+
+	// List returns the keys of all blobs stored under the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Delete removes the blob stored under the given key. Deleting a key
+	// that nothing has been stored under is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	// TaskCachesPrefix is the prefix of the keys of task caches.
+	TaskCachesPrefix = "task-caches/"
+
+	// ResourceCachesPrefix is the prefix of the keys of resource caches.
+	ResourceCachesPrefix = "resource-caches/"
+
+	// DigestsPrefix is the prefix of the keys of the digests of uploaded
+	// task caches, which are stored next to them as <prefix><key>.
+	DigestsPrefix = "digests/"
+)
+
+// TaskCacheKey returns the key under which the contents of a task cache are
+// stored.
+func TaskCacheKey(jobID int, stepName string, path string) string {
+	return fmt.Sprintf(TaskCachesPrefix+"%d/%s/%s", jobID, url.PathEscape(stepName), url.PathEscape(path))
+}
+
+// ParseTaskCacheKey returns the task cache that the contents under the key
+// belong to. It returns false if the key is not the key of a task cache.
+func ParseTaskCacheKey(key string) (int, string, string, bool) {
+	if !strings.HasPrefix(key, TaskCachesPrefix) {
+		return 0, "", "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(key, TaskCachesPrefix), "/")
+	if len(parts) != 3 {
+		return 0, "", "", false
+	}
+
+	jobID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	stepName, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	path, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	return jobID, stepName, path, true
+}
+
+// ResourceCacheKey returns the key under which the contents of a resource
+// cache are stored.
+func ResourceCacheKey(resourceCache db.UsedResourceCache) string {
+	return fmt.Sprintf(ResourceCachesPrefix+"%d", resourceCache.ID())
+}
+
+// ParseResourceCacheKey returns the ID of the resource cache that the
+// contents under the key belong to. It returns false if the key is not the
+// key of a resource cache.
+func ParseResourceCacheKey(key string) (int, bool) {
+	if !strings.HasPrefix(key, ResourceCachesPrefix) {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(key, ResourceCachesPrefix))
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
+func uploadVolume(ctx context.Context, remoteCache RemoteCache, key string, volume Volume) error {
+	out, err := volume.StreamOut(ctx, ".", baggageclaim.GzipEncoding)
+	if err != nil {
+		return fmt.Errorf("stream out: %w", err)
+	}
+
+	defer out.Close()
+
+	err = remoteCache.Upload(ctx, key, out)
+	if err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+
+	return nil
+}
+
+// uploadDigestedVolume uploads the contents of the volume along with their
+// digest. The contents are digested as they are uploaded, so that the volume
+// is only streamed out once. It returns false if the contents were the same
+// as the contents that were last uploaded under the key, in which case the
+// digest is left as is.
+func uploadDigestedVolume(ctx context.Context, remoteCache RemoteCache, key string, volume Volume) (bool, error) {
+	out, err := volume.StreamOut(ctx, ".", baggageclaim.GzipEncoding)
+	if err != nil {
+		return false, fmt.Errorf("stream out: %w", err)
+	}
+
+	defer out.Close()
+
+	digestReader, digestWriter := io.Pipe()
+
+	var digest string
+	digestErr := make(chan error, 1)
+	go func() {
+		var err error
+		digest, err = tarballDigest(digestReader)
+
+		// keep the upload going past the end of the archive
+		_, _ = io.Copy(ioutil.Discard, digestReader)
+
+		digestErr <- err
+	}()
+
+	err = remoteCache.Upload(ctx, key, io.TeeReader(out, digestWriter))
+	digestWriter.CloseWithError(err)
+	if err != nil {
+		return false, fmt.Errorf("upload: %w", err)
+	}
+
+	err = <-digestErr
+	if err != nil {
+		return false, fmt.Errorf("digest: %w", err)
+	}
+
+	uploadedDigest, found, err := remoteCache.Download(ctx, DigestsPrefix+key)
+	if err != nil {
+		return false, fmt.Errorf("download digest: %w", err)
+	}
+
+	if found {
+		payload, err := ioutil.ReadAll(uploadedDigest)
+		uploadedDigest.Close()
+		if err != nil {
+			return false, fmt.Errorf("read digest: %w", err)
+		}
+
+		if string(payload) == digest {
+			return false, nil
+		}
+	}
+
+	err = remoteCache.Upload(ctx, DigestsPrefix+key, strings.NewReader(digest))
+	if err != nil {
+		return false, fmt.Errorf("upload digest: %w", err)
+	}
+
+	return true, nil
+}
+
+// tarballDigest returns a digest of the files in the gzipped tarball. The
+// tarball is not digested as is, as neither gzip nor the order of its entries
+// are guaranteed to be the same for the same files.
+func tarballDigest(tarball io.Reader) (string, error) {
+	gzipReader, err := gzip.NewReader(tarball)
+	if err != nil {
+		return "", err
+	}
+
+	fileDigests := map[string]string{}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%c %o %d %s\x00", header.Typeflag, header.Mode, header.ModTime.Unix(), header.Linkname)
+
+		_, err = io.Copy(hash, tarReader)
+		if err != nil {
+			return "", err
+		}
+
+		fileDigests[header.Name] = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	names := make([]string, 0, len(fileDigests))
+	for name := range fileDigests {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00%s\n", name, fileDigests[name])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hydrateVolume(ctx context.Context, remoteCache RemoteCache, key string, volume Volume) (bool, error) {
+	in, found, err := remoteCache.Download(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("download: %w", err)
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	defer in.Close()
+
+	err = volume.StreamIn(ctx, ".", baggageclaim.GzipEncoding, in)
+	if err != nil {
+		return false, fmt.Errorf("stream in: %w", err)
+	}
+
+	return true, nil
+}
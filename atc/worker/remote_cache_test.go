@@ -0,0 +1,253 @@
+package worker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteCache", func() {
+	Describe("TaskCacheKey", func() {
+		It("can be parsed back", func() {
+			key := worker.TaskCacheKey(18, "some/task", "/some/work-dir/cache")
+			Expect(key).To(Equal("task-caches/18/some%2Ftask/%2Fsome%2Fwork-dir%2Fcache"))
+
+			jobID, stepName, path, ok := worker.ParseTaskCacheKey(key)
+			Expect(ok).To(BeTrue())
+			Expect(jobID).To(Equal(18))
+			Expect(stepName).To(Equal("some/task"))
+			Expect(path).To(Equal("/some/work-dir/cache"))
+		})
+
+		It("does not parse other keys", func() {
+			for _, key := range []string{
+				"resource-caches/1",
+				"digests/task-caches/18/some-task/some-path",
+				"task-caches/18/some-task",
+				"task-caches/not-a-job/some-task/some-path",
+			} {
+				_, _, _, ok := worker.ParseTaskCacheKey(key)
+				Expect(ok).To(BeFalse(), key)
+			}
+		})
+	})
+
+	Describe("ResourceCacheKey", func() {
+		It("can be parsed back", func() {
+			fakeResourceCache := new(dbfakes.FakeUsedResourceCache)
+			fakeResourceCache.IDReturns(42)
+
+			key := worker.ResourceCacheKey(fakeResourceCache)
+			Expect(key).To(Equal("resource-caches/42"))
+
+			id, ok := worker.ParseResourceCacheKey(key)
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(42))
+		})
+
+		It("does not parse other keys", func() {
+			_, ok := worker.ParseResourceCacheKey("task-caches/18/some-task/some-path")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("initializing a task cache", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+
+			fakeRemoteCache        *workerfakes.FakeRemoteCache
+			fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+			fakeCreatedVolume      *dbfakes.FakeCreatedVolume
+
+			cacheContents []byte
+			blobs         map[string][]byte
+			uploaded      chan string
+
+			logger *lagertest.TestLogger
+			volume worker.Volume
+
+			initErr error
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+
+			blobs = map[string][]byte{}
+			uploaded = make(chan string, 10)
+
+			fakeRemoteCache = new(workerfakes.FakeRemoteCache)
+			fakeRemoteCache.UploadStub = func(_ context.Context, key string, contents io.Reader) error {
+				payload, err := ioutil.ReadAll(contents)
+				if err != nil {
+					return err
+				}
+
+				blobs[key] = payload
+				uploaded <- key
+				return nil
+			}
+			fakeRemoteCache.DownloadStub = func(_ context.Context, key string) (io.ReadCloser, bool, error) {
+				payload, found := blobs[key]
+				if !found {
+					return nil, false, nil
+				}
+
+				return ioutil.NopCloser(bytes.NewReader(payload)), true, nil
+			}
+
+			cacheContents = tarGzContent(file{name: "some-file", content: []byte("some-content")})
+
+			fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+			fakeBaggageclaimVolume.StreamOutStub = func(context.Context, string, baggageclaim.Encoding) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(cacheContents)), nil
+			}
+
+			fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		JustBeforeEach(func() {
+			fakeBaggageclaimClient := new(baggageclaimfakes.FakeClient)
+			fakeBaggageclaimClient.LookupVolumeReturns(fakeBaggageclaimVolume, true, nil)
+
+			fakeDBVolumeRepository := new(dbfakes.FakeVolumeRepository)
+			fakeDBVolumeRepository.FindContainerVolumeReturns(nil, fakeCreatedVolume, nil)
+
+			volumeClient := worker.NewVolumeClient(
+				fakeBaggageclaimClient,
+				new(dbfakes.FakeWorker),
+				fakeclock.NewFakeClock(time.Now()),
+				new(lockfakes.FakeLockFactory),
+				fakeDBVolumeRepository,
+				new(dbfakes.FakeWorkerBaseResourceTypeFactory),
+				new(dbfakes.FakeTaskCacheFactory),
+				new(dbfakes.FakeWorkerTaskCacheFactory),
+				fakeRemoteCache,
+			)
+
+			logger = lagertest.NewTestLogger("test")
+
+			var err error
+			volume, err = volumeClient.FindOrCreateVolumeForContainer(
+				logger,
+				worker.VolumeSpec{Strategy: baggageclaim.EmptyStrategy{}},
+				new(dbfakes.FakeCreatingContainer),
+				42,
+				"/some/work-dir/cache",
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			initErr = volume.InitializeTaskCache(ctx, logger, 18, "some-task", "/some/work-dir/cache", false)
+		})
+
+		It("initializes the task cache", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+			Expect(fakeCreatedVolume.InitializeTaskCacheCallCount()).To(Equal(1))
+		})
+
+		It("uploads the cache and its digest in the background", func() {
+			key := "task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache"
+
+			Eventually(uploaded).Should(Receive(Equal(key)))
+			Eventually(uploaded).Should(Receive(Equal("digests/" + key)))
+
+			Expect(blobs[key]).To(Equal(cacheContents))
+			Expect(blobs["digests/"+key]).ToNot(BeEmpty())
+		})
+
+		It("streams the cache out once", func() {
+			Eventually(uploaded).Should(Receive())
+			Eventually(uploaded).Should(Receive())
+
+			Expect(fakeBaggageclaimVolume.StreamOutCallCount()).To(Equal(1))
+		})
+
+		Context("when the cache has not changed since it was last uploaded", func() {
+			JustBeforeEach(func() {
+				key := "task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache"
+				Eventually(uploaded).Should(Receive(Equal(key)))
+				Eventually(uploaded).Should(Receive(Equal("digests/" + key)))
+
+				// the digest doesn't depend on the compression, so compressing
+				// the same files again must not count as a change
+				cacheContents = tarGzContent(file{name: "some-file", content: []byte("some-content")})
+
+				err := volume.InitializeTaskCache(ctx, logger, 18, "some-task", "/some/work-dir/cache", false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves its digest as is", func() {
+				Eventually(uploaded).Should(Receive(Equal("task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache")))
+				Eventually(fakeRemoteCache.DownloadCallCount).Should(Equal(2))
+				Consistently(uploaded).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the cache has changed since it was last uploaded", func() {
+			BeforeEach(func() {
+				blobs["digests/task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache"] = []byte("some-other-digest")
+			})
+
+			It("uploads it along with its new digest", func() {
+				Eventually(uploaded).Should(Receive(Equal("task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache")))
+				Eventually(uploaded).Should(Receive(Equal("digests/task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache")))
+
+				Expect(blobs["digests/task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache"]).ToNot(Equal([]byte("some-other-digest")))
+			})
+		})
+
+		Context("when the build is aborted", func() {
+			var streamCanceled chan struct{}
+
+			BeforeEach(func() {
+				streamCanceled = make(chan struct{})
+
+				fakeBaggageclaimVolume.StreamOutStub = func(ctx context.Context, _ string, _ baggageclaim.Encoding) (io.ReadCloser, error) {
+					<-ctx.Done()
+					close(streamCanceled)
+					return nil, ctx.Err()
+				}
+			})
+
+			It("cancels the upload", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+
+				cancel()
+
+				Eventually(streamCanceled).Should(BeClosed())
+				Consistently(fakeRemoteCache.UploadCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the upload fails", func() {
+			BeforeEach(func() {
+				fakeRemoteCache.UploadReturns(errors.New("nope"))
+				fakeRemoteCache.UploadStub = nil
+			})
+
+			It("still initializes the task cache", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+				Eventually(fakeRemoteCache.UploadCallCount).Should(Equal(1))
+				Consistently(fakeRemoteCache.UploadCallCount).Should(Equal(1))
+			})
+		})
+	})
+})
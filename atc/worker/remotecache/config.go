@@ -0,0 +1,32 @@
+package remotecache
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// Config selects the blob store backing the remote cache tier, or the build
+// event archive. At most one backend may be configured; if none are, the
+// store is disabled.
+type Config struct {
+	S3         S3
+	Filesystem Filesystem
+}
+
+// RemoteCache returns the configured remote cache, or nil if no backend has
+// been configured.
+func (c Config) RemoteCache() (worker.RemoteCache, error) {
+	if c.S3.IsConfigured() && c.Filesystem.IsConfigured() {
+		return nil, fmt.Errorf("only one remote cache backend may be configured")
+	}
+
+	switch {
+	case c.S3.IsConfigured():
+		return c.S3.RemoteCache()
+	case c.Filesystem.IsConfigured():
+		return c.Filesystem.RemoteCache()
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,39 @@
+package remotecache_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/concourse/atc/worker/remotecache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	It("is disabled when nothing is configured", func() {
+		cache, err := remotecache.Config{}.RemoteCache()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cache).To(BeNil())
+	})
+
+	It("uses the configured backend", func() {
+		dir, err := ioutil.TempDir("", "remote-cache")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		cache, err := remotecache.Config{
+			Filesystem: remotecache.Filesystem{Path: dir},
+		}.RemoteCache()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cache).ToNot(BeNil())
+	})
+
+	It("errors when more than one backend is configured", func() {
+		_, err := remotecache.Config{
+			S3:         remotecache.S3{Bucket: "some-bucket"},
+			Filesystem: remotecache.Filesystem{Path: "/some/path"},
+		}.RemoteCache()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,122 @@
+package remotecache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// Filesystem stores blobs in a directory, which would typically be a
+// network filesystem (e.g. NFS) mounted on every web node.
+type Filesystem struct {
+	Path string `long:"filesystem-path" description:"Directory to store blobs in, e.g. an NFS mount shared by all web nodes."`
+}
+
+// IsConfigured identifies if a path has been set
+func (f Filesystem) IsConfigured() bool {
+	return f.Path != ""
+}
+
+// RemoteCache returns a remote cache backed by the directory
+func (f Filesystem) RemoteCache() (worker.RemoteCache, error) {
+	err := os.MkdirAll(f.Path, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("create remote cache directory: %w", err)
+	}
+
+	return filesystemCache{path: f.Path}, nil
+}
+
+type filesystemCache struct {
+	path string
+}
+
+func (cache filesystemCache) Upload(ctx context.Context, key string, contents io.Reader) error {
+	dest := filepath.Join(cache.path, filepath.FromSlash(key))
+
+	err := os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a concurrent download never
+	// sees a partially written blob
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), ".upload-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, contents)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dest)
+}
+
+func (cache filesystemCache) Download(ctx context.Context, key string) (io.ReadCloser, bool, error) {
+	file, err := os.Open(filepath.Join(cache.path, filepath.FromSlash(key)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (cache filesystemCache) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(cache.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// skip directories and uploads which are still in progress
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(cache.path, path)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (cache filesystemCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(cache.path, filepath.FromSlash(key)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package remotecache_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/remotecache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filesystem", func() {
+	var (
+		dir   string
+		cache worker.RemoteCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "remote-cache")
+		Expect(err).ToNot(HaveOccurred())
+
+		cache, err = remotecache.Filesystem{Path: filepath.Join(dir, "blobs")}.RemoteCache()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("downloads what was uploaded", func() {
+		err := cache.Upload(context.Background(), "task-caches/1/unit/some-path", strings.NewReader("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, found, err := cache.Download(context.Background(), "task-caches/1/unit/some-path")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer contents.Close()
+		Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-contents")))
+	})
+
+	It("replaces existing blobs", func() {
+		Expect(cache.Upload(context.Background(), "some-key", strings.NewReader("old"))).To(Succeed())
+		Expect(cache.Upload(context.Background(), "some-key", strings.NewReader("new"))).To(Succeed())
+
+		contents, found, err := cache.Download(context.Background(), "some-key")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer contents.Close()
+		Expect(ioutil.ReadAll(contents)).To(Equal([]byte("new")))
+
+		entries, err := ioutil.ReadDir(filepath.Join(dir, "blobs"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("returns false when nothing was uploaded", func() {
+		_, found, err := cache.Download(context.Background(), "missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("lists the keys under a prefix", func() {
+		Expect(cache.Upload(context.Background(), "task-caches/1/unit/some-path", strings.NewReader("a"))).To(Succeed())
+		Expect(cache.Upload(context.Background(), "task-caches/2/unit/some-path", strings.NewReader("b"))).To(Succeed())
+		Expect(cache.Upload(context.Background(), "resource-caches/1", strings.NewReader("c"))).To(Succeed())
+
+		keys, err := cache.List(context.Background(), "task-caches/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(ConsistOf("task-caches/1/unit/some-path", "task-caches/2/unit/some-path"))
+	})
+
+	It("deletes blobs", func() {
+		Expect(cache.Upload(context.Background(), "some-key", strings.NewReader("some-contents"))).To(Succeed())
+		Expect(cache.Delete(context.Background(), "some-key")).To(Succeed())
+
+		_, found, err := cache.Download(context.Background(), "some-key")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("does not fail to delete a blob which does not exist", func() {
+		Expect(cache.Delete(context.Background(), "missing")).To(Succeed())
+	})
+})
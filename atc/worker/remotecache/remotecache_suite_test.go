@@ -0,0 +1,13 @@
+package remotecache_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRemoteCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Remote Cache Suite")
+}
@@ -0,0 +1,133 @@
+package remotecache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// S3 stores blobs in an S3-compatible bucket. Setting an endpoint
+// allows for other S3-compatible stores, such as MinIO.
+type S3 struct {
+	Bucket          string `long:"s3-bucket" description:"Bucket to store blobs in."`
+	Prefix          string `long:"s3-prefix" description:"Prefix to prepend to the key of every blob."`
+	Region          string `long:"s3-region" description:"AWS region of the bucket."`
+	Endpoint        string `long:"s3-endpoint" description:"URL of an S3-compatible API, e.g. a MinIO server."`
+	ForcePathStyle  bool   `long:"s3-force-path-style" description:"Address the bucket as part of the path rather than the host. Typically required by S3-compatible stores."`
+	AccessKeyID     string `long:"s3-access-key-id" description:"AWS access key ID. If not set, credentials are obtained from the environment."`
+	SecretAccessKey string `long:"s3-secret-access-key" description:"AWS secret access key."`
+	SessionToken    string `long:"s3-session-token" description:"AWS session token."`
+}
+
+// IsConfigured identifies if a bucket has been set
+func (s S3) IsConfigured() bool {
+	return s.Bucket != ""
+}
+
+// RemoteCache returns a remote cache backed by the bucket
+func (s S3) RemoteCache() (worker.RemoteCache, error) {
+	config := &aws.Config{
+		S3ForcePathStyle: aws.Bool(s.ForcePathStyle),
+	}
+
+	if s.Region != "" {
+		config.Region = aws.String(s.Region)
+	}
+
+	if s.Endpoint != "" {
+		config.Endpoint = aws.String(s.Endpoint)
+	}
+
+	if s.AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(s.AccessKeyID, s.SecretAccessKey, s.SessionToken)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create aws session: %w", err)
+	}
+
+	return s3Cache{
+		bucket:   s.Bucket,
+		prefix:   s.Prefix,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+type s3Cache struct {
+	bucket string
+	prefix string
+
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func (cache s3Cache) Upload(ctx context.Context, key string, contents io.Reader) error {
+	_, err := cache.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(cache.bucket),
+		Key:    aws.String(path.Join(cache.prefix, key)),
+		Body:   contents,
+	})
+	return err
+}
+
+func (cache s3Cache) Download(ctx context.Context, key string) (io.ReadCloser, bool, error) {
+	output, err := cache.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(cache.bucket),
+		Key:    aws.String(path.Join(cache.prefix, key)),
+	})
+	if err != nil {
+		if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusNotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return output.Body, true, nil
+}
+
+func (cache s3Cache) List(ctx context.Context, prefix string) ([]string, error) {
+	// not using path.Join, as it would drop the trailing slash of the prefix
+	var keyPrefix string
+	if cache.prefix != "" {
+		keyPrefix = strings.TrimSuffix(cache.prefix, "/") + "/"
+	}
+
+	var keys []string
+	err := cache.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(cache.bucket),
+		Prefix: aws.String(keyPrefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.StringValue(object.Key), keyPrefix))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (cache s3Cache) Delete(ctx context.Context, key string) error {
+	_, err := cache.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(cache.bucket),
+		Key:    aws.String(path.Join(cache.prefix, key)),
+	})
+	return err
+}
@@ -0,0 +1,132 @@
+package remotecache_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/remotecache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		server *httptest.Server
+		blobs  map[string][]byte
+		cache  worker.RemoteCache
+	)
+
+	BeforeEach(func() {
+		var lock sync.Mutex
+		blobs = map[string][]byte{}
+
+		// a minimal stand-in for an S3-compatible store such as MinIO
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			switch r.Method {
+			case http.MethodPut:
+				payload, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				blobs[r.URL.Path] = payload
+			case http.MethodGet:
+				if r.URL.Query().Get("list-type") == "2" {
+					prefix := "/some-bucket/" + r.URL.Query().Get("prefix")
+
+					var contents string
+					for name := range blobs {
+						if strings.HasPrefix(name, prefix) {
+							contents += "<Contents><Key>" + strings.TrimPrefix(name, "/some-bucket/") + "</Key></Contents>"
+						}
+					}
+
+					w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult>` + contents + `<IsTruncated>false</IsTruncated></ListBucketResult>`))
+					return
+				}
+
+				payload, found := blobs[r.URL.Path]
+				if !found {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`))
+					return
+				}
+
+				w.Write(payload)
+			case http.MethodDelete:
+				delete(blobs, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+
+		var err error
+		cache, err = remotecache.S3{
+			Bucket:          "some-bucket",
+			Prefix:          "some-prefix",
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			ForcePathStyle:  true,
+			AccessKeyID:     "some-access-key",
+			SecretAccessKey: "some-secret-key",
+		}.RemoteCache()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads under the prefix", func() {
+		err := cache.Upload(context.Background(), "resource-caches/1", strings.NewReader("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(blobs).To(HaveKeyWithValue("/some-bucket/some-prefix/resource-caches/1", []byte("some-contents")))
+	})
+
+	It("downloads what was uploaded", func() {
+		err := cache.Upload(context.Background(), "resource-caches/1", strings.NewReader("some-contents"))
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, found, err := cache.Download(context.Background(), "resource-caches/1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer contents.Close()
+		Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-contents")))
+	})
+
+	It("returns false when nothing was uploaded", func() {
+		_, found, err := cache.Download(context.Background(), "resource-caches/2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("lists the keys under a prefix", func() {
+		Expect(cache.Upload(context.Background(), "task-caches/1/unit/some-path", strings.NewReader("a"))).To(Succeed())
+		Expect(cache.Upload(context.Background(), "task-caches/2/unit/some-path", strings.NewReader("b"))).To(Succeed())
+		Expect(cache.Upload(context.Background(), "resource-caches/1", strings.NewReader("c"))).To(Succeed())
+
+		keys, err := cache.List(context.Background(), "task-caches/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(ConsistOf("task-caches/1/unit/some-path", "task-caches/2/unit/some-path"))
+	})
+
+	It("deletes blobs", func() {
+		Expect(cache.Upload(context.Background(), "resource-caches/1", strings.NewReader("some-contents"))).To(Succeed())
+		Expect(cache.Delete(context.Background(), "resource-caches/1")).To(Succeed())
+
+		Expect(blobs).ToNot(HaveKey("/some-bucket/some-prefix/resource-caches/1"))
+	})
+})
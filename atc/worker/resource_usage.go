@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+// ResourceUsageSampleInterval is how often the metrics of a task's container
+// are sampled while the task is running.
+var ResourceUsageSampleInterval = 10 * time.Second
+
+// sampleResourceUsage samples the metrics of the container until the returned
+// function is called, which takes a last sample and returns the usage. The
+// usage is nil if no sample could be taken, e.g. when the runtime does not
+// report metrics.
+func sampleResourceUsage(logger lager.Logger, container Container) func() *atc.ResourceUsage {
+	var usage *atc.ResourceUsage
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(ResourceUsageSampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				usage = addResourceUsageSample(logger, container, usage)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() *atc.ResourceUsage {
+		close(stop)
+		<-stopped
+
+		// the container outlives its process, so the cumulative cpu time now
+		// includes everything the process did
+		usage = addResourceUsageSample(logger, container, usage)
+
+		return addReportedResourceUsage(logger, container, usage)
+	}
+}
+
+type reportedResourceUsage struct {
+	MemoryPeakBytes uint64 `json:"memory_peak_bytes"`
+	DiskReadBytes   uint64 `json:"disk_read_bytes"`
+	DiskWriteBytes  uint64 `json:"disk_write_bytes"`
+}
+
+// addReportedResourceUsage adds the memory high-water mark and disk I/O
+// reported by the worker's runtime, which catches spikes in between samples.
+// Runtimes which do not report them (e.g. Guardian) are left with the
+// sampled usage.
+func addReportedResourceUsage(logger lager.Logger, container Container, usage *atc.ResourceUsage) *atc.ResourceUsage {
+	if usage == nil {
+		return nil
+	}
+
+	property, err := container.Property(resourceUsagePropertyName)
+	if err != nil {
+		logger.Debug("failed-to-get-reported-resource-usage", lager.Data{"error": err.Error()})
+		return usage
+	}
+
+	var reported reportedResourceUsage
+	err = json.Unmarshal([]byte(property), &reported)
+	if err != nil {
+		logger.Debug("failed-to-parse-reported-resource-usage", lager.Data{"error": err.Error()})
+		return usage
+	}
+
+	if reported.MemoryPeakBytes > usage.PeakMemoryBytes {
+		usage.PeakMemoryBytes = reported.MemoryPeakBytes
+	}
+
+	usage.DiskIO = &atc.DiskIOUsage{
+		ReadBytes:  reported.DiskReadBytes,
+		WriteBytes: reported.DiskWriteBytes,
+	}
+
+	return usage
+}
+
+func addResourceUsageSample(logger lager.Logger, container Container, usage *atc.ResourceUsage) *atc.ResourceUsage {
+	metrics, err := container.Metrics()
+	if err != nil {
+		logger.Debug("failed-to-sample-resource-usage", lager.Data{"error": err.Error()})
+		return usage
+	}
+
+	if usage == nil {
+		usage = &atc.ResourceUsage{}
+	}
+
+	if metrics.MemoryStat.TotalUsageTowardLimit > usage.PeakMemoryBytes {
+		usage.PeakMemoryBytes = metrics.MemoryStat.TotalUsageTowardLimit
+	}
+
+	cpuSeconds := time.Duration(metrics.CPUStat.Usage).Seconds()
+	if cpuSeconds > usage.CPUSeconds {
+		usage.CPUSeconds = cpuSeconds
+	}
+
+	return usage
+}
@@ -32,7 +32,7 @@ type Volume interface {
 	InitializeResourceCache(db.UsedResourceCache) error
 	InitializeStreamedResourceCache(db.UsedResourceCache, string) error
 	GetResourceCacheID() int
-	InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
+	InitializeTaskCache(ctx context.Context, logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
 	InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error)
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
@@ -50,6 +50,7 @@ type volume struct {
 	bcVolume     baggageclaim.Volume
 	dbVolume     db.CreatedVolume
 	volumeClient VolumeClient
+	remoteCache  RemoteCache
 }
 
 type byMountPath []VolumeMount
@@ -149,6 +150,7 @@ func (v *volume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact
 }
 
 func (v *volume) InitializeTaskCache(
+	ctx context.Context,
 	logger lager.Logger,
 	jobID int,
 	stepName string,
@@ -156,7 +158,18 @@ func (v *volume) InitializeTaskCache(
 	privileged bool,
 ) error {
 	if v.dbVolume.ParentHandle() == "" {
-		return v.dbVolume.InitializeTaskCache(jobID, stepName, path)
+		err := v.dbVolume.InitializeTaskCache(jobID, stepName, path)
+		if err != nil {
+			return err
+		}
+
+		if v.remoteCache != nil {
+			// upload in the background so that the step doesn't wait for it;
+			// the upload is still canceled if the build is aborted
+			go v.uploadTaskCache(ctx, logger, TaskCacheKey(jobID, stepName, path))
+		}
+
+		return nil
 	}
 
 	logger.Debug("creating-an-import-volume", lager.Data{"path": v.bcVolume.Path()})
@@ -178,7 +191,22 @@ func (v *volume) InitializeTaskCache(
 		return err
 	}
 
-	return importVolume.InitializeTaskCache(logger, jobID, stepName, path, privileged)
+	return importVolume.InitializeTaskCache(ctx, logger, jobID, stepName, path, privileged)
+}
+
+// uploadTaskCache uploads the contents of the task cache to the remote cache.
+// The remote cache is best-effort; failing to upload only means that other
+// workers will start cold.
+func (v *volume) uploadTaskCache(ctx context.Context, logger lager.Logger, key string) {
+	logger = logger.Session("upload-task-cache", lager.Data{"key": key})
+
+	changed, err := uploadDigestedVolume(ctx, v.remoteCache, key, v)
+	if err != nil {
+		logger.Error("failed-to-upload", err)
+		return
+	}
+
+	logger.Debug("uploaded", lager.Data{"changed": changed})
 }
 
 func (v *volume) CreateChildForContainer(creatingContainer db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
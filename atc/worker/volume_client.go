@@ -108,6 +108,7 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+	remoteCache                     RemoteCache
 }
 
 func NewVolumeClient(
@@ -120,6 +121,7 @@ func NewVolumeClient(
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbTaskCacheFactory db.TaskCacheFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	remoteCache RemoteCache,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -130,6 +132,7 @@ func NewVolumeClient(
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
 		clock:                           clock,
 		dbWorker:                        dbWorker,
+		remoteCache:                     remoteCache,
 	}
 }
 
@@ -243,7 +246,7 @@ func (c *volumeClient) FindVolumeForResourceCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) CreateVolumeForTaskCache(
@@ -359,7 +362,7 @@ func (c *volumeClient) FindVolumeForTaskCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
@@ -383,7 +386,7 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) findOrCreateVolume(
@@ -417,7 +420,7 @@ func (c *volumeClient) findOrCreateVolume(
 
 		logger.Debug("found-created-volume")
 
-		return NewVolume(bcVolume, createdVolume, c), nil
+		return c.newVolume(bcVolume, createdVolume), nil
 	}
 
 	if creatingVolume != nil {
@@ -491,5 +494,14 @@ func (c *volumeClient) findOrCreateVolume(
 
 	logger.Debug("created")
 
-	return NewVolume(bcVolume, createdVolume, c), nil
+	return c.newVolume(bcVolume, createdVolume), nil
+}
+
+func (c *volumeClient) newVolume(bcVolume baggageclaim.Volume, dbVolume db.CreatedVolume) Volume {
+	return &volume{
+		bcVolume:     bcVolume,
+		dbVolume:     dbVolume,
+		volumeClient: c,
+		remoteCache:  c.remoteCache,
+	}
 }
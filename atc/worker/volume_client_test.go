@@ -61,6 +61,7 @@ var _ = Describe("VolumeClient", func() {
 			fakeWorkerBaseResourceTypeFactory,
 			fakeTaskCacheFactory,
 			fakeWorkerTaskCacheFactory,
+			nil,
 		)
 	})
 
@@ -771,6 +772,7 @@ var _ = Describe("VolumeClient", func() {
 				fakeWorkerBaseResourceTypeFactory,
 				fakeTaskCacheFactory,
 				fakeWorkerTaskCacheFactory,
+				nil,
 			).LookupVolume(testLogger, handle)
 		})
 
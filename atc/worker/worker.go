@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,27 @@ import (
 
 const userPropertyName = "user"
 
+// runtimeClassPropertyName is the container property through which the
+// runtime class of a container is passed on to the worker.
+const runtimeClassPropertyName = "concourse:runtime-class"
+
+// networkPolicyPropertyName is the container property through which the
+// network policy of a container is passed on to the worker, as JSON.
+const networkPolicyPropertyName = "concourse:network-policy"
+
+// remoteCacheHydratedPropertyName is the volume property which marks that a
+// task cache volume has been hydrated from the remote cache.
+const remoteCacheHydratedPropertyName = "concourse:remote-cache-hydrated"
+
+// resourceUsagePropertyName is the read-only container property through
+// which the worker reports the memory high-water mark and disk I/O of a
+// container, as JSON.
+const resourceUsagePropertyName = "concourse:resource-usage"
+
+type networkPolicy struct {
+	Allow []string `json:"allow"`
+}
+
 var ErrResourceConfigCheckSessionExpired = errors.New("no db container was found for owner")
 
 //counterfeiter:generate . Worker
@@ -92,6 +114,7 @@ type gardenWorker struct {
 	volumeClient         VolumeClient
 	imageFactory         ImageFactory
 	resourceCacheFactory db.ResourceCacheFactory
+	remoteCache          RemoteCache
 	Fetcher
 	dbWorker        db.Worker
 	buildContainers int
@@ -110,6 +133,7 @@ func NewGardenWorker(
 	dbTeamFactory db.TeamFactory,
 	dbWorker db.Worker,
 	resourceCacheFactory db.ResourceCacheFactory,
+	remoteCache RemoteCache,
 	numBuildContainers int,
 	// TODO: numBuildContainers is only needed for placement strategy but this
 	// method is called in ContainerProvider.FindOrCreateContainer as well and
@@ -130,6 +154,7 @@ func NewGardenWorker(
 		Fetcher:              fetcher,
 		dbWorker:             dbWorker,
 		resourceCacheFactory: resourceCacheFactory,
+		remoteCache:          remoteCache,
 		buildContainers:      numBuildContainers,
 		helper:               workerHelper,
 	}
@@ -547,6 +572,7 @@ func (worker *gardenWorker) createVolumes(
 	}
 
 	cacheMounts, err := worker.createCacheVolumes(
+		ctx,
 		logger,
 		spec.TeamID,
 		isPrivileged,
@@ -694,6 +720,7 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 }
 
 func (worker *gardenWorker) createCacheVolumes(
+	ctx context.Context,
 	logger lager.Logger,
 	teamID int,
 	privileged bool,
@@ -723,6 +750,10 @@ func (worker *gardenWorker) createCacheVolumes(
 			return []VolumeMount{}, err
 		}
 
+		if cache, ok := nonLocalInput.desiredArtifact.(*CacheArtifactSource); ok && worker.remoteCache != nil {
+			worker.hydrateTaskCache(ctx, logger, cache, inputVolume)
+		}
+
 		mounts[i] = VolumeMount{
 			Volume:    inputVolume,
 			MountPath: nonLocalInput.desiredMountPath,
@@ -734,6 +765,46 @@ func (worker *gardenWorker) createCacheVolumes(
 	return mounts, nil
 }
 
+// hydrateTaskCache populates a task cache volume created for the container
+// with the contents of the remote cache, if there are any. The remote cache
+// is best-effort, so failures are only logged and the task runs with an
+// empty cache instead.
+func (worker *gardenWorker) hydrateTaskCache(ctx context.Context, logger lager.Logger, cache *CacheArtifactSource, volume Volume) {
+	logger = logger.Session("hydrate-task-cache", lager.Data{
+		"job-id": cache.JobID,
+		"step":   cache.StepName,
+		"path":   cache.Path,
+	})
+
+	properties, err := volume.Properties()
+	if err != nil {
+		logger.Error("failed-to-get-volume-properties", err)
+		return
+	}
+
+	// the volume was found rather than created, e.g. when creating the
+	// container is retried, and has already been hydrated
+	if _, hydrated := properties[remoteCacheHydratedPropertyName]; hydrated {
+		return
+	}
+
+	found, err := hydrateVolume(ctx, worker.remoteCache, TaskCacheKey(cache.JobID, cache.StepName, cache.Path), volume)
+	if err != nil {
+		logger.Error("failed-to-hydrate", err)
+		return
+	}
+
+	err = volume.SetProperty(remoteCacheHydratedPropertyName, strconv.FormatBool(found))
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-hydrated", err)
+		return
+	}
+
+	if found {
+		logger.Debug("hydrated")
+	}
+}
+
 func (worker *gardenWorker) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	gardenContainer, err := worker.gardenClient.Lookup(handle)
 	if err != nil {
@@ -792,11 +863,25 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if spec.Privileged && worker.dbWorker.Rootless() {
+		return false
+	}
+
+	if spec.RuntimeClass != "" && !worker.offersRuntimeClass(spec.RuntimeClass) {
+		return false
+	}
+
+	if spec.NetworkPolicy && !worker.dbWorker.NetworkPolicies() {
+		return false
+	}
+
 	if spec.ResourceType != "" {
 		matchedType := false
 		for _, t := range workerResourceTypes {
 			if t.Type == spec.ResourceType {
-				matchedType = true
+				// rootless workers cannot run the privileged containers the
+				// resource type needs
+				matchedType = !(t.Privileged && worker.dbWorker.Rootless())
 				break
 			}
 		}
@@ -828,6 +913,18 @@ func (worker *gardenWorker) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if worker.dbWorker.Rootless() {
+		messages = append(messages, "rootless")
+	}
+
+	for _, class := range worker.dbWorker.RuntimeClasses() {
+		messages = append(messages, fmt.Sprintf("runtime class '%s'", class))
+	}
+
+	if worker.dbWorker.NetworkPolicies() {
+		messages = append(messages, "network policies")
+	}
+
 	return strings.Join(messages, ", ")
 }
 
@@ -839,6 +936,16 @@ func (worker *gardenWorker) Uptime() time.Duration {
 	return time.Since(worker.dbWorker.StartTime())
 }
 
+func (worker *gardenWorker) offersRuntimeClass(class string) bool {
+	for _, c := range worker.dbWorker.RuntimeClasses() {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (worker *gardenWorker) tagsMatch(tags []string) bool {
 	workerTags := worker.dbWorker.Tags()
 	if len(tags) == 0 {
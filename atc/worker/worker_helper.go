@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -33,6 +34,21 @@ func (w workerHelper) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if containerSpec.RuntimeClass != "" {
+		gardenProperties[runtimeClassPropertyName] = containerSpec.RuntimeClass
+	}
+
+	if containerSpec.Network != nil {
+		// a container that is not allowed to reach anything is cut off from
+		// the network entirely
+		policy, err := json.Marshal(networkPolicy{Allow: containerSpec.Network.Allow})
+		if err != nil {
+			return nil, err
+		}
+
+		gardenProperties[networkPolicyPropertyName] = string(policy)
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
 	if w.dbWorker.HTTPProxyURL() != "" {
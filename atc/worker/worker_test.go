@@ -51,6 +51,7 @@ var _ = Describe("Worker", func() {
 		fakeGardenContainer      *gclientfakes.FakeContainer
 		fakeBaggageclaimClient   *baggageclaimfakes.FakeClient
 		fakeFetcher              *workerfakes.FakeFetcher
+		fakeRemoteCache          *workerfakes.FakeRemoteCache
 
 		fakeLocalInput    *workerfakes.FakeInputSource
 		fakeRemoteInput   *workerfakes.FakeInputSource
@@ -111,6 +112,7 @@ var _ = Describe("Worker", func() {
 
 		fakeDBVolumeRepository = new(dbfakes.FakeVolumeRepository)
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		fakeRemoteCache = new(workerfakes.FakeRemoteCache)
 
 		fakeDBTeamFactory = new(dbfakes.FakeTeamFactory)
 		fakeDBTeam = new(dbfakes.FakeTeam)
@@ -250,6 +252,7 @@ var _ = Describe("Worker", func() {
 			fakeDBTeamFactory,
 			fakeDBWorker,
 			fakeResourceCacheFactory,
+			fakeRemoteCache,
 			0,
 		)
 	})
@@ -722,6 +725,112 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the worker is rootless", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RootlessReturns(true)
+			})
+
+			It("returns true", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+
+			Context("when the spec is privileged", func() {
+				BeforeEach(func() {
+					spec.Privileged = true
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the resource type is privileged", func() {
+				BeforeEach(func() {
+					resourceTypes[0].Privileged = true
+					spec.ResourceType = "some-base-type"
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the spec requests a runtime class", func() {
+			BeforeEach(func() {
+				spec.RuntimeClass = "runsc"
+			})
+
+			Context("when the worker offers the runtime class", func() {
+				BeforeEach(func() {
+					fakeDBWorker.RuntimeClassesReturns([]string{"kata", "runsc"})
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker does not offer the runtime class", func() {
+				BeforeEach(func() {
+					fakeDBWorker.RuntimeClassesReturns([]string{"kata"})
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the spec requires network policies", func() {
+			BeforeEach(func() {
+				spec.NetworkPolicy = true
+			})
+
+			Context("when the worker enforces network policies", func() {
+				BeforeEach(func() {
+					fakeDBWorker.NetworkPoliciesReturns(true)
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker does not enforce network policies", func() {
+				BeforeEach(func() {
+					fakeDBWorker.NetworkPoliciesReturns(false)
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the worker is not rootless", func() {
+			Context("when the spec is privileged", func() {
+				BeforeEach(func() {
+					spec.Privileged = true
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the resource type is privileged", func() {
+				BeforeEach(func() {
+					resourceTypes[0].Privileged = true
+					spec.ResourceType = "some-base-type"
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when spec specifies team", func() {
 			BeforeEach(func() {
 				teamID = 123
@@ -928,6 +1037,51 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				Context("when the container spec requests a runtime class", func() {
+					BeforeEach(func() {
+						containerSpec.RuntimeClass = "runsc"
+					})
+
+					It("creates the container in garden with the runtime class property", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":                    "some-user",
+							"concourse:runtime-class": "runsc",
+						}))
+					})
+				})
+
+				Context("when the container spec restricts the network", func() {
+					BeforeEach(func() {
+						containerSpec.Network = &atc.NetworkConfig{Allow: []string{"10.0.0.0/8", "api.example.com"}}
+					})
+
+					It("creates the container in garden with the network policy property", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":                     "some-user",
+							"concourse:network-policy": `{"allow":["10.0.0.0/8","api.example.com"]}`,
+						}))
+					})
+				})
+
+				Context("when the container spec cuts off the network", func() {
+					BeforeEach(func() {
+						containerSpec.Network = &atc.NetworkConfig{None: true}
+					})
+
+					It("creates the container in garden with an empty network policy", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse:network-policy", `{"allow":null}`))
+					})
+				})
+
 				Context("when the input and output destination paths overlap", func() {
 					var (
 						fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
@@ -1312,6 +1466,62 @@ var _ = Describe("Worker", func() {
 					Expect(ioutil.ReadAll(from)).To(Equal([]byte("some-stream")))
 				})
 
+				It("looks up task caches in the remote cache", func() {
+					Expect(fakeRemoteCache.DownloadCallCount()).To(Equal(1))
+					_, key := fakeRemoteCache.DownloadArgsForCall(0)
+					Expect(key).To(Equal("task-caches/18/some-task/%2Fsome%2Fwork-dir%2Fcache"))
+
+					Expect(fakeCacheVolume.StreamInCallCount()).To(Equal(0))
+				})
+
+				Context("when the remote cache has the task cache", func() {
+					BeforeEach(func() {
+						fakeRemoteCache.DownloadReturns(ioutil.NopCloser(bytes.NewBufferString("some-cache")), true, nil)
+					})
+
+					It("hydrates the cache volume", func() {
+						Expect(fakeCacheVolume.StreamInCallCount()).To(Equal(1))
+
+						_, dst, encoding, from := fakeCacheVolume.StreamInArgsForCall(0)
+						Expect(dst).To(Equal("."))
+						Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+						Expect(ioutil.ReadAll(from)).To(Equal([]byte("some-cache")))
+					})
+
+					It("marks the cache volume as hydrated", func() {
+						Expect(fakeCacheVolume.SetPropertyCallCount()).To(Equal(1))
+
+						name, value := fakeCacheVolume.SetPropertyArgsForCall(0)
+						Expect(name).To(Equal("concourse:remote-cache-hydrated"))
+						Expect(value).To(Equal("true"))
+					})
+				})
+
+				Context("when the cache volume was found rather than created", func() {
+					BeforeEach(func() {
+						fakeCacheVolume.PropertiesReturns(baggageclaim.VolumeProperties{
+							"concourse:remote-cache-hydrated": "true",
+						}, nil)
+						fakeRemoteCache.DownloadReturns(ioutil.NopCloser(bytes.NewBufferString("some-cache")), true, nil)
+					})
+
+					It("does not hydrate it again", func() {
+						Expect(fakeRemoteCache.DownloadCallCount()).To(Equal(0))
+						Expect(fakeCacheVolume.StreamInCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the remote cache fails", func() {
+					BeforeEach(func() {
+						fakeRemoteCache.DownloadReturns(nil, false, errors.New("nope"))
+					})
+
+					It("creates the container with an empty cache volume", func() {
+						Expect(fakeCacheVolume.StreamInCallCount()).To(Equal(0))
+						Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
+					})
+				})
+
 				It("marks container as created", func() {
 					Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 				})
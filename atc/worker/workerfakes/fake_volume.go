@@ -118,14 +118,15 @@ type FakeVolume struct {
 	initializeStreamedResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializeTaskCacheStub        func(lager.Logger, int, string, string, bool) error
+	InitializeTaskCacheStub        func(context.Context, lager.Logger, int, string, string, bool) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 int
-		arg3 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
 		arg4 string
-		arg5 bool
+		arg5 string
+		arg6 bool
 	}
 	initializeTaskCacheReturns struct {
 		result1 error
@@ -221,16 +222,6 @@ type FakeVolume struct {
 	streamP2pOutReturnsOnCall map[int]struct {
 		result1 error
 	}
-	VolumeStub        func() worker.Volume
-	volumeMutex       sync.RWMutex
-	volumeArgsForCall []struct {
-	}
-	volumeReturns struct {
-		result1 worker.Volume
-	}
-	volumeReturnsOnCall map[int]struct {
-		result1 worker.Volume
-	}
 	WorkerNameStub        func() string
 	workerNameMutex       sync.RWMutex
 	workerNameArgsForCall []struct {
@@ -775,22 +766,23 @@ func (fake *FakeVolume) InitializeStreamedResourceCacheReturnsOnCall(i int, resu
 	}{result1}
 }
 
-func (fake *FakeVolume) InitializeTaskCache(arg1 lager.Logger, arg2 int, arg3 string, arg4 string, arg5 bool) error {
+func (fake *FakeVolume) InitializeTaskCache(arg1 context.Context, arg2 lager.Logger, arg3 int, arg4 string, arg5 string, arg6 bool) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
 	fake.initializeTaskCacheArgsForCall = append(fake.initializeTaskCacheArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 int
-		arg3 string
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
 		arg4 string
-		arg5 bool
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg5 string
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
 	stub := fake.InitializeTaskCacheStub
 	fakeReturns := fake.initializeTaskCacheReturns
-	fake.recordInvocation("InitializeTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("InitializeTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.initializeTaskCacheMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -804,17 +796,17 @@ func (fake *FakeVolume) InitializeTaskCacheCallCount() int {
 	return len(fake.initializeTaskCacheArgsForCall)
 }
 
-func (fake *FakeVolume) InitializeTaskCacheCalls(stub func(lager.Logger, int, string, string, bool) error) {
+func (fake *FakeVolume) InitializeTaskCacheCalls(stub func(context.Context, lager.Logger, int, string, string, bool) error) {
 	fake.initializeTaskCacheMutex.Lock()
 	defer fake.initializeTaskCacheMutex.Unlock()
 	fake.InitializeTaskCacheStub = stub
 }
 
-func (fake *FakeVolume) InitializeTaskCacheArgsForCall(i int) (lager.Logger, int, string, string, bool) {
+func (fake *FakeVolume) InitializeTaskCacheArgsForCall(i int) (context.Context, lager.Logger, int, string, string, bool) {
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
 	argsForCall := fake.initializeTaskCacheArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeVolume) InitializeTaskCacheReturns(result1 error) {
@@ -1266,59 +1258,6 @@ func (fake *FakeVolume) StreamP2pOutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeVolume) Volume() worker.Volume {
-	fake.volumeMutex.Lock()
-	ret, specificReturn := fake.volumeReturnsOnCall[len(fake.volumeArgsForCall)]
-	fake.volumeArgsForCall = append(fake.volumeArgsForCall, struct {
-	}{})
-	stub := fake.VolumeStub
-	fakeReturns := fake.volumeReturns
-	fake.recordInvocation("Volume", []interface{}{})
-	fake.volumeMutex.Unlock()
-	if stub != nil {
-		return stub()
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeVolume) VolumeCallCount() int {
-	fake.volumeMutex.RLock()
-	defer fake.volumeMutex.RUnlock()
-	return len(fake.volumeArgsForCall)
-}
-
-func (fake *FakeVolume) VolumeCalls(stub func() worker.Volume) {
-	fake.volumeMutex.Lock()
-	defer fake.volumeMutex.Unlock()
-	fake.VolumeStub = stub
-}
-
-func (fake *FakeVolume) VolumeReturns(result1 worker.Volume) {
-	fake.volumeMutex.Lock()
-	defer fake.volumeMutex.Unlock()
-	fake.VolumeStub = nil
-	fake.volumeReturns = struct {
-		result1 worker.Volume
-	}{result1}
-}
-
-func (fake *FakeVolume) VolumeReturnsOnCall(i int, result1 worker.Volume) {
-	fake.volumeMutex.Lock()
-	defer fake.volumeMutex.Unlock()
-	fake.VolumeStub = nil
-	if fake.volumeReturnsOnCall == nil {
-		fake.volumeReturnsOnCall = make(map[int]struct {
-			result1 worker.Volume
-		})
-	}
-	fake.volumeReturnsOnCall[i] = struct {
-		result1 worker.Volume
-	}{result1}
-}
-
 func (fake *FakeVolume) WorkerName() string {
 	fake.workerNameMutex.Lock()
 	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
@@ -1409,8 +1348,6 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.streamOutMutex.RUnlock()
 	fake.streamP2pOutMutex.RLock()
 	defer fake.streamP2pOutMutex.RUnlock()
-	fake.volumeMutex.RLock()
-	defer fake.volumeMutex.RUnlock()
 	fake.workerNameMutex.RLock()
 	defer fake.workerNameMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -47,11 +47,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
+			atc.GetBuildDiff,
+			atc.ListBuildApprovals,
 			atc.ListBuildArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ApproveBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -95,6 +98,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// unauthenticated / delegating to handler (validate token if provided)
 		case atc.DownloadCLI,
 			atc.CheckResourceWebHook,
+			atc.TriggerResourceWebHook,
+			atc.PipelineSyncWebhook,
 			atc.GetInfo,
 			atc.ListTeams,
 			atc.ListAllPipelines,
@@ -120,6 +125,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetTeam,
 			atc.SetTeam,
 			atc.RenameTeam,
+			atc.SavePipelineLibrary,
+			atc.GetPipelineLibrary,
+			atc.GetPipelineSync,
+			atc.SyncPipelines,
 			atc.ListContainers,
 			atc.GetContainer,
 			atc.HijackContainer,
@@ -137,6 +146,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
+			atc.GetConfigHistory,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
@@ -150,6 +160,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
+			atc.DryRunConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
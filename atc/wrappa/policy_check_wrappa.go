@@ -2,6 +2,7 @@ package wrappa
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/tedsuo/rata"
 )
@@ -22,6 +23,13 @@ func (w *PolicyCheckWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	wrapped := rata.Handlers{}
 
 	for name, handler := range handlers {
+		// a dry run checks the policy itself and reports the result rather
+		// than rejecting the request
+		if name == atc.DryRunConfig {
+			wrapped[name] = handler
+			continue
+		}
+
 		wrapped[name] = policychecker.NewHandler(w.logger, handler, name, w.checker)
 	}
 
@@ -35,19 +35,24 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
-			atc.RerunJobBuild:
+			atc.RerunJobBuild,
+			atc.TriggerResourceWebHook:
 
 			newHandler = rw.handlerFactory.RejectArchived(handler)
 
 			// leave the handler as-is
 		case
 			atc.GetConfig,
+			atc.GetConfigHistory,
 			atc.GetBuild,
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildDiff,
+			atc.ListBuildApprovals,
+			atc.ApproveBuild,
 			atc.AbortBuild,
 			atc.PruneWorker,
 			atc.LandWorker,
@@ -86,6 +91,11 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetTeam,
 			atc.RenameTeam,
 			atc.DestroyTeam,
+			atc.SavePipelineLibrary,
+			atc.GetPipelineLibrary,
+			atc.GetPipelineSync,
+			atc.SyncPipelines,
+			atc.PipelineSyncWebhook,
 			atc.GetUser,
 			atc.GetInfo,
 			atc.DownloadCLI,
@@ -114,6 +124,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ArchivePipeline,
 			atc.RenamePipeline,
 			atc.SaveConfig,
+			atc.DryRunConfig,
 			atc.UnpauseJob,
 			atc.ExposePipeline,
 			atc.HidePipeline,
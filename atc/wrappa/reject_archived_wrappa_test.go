@@ -43,6 +43,7 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.RerunJobBuild,
+			atc.TriggerResourceWebHook,
 		}
 
 		rejectArchivedLookup := make(map[string]bool)
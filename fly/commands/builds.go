@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -30,17 +31,14 @@ type BuildsCommand struct {
 	Teams       []string                  `short:"n"  long:"team" description:"Show builds for these teams"`
 	Since       string                    `long:"since" description:"Start of the range to filter builds"`
 	Until       string                    `long:"until" description:"End of the range to filter builds"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Show builds on each target in this group"`
 }
 
 func (command *BuildsCommand) Execute([]string) error {
-	var (
-		builds = make([]atc.Build, 0)
-		teams  = make([]concourse.Team, 0)
-
-		timeSince time.Time
-		timeUntil time.Time
-		page      = concourse.Page{}
-	)
+	if command.TargetGroup != "" {
+		return command.executeOnGroup()
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -52,23 +50,64 @@ func (command *BuildsCommand) Execute([]string) error {
 		return err
 	}
 
-	page, err = command.validateBuildArguments(timeSince, page, timeUntil)
+	builds, err := command.fetch(target)
 	if err != nil {
 		return err
 	}
 
-	page.Limit = command.Count
-	page.Timestamps = command.Since != "" || command.Until != ""
+	return command.displayBuilds(builds)
+}
+
+func (command *BuildsCommand) executeOnGroup() error {
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+	if err != nil {
+		return err
+	}
 
-	currentTeam := target.Team()
-	client := target.Client()
+	results := targetgrouphelpers.Fetch(targetNames, Fly.Verbose, func(target rc.Target) (interface{}, error) {
+		return command.fetch(target)
+	})
 
-	builds, err = command.getBuilds(builds, currentTeam, page, client, teams)
+	if command.Json {
+		return displayhelpers.JsonPrint(targetgrouphelpers.JSON(results))
+	}
+
+	table := targetgrouphelpers.Table(results, func(value interface{}) ui.Table {
+		return command.buildsTable(value.([]atc.Build))
+	})
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
 	if err != nil {
 		return err
 	}
 
-	return command.displayBuilds(builds)
+	return targetgrouphelpers.ShowErrors(results)
+}
+
+func (command *BuildsCommand) fetch(target rc.Target) ([]atc.Build, error) {
+	var (
+		builds = make([]atc.Build, 0)
+		teams  = make([]concourse.Team, 0)
+
+		timeSince time.Time
+		timeUntil time.Time
+		page      = concourse.Page{}
+	)
+
+	page, err := command.validateBuildArguments(timeSince, page, timeUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Limit = command.Count
+	page.Timestamps = command.Since != "" || command.Until != ""
+
+	builds, err = command.getBuilds(builds, target.Team(), page, target.Client(), teams)
+	if err != nil {
+		return nil, err
+	}
+
+	return builds[:command.buildCap(builds)], nil
 }
 
 func (command *BuildsCommand) getBuilds(builds []atc.Build, currentTeam concourse.Team, page concourse.Page, client concourse.Client, teams []concourse.Team) ([]atc.Build, error) {
@@ -183,6 +222,10 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 		return nil
 	}
 
+	return command.buildsTable(builds).Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *BuildsCommand) buildsTable(builds []atc.Build) ui.Table {
 	table := ui.Table{
 		Headers: ui.TableRow{
 			{Contents: "id", Color: color.New(color.Bold)},
@@ -196,8 +239,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 		},
 	}
 
-	buildCap := command.buildCap(builds)
-	for _, b := range builds[:buildCap] {
+	for _, b := range builds {
 		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(b.StartTime, 0), time.Unix(b.EndTime, 0))
 
 		var nameCell ui.TableCell
@@ -240,7 +282,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 		})
 	}
 
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+	return table
 }
 
 func (command *BuildsCommand) validateBuildArguments(timeSince time.Time, page concourse.Page, timeUntil time.Time) (concourse.Page, error) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/fatih/color"
@@ -14,9 +15,15 @@ import (
 
 type ContainersCommand struct {
 	Json bool `long:"json" description:"Print command result as JSON"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Show containers on each target in this group"`
 }
 
 func (command *ContainersCommand) Execute([]string) error {
+	if command.TargetGroup != "" {
+		return command.executeOnGroup()
+	}
+
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -27,7 +34,7 @@ func (command *ContainersCommand) Execute([]string) error {
 		return err
 	}
 
-	containers, err := target.Team().ListContainers(map[string]string{})
+	containers, err := command.fetch(target)
 	if err != nil {
 		return err
 	}
@@ -40,6 +47,40 @@ func (command *ContainersCommand) Execute([]string) error {
 		return nil
 	}
 
+	return command.tableFor(containers).Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *ContainersCommand) executeOnGroup() error {
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+	if err != nil {
+		return err
+	}
+
+	results := targetgrouphelpers.Fetch(targetNames, Fly.Verbose, func(target rc.Target) (interface{}, error) {
+		return command.fetch(target)
+	})
+
+	if command.Json {
+		return displayhelpers.JsonPrint(targetgrouphelpers.JSON(results))
+	}
+
+	table := targetgrouphelpers.Table(results, func(value interface{}) ui.Table {
+		return command.tableFor(value.([]atc.Container))
+	})
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	return targetgrouphelpers.ShowErrors(results)
+}
+
+func (command *ContainersCommand) fetch(target rc.Target) ([]atc.Container, error) {
+	return target.Team().ListContainers(map[string]string{})
+}
+
+func (command *ContainersCommand) tableFor(containers []atc.Container) ui.Table {
 	table := ui.Table{
 		Headers: ui.TableRow{
 			{Contents: "handle", Color: color.New(color.Bold)},
@@ -76,7 +117,7 @@ func (command *ContainersCommand) Execute([]string) error {
 
 	sort.Sort(table.Data)
 
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+	return table
 }
 
 func buildIDOrNone(id int) ui.TableCell {
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/dashboard"
+	"github.com/concourse/concourse/fly/pty"
+	"github.com/concourse/concourse/fly/rc"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+type DashboardCommand struct {
+	RefreshInterval time.Duration `long:"refresh-interval" default:"5s" description:"How often to refresh the dashboard"`
+}
+
+func (command *DashboardCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if !pty.IsTerminal() {
+		return errors.New("the dashboard can only be shown in a terminal")
+	}
+
+	if command.RefreshInterval <= 0 {
+		return errors.New("the refresh interval must be positive")
+	}
+
+	term, err := pty.OpenRawTerm()
+	if err != nil {
+		return err
+	}
+
+	defer term.Restore()
+
+	height := func() int {
+		_, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			return 24
+		}
+
+		return height
+	}
+
+	return dashboard.New(target.Client(), string(Fly.Target)).Run(term, os.Stdout, height, command.RefreshInterval)
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type DeleteTargetGroupCommand struct {
+	Group rc.TargetGroupName `short:"g" long:"group" required:"true" description:"Name of the group"`
+}
+
+func (command *DeleteTargetGroupCommand) Execute([]string) error {
+	err := rc.DeleteTargetGroup(command.Group)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("deleted target group: %s\n", command.Group)
+
+	return nil
+}
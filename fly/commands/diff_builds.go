@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type DiffBuildsCommand struct {
+	Job  flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job the builds belong to"`
+	From string              `long:"from" required:"true" description:"If job is specified: build number to compare from. If job not specified: build id"`
+	To   string              `long:"to" required:"true" description:"If job is specified: build number to compare to. If job not specified: build id"`
+	Json bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *DiffBuildsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	from, err := command.build(target, command.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := command.build(target, command.To)
+	if err != nil {
+		return err
+	}
+
+	diff, found, err := target.Client().BuildDiff(from.ID, to.ID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build does not exist")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(diff)
+	}
+
+	headers := []string{"section", "name", "from", "to"}
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range headers {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, d := range diff.Inputs {
+		table.Data = append(table.Data, diffRow("input", d.Name, versionCell(d.From), versionCell(d.To)))
+	}
+
+	for _, d := range diff.Plan {
+		table.Data = append(table.Data, diffRow("plan", d.Step, jsonCell(d.From), jsonCell(d.To)))
+	}
+
+	for _, d := range diff.Tasks {
+		table.Data = append(table.Data, diffRow("task config", d.Step, jsonCell(d.From), jsonCell(d.To)))
+	}
+
+	for _, d := range diff.Images {
+		table.Data = append(table.Data, diffRow("image", d.Name, versionCell(d.From), versionCell(d.To)))
+	}
+
+	for _, d := range diff.Workers {
+		table.Data = append(table.Data, diffRow("worker", d.Step, stringCell(d.From), stringCell(d.To)))
+	}
+
+	for _, d := range diff.Durations {
+		table.Data = append(table.Data, diffRow("duration", d.Step, durationCell(d.From), durationCell(d.To)))
+	}
+
+	if len(table.Data) == 0 {
+		fmt.Printf("builds %s and %s do not differ\n", from.Name, to.Name)
+		return nil
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *DiffBuildsCommand) build(target rc.Target, build string) (atc.Build, error) {
+	var b atc.Build
+	var exists bool
+	var err error
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		b, exists, err = target.Client().Build(build)
+	} else {
+		b, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, build)
+	}
+	if err != nil {
+		return atc.Build{}, err
+	}
+
+	if !exists {
+		return atc.Build{}, fmt.Errorf("build %s does not exist", build)
+	}
+
+	return b, nil
+}
+
+func diffRow(section string, name string, from ui.TableCell, to ui.TableCell) ui.TableRow {
+	return ui.TableRow{
+		ui.TableCell{Contents: section},
+		ui.TableCell{Contents: name},
+		from,
+		to,
+	}
+}
+
+func versionCell(version atc.Version) ui.TableCell {
+	if version == nil {
+		return absentCell()
+	}
+
+	return ui.TableCell{Contents: ui.PresentVersion(version)}
+}
+
+func jsonCell(payload *json.RawMessage) ui.TableCell {
+	if payload == nil {
+		return absentCell()
+	}
+
+	return ui.TableCell{Contents: string(*payload)}
+}
+
+func stringCell(s string) ui.TableCell {
+	if s == "" {
+		return absentCell()
+	}
+
+	return ui.TableCell{Contents: s}
+}
+
+func durationCell(seconds *int64) ui.TableCell {
+	if seconds == nil {
+		return absentCell()
+	}
+
+	return ui.TableCell{Contents: fmt.Sprintf("%ds", *seconds)}
+}
+
+func absentCell() ui.TableCell {
+	return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+}
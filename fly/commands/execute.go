@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"os/signal"
@@ -36,6 +37,7 @@ type ExecuteCommand struct {
 	Var            []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  unquote:"false"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar        []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    unquote:"false"  description:"Specify a YAML value to set for a variable in the pipeline"`
 	VarsFrom       []atc.PathFlag                     `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+	Output         eventstream.OutputFormat           `long:"output-format" default:"text" choice:"text" choice:"json" choice:"ndjson" choice:"junit" description:"Format to print the build in. json and ndjson print each event with its origin, step name and time, and junit prints a report with a test case for each step"`
 }
 
 func (command *ExecuteCommand) Execute(args []string) error {
@@ -49,6 +51,13 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		return err
 	}
 
+	// stdout is reserved for the build's events, so that they can be parsed
+	status := io.Writer(os.Stdout)
+	if command.Output.IsStructured() {
+		status = ui.Stderr
+		progress.Output = ui.Stderr
+	}
+
 	taskConfig, err := command.CreateTaskConfig(args)
 	if err != nil {
 		return err
@@ -127,7 +136,7 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		return err
 	}
 
-	fmt.Printf("executing build %d at %s\n", build.ID, clientURL.ResolveReference(buildURL))
+	fmt.Fprintf(status, "executing build %d at %s\n", build.ID, clientURL.ResolveReference(buildURL))
 
 	terminate := make(chan os.Signal, 1)
 
@@ -135,13 +144,22 @@ func (command *ExecuteCommand) Execute(args []string) error {
 
 	signal.Notify(terminate, syscall.SIGINT, syscall.SIGTERM)
 
+	renderOptions := eventstream.RenderOptions{
+		Output: command.Output,
+	}
+
+	if command.Output.IsStructured() {
+		renderOptions.BuildName, renderOptions.StepNames, err = describeBuild(client, build)
+		if err != nil {
+			return err
+		}
+	}
+
 	eventSource, err := client.BuildEvents(strconv.Itoa(build.ID))
 	if err != nil {
 		return err
 	}
 
-	renderOptions := eventstream.RenderOptions{}
-
 	exitCode := eventstream.Render(os.Stdout, eventSource, renderOptions)
 	eventSource.Close()
 
@@ -12,6 +12,10 @@ type FlyCommand struct {
 	DeleteTarget DeleteTargetCommand `command:"delete-target" alias:"dtg" description:"Delete target"`
 	EditTarget   EditTargetCommand   `command:"edit-target" alias:"etg" description:"Edit a target"`
 
+	TargetGroups      TargetGroupsCommand      `command:"target-groups" alias:"tgs" description:"List saved target groups"`
+	SetTargetGroup    SetTargetGroupCommand    `command:"set-target-group" alias:"stg" description:"Create or replace a group of targets"`
+	DeleteTargetGroup DeleteTargetGroupCommand `command:"delete-target-group" alias:"dtgr" description:"Delete a group of targets"`
+
 	Version func() `short:"v" long:"version" description:"Print the version of Fly and exit"`
 
 	Verbose bool `long:"verbose" description:"Print API requests and responses"`
@@ -35,8 +39,11 @@ type FlyCommand struct {
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
 	Execute ExecuteCommand `command:"execute" alias:"e" description:"Execute a one-off build using local bits"`
+	RunJob  RunJobCommand  `command:"run-job" alias:"rj" description:"Run a job from a local pipeline config as a one-off build"`
 	Watch   WatchCommand   `command:"watch"   alias:"w" description:"Stream a build's output"`
 
+	Dashboard DashboardCommand `command:"dashboard" alias:"dash" description:"Show a live view of the target's pipelines, jobs and running builds"`
+
 	Containers ContainersCommand `command:"containers" alias:"cs" description:"Print the active containers"`
 	Hijack     HijackCommand     `command:"hijack"     alias:"intercept" alias:"i" description:"Execute a command in a container"`
 
@@ -57,6 +64,10 @@ type FlyCommand struct {
 	RenamePipeline            RenamePipelineCommand          `command:"rename-pipeline"           alias:"rp"   description:"Rename a pipeline"`
 	ValidatePipeline          ValidatePipelineCommand        `command:"validate-pipeline"         alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline            FormatPipelineCommand          `command:"format-pipeline"           alias:"fp"   description:"Format a pipeline config"`
+	SetPipelineLibrary        SetPipelineLibraryCommand      `command:"set-pipeline-library"      alias:"spl"  description:"Save a config as the next version of a pipeline library"`
+	PipelineHistory           PipelineHistoryCommand         `command:"pipeline-history"          alias:"ph"   description:"List the configs a pipeline has been set to"`
+	RollbackPipeline          RollbackPipelineCommand        `command:"rollback-pipeline"         alias:"rbp"  description:"Set a pipeline back to a config from its history"`
+	PipelineSync              PipelineSyncCommand            `command:"pipeline-sync"             alias:"psy"  description:"Show how the team's pipelines are synced from git, or sync them now"`
 	OrderPipelines            OrderPipelinesCommand          `command:"order-pipelines"           alias:"op"   description:"Orders pipelines"`
 	OrderPipelinesWithinGroup OrderInstancedPipelinesCommand `command:"order-instanced-pipelines" alias:"oip"  description:"Orders instanced pipelines within an instance group"`
 
@@ -72,9 +83,11 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds       BuildsCommand       `command:"builds"      alias:"bs" description:"List builds data"`
+	AbortBuild   AbortBuildCommand   `command:"abort-build" alias:"ab" description:"Abort a build"`
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve or reject a build waiting on an approve step"`
+	RerunBuild   RerunBuildCommand   `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	DiffBuilds   DiffBuildsCommand   `command:"diff-builds" alias:"db" description:"Compare two builds"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
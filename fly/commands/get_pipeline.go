@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"sigs.k8s.io/yaml"
 
@@ -14,12 +15,14 @@ import (
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
 	"github.com/mattn/go-isatty"
 )
 
 type GetPipelineCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get configuration of this pipeline"`
 	JSON     bool                     `short:"j" long:"json"                     description:"Print config as json instead of yaml"`
+	Imports  bool                     `long:"imports"                            description:"Print where each imported job, resource, and resource type came from"`
 	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
 }
 
@@ -64,9 +67,66 @@ func (command *GetPipelineCommand) Execute(args []string) error {
 		return errors.New("pipeline not found")
 	}
 
+	if command.Imports {
+		return command.showImports(config.Imports)
+	}
+
 	return dump(config, command.JSON)
 }
 
+func (command *GetPipelineCommand) showImports(imports atc.ImportConfigs) error {
+	if command.JSON {
+		return displayhelpers.JsonPrint(imports)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "kind", Color: color.New(color.Bold)},
+			{Contents: "source", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "digest", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, imp := range imports {
+		if imp.Resolved == nil {
+			continue
+		}
+
+		source := ui.TableCell{Contents: imp.File}
+		version := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if imp.Library != "" {
+			source.Contents = "library:" + imp.Library
+			version.Contents = strconv.Itoa(imp.Resolved.Version)
+			version.Color = nil
+		}
+
+		kinds := []struct {
+			kind  string
+			names []string
+		}{
+			{"resource type", imp.Resolved.ResourceTypes},
+			{"resource", imp.Resolved.Resources},
+			{"job", imp.Resolved.Jobs},
+		}
+
+		for _, k := range kinds {
+			for _, name := range k.names {
+				table.Data = append(table.Data, ui.TableRow{
+					{Contents: name},
+					{Contents: k.kind},
+					source,
+					version,
+					{Contents: imp.Resolved.Digest},
+				})
+			}
+		}
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
 func dump(config atc.Config, asJSON bool) error {
 	var payload []byte
 	var err error
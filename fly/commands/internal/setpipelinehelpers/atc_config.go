@@ -1,9 +1,11 @@
 package setpipelinehelpers
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configimport"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
@@ -27,6 +30,7 @@ type ATCConfig struct {
 	Target           string
 	SkipInteraction  bool
 	CheckCredentials bool
+	DryRun           bool
 	CommandWarnings  []concourse.ConfigWarning
 	GivenTeamName    string
 }
@@ -46,20 +50,51 @@ func (atcConfig ATCConfig) ApplyConfigInteraction() bool {
 }
 
 func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTemplateWithParams) error {
+	_, err := atcConfig.SetWithResult(yamlTemplateWithParams)
+	return err
+}
+
+// SetWithResult is like Set, but also returns a short description of what
+// happened to the pipeline: "created", "updated", "unchanged", "bailed out",
+// or "dry run".
+func (atcConfig ATCConfig) SetWithResult(yamlTemplateWithParams templatehelpers.YamlTemplateWithParams) (string, error) {
 	evaluatedTemplate, err := yamlTemplateWithParams.Evaluate(false, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	existingConfig, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineRef)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var newConfig atc.Config
 	err = yaml.Unmarshal([]byte(evaluatedTemplate), &newConfig)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if len(newConfig.Imports) > 0 {
+		resolver := configimport.Resolver{
+			Files:     configimport.Dir(filepath.Dir(string(yamlTemplateWithParams.Path()))),
+			Libraries: atcConfig.Team,
+		}
+
+		err = resolver.Resolve(&newConfig)
+		if err != nil {
+			return "", err
+		}
+
+		// the ATC records how the imports were resolved, so send the config
+		// they were merged into rather than the template
+		evaluatedTemplate, err = yaml.Marshal(newConfig)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if atcConfig.DryRun {
+		return atcConfig.dryRun(evaluatedTemplate)
 	}
 
 	configWarnings, _ := configvalidate.Validate(newConfig)
@@ -78,7 +113,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 
 	if !diffExists {
 		fmt.Println("no changes to apply")
-		return nil
+		return "unchanged", nil
 	}
 
 	fmt.Println(bold("pipeline name: ") + atcConfig.PipelineRef.Name)
@@ -86,7 +121,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		fmt.Println(bold("pipeline instance vars:"))
 		instanceVarsBytes, err := yaml.Marshal(atcConfig.PipelineRef.InstanceVars)
 		if err != nil {
-			return err
+			return "", err
 		}
 		fmt.Println(indent(string(instanceVarsBytes), "  "))
 	}
@@ -94,7 +129,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 
 	pipeline, _, err := atcConfig.Team.Pipeline(atcConfig.PipelineRef)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if pipeline.ParentJobID != 0 && pipeline.ParentBuildID != 0 {
 		fmt.Println("\x1b[1;33mWARNING: pipeline has been configured through the 'set_pipeline' step, your changes may be overwritten on the next 'set_pipeline' step execution\x1b[0m")
@@ -103,7 +138,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 
 	if !atcConfig.ApplyConfigInteraction() {
 		fmt.Println("bailing out")
-		return nil
+		return "bailed out", nil
 	}
 
 	created, updated, warnings, err := atcConfig.Team.CreateOrUpdatePipelineConfig(
@@ -113,12 +148,12 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		atcConfig.CheckCredentials,
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	updatedPipeline, _, err := atcConfig.Team.Pipeline(atcConfig.PipelineRef)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if len(warnings) > 0 {
@@ -126,7 +161,76 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 	}
 
 	atcConfig.showPipelineUpdateResult(updatedPipeline, created, updated)
-	return nil
+
+	if created {
+		return "created", nil
+	}
+
+	return "updated", nil
+}
+
+// dryRun shows what setting the pipeline would change and whether the ATC
+// would accept it, without setting it. It returns an error if the config
+// would be rejected.
+func (atcConfig ATCConfig) dryRun(evaluatedTemplate []byte) (string, error) {
+	result, err := atcConfig.Team.DryRunPipelineConfig(atcConfig.PipelineRef, evaluatedTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	for _, w := range result.Warnings {
+		atcConfig.CommandWarnings = append(atcConfig.CommandWarnings, concourse.ConfigWarning{
+			Type:    w.Type,
+			Message: w.Message,
+		})
+	}
+
+	if len(atcConfig.CommandWarnings) > 0 {
+		displayhelpers.ShowWarnings(atcConfig.CommandWarnings)
+	}
+
+	if len(result.Changes) == 0 {
+		fmt.Println("no changes to apply")
+	} else {
+		fmt.Println(bold("pipeline name: ") + atcConfig.PipelineRef.Name)
+		if result.NewPipeline {
+			fmt.Println("the pipeline does not exist yet and would be created")
+		}
+		fmt.Println()
+
+		stdout, _ := ui.ForTTY(os.Stdout)
+		for _, change := range result.Changes {
+			change.Render(stdout)
+		}
+	}
+
+	fmt.Println()
+
+	if len(result.CredentialErrors) > 0 {
+		fmt.Println(bold("credential errors:"))
+		for _, credErr := range result.CredentialErrors {
+			fmt.Println("  - " + credErr)
+		}
+		fmt.Println()
+	}
+
+	if result.Policy.Allowed {
+		fmt.Println("policy check passed")
+	} else {
+		fmt.Println(bold("policy check failed:"))
+		for _, reason := range result.Policy.Reasons {
+			fmt.Println("  - " + reason)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("dry run: the pipeline was not set")
+
+	if result.Rejected() {
+		return "", errors.New("the pipeline config would be rejected")
+	}
+
+	return "dry run", nil
 }
 
 func (atcConfig ATCConfig) UnpausePipelineCommand() string {
@@ -0,0 +1,218 @@
+package targetgrouphelpers
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/vito/go-interact/interact"
+)
+
+// Result is the outcome of running against one of a group's targets.
+type Result struct {
+	Target rc.TargetName
+	Value  interface{}
+	Err    error
+}
+
+type jsonResult struct {
+	Target rc.TargetName `json:"target"`
+	Result interface{}   `json:"result,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// Fetch loads each of the targets and runs fn against them concurrently. The
+// results are in the same order as the targets.
+func Fetch(targetNames []rc.TargetName, tracing bool, fn func(rc.Target) (interface{}, error)) []Result {
+	results := make([]Result, len(targetNames))
+
+	wg := new(sync.WaitGroup)
+	for i, targetName := range targetNames {
+		wg.Add(1)
+
+		go func(i int, targetName rc.TargetName) {
+			defer wg.Done()
+
+			results[i].Target = targetName
+
+			target, err := loadTarget(targetName, tracing)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+
+			results[i].Value, results[i].Err = fn(target)
+		}(i, targetName)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Table merges the tables built for each successful result into one, with a
+// leading target column.
+func Table(results []Result, tableFor func(interface{}) ui.Table) ui.Table {
+	merged := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "target", Color: color.New(color.Bold)},
+		},
+	}
+
+	headersSet := false
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		table := tableFor(result.Value)
+		if !headersSet {
+			merged.Headers = append(merged.Headers, table.Headers...)
+			headersSet = true
+		}
+
+		for _, row := range table.Data {
+			merged.Data = append(merged.Data, append(ui.TableRow{{Contents: string(result.Target)}}, row...))
+		}
+	}
+
+	return merged
+}
+
+// JSON converts the results to a form which can be printed as JSON, listing
+// each target's name alongside its result or error.
+func JSON(results []Result) interface{} {
+	converted := []jsonResult{}
+	for _, result := range results {
+		r := jsonResult{Target: result.Target}
+		if result.Err != nil {
+			r.Error = result.Err.Error()
+		} else {
+			r.Result = result.Value
+		}
+
+		converted = append(converted, r)
+	}
+
+	return converted
+}
+
+// ShowErrors prints the error for each target that failed, returning an error
+// if any did.
+func ShowErrors(results []Result) error {
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(ui.Stderr, "%s: %s\n", ui.Embolden("%s", result.Target), result.Err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d targets", failed, len(results))
+	}
+
+	return nil
+}
+
+// Apply runs fn against each of the targets in turn. Unless skipInteraction
+// is set, each target must be confirmed before fn is run against it. fn
+// returns a short description of what it did, which is shown in a summary
+// once every target has been visited.
+func Apply(targetNames []rc.TargetName, tracing bool, skipInteraction bool, fn func(rc.TargetName, rc.Target) (string, error)) error {
+	results := make([]Result, len(targetNames))
+
+	for i, targetName := range targetNames {
+		results[i].Target = targetName
+
+		fmt.Println()
+		fmt.Println("target:", ui.Embolden("%s", targetName))
+
+		target, err := loadTarget(targetName, tracing)
+		if err != nil {
+			fmt.Fprintln(ui.Stderr, "error:", err)
+			results[i].Err = err
+			continue
+		}
+
+		if !skipInteraction {
+			confirm := false
+			err = interact.NewInteraction(fmt.Sprintf("apply to %s?", targetName)).Resolve(&confirm)
+			if err != nil {
+				return err
+			}
+
+			if !confirm {
+				results[i].Value = "skipped"
+				continue
+			}
+		}
+
+		results[i].Value, results[i].Err = fn(targetName, target)
+		if results[i].Err != nil {
+			fmt.Fprintln(ui.Stderr, "error:", results[i].Err)
+		}
+	}
+
+	return showSummary(results)
+}
+
+func showSummary(results []Result) error {
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "target", Color: color.New(color.Bold)},
+			{Contents: "result", Color: color.New(color.Bold)},
+		},
+	}
+
+	var failed int
+	for _, result := range results {
+		outcome := ui.TableCell{}
+		if result.Err != nil {
+			failed++
+			outcome.Contents = "errored"
+			outcome.Color = ui.ErroredColor
+		} else {
+			outcome.Contents = result.Value.(string)
+			if outcome.Contents == "skipped" {
+				outcome.Color = ui.OffColor
+			} else {
+				outcome.Color = ui.SucceededColor
+			}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: string(result.Target)},
+			outcome,
+		})
+	}
+
+	fmt.Println()
+	err := table.Render(os.Stdout, true)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d targets", failed, len(results))
+	}
+
+	return nil
+}
+
+func loadTarget(targetName rc.TargetName, tracing bool) (rc.Target, error) {
+	target, err := rc.LoadTarget(targetName, tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
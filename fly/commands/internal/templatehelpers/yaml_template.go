@@ -34,6 +34,11 @@ func NewYamlTemplateWithParams(
 	}
 }
 
+// Path is the path to the template file.
+func (yamlTemplate YamlTemplateWithParams) Path() atc.PathFlag {
+	return yamlTemplate.filePath
+}
+
 func (yamlTemplate YamlTemplateWithParams) Evaluate(
 	allowEmpty bool,
 	strict bool,
@@ -0,0 +1,114 @@
+package validatepipelinehelpers
+
+import (
+	"github.com/concourse/concourse/atc/configvalidate"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// SARIFLog is a minimal SARIF 2.1.0 log, which is understood by code scanning
+// tools such as GitHub's.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func NewSARIFLog(path string, rules []configvalidate.LintRule, findings []configvalidate.LintFinding) SARIFLog {
+	driver := SARIFDriver{
+		Name:           "fly validate-pipeline",
+		InformationURI: "https://concourse-ci.org",
+		Rules:          []SARIFRule{},
+	}
+
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, SARIFRule{
+			ID:               rule.ID(),
+			ShortDescription: SARIFMessage{Text: rule.Description()},
+		})
+	}
+
+	results := []SARIFResult{}
+	for _, finding := range findings {
+		location := SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: path},
+		}
+
+		if finding.Line != 0 {
+			location.Region = &SARIFRegion{
+				StartLine:   finding.Line,
+				StartColumn: finding.Column,
+			}
+		}
+
+		results = append(results, SARIFResult{
+			RuleID:    finding.RuleID,
+			Level:     "warning",
+			Message:   SARIFMessage{Text: finding.Message},
+			Locations: []SARIFLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	return SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{
+			{
+				Tool:    SARIFTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
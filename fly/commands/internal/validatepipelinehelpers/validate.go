@@ -3,17 +3,66 @@ package validatepipelinehelpers
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/concourse/concourse/atc"
 
+	"github.com/concourse/concourse/atc/configimport"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"sigs.k8s.io/yaml"
 )
 
-func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool, output bool, enableAcrossStep bool) error {
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// LintOptions configures the semantic lint rules run by Validate.
+type LintOptions struct {
+	// Enabled runs the lint rules, failing validation if they report any
+	// findings.
+	Enabled bool
+
+	// Rules are the IDs of optional rules to run in addition to
+	// configvalidate.LintRules.
+	Rules []string
+
+	// Format is the format to report findings in.
+	Format string
+}
+
+func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, libraries configimport.Libraries, strict bool, output bool, enableAcrossStep bool, lintOptions LintOptions) error {
+	format := lintOptions.Format
+	if format != "" && format != FormatText {
+		if !lintOptions.Enabled {
+			return fmt.Errorf("--format %s requires --lint", format)
+		}
+
+		if output {
+			return fmt.Errorf("--format %s cannot be used with --output", format)
+		}
+	}
+
+	if len(lintOptions.Rules) > 0 && !lintOptions.Enabled {
+		return errors.New("--enable-lint-rule requires --lint")
+	}
+
+	rules := append([]configvalidate.LintRule{}, configvalidate.LintRules...)
+	for _, id := range lintOptions.Rules {
+		rule, found := configvalidate.LookupLintRule(id)
+		if !found {
+			return fmt.Errorf("unknown lint rule '%s'", id)
+		}
+
+		rules = append(rules, rule)
+	}
+
 	evaluatedTemplate, err := yamlTemplate.Evaluate(true, strict)
 	if err != nil {
 		return err
@@ -32,6 +81,23 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 		}
 	}
 
+	if len(unmarshalledTemplate.Imports) > 0 {
+		resolver := configimport.Resolver{
+			Files:     configimport.Dir(filepath.Dir(string(yamlTemplate.Path()))),
+			Libraries: libraries,
+		}
+
+		err = resolver.Resolve(&unmarshalledTemplate)
+		if err != nil {
+			return err
+		}
+
+		evaluatedTemplate, err = yaml.Marshal(unmarshalledTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
 	if enableAcrossStep {
 		atc.EnableAcrossStep = true
 	}
@@ -50,10 +116,22 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 		displayhelpers.ShowErrors("Error loading existing config", errorMessages)
 	}
 
-	if len(errorMessages) > 0 || (strict && len(warnings) > 0) {
+	var findings []configvalidate.LintFinding
+	if lintOptions.Enabled && len(errorMessages) == 0 {
+		findings, err = lint(yamlTemplate, unmarshalledTemplate, rules, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(errorMessages) > 0 || (strict && len(warnings) > 0) || len(findings) > 0 {
 		return errors.New("configuration invalid")
 	}
 
+	if format == FormatJSON || format == FormatSARIF {
+		return nil
+	}
+
 	if output {
 		fmt.Println(string(evaluatedTemplate))
 	} else {
@@ -62,3 +140,96 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 
 	return nil
 }
+
+// lint runs the semantic lint rules against the config and reports the
+// findings in the given format. Machine-readable reports are always printed to
+// stdout, even if there are no findings, so that they can be collected by CI.
+//
+// The rules are run against the config as written, before any ((vars)) are
+// interpolated, so that secrets passed in with --var aren't reported as being
+// hard-coded. Jobs which only exist once imports are resolved are linted as
+// they were imported.
+func lint(yamlTemplate templatehelpers.YamlTemplateWithParams, evaluated atc.Config, rules []configvalidate.LintRule, format string) ([]configvalidate.LintFinding, error) {
+	source, err := ioutil.ReadFile(string(yamlTemplate.Path()))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %s", err.Error())
+	}
+
+	config, err := uninterpolatedConfig(source, evaluated)
+	if err != nil {
+		// a ((var)) is standing in for a value which isn't a string, so fall
+		// back to the interpolated config, which can't be checked for
+		// hard-coded secrets
+		config = evaluated
+
+		var interpolatedRules []configvalidate.LintRule
+		for _, rule := range rules {
+			if _, ok := rule.(configvalidate.HardcodedSecretRule); !ok {
+				interpolatedRules = append(interpolatedRules, rule)
+			}
+		}
+
+		rules = interpolatedRules
+	}
+
+	findings, err := configvalidate.Lint(config, source, rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSON:
+		err = displayhelpers.JsonPrint(findings)
+	case FormatSARIF:
+		err = displayhelpers.JsonPrint(NewSARIFLog(string(yamlTemplate.Path()), rules, findings))
+	default:
+		showFindings(string(yamlTemplate.Path()), findings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+// uninterpolatedConfig loads the config from its source without
+// interpolating any ((vars)), adding the jobs which were imported into the
+// evaluated config.
+func uninterpolatedConfig(source []byte, evaluated atc.Config) (atc.Config, error) {
+	var config atc.Config
+	err := yaml.Unmarshal(source, &config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	for _, job := range evaluated.Jobs {
+		if _, found := config.Jobs.Lookup(job.Name); !found {
+			config.Jobs = append(config.Jobs, job)
+		}
+	}
+
+	return config, nil
+}
+
+func showFindings(path string, findings []configvalidate.LintFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(ui.Stderr, "")
+	displayhelpers.PrintWarningHeader()
+
+	fmt.Fprintln(ui.Stderr, "Lint findings:")
+	for _, finding := range findings {
+		location := path
+		if finding.Line != 0 {
+			location = fmt.Sprintf("%s:%d", path, finding.Line)
+		}
+
+		fmt.Fprintf(ui.Stderr, "  - %s: %s (%s)\n", location, finding.Message, finding.RuleID)
+	}
+
+	fmt.Fprintln(ui.Stderr, "")
+	fmt.Fprintln(ui.Stderr, "findings can be disabled with a `# lint:disable <rule-id>` comment on or above the offending line")
+	fmt.Fprintln(ui.Stderr, "")
+}
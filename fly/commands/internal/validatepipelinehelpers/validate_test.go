@@ -4,9 +4,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/commands/internal/validatepipelinehelpers"
+	"github.com/concourse/concourse/vars"
 
 	"github.com/concourse/concourse/atc"
 
@@ -21,6 +24,10 @@ var _ = Describe("Validate Pipeline", func() {
 		var goodPipeline templatehelpers.YamlTemplateWithParams
 		var dupkeyPipeline templatehelpers.YamlTemplateWithParams
 		var goodAcrossPipeline templatehelpers.YamlTemplateWithParams
+		var lintPipeline templatehelpers.YamlTemplateWithParams
+		var lintDisabledPipeline templatehelpers.YamlTemplateWithParams
+		var pinnedLintPipeline templatehelpers.YamlTemplateWithParams
+		var secretLintPipeline templatehelpers.YamlTemplateWithParams
 
 		BeforeEach(func() {
 			var err error
@@ -40,15 +47,22 @@ resource_types:
   type: registry-image
   source:
     repository: bar/bar
+resources:
+- name: some-repo
+  type: git
+  source: {uri: https://example.com/repo.git}
 jobs:
 - name: hello-world
   plan:
+  - get: some-repo
+    trigger: true
   - task: say-hello
+    timeout: 5m
     config:
       platform: linux
       image_resource:
         type: registry-image
-        source: {repository: ubuntu}
+        source: {repository: ubuntu, tag: focal}
       run:
         path: echo
         args: ["Hello, world!"]
@@ -123,9 +137,58 @@ jobs:
 			)
 			Expect(err).NotTo(HaveOccurred())
 
+			lintConfig := `---
+jobs:
+- name: hello-world
+  plan:
+  - task: say-hello
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ubuntu}
+      run:
+        path: echo
+        args: ["Hello, world!"]
+`
+
+			err = ioutil.WriteFile(filepath.Join(tmpdir, "lint-pipeline.yml"), []byte(lintConfig), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(
+				filepath.Join(tmpdir, "lint-disabled-pipeline.yml"),
+				[]byte("# lint:disable-file no-trigger, task-timeout, unpinned-image\n"+lintConfig),
+				0644,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(
+				filepath.Join(tmpdir, "pinned-lint-pipeline.yml"),
+				[]byte(strings.Replace(lintConfig, "{repository: ubuntu}", "{repository: ubuntu, tag: focal}", 1)),
+				0644,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(
+				filepath.Join(tmpdir, "secret-lint-pipeline.yml"),
+				[]byte(strings.Replace(lintConfig, "{repository: ubuntu}", "{repository: ubuntu, tag: focal}\n      params:\n        PASSWORD: ((password))", 1)),
+				0644,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
 			goodPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "good-pipeline.yml")), nil, nil, nil, nil)
 			dupkeyPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "dupkey-pipeline.yml")), nil, nil, nil, nil)
 			goodAcrossPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "good-across-pipeline.yml")), nil, nil, nil, nil)
+			lintPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "lint-pipeline.yml")), nil, nil, nil, nil)
+			lintDisabledPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "lint-disabled-pipeline.yml")), nil, nil, nil, nil)
+			pinnedLintPipeline = templatehelpers.NewYamlTemplateWithParams(atc.PathFlag(filepath.Join(tmpdir, "pinned-lint-pipeline.yml")), nil, nil, nil, nil)
+			secretLintPipeline = templatehelpers.NewYamlTemplateWithParams(
+				atc.PathFlag(filepath.Join(tmpdir, "secret-lint-pipeline.yml")),
+				nil,
+				[]flaghelpers.VariablePairFlag{{Ref: vars.Reference{Path: "password"}, Value: "hunter2"}},
+				nil,
+				nil,
+			)
 		})
 
 		AfterEach(func() {
@@ -133,32 +196,76 @@ jobs:
 		})
 
 		It("validates a good pipeline", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with strict", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, nil, true, false, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with output", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, true, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, nil, true, true, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).To(BeNil())
 		})
 		It("do not fail validating a pipeline with repeated resource types (probably should but for compat doesn't)", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).To(BeNil())
 		})
 		It("fail validating a pipeline with repeated resource types with strict", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, nil, true, false, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).ToNot(BeNil())
 		})
 		It("fail validating a pipeline using experimental `across` without the command flag enabling it", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(goodAcrossPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{})
 			Expect(err).ToNot(BeNil())
 		})
 		It("validates a pipeline using experimental `across` when the command flag enabling it is present", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, true)
+			err := validatepipelinehelpers.Validate(goodAcrossPipeline, nil, false, false, true, validatepipelinehelpers.LintOptions{})
 			Expect(err).To(BeNil())
 		})
+		It("does not lint a pipeline without lint", func() {
+			err := validatepipelinehelpers.Validate(lintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{})
+			Expect(err).To(BeNil())
+		})
+		It("does not lint a pipeline with strict", func() {
+			err := validatepipelinehelpers.Validate(lintPipeline, nil, true, false, false, validatepipelinehelpers.LintOptions{})
+			Expect(err).To(BeNil())
+		})
+		It("fail validating a pipeline with lint findings with lint", func() {
+			err := validatepipelinehelpers.Validate(lintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true})
+			Expect(err).ToNot(BeNil())
+		})
+		It("fail validating a pipeline with lint findings with lint in a machine-readable format", func() {
+			err := validatepipelinehelpers.Validate(lintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true, Format: "sarif"})
+			Expect(err).ToNot(BeNil())
+		})
+		It("validates a pipeline with lint when its lint findings are disabled", func() {
+			err := validatepipelinehelpers.Validate(lintDisabledPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true, Format: "json"})
+			Expect(err).To(BeNil())
+		})
+		It("validates a pipeline with lint when only optional rules would report findings", func() {
+			err := validatepipelinehelpers.Validate(pinnedLintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true})
+			Expect(err).To(BeNil())
+		})
+		It("fail validating a pipeline with lint when an enabled optional rule reports findings", func() {
+			err := validatepipelinehelpers.Validate(pinnedLintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true, Rules: []string{"task-timeout"}})
+			Expect(err).ToNot(BeNil())
+		})
+		It("fail validating with an unknown lint rule", func() {
+			err := validatepipelinehelpers.Validate(pinnedLintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true, Rules: []string{"bogus"}})
+			Expect(err).To(MatchError("unknown lint rule 'bogus'"))
+		})
+		It("fail validating with an optional lint rule without lint", func() {
+			err := validatepipelinehelpers.Validate(pinnedLintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Rules: []string{"task-timeout"}})
+			Expect(err).To(MatchError("--enable-lint-rule requires --lint"))
+		})
+		It("lints a pipeline before its vars are interpolated", func() {
+			err := validatepipelinehelpers.Validate(secretLintPipeline, nil, false, false, false, validatepipelinehelpers.LintOptions{Enabled: true})
+			Expect(err).To(BeNil())
+		})
+		It("fail validating with a machine-readable format without lint", func() {
+			err := validatepipelinehelpers.Validate(goodPipeline, nil, true, false, false, validatepipelinehelpers.LintOptions{Format: "json"})
+			Expect(err).To(MatchError("--format json requires --lint"))
+		})
 	})
 })
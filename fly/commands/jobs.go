@@ -6,6 +6,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -16,13 +17,14 @@ type JobsCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get jobs in this pipeline"`
 	Json     bool                     `long:"json" description:"Print command result as JSON"`
 	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Show the pipeline's jobs on each target in this group"`
 }
 
 func (command *JobsCommand) Execute([]string) error {
-	var (
-		headers []string
-		team    concourse.Team
-	)
+	if command.TargetGroup != "" {
+		return command.executeOnGroup()
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -34,30 +36,66 @@ func (command *JobsCommand) Execute([]string) error {
 		return err
 	}
 
-	if command.Team != "" {
-		team, err = target.FindTeam(command.Team)
+	jobs, err := command.fetch(target)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(jobs)
 		if err != nil {
 			return err
 		}
-	} else {
-		team = target.Team()
+		return nil
 	}
 
-	var jobs []atc.Job
-	jobs, err = team.ListJobs(command.Pipeline.Ref())
+	return command.tableFor(jobs).Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *JobsCommand) executeOnGroup() error {
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
 	if err != nil {
 		return err
 	}
 
+	results := targetgrouphelpers.Fetch(targetNames, Fly.Verbose, func(target rc.Target) (interface{}, error) {
+		return command.fetch(target)
+	})
+
 	if command.Json {
-		err = displayhelpers.JsonPrint(jobs)
+		return displayhelpers.JsonPrint(targetgrouphelpers.JSON(results))
+	}
+
+	table := targetgrouphelpers.Table(results, func(value interface{}) ui.Table {
+		return command.tableFor(value.([]atc.Job))
+	})
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	return targetgrouphelpers.ShowErrors(results)
+}
+
+func (command *JobsCommand) fetch(target rc.Target) ([]atc.Job, error) {
+	var team concourse.Team
+	var err error
+
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		return nil
+	} else {
+		team = target.Team()
 	}
 
-	headers = []string{"name", "paused", "status", "next"}
+	return team.ListJobs(command.Pipeline.Ref())
+}
+
+func (command *JobsCommand) tableFor(jobs []atc.Job) ui.Table {
+	headers := []string{"name", "paused", "status", "next"}
 	table := ui.Table{Headers: ui.TableRow{}}
 	for _, h := range headers {
 		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
@@ -96,5 +134,5 @@ func (command *JobsCommand) Execute([]string) error {
 		table.Data = append(table.Data, row)
 	}
 
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+	return table
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/go-concourse/concourse"
 )
@@ -14,6 +15,9 @@ type PausePipelineCommand struct {
 	Pipeline *flaghelpers.PipelineFlag `short:"p"   long:"pipeline" description:"Pipeline to pause"`
 	All      bool                      `short:"a"   long:"all"      description:"Pause all pipelines"`
 	Team     string                    `long:"team"                 description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	TargetGroup     rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Pause the pipeline on each target in this group"`
+	SkipInteractive bool               `short:"n" long:"non-interactive" description:"Pause the pipeline on each target in the group without confirmation"`
 }
 
 func (command *PausePipelineCommand) Validate() error {
@@ -35,6 +39,22 @@ func (command *PausePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
+	if command.TargetGroup != "" {
+		targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+		if err != nil {
+			return err
+		}
+
+		return targetgrouphelpers.Apply(targetNames, Fly.Verbose, command.SkipInteractive, func(_ rc.TargetName, target rc.Target) (string, error) {
+			err := command.pause(target)
+			if err != nil {
+				return "", err
+			}
+
+			return "paused", nil
+		})
+	}
+
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -45,7 +65,12 @@ func (command *PausePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
+	return command.pause(target)
+}
+
+func (command *PausePipelineCommand) pause(target rc.Target) error {
 	var team concourse.Team
+	var err error
 	if command.Team != "" {
 		team, err = target.FindTeam(command.Team)
 		if err != nil {
@@ -80,7 +105,7 @@ func (command *PausePipelineCommand) Execute(args []string) error {
 		if found {
 			fmt.Printf("paused '%s'\n", pipelineRef.String())
 		} else {
-			displayhelpers.Failf("pipeline '%s' not found\n", pipelineRef.String())
+			return fmt.Errorf("pipeline '%s' not found", pipelineRef.String())
 		}
 	}
 
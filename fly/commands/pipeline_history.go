@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Show the config history of this pipeline"`
+	Diff     int                      `short:"d" long:"diff"     value-name:"VERSION" description:"Show what changed in the given version"`
+	JSON     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *PipelineHistoryCommand) Execute(args []string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := pipelineTeam(target, command.Team)
+	if err != nil {
+		return err
+	}
+
+	history, found, err := team.PipelineConfigHistory(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Diff != 0 {
+		return showConfigChange(history, command.Diff)
+	}
+
+	if command.JSON {
+		return displayhelpers.JsonPrint(history)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "author", Color: color.New(color.Bold)},
+			{Contents: "set at", Color: color.New(color.Bold)},
+		},
+	}
+
+	for i, entry := range history {
+		version := ui.TableCell{Contents: strconv.Itoa(entry.Version)}
+		if i == 0 {
+			version.Contents += " (current)"
+		}
+
+		author := ui.TableCell{Contents: entry.Author}
+		if entry.Author == "" {
+			author = ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			version,
+			author,
+			{Contents: time.Unix(entry.CreatedAt, 0).Local().Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+// showConfigChange prints the difference between the given version and the
+// version before it. The oldest version in the history is compared to an
+// empty config.
+func showConfigChange(history []atc.ConfigHistoryEntry, version int) error {
+	i, found := findConfigVersion(history, version)
+	if !found {
+		return fmt.Errorf("version %d is not in the pipeline's config history", version)
+	}
+
+	var previous atc.Config
+	if i+1 < len(history) {
+		previous = history[i+1].Config
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !previous.Diff(stdout, history[i].Config) {
+		fmt.Println("no changes")
+	}
+
+	return nil
+}
+
+func findConfigVersion(history []atc.ConfigHistoryEntry, version int) (int, bool) {
+	for i, entry := range history {
+		if entry.Version == version {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func pipelineTeam(target rc.Target, teamName string) (concourse.Team, error) {
+	if teamName != "" {
+		return target.FindTeam(teamName)
+	}
+
+	return target.Team(), nil
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineSyncCommand struct {
+	Sync bool   `long:"sync" description:"Sync the pipelines now instead of waiting for the next interval"`
+	JSON bool   `long:"json" description:"Print command result as JSON"`
+	Team string `long:"team" description:"Name of the team whose pipelines to show, if different from the target default"`
+}
+
+func (command *PipelineSyncCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := pipelineTeam(target, command.Team)
+	if err != nil {
+		return err
+	}
+
+	if command.Sync {
+		found, err := team.SyncPipelines()
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errors.New("team does not sync its pipelines")
+		}
+
+		fmt.Printf("started syncing pipelines of team '%s'\n", team.Name())
+		return nil
+	}
+
+	response, found, err := team.PipelineSync()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("team does not sync its pipelines")
+	}
+
+	if command.JSON {
+		return displayhelpers.JsonPrint(response)
+	}
+
+	fmt.Printf("syncing %s from %s", ui.Embolden("%s", response.Config.Paths), ui.Embolden("%s", response.Config.URI))
+	if response.Config.Branch != "" {
+		fmt.Printf(" (branch %s)", ui.Embolden("%s", response.Config.Branch))
+	}
+	fmt.Printf("\n\n")
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "file", Color: color.New(color.Bold)},
+			{Contents: "commit", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "drifted", Color: color.New(color.Bold)},
+			{Contents: "synced at", Color: color.New(color.Bold)},
+			{Contents: "error", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, status := range response.Pipelines {
+		commit := ui.TableCell{Contents: status.Commit}
+		if len(status.Commit) > 7 {
+			commit.Contents = status.Commit[:7]
+		} else if status.Commit == "" {
+			commit = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		statusCell := ui.TableCell{Contents: status.Status}
+		switch status.Status {
+		case atc.PipelineSyncStatusSynced:
+			statusCell.Color = ui.SucceededColor
+		case atc.PipelineSyncStatusErrored:
+			statusCell.Color = ui.ErroredColor
+		case atc.PipelineSyncStatusArchived:
+			statusCell.Color = ui.OffColor
+		}
+
+		drifted := ui.TableCell{Contents: "no"}
+		if status.Drifted {
+			drifted = ui.TableCell{Contents: "yes", Color: ui.StartedColor}
+		}
+
+		errorCell := ui.TableCell{Contents: status.Error}
+		if status.Error == "" {
+			errorCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: status.Pipeline},
+			{Contents: status.File},
+			commit,
+			statusCell,
+			drifted,
+			{Contents: time.Unix(status.SyncedAt, 0).Local().Format(timeDateLayout)},
+			errorCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
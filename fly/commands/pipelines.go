@@ -7,6 +7,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/fatih/color"
@@ -16,9 +17,15 @@ type PipelinesCommand struct {
 	All             bool `short:"a"  long:"all" description:"Show pipelines across all teams"`
 	IncludeArchived bool `long:"include-archived" description:"Show archived pipelines"`
 	Json            bool `long:"json" description:"Print command result as JSON"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Show pipelines on each target in this group"`
 }
 
 func (command *PipelinesCommand) Execute([]string) error {
+	if command.TargetGroup != "" {
+		return command.executeOnGroup()
+	}
+
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -29,20 +36,11 @@ func (command *PipelinesCommand) Execute([]string) error {
 		return err
 	}
 
-	var unfilteredPipelines []atc.Pipeline
-
-	if command.All {
-		unfilteredPipelines, err = target.Client().ListPipelines()
-	} else {
-		unfilteredPipelines, err = target.Team().ListPipelines()
-	}
+	pipelines, err := command.fetch(target)
 	if err != nil {
 		return err
 	}
 
-	headers := command.buildHeader()
-	pipelines := command.filterPipelines(unfilteredPipelines)
-
 	if command.Json {
 		err = displayhelpers.JsonPrint(pipelines)
 		if err != nil {
@@ -51,6 +49,54 @@ func (command *PipelinesCommand) Execute([]string) error {
 		return nil
 	}
 
+	return command.tableFor(pipelines).Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *PipelinesCommand) executeOnGroup() error {
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+	if err != nil {
+		return err
+	}
+
+	results := targetgrouphelpers.Fetch(targetNames, Fly.Verbose, func(target rc.Target) (interface{}, error) {
+		return command.fetch(target)
+	})
+
+	if command.Json {
+		return displayhelpers.JsonPrint(targetgrouphelpers.JSON(results))
+	}
+
+	table := targetgrouphelpers.Table(results, func(value interface{}) ui.Table {
+		return command.tableFor(value.([]atc.Pipeline))
+	})
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	return targetgrouphelpers.ShowErrors(results)
+}
+
+func (command *PipelinesCommand) fetch(target rc.Target) ([]atc.Pipeline, error) {
+	var unfilteredPipelines []atc.Pipeline
+	var err error
+
+	if command.All {
+		unfilteredPipelines, err = target.Client().ListPipelines()
+	} else {
+		unfilteredPipelines, err = target.Team().ListPipelines()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return command.filterPipelines(unfilteredPipelines), nil
+}
+
+func (command *PipelinesCommand) tableFor(pipelines []atc.Pipeline) ui.Table {
+	headers := command.buildHeader()
+
 	table := ui.Table{Headers: ui.TableRow{}}
 	for _, h := range headers {
 		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
@@ -99,7 +145,7 @@ func (command *PipelinesCommand) Execute([]string) error {
 		table.Data = append(table.Data, row)
 	}
 
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+	return table
 }
 
 func (command *PipelinesCommand) buildHeader() []string {
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type RollbackPipelineCommand struct {
+	Pipeline        flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to roll back"`
+	To              int                      `long:"to" required:"true" value-name:"VERSION" description:"Version from the pipeline's config history to roll back to"`
+	SkipInteractive bool                     `short:"n" long:"non-interactive" description:"Skips interactions, uses default values"`
+	Team            string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *RollbackPipelineCommand) Execute(args []string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := pipelineTeam(target, command.Team)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+
+	history, found, err := team.PipelineConfigHistory(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	i, found := findConfigVersion(history, command.To)
+	if !found {
+		return fmt.Errorf("version %d is not in the pipeline's config history", command.To)
+	}
+
+	existingConfig, existingConfigVersion, _, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	// the rollback is saved against the version of the config it was compared
+	// with, so if the pipeline is set again in the meantime it fails rather
+	// than clobbering the new config
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !existingConfig.Diff(stdout, history[i].Config) {
+		fmt.Println("no changes to apply")
+		return nil
+	}
+
+	if !command.SkipInteractive {
+		confirm := false
+		err := interact.NewInteraction(fmt.Sprintf("roll back pipeline '%s' to version %d?", pipelineRef, command.To)).Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	payload, err := yaml.Marshal(history[i].Config)
+	if err != nil {
+		return err
+	}
+
+	_, _, warnings, err := team.CreateOrUpdatePipelineConfig(pipelineRef, existingConfigVersion, payload, false)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("rolled back '%s' to version %d\n", pipelineRef, command.To)
+
+	return nil
+}
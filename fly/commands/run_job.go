@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/executehelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/eventstream"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type RunJobCommand struct {
+	Config         atc.PathFlag                       `short:"c" long:"config"   required:"true"          description:"Pipeline configuration file containing the job"`
+	Job            string                             `short:"j" long:"job"      required:"true"          description:"Name of the job to run"`
+	Inputs         []flaghelpers.InputPairFlag        `short:"i" long:"input"    value-name:"NAME=PATH"   description:"A local directory to use in place of a get step (can be specified multiple times)"`
+	DryRun         bool                               `          long:"dry-run"                           description:"Skip put steps instead of pushing to the resources"`
+	IncludeIgnored bool                               `          long:"include-ignored"                   description:"Including .gitignored paths. Disregards .gitignore entries and uploads everything"`
+	Tags           []string                           `          long:"tag"      value-name:"TAG"         description:"A tag for a specific environment (can be specified multiple times)"`
+	Var            []flaghelpers.VariablePairFlag     `short:"v" long:"var"       value-name:"[NAME=STRING]"  unquote:"false"  description:"Specify a string value to set for a variable in the pipeline"`
+	YAMLVar        []flaghelpers.YAMLVariablePairFlag `short:"y" long:"yaml-var"  value-name:"[NAME=YAML]"    unquote:"false"  description:"Specify a YAML value to set for a variable in the pipeline"`
+	VarsFrom       []atc.PathFlag                     `short:"l" long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+}
+
+func (command *RunJobCommand) Execute(args []string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	config, err := command.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	job, found := config.Jobs.Lookup(command.Job)
+	if !found {
+		return fmt.Errorf("job '%s' not found in %s", command.Job, command.Config)
+	}
+
+	if len(job.Matrix) != 0 {
+		return errors.New("jobs with a matrix cannot be run locally")
+	}
+
+	err = checkForUnknownGets(job, command.Inputs)
+	if err != nil {
+		return err
+	}
+
+	err = executehelpers.CheckForInputType(command.Inputs)
+	if err != nil {
+		return err
+	}
+
+	planFactory := atc.NewPlanFactory(time.Now().Unix())
+
+	localInputs, err := executehelpers.GenerateLocalInputs(
+		planFactory,
+		target.Team(),
+		command.Inputs,
+		command.IncludeIgnored,
+		"",
+		command.Tags,
+	)
+	if err != nil {
+		return err
+	}
+
+	options := builds.OneOffOptions{
+		Artifacts: map[string]int{},
+		DryRun:    command.DryRun,
+	}
+
+	for name, input := range localInputs {
+		options.Artifacts[name] = input.Plan.ArtifactInput.ArtifactID
+	}
+
+	resources := db.SchedulerResources{}
+	for _, resource := range config.Resources {
+		resources = append(resources, db.SchedulerResource{
+			Name:                 resource.Name,
+			Type:                 resource.Type,
+			Source:               resource.Source,
+			ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
+		})
+	}
+
+	// the versions of custom resource types are checked for by the build
+	resourceTypes := atc.VersionedResourceTypes{}
+	for _, resourceType := range config.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.VersionedResourceType{
+			ResourceType: resourceType,
+		})
+	}
+
+	plan, err := builds.NewPlanner(planFactory).CreateOneOff(job.StepConfig(), resources, resourceTypes, options)
+	if err != nil {
+		return err
+	}
+
+	if command.DryRun {
+		for _, output := range job.Outputs() {
+			fmt.Printf("dry run: skipping put to %s\n", ui.Embolden("%s", output.Resource))
+		}
+	}
+
+	client := target.Client()
+	clientURL, err := url.Parse(client.URL())
+	if err != nil {
+		return err
+	}
+
+	build, err := target.Team().CreateBuild(plan)
+	if err != nil {
+		return err
+	}
+
+	buildURL, err := url.Parse(fmt.Sprintf("/builds/%d", build.ID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("running job %s in build %d at %s\n", command.Job, build.ID, clientURL.ResolveReference(buildURL))
+
+	terminate := make(chan os.Signal, 1)
+
+	go abortOnSignal(client, terminate, build)
+
+	signal.Notify(terminate, syscall.SIGINT, syscall.SIGTERM)
+
+	eventSource, err := client.BuildEvents(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	exitCode := eventstream.Render(os.Stdout, eventSource, eventstream.RenderOptions{})
+	eventSource.Close()
+
+	os.Exit(exitCode)
+
+	return nil
+}
+
+func (command *RunJobCommand) loadConfig() (atc.Config, error) {
+	template := templatehelpers.NewYamlTemplateWithParams(
+		command.Config,
+		command.VarsFrom,
+		command.Var,
+		command.YAMLVar,
+		nil,
+	)
+
+	evaluatedTemplate, err := template.Evaluate(false, false)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var config atc.Config
+	err = yaml.Unmarshal(evaluatedTemplate, &config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	warnings, errorMessages := configvalidate.Validate(config)
+
+	if len(warnings) > 0 {
+		configWarnings := make([]concourse.ConfigWarning, len(warnings))
+		for idx, warning := range warnings {
+			configWarnings[idx] = concourse.ConfigWarning(warning)
+		}
+		displayhelpers.ShowWarnings(configWarnings)
+	}
+
+	if len(errorMessages) > 0 {
+		displayhelpers.ShowErrors("Error loading config", errorMessages)
+		return atc.Config{}, errors.New("configuration invalid")
+	}
+
+	return config, nil
+}
+
+func checkForUnknownGets(job atc.JobConfig, inputs []flaghelpers.InputPairFlag) error {
+	gets := map[string]bool{}
+	for _, input := range job.Inputs() {
+		gets[input.Name] = true
+	}
+
+	for _, input := range inputs {
+		if !gets[input.Name] {
+			names := []string{}
+			for name := range gets {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			return fmt.Errorf("unknown get step `%s` (job gets: %v)", input.Name, names)
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/commands/internal/setpipelinehelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -20,6 +21,7 @@ type SetPipelineCommand struct {
 	DisableAnsiColor bool `long:"no-color"               description:"Disable color output"`
 
 	CheckCredentials bool `long:"check-creds"  description:"Validate credential variables against credential manager"`
+	DryRun           bool `long:"dry-run"      description:"Show what would change and whether the config would be accepted, without setting the pipeline"`
 
 	PipelineName string       `short:"p"  long:"pipeline"  required:"true"  description:"Pipeline to configure"`
 	Config       atc.PathFlag `short:"c"  long:"config"    required:"true"  description:"Pipeline configuration file, \"-\" stands for stdin"`
@@ -31,6 +33,8 @@ type SetPipelineCommand struct {
 	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
 
 	Team string `long:"team"              description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Configure the pipeline on each target in this group"`
 }
 
 func (command *SetPipelineCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -56,9 +60,12 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
-	configPath := command.Config
-	templateVariablesFiles := command.VarsFrom
-	pipelineName := command.PipelineName
+
+	ansi.DisableColors(command.DisableAnsiColor)
+
+	if command.TargetGroup != "" {
+		return command.executeOnGroup(warnings)
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -70,19 +77,40 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 		return err
 	}
 
+	_, err = command.set(Fly.Target, target, warnings)
+	return err
+}
+
+func (command *SetPipelineCommand) executeOnGroup(warnings []concourse.ConfigWarning) error {
+	if command.Config.FromStdin() {
+		return errors.New("cannot read the config from stdin when configuring a group of targets")
+	}
+
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+	if err != nil {
+		return err
+	}
+
+	// each target confirms the diff of its own config, so there is no need to
+	// confirm the target separately
+	return targetgrouphelpers.Apply(targetNames, Fly.Verbose, true, func(targetName rc.TargetName, target rc.Target) (string, error) {
+		return command.set(targetName, target, warnings)
+	})
+}
+
+func (command *SetPipelineCommand) set(targetName rc.TargetName, target rc.Target, warnings []concourse.ConfigWarning) (string, error) {
 	var team concourse.Team
+	var err error
 
 	if command.Team != "" {
 		team, err = target.FindTeam(command.Team)
 		if err != nil {
-			return err
+			return "", err
 		}
 	} else {
 		team = target.Team()
 	}
 
-	ansi.DisableColors(command.DisableAnsiColor)
-
 	var instanceVars atc.InstanceVars
 	if len(command.InstanceVars) != 0 {
 		var kvPairs vars.KVPairs
@@ -95,17 +123,18 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	atcConfig := setpipelinehelpers.ATCConfig{
 		Team: team,
 		PipelineRef: atc.PipelineRef{
-			Name:         pipelineName,
+			Name:         command.PipelineName,
 			InstanceVars: instanceVars,
 		},
-		TargetName:       Fly.Target,
+		TargetName:       targetName,
 		Target:           target.Client().URL(),
 		SkipInteraction:  command.SkipInteractive || command.Config.FromStdin(),
 		CheckCredentials: command.CheckCredentials,
+		DryRun:           command.DryRun,
 		CommandWarnings:  warnings,
 		GivenTeamName:    command.Team,
 	}
 
-	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, command.YAMLVar, instanceVars)
-	return atcConfig.Set(yamlTemplateWithParams)
+	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar, instanceVars)
+	return atcConfig.SetWithResult(yamlTemplateWithParams)
 }
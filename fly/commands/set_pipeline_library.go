@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type SetPipelineLibraryCommand struct {
+	Library string       `short:"l" long:"library" required:"true" description:"Name of the pipeline library"`
+	Config  atc.PathFlag `short:"c" long:"config"  required:"true" description:"Config to save as the next version of the library"`
+	Team    string       `long:"team" description:"Name of the team to which the library belongs, if different from the target default"`
+}
+
+func (command *SetPipelineLibraryCommand) Execute(args []string) error {
+	config, err := ioutil.ReadFile(string(command.Config))
+	if err != nil {
+		return fmt.Errorf("could not read file: %s", err.Error())
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	// the config is saved as-is, since it is a template which is evaluated
+	// with the vars of each import
+	library, err := team.SavePipelineLibrary(command.Library, config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pipeline library %s is at version %d (%s)\n", library.Name, library.Version, library.Digest)
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type SetTargetGroupCommand struct {
+	Group   rc.TargetGroupName `short:"g" long:"group" required:"true" description:"Name of the group"`
+	Members []rc.TargetName    `short:"m" long:"member" required:"true" description:"Target to include in the group (can be specified multiple times)"`
+}
+
+func (command *SetTargetGroupCommand) Execute([]string) error {
+	err := rc.SaveTargetGroup(command.Group, command.Members)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("saved target group: %s\n", command.Group)
+
+	return nil
+}
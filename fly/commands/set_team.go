@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -26,9 +27,71 @@ func WireTeamConnectors(command *flags.Command) {
 }
 
 type SetTeamCommand struct {
-	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
-	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
-	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+	Team            flaghelpers.TeamFlag     `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
+	SkipInteractive bool                     `long:"non-interactive" description:"Force apply configuration"`
+	AuthFlags       skycmd.AuthTeamFlags     `group:"Authentication"`
+	QuotaFlags      SetTeamQuotaFlags        `group:"Quotas"`
+	PipelineSync    SetTeamPipelineSyncFlags `group:"Pipeline Sync"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Create or modify the team on each target in this group"`
+}
+
+type SetTeamQuotaFlags struct {
+	MaxConcurrentBuilds *int `long:"max-concurrent-builds" description:"Maximum number of builds the team can run at once (0 for no limit)"`
+	MaxContainers       *int `long:"max-containers" description:"Maximum number of containers the team can have (0 for no limit)"`
+	MaxVolumes          *int `long:"max-volumes" description:"Maximum number of volumes the team can have (0 for no limit)"`
+}
+
+// Quotas returns the team's quotas if any of the flags were given. Any quotas
+// which were not given are removed, just as the team's auth is replaced.
+func (flags SetTeamQuotaFlags) Quotas() *atc.TeamQuotas {
+	if flags.MaxConcurrentBuilds == nil && flags.MaxContainers == nil && flags.MaxVolumes == nil {
+		return nil
+	}
+
+	quotas := atc.TeamQuotas{}
+	if flags.MaxConcurrentBuilds != nil {
+		quotas.MaxConcurrentBuilds = *flags.MaxConcurrentBuilds
+	}
+
+	if flags.MaxContainers != nil {
+		quotas.MaxContainers = *flags.MaxContainers
+	}
+
+	if flags.MaxVolumes != nil {
+		quotas.MaxVolumes = *flags.MaxVolumes
+	}
+
+	return &quotas
+}
+
+type SetTeamPipelineSyncFlags struct {
+	URI            string `long:"pipeline-sync-uri" description:"Git repository to keep the team's pipelines in sync with"`
+	Branch         string `long:"pipeline-sync-branch" description:"Branch of the repository to sync from (defaults to the repository's default branch)"`
+	Paths          string `long:"pipeline-sync-paths" value-name:"GLOB" description:"Glob of the pipeline configs in the repository. Each pipeline is named after its file."`
+	WebhookToken   string `long:"pipeline-sync-webhook-token" description:"Token with which the git host may trigger a sync through the team's pipeline sync webhook"`
+	NoPipelineSync bool   `long:"no-pipeline-sync" description:"Stop syncing the team's pipelines. The pipelines are left as they are."`
+}
+
+// Config returns the team's pipeline sync if it was configured or removed.
+// When none of the flags are given the team's pipeline sync is left as it is.
+func (flags SetTeamPipelineSyncFlags) Config() *atc.PipelineSyncConfig {
+	if flags.NoPipelineSync {
+		return &atc.PipelineSyncConfig{}
+	}
+
+	config := atc.PipelineSyncConfig{
+		URI:          flags.URI,
+		Branch:       flags.Branch,
+		Paths:        flags.Paths,
+		WebhookToken: flags.WebhookToken,
+	}
+
+	if config.IsZero() {
+		return nil
+	}
+
+	return &config
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -43,6 +106,21 @@ func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
 			Message: warning.Message,
 		})
 	}
+
+	if quotas := command.QuotaFlags.Quotas(); quotas != nil {
+		err := quotas.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config := command.PipelineSync.Config(); config != nil && !config.IsZero() {
+		err := config.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return warnings, nil
 }
 
@@ -52,14 +130,23 @@ func (command *SetTeamCommand) Execute([]string) error {
 		return err
 	}
 
-	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
-	if err != nil {
-		return err
-	}
+	var target rc.Target
+	var targetNames []rc.TargetName
+	if command.TargetGroup != "" {
+		targetNames, err = rc.LoadTargetGroup(command.TargetGroup)
+		if err != nil {
+			return err
+		}
+	} else {
+		target, err = rc.LoadTarget(Fly.Target, Fly.Verbose)
+		if err != nil {
+			return err
+		}
 
-	err = target.Validate()
-	if err != nil {
-		return err
+		err = target.Validate()
+		if err != nil {
+			return err
+		}
 	}
 
 	authRoles, err := command.AuthFlags.Format()
@@ -103,10 +190,39 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	quotas := command.QuotaFlags.Quotas()
+	if quotas != nil {
+		fmt.Println()
+		fmt.Printf("quotas: %s\n", quotas)
+	}
+
+	pipelineSync := command.PipelineSync.Config()
+	if pipelineSync != nil {
+		fmt.Println()
+		if pipelineSync.IsZero() {
+			fmt.Printf("pipeline sync: %s\n", ui.OffColor.Sprint("none"))
+		} else {
+			fmt.Printf("pipeline sync:\n")
+			fmt.Printf("  uri: %s\n", pipelineSync.URI)
+			if pipelineSync.Branch != "" {
+				fmt.Printf("  branch: %s\n", pipelineSync.Branch)
+			}
+			fmt.Printf("  paths: %s\n", pipelineSync.Paths)
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
 
+	team := atc.Team{Auth: authRoles, Quotas: quotas, PipelineSync: pipelineSync}
+
+	if command.TargetGroup != "" {
+		return targetgrouphelpers.Apply(targetNames, Fly.Verbose, command.SkipInteractive, func(_ rc.TargetName, target rc.Target) (string, error) {
+			return command.set(target, team)
+		})
+	}
+
 	confirm := true
 	if !command.SkipInteractive {
 		confirm = false
@@ -120,22 +236,30 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	_, err = command.set(target, team)
+	return err
+}
 
-	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
+func (command *SetTeamCommand) set(target rc.Target, team atc.Team) (string, error) {
+	_, created, updated, warnings, err := target.Client().Team(command.Team.Name()).CreateOrUpdate(team)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
 
+	var result string
 	if created {
-		fmt.Println("team created")
+		result = "team created"
 	} else if updated {
-		fmt.Println("team updated")
+		result = "team updated"
+	}
+
+	if result != "" {
+		fmt.Println(result)
 	}
 
-	return nil
+	return result, nil
 }
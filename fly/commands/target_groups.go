@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type TargetGroupsCommand struct{}
+
+func (command *TargetGroupsCommand) Execute([]string) error {
+	groups, err := rc.LoadTargetGroups()
+	if err != nil {
+		return err
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "targets", Color: color.New(color.Bold)},
+		},
+	}
+
+	for groupName, members := range groups {
+		names := make([]string, len(members))
+		for i, member := range members {
+			names[i] = string(member)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: string(groupName)},
+			{Contents: strings.Join(names, ",")},
+		})
+	}
+
+	sort.Sort(table.Data)
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/commands/internal/validatepipelinehelpers"
+	"github.com/concourse/concourse/fly/rc"
 
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/conjur"
@@ -21,6 +22,9 @@ type ValidatePipelineCommand struct {
 	Strict           bool         `short:"s" long:"strict"                  description:"Fail on warnings"`
 	Output           bool         `short:"o" long:"output"                  description:"Output templated pipeline to stdout"`
 	EnableAcrossStep bool         `long:"enable-across-step"                description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
+	Lint             bool         `long:"lint"                              description:"Fail on findings of the semantic lint rules"`
+	EnableLintRule   []string     `long:"enable-lint-rule" value-name:"RULE" choice:"task-timeout"  description:"Run an optional lint rule in addition to the default rules when using --lint. Can be specified multiple times."`
+	Format           string       `long:"format" default:"text" choice:"text" choice:"json" choice:"sarif"  description:"Format to report lint findings in when using --lint"`
 
 	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
@@ -30,5 +34,28 @@ type ValidatePipelineCommand struct {
 
 func (command *ValidatePipelineCommand) Execute(args []string) error {
 	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar, nil)
-	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output, command.EnableAcrossStep)
+	return validatepipelinehelpers.Validate(yamlTemplate, targetPipelineLibraries{}, command.Strict, command.Output, command.EnableAcrossStep, validatepipelinehelpers.LintOptions{
+		Enabled: command.Lint,
+		Rules:   command.EnableLintRule,
+		Format:  command.Format,
+	})
+}
+
+// targetPipelineLibraries fetches pipeline libraries from the target. The
+// target is only loaded once a library is imported, so that configs without
+// library imports can be validated without a target.
+type targetPipelineLibraries struct{}
+
+func (targetPipelineLibraries) PipelineLibrary(libraryName string, version int) (atc.PipelineLibrary, bool, error) {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return atc.PipelineLibrary{}, false, err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return atc.PipelineLibrary{}, false, err
+	}
+
+	return target.Team().PipelineLibrary(libraryName, version)
 }
@@ -7,9 +7,11 @@ import (
 	"strconv"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type WatchCommand struct {
@@ -18,6 +20,8 @@ type WatchCommand struct {
 	Url                      string              `short:"u" long:"url"                                    description:"URL for the build or job to watch"`
 	Timestamp                bool                `short:"t" long:"timestamps"                             description:"Print with local timestamp"`
 	IgnoreEventParsingErrors bool                `long:"ignore-event-parsing-errors"                      description:"Ignore event parsing errors"`
+
+	Output eventstream.OutputFormat `long:"output" default:"text" choice:"text" choice:"json" choice:"ndjson" choice:"junit" description:"Format to print the build in. json and ndjson print each event with its origin, step name and time, and junit prints a report with a test case for each step"`
 }
 
 func getBuildIDFromURL(target rc.Target, urlParam string) (int, error) {
@@ -72,6 +76,31 @@ func getBuildIDFromURL(target rc.Target, urlParam string) (int, error) {
 	return buildId, nil
 }
 
+// describeBuild returns the name of the build and the names of the steps in its
+// plan, which label the events in structured output.
+func describeBuild(client concourse.Client, build atc.Build) (string, map[event.OriginID]string, error) {
+	name := fmt.Sprintf("build #%d", build.ID)
+	if build.JobName != "" {
+		pipelineRef := atc.PipelineRef{
+			Name:         build.PipelineName,
+			InstanceVars: build.PipelineInstanceVars,
+		}
+
+		name = fmt.Sprintf("%s/%s #%s", pipelineRef.String(), build.JobName, build.Name)
+	}
+
+	plan, found, err := client.BuildPlan(build.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !found {
+		return name, nil, nil
+	}
+
+	return name, eventstream.StepNames(plan), nil
+}
+
 func (command *WatchCommand) Execute(args []string) error {
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -105,14 +134,31 @@ func (command *WatchCommand) Execute(args []string) error {
 		}
 	}
 
-	eventSource, err := client.BuildEvents(fmt.Sprintf("%d", buildId))
-	if err != nil {
-		return err
-	}
-
 	renderOptions := eventstream.RenderOptions{
 		ShowTimestamp:            command.Timestamp,
 		IgnoreEventParsingErrors: command.IgnoreEventParsingErrors,
+		Output:                   command.Output,
+	}
+
+	if command.Output.IsStructured() {
+		build, found, err := client.Build(strconv.Itoa(buildId))
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("build %d not found", buildId)
+		}
+
+		renderOptions.BuildName, renderOptions.StepNames, err = describeBuild(client, build)
+		if err != nil {
+			return err
+		}
+	}
+
+	eventSource, err := client.BuildEvents(fmt.Sprintf("%d", buildId))
+	if err != nil {
+		return err
 	}
 
 	exitCode := eventstream.Render(os.Stdout, eventSource, renderOptions)
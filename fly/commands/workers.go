@@ -10,6 +10,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/targetgrouphelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/fatih/color"
@@ -18,9 +19,15 @@ import (
 type WorkersCommand struct {
 	Details bool `short:"d" long:"details" description:"Print additional information for each worker"`
 	Json    bool `long:"json" description:"Print command result as JSON"`
+
+	TargetGroup rc.TargetGroupName `long:"targets" value-name:"GROUP" description:"Show workers on each target in this group"`
 }
 
 func (command *WorkersCommand) Execute([]string) error {
+	if command.TargetGroup != "" {
+		return command.executeOnGroup()
+	}
+
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -44,26 +51,9 @@ func (command *WorkersCommand) Execute([]string) error {
 		return nil
 	}
 
-	sort.Sort(byWorkerName(workers))
-
-	var runningWorkers []worker
-	var stalledWorkers []worker
-	var outdatedWorkers []worker
-	for _, w := range workers {
-		if w.State == "stalled" {
-			stalledWorkers = append(stalledWorkers, worker{w, false})
-		} else {
-			workerVersionCompatible, err := target.IsWorkerVersionCompatible(w.Version)
-			if err != nil {
-				return err
-			}
-
-			if !workerVersionCompatible {
-				outdatedWorkers = append(outdatedWorkers, worker{w, true})
-			} else {
-				runningWorkers = append(runningWorkers, worker{w, false})
-			}
-		}
+	runningWorkers, outdatedWorkers, stalledWorkers, err := classifyWorkers(target, workers)
+	if err != nil {
+		return err
 	}
 
 	dst, isTTY := ui.ForTTY(os.Stdout)
@@ -114,6 +104,74 @@ func (command *WorkersCommand) Execute([]string) error {
 	return nil
 }
 
+func (command *WorkersCommand) executeOnGroup() error {
+	targetNames, err := rc.LoadTargetGroup(command.TargetGroup)
+	if err != nil {
+		return err
+	}
+
+	results := targetgrouphelpers.Fetch(targetNames, Fly.Verbose, func(target rc.Target) (interface{}, error) {
+		workers, err := target.Client().ListWorkers()
+		if err != nil {
+			return nil, err
+		}
+
+		if command.Json {
+			return workers, nil
+		}
+
+		runningWorkers, outdatedWorkers, stalledWorkers, err := classifyWorkers(target, workers)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(append(runningWorkers, outdatedWorkers...), stalledWorkers...), nil
+	})
+
+	if command.Json {
+		return displayhelpers.JsonPrint(targetgrouphelpers.JSON(results))
+	}
+
+	table := targetgrouphelpers.Table(results, func(value interface{}) ui.Table {
+		return command.tableFor(value.([]worker))
+	})
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	return targetgrouphelpers.ShowErrors(results)
+}
+
+// classifyWorkers sorts the workers by name and splits them into those which
+// are running, those which need to be updated, and those which have stalled.
+func classifyWorkers(target rc.Target, workers []atc.Worker) ([]worker, []worker, []worker, error) {
+	sort.Sort(byWorkerName(workers))
+
+	var runningWorkers []worker
+	var stalledWorkers []worker
+	var outdatedWorkers []worker
+	for _, w := range workers {
+		if w.State == "stalled" {
+			stalledWorkers = append(stalledWorkers, worker{w, false})
+		} else {
+			workerVersionCompatible, err := target.IsWorkerVersionCompatible(w.Version)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			if !workerVersionCompatible {
+				outdatedWorkers = append(outdatedWorkers, worker{w, true})
+			} else {
+				runningWorkers = append(runningWorkers, worker{w, false})
+			}
+		}
+	}
+
+	return runningWorkers, outdatedWorkers, stalledWorkers, nil
+}
+
 func (command *WorkersCommand) tableFor(workers []worker) ui.Table {
 	headers := ui.TableRow{
 		{Contents: "name", Color: color.New(color.Bold)},
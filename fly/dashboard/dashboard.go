@@ -0,0 +1,420 @@
+package dashboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+// Key is a key pressed while the dashboard is shown. Printable keys are the
+// character they print.
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyEnter     Key = "enter"
+	KeyEscape    Key = "esc"
+	KeyInterrupt Key = "ctrl-c"
+)
+
+// Action is what the dashboard should do after a key has been handled.
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionWatch
+	ActionQuit
+)
+
+// maxRunningBuilds is how many running builds are shown below the jobs.
+const maxRunningBuilds = 5
+
+const columnWidth = 30
+
+// Dashboard is a live view of the pipelines, jobs and running builds that
+// are visible to a target.
+type Dashboard struct {
+	client concourse.Client
+	title  string
+
+	pipelines map[int]atc.Pipeline
+	jobs      []atc.Job
+	running   []atc.Build
+
+	selected      int
+	selectedJobID int
+	message       string
+	refreshedAt   time.Time
+
+	now func() time.Time
+}
+
+func New(client concourse.Client, title string) *Dashboard {
+	return &Dashboard{
+		client:    client,
+		title:     title,
+		pipelines: map[int]atc.Pipeline{},
+		now:       time.Now,
+	}
+}
+
+// Refresh fetches the pipelines, jobs and running builds. The same job stays
+// selected if it still exists.
+func (dashboard *Dashboard) Refresh() error {
+	pipelines, err := dashboard.client.ListPipelines()
+	if err != nil {
+		return err
+	}
+
+	jobs, err := dashboard.client.ListAllJobs()
+	if err != nil {
+		return err
+	}
+
+	builds, _, err := dashboard.client.Builds(concourse.Page{Limit: 100})
+	if err != nil {
+		return err
+	}
+
+	dashboard.pipelines = map[int]atc.Pipeline{}
+	for _, pipeline := range pipelines {
+		dashboard.pipelines[pipeline.ID] = pipeline
+	}
+
+	dashboard.jobs = jobs
+
+	dashboard.running = nil
+	for _, build := range builds {
+		if build.IsRunning() {
+			dashboard.running = append(dashboard.running, build)
+		}
+	}
+
+	dashboard.selected = 0
+	for i, job := range jobs {
+		if job.ID == dashboard.selectedJobID {
+			dashboard.selected = i
+		}
+	}
+
+	dashboard.selectJob(dashboard.selected)
+	dashboard.refreshedAt = dashboard.now()
+
+	return nil
+}
+
+// SelectedBuild returns the build that is shown when the selected job is
+// watched: its running build if it has one, otherwise its latest build.
+func (dashboard *Dashboard) SelectedBuild() (atc.Build, bool) {
+	job, found := dashboard.selectedJob()
+	if !found {
+		return atc.Build{}, false
+	}
+
+	if job.NextBuild != nil {
+		return *job.NextBuild, true
+	}
+
+	if job.FinishedBuild != nil {
+		return *job.FinishedBuild, true
+	}
+
+	return atc.Build{}, false
+}
+
+// Handle moves the selection or acts on the selected job. Any error is shown
+// in the dashboard's status line.
+func (dashboard *Dashboard) Handle(key Key) Action {
+	switch key {
+	case KeyUp, "k":
+		dashboard.selectJob(dashboard.selected - 1)
+
+	case KeyDown, "j":
+		dashboard.selectJob(dashboard.selected + 1)
+
+	case KeyEnter:
+		if _, found := dashboard.SelectedBuild(); found {
+			return ActionWatch
+		}
+
+		dashboard.message = "the job has no builds"
+
+	case "t":
+		dashboard.act(dashboard.trigger)
+
+	case "p":
+		dashboard.act(dashboard.toggleJobPaused)
+
+	case "P":
+		dashboard.act(dashboard.togglePipelinePaused)
+
+	case "a":
+		dashboard.act(dashboard.abort)
+
+	case "r":
+		dashboard.message = ""
+
+		err := dashboard.Refresh()
+		if err != nil {
+			dashboard.message = "error: " + err.Error()
+		}
+
+	case "q", KeyEscape, KeyInterrupt:
+		return ActionQuit
+	}
+
+	return ActionNone
+}
+
+func (dashboard *Dashboard) act(action func(atc.Job) (string, error)) {
+	job, found := dashboard.selectedJob()
+	if !found {
+		return
+	}
+
+	message, err := action(job)
+	if err != nil {
+		dashboard.message = "error: " + err.Error()
+		return
+	}
+
+	if message != "" {
+		dashboard.message = message
+	}
+
+	err = dashboard.Refresh()
+	if err != nil {
+		dashboard.message = "error: " + err.Error()
+	}
+}
+
+func (dashboard *Dashboard) trigger(job atc.Job) (string, error) {
+	build, err := dashboard.client.Team(job.TeamName).CreateJobBuild(pipelineRef(job), job.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("started %s/%s #%s", pipelineRef(job), job.Name, build.Name), nil
+}
+
+func (dashboard *Dashboard) toggleJobPaused(job atc.Job) (string, error) {
+	team := dashboard.client.Team(job.TeamName)
+
+	var err error
+	var verb string
+	if job.Paused {
+		_, err = team.UnpauseJob(pipelineRef(job), job.Name)
+		verb = "unpaused"
+	} else {
+		_, err = team.PauseJob(pipelineRef(job), job.Name)
+		verb = "paused"
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s/%s", verb, pipelineRef(job), job.Name), nil
+}
+
+func (dashboard *Dashboard) togglePipelinePaused(job atc.Job) (string, error) {
+	team := dashboard.client.Team(job.TeamName)
+
+	var err error
+	var verb string
+	if dashboard.pipelines[job.PipelineID].Paused {
+		_, err = team.UnpausePipeline(pipelineRef(job))
+		verb = "unpaused"
+	} else {
+		_, err = team.PausePipeline(pipelineRef(job))
+		verb = "paused"
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s", verb, pipelineRef(job)), nil
+}
+
+func (dashboard *Dashboard) abort(job atc.Job) (string, error) {
+	if job.NextBuild == nil || !job.NextBuild.IsRunning() {
+		return "", fmt.Errorf("%s/%s has no running build", pipelineRef(job), job.Name)
+	}
+
+	err := dashboard.client.AbortBuild(strconv.Itoa(job.NextBuild.ID))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("aborted %s", buildName(*job.NextBuild)), nil
+}
+
+func (dashboard *Dashboard) selectJob(i int) {
+	if i >= len(dashboard.jobs) {
+		i = len(dashboard.jobs) - 1
+	}
+
+	if i < 0 {
+		i = 0
+	}
+
+	dashboard.selected = i
+	if i < len(dashboard.jobs) {
+		dashboard.selectedJobID = dashboard.jobs[i].ID
+	}
+}
+
+func (dashboard *Dashboard) selectedJob() (atc.Job, bool) {
+	if dashboard.selected >= len(dashboard.jobs) {
+		return atc.Job{}, false
+	}
+
+	return dashboard.jobs[dashboard.selected], true
+}
+
+// Render returns the lines of the dashboard for a terminal of the given
+// height. If there are more jobs than fit, the jobs around the selected one
+// are shown.
+func (dashboard *Dashboard) Render(height int) []string {
+	bold := color.New(color.Bold)
+
+	lines := []string{
+		bold.Sprint(dashboard.title) + ui.OffColor.Sprintf(" (refreshed at %s)", dashboard.refreshedAt.Format("15:04:05")),
+		"",
+	}
+
+	var footer []string
+
+	footer = append(footer, "", bold.Sprintf("running builds (%d)", len(dashboard.running)))
+	for i, build := range dashboard.running {
+		if i == maxRunningBuilds {
+			footer = append(footer, ui.OffColor.Sprintf("  ...and %d more", len(dashboard.running)-maxRunningBuilds))
+			break
+		}
+
+		duration := ""
+		if build.StartTime != 0 {
+			duration = dashboard.now().Sub(time.Unix(build.StartTime, 0)).Round(time.Second).String()
+		}
+
+		footer = append(footer, fmt.Sprintf("  %s  %s  %s",
+			cell(ui.TableCell{Contents: buildName(build)}, columnWidth*2),
+			cell(ui.BuildStatusCell(build.Status), 9),
+			duration,
+		))
+	}
+
+	footer = append(footer, "")
+	if dashboard.message != "" {
+		footer = append(footer, dashboard.message)
+	}
+
+	footer = append(footer, ui.OffColor.Sprint("↑/↓ select  enter watch  t trigger  p pause job  P pause pipeline  a abort  r refresh  q quit"))
+
+	if len(dashboard.jobs) == 0 {
+		lines = append(lines, ui.OffColor.Sprint("no jobs"))
+		return append(lines, footer...)
+	}
+
+	lines = append(lines, fmt.Sprintf("  %s  %s  %s  %s  %s",
+		cell(ui.TableCell{Contents: "pipeline", Color: bold}, columnWidth),
+		cell(ui.TableCell{Contents: "job", Color: bold}, columnWidth),
+		cell(ui.TableCell{Contents: "paused", Color: bold}, 8),
+		cell(ui.TableCell{Contents: "status", Color: bold}, 9),
+		cell(ui.TableCell{Contents: "next", Color: bold}, 9),
+	))
+
+	rows := height - len(lines) - len(footer)
+	if rows < 1 {
+		rows = 1
+	}
+
+	first := 0
+	if dashboard.selected >= rows {
+		first = dashboard.selected - rows + 1
+	}
+
+	for i := first; i < len(dashboard.jobs) && i < first+rows; i++ {
+		lines = append(lines, dashboard.jobRow(i))
+	}
+
+	return append(lines, footer...)
+}
+
+func (dashboard *Dashboard) jobRow(i int) string {
+	job := dashboard.jobs[i]
+
+	marker := "  "
+	if i == dashboard.selected {
+		marker = "> "
+	}
+
+	paused := ui.TableCell{Contents: "no"}
+	if dashboard.pipelines[job.PipelineID].Paused {
+		paused = ui.TableCell{Contents: "pipeline", Color: ui.PausedColor}
+	} else if job.Paused {
+		paused = ui.TableCell{Contents: "job", Color: ui.PausedColor}
+	}
+
+	status := ui.TableCell{Contents: "n/a"}
+	if job.FinishedBuild != nil {
+		status = ui.BuildStatusCell(job.FinishedBuild.Status)
+	}
+
+	next := ui.TableCell{Contents: "n/a"}
+	if job.NextBuild != nil {
+		next = ui.BuildStatusCell(job.NextBuild.Status)
+	}
+
+	return marker + strings.Join([]string{
+		cell(ui.TableCell{Contents: pipelineRef(job).String()}, columnWidth),
+		cell(ui.TableCell{Contents: job.Name}, columnWidth),
+		cell(paused, 8),
+		cell(status, 9),
+		cell(next, 9),
+	}, "  ")
+}
+
+// cell pads or truncates the cell's contents to the width before coloring
+// it, so that the columns line up.
+func cell(c ui.TableCell, width int) string {
+	contents := c.Contents
+	if len(contents) > width {
+		contents = contents[:width-1] + "…"
+	}
+
+	contents = fmt.Sprintf("%-*s", width, contents)
+	if c.Color != nil {
+		return c.Color.Sprint(contents)
+	}
+
+	return contents
+}
+
+func pipelineRef(job atc.Job) atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         job.PipelineName,
+		InstanceVars: job.PipelineInstanceVars,
+	}
+}
+
+func buildName(build atc.Build) string {
+	if build.JobName == "" {
+		return fmt.Sprintf("one-off #%d", build.ID)
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         build.PipelineName,
+		InstanceVars: build.PipelineInstanceVars,
+	}
+
+	return fmt.Sprintf("%s/%s #%s", pipelineRef, build.JobName, build.Name)
+}
@@ -0,0 +1,18 @@
+package dashboard_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/fatih/color"
+)
+
+func TestDashboard(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	color.NoColor = true
+
+	RunSpecs(t, "Dashboard Suite")
+}
@@ -0,0 +1,291 @@
+package dashboard_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/fly/dashboard"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/go-concourse/concourse/concoursefakes"
+	"github.com/concourse/concourse/go-concourse/concourse/eventstream/eventstreamfakes"
+)
+
+var _ = Describe("Dashboard", func() {
+	var (
+		fakeClient *concoursefakes.FakeClient
+		fakeTeam   *concoursefakes.FakeTeam
+
+		dash *dashboard.Dashboard
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(concoursefakes.FakeClient)
+		fakeTeam = new(concoursefakes.FakeTeam)
+		fakeClient.TeamReturns(fakeTeam)
+
+		fakeClient.ListPipelinesReturns([]atc.Pipeline{
+			{ID: 1, Name: "some-pipeline", TeamName: "main"},
+			{ID: 2, Name: "paused-pipeline", TeamName: "main", Paused: true},
+		}, nil)
+
+		fakeClient.ListAllJobsReturns([]atc.Job{
+			{
+				ID:            1,
+				Name:          "unit",
+				TeamName:      "main",
+				PipelineID:    1,
+				PipelineName:  "some-pipeline",
+				FinishedBuild: &atc.Build{ID: 10, Name: "3", Status: atc.StatusSucceeded, PipelineName: "some-pipeline", JobName: "unit"},
+				NextBuild:     &atc.Build{ID: 11, Name: "4", Status: atc.StatusStarted, PipelineName: "some-pipeline", JobName: "unit"},
+			},
+			{
+				ID:            2,
+				Name:          "deploy",
+				TeamName:      "main",
+				PipelineID:    1,
+				PipelineName:  "some-pipeline",
+				Paused:        true,
+				FinishedBuild: &atc.Build{ID: 9, Name: "1", Status: atc.StatusFailed, PipelineName: "some-pipeline", JobName: "deploy"},
+			},
+			{
+				ID:           3,
+				Name:         "lint",
+				TeamName:     "main",
+				PipelineID:   2,
+				PipelineName: "paused-pipeline",
+			},
+		}, nil)
+
+		fakeClient.BuildsReturns([]atc.Build{
+			{ID: 11, Name: "4", Status: atc.StatusStarted, PipelineName: "some-pipeline", JobName: "unit"},
+			{ID: 10, Name: "3", Status: atc.StatusSucceeded, PipelineName: "some-pipeline", JobName: "unit"},
+			{ID: 8, Status: atc.StatusPending},
+		}, concourse.Pagination{}, nil)
+
+		dash = dashboard.New(fakeClient, "some-target")
+	})
+
+	Describe("Refresh", func() {
+		It("fetches the pipelines, jobs and builds", func() {
+			Expect(dash.Refresh()).To(Succeed())
+
+			Expect(fakeClient.ListPipelinesCallCount()).To(Equal(1))
+			Expect(fakeClient.ListAllJobsCallCount()).To(Equal(1))
+			Expect(fakeClient.BuildsCallCount()).To(Equal(1))
+		})
+
+		Context("when fetching fails", func() {
+			BeforeEach(func() {
+				fakeClient.ListAllJobsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(dash.Refresh()).To(MatchError("nope"))
+			})
+		})
+
+		It("keeps the same job selected when the jobs change", func() {
+			Expect(dash.Refresh()).To(Succeed())
+			dash.Handle(dashboard.KeyDown)
+
+			fakeClient.ListAllJobsReturns([]atc.Job{
+				{ID: 4, Name: "new-job", PipelineID: 1, PipelineName: "some-pipeline"},
+				{ID: 2, Name: "deploy", PipelineID: 1, PipelineName: "some-pipeline"},
+			}, nil)
+
+			Expect(dash.Refresh()).To(Succeed())
+			Expect(selectedLine(dash)).To(ContainSubstring("deploy"))
+		})
+	})
+
+	Describe("Render", func() {
+		BeforeEach(func() {
+			Expect(dash.Refresh()).To(Succeed())
+		})
+
+		It("shows each job with its latest and next build and whether it is paused", func() {
+			lines := dash.Render(24)
+
+			Expect(lines[0]).To(HavePrefix("some-target (refreshed at "))
+			Expect(strings.Fields(lines[2])).To(Equal([]string{"pipeline", "job", "paused", "status", "next"}))
+			Expect(strings.Fields(lines[3])).To(Equal([]string{">", "some-pipeline", "unit", "no", "succeeded", "started"}))
+			Expect(strings.Fields(lines[4])).To(Equal([]string{"some-pipeline", "deploy", "job", "failed", "n/a"}))
+			Expect(strings.Fields(lines[5])).To(Equal([]string{"paused-pipeline", "lint", "pipeline", "n/a", "n/a"}))
+		})
+
+		It("shows the running builds", func() {
+			lines := dash.Render(24)
+
+			Expect(lines).To(ContainElement("running builds (2)"))
+			Expect(strings.Join(lines, "\n")).To(ContainSubstring("some-pipeline/unit #4"))
+			Expect(strings.Join(lines, "\n")).To(ContainSubstring("one-off #8"))
+		})
+
+		Context("when there are more jobs than fit", func() {
+			It("shows the jobs around the selected one", func() {
+				dash.Handle(dashboard.KeyDown)
+				dash.Handle(dashboard.KeyDown)
+
+				lines := dash.Render(11)
+				Expect(lines).NotTo(ContainElement(ContainSubstring("  unit  ")))
+				Expect(lines).To(ContainElement(ContainSubstring("  deploy  ")))
+				Expect(selectedLine(dash)).To(ContainSubstring("lint"))
+			})
+		})
+	})
+
+	Describe("Handle", func() {
+		BeforeEach(func() {
+			Expect(dash.Refresh()).To(Succeed())
+		})
+
+		It("moves the selection without going past the ends", func() {
+			dash.Handle(dashboard.KeyUp)
+			Expect(selectedLine(dash)).To(ContainSubstring("unit"))
+
+			dash.Handle("j")
+			Expect(selectedLine(dash)).To(ContainSubstring("deploy"))
+
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle(dashboard.KeyDown)
+			Expect(selectedLine(dash)).To(ContainSubstring("lint"))
+
+			dash.Handle("k")
+			Expect(selectedLine(dash)).To(ContainSubstring("deploy"))
+		})
+
+		It("watches the selected job's running build", func() {
+			Expect(dash.Handle(dashboard.KeyEnter)).To(Equal(dashboard.ActionWatch))
+
+			build, found := dash.SelectedBuild()
+			Expect(found).To(BeTrue())
+			Expect(build.ID).To(Equal(11))
+		})
+
+		It("watches the selected job's latest build if it is not running", func() {
+			dash.Handle(dashboard.KeyDown)
+
+			build, found := dash.SelectedBuild()
+			Expect(found).To(BeTrue())
+			Expect(build.ID).To(Equal(9))
+		})
+
+		It("does not watch a job without builds", func() {
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle(dashboard.KeyDown)
+
+			Expect(dash.Handle(dashboard.KeyEnter)).To(Equal(dashboard.ActionNone))
+			Expect(dash.Render(24)).To(ContainElement("the job has no builds"))
+		})
+
+		It("triggers the selected job", func() {
+			fakeTeam.CreateJobBuildReturns(atc.Build{Name: "5"}, nil)
+
+			Expect(dash.Handle("t")).To(Equal(dashboard.ActionNone))
+
+			Expect(fakeClient.TeamArgsForCall(0)).To(Equal("main"))
+			Expect(fakeTeam.CreateJobBuildCallCount()).To(Equal(1))
+			pipelineRef, jobName := fakeTeam.CreateJobBuildArgsForCall(0)
+			Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(jobName).To(Equal("unit"))
+
+			Expect(dash.Render(24)).To(ContainElement("started some-pipeline/unit #5"))
+			Expect(fakeClient.ListAllJobsCallCount()).To(Equal(2))
+		})
+
+		It("pauses and unpauses the selected job", func() {
+			dash.Handle("p")
+			Expect(fakeTeam.PauseJobCallCount()).To(Equal(1))
+
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle("p")
+			Expect(fakeTeam.UnpauseJobCallCount()).To(Equal(1))
+			_, jobName := fakeTeam.UnpauseJobArgsForCall(0)
+			Expect(jobName).To(Equal("deploy"))
+		})
+
+		It("pauses and unpauses the selected job's pipeline", func() {
+			dash.Handle("P")
+			Expect(fakeTeam.PausePipelineCallCount()).To(Equal(1))
+
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle("P")
+			Expect(fakeTeam.UnpausePipelineCallCount()).To(Equal(1))
+			Expect(fakeTeam.UnpausePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "paused-pipeline"}))
+		})
+
+		It("aborts the selected job's running build", func() {
+			dash.Handle("a")
+			Expect(fakeClient.AbortBuildCallCount()).To(Equal(1))
+			Expect(fakeClient.AbortBuildArgsForCall(0)).To(Equal("11"))
+			Expect(dash.Render(24)).To(ContainElement("aborted some-pipeline/unit #4"))
+		})
+
+		It("shows an error if the selected job has no running build to abort", func() {
+			dash.Handle(dashboard.KeyDown)
+			dash.Handle("a")
+			Expect(fakeClient.AbortBuildCallCount()).To(BeZero())
+			Expect(dash.Render(24)).To(ContainElement("error: some-pipeline/deploy has no running build"))
+		})
+
+		It("shows an error if an action fails", func() {
+			fakeTeam.CreateJobBuildReturns(atc.Build{}, errors.New("nope"))
+
+			dash.Handle("t")
+			Expect(dash.Render(24)).To(ContainElement("error: nope"))
+		})
+
+		It("quits", func() {
+			Expect(dash.Handle("q")).To(Equal(dashboard.ActionQuit))
+			Expect(dash.Handle(dashboard.KeyEscape)).To(Equal(dashboard.ActionQuit))
+			Expect(dash.Handle(dashboard.KeyInterrupt)).To(Equal(dashboard.ActionQuit))
+		})
+	})
+
+	Describe("Run", func() {
+		It("handles keys until the dashboard is quit", func() {
+			out := new(bytes.Buffer)
+
+			err := dash.Run(strings.NewReader("\x1b[Bpq"), out, func() int { return 24 }, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTeam.PauseJobCallCount()).To(Equal(0))
+			Expect(fakeTeam.UnpauseJobCallCount()).To(Equal(1))
+			Expect(out.String()).To(ContainSubstring("unpaused some-pipeline/deploy"))
+		})
+
+		It("tails the events of the selected job's build", func() {
+			fakeEvents := new(eventstreamfakes.FakeEventStream)
+			fakeEvents.NextEventReturns(event.Log{Payload: "hello\n"}, nil)
+			fakeEvents.NextEventReturnsOnCall(1, event.Status{Status: atc.StatusSucceeded}, nil)
+			fakeClient.BuildEventsReturns(fakeEvents, nil)
+
+			out := new(bytes.Buffer)
+
+			err := dash.Run(strings.NewReader("\rq"), out, func() int { return 24 }, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.BuildEventsArgsForCall(0)).To(Equal("11"))
+			Expect(fakeEvents.CloseCallCount()).To(Equal(1))
+			Expect(out.String()).To(ContainSubstring("some-pipeline/unit #4"))
+		})
+	})
+})
+
+func selectedLine(dash *dashboard.Dashboard) string {
+	for _, line := range dash.Render(100) {
+		if strings.HasPrefix(line, "> ") {
+			return line
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,202 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/eventstream"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	exitScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen = "\x1b[H\x1b[2J"
+
+	keyCtrlC = 3
+	keyEsc   = 27
+)
+
+// Run shows the dashboard until it is quit, refreshing it at the given
+// interval. in must be a terminal in raw mode, and height returns the
+// current height of the terminal.
+func (dashboard *Dashboard) Run(in io.Reader, out io.Writer, height func() int, interval time.Duration) error {
+	err := dashboard.Refresh()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(out, enterScreen)
+	defer fmt.Fprint(out, exitScreen)
+
+	keys := make(chan Key)
+	go readKeys(in, keys)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Fprint(out, clearScreen+strings.Join(dashboard.Render(height()), "\r\n"))
+
+		select {
+		case <-ticker.C:
+			err := dashboard.Refresh()
+			if err != nil {
+				dashboard.message = "error: " + err.Error()
+			}
+
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			switch dashboard.Handle(key) {
+			case ActionQuit:
+				return nil
+
+			case ActionWatch:
+				build, _ := dashboard.SelectedBuild()
+
+				err := dashboard.watch(build, keys, out)
+				if err != nil {
+					dashboard.message = "error: " + err.Error()
+				}
+
+				err = dashboard.Refresh()
+				if err != nil {
+					dashboard.message = "error: " + err.Error()
+				}
+			}
+		}
+	}
+}
+
+// watch tails the build's events until it finishes or the user goes back to
+// the dashboard.
+func (dashboard *Dashboard) watch(build atc.Build, keys <-chan Key, out io.Writer) error {
+	events, err := dashboard.client.BuildEvents(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(out, clearScreen)
+	fmt.Fprintf(out, "%s %s\r\n\r\n", ui.Embolden("%s", buildName(build)), ui.OffColor.Sprint("(press q to go back)"))
+
+	// events are printed until the user goes back, after which the stream is
+	// closed and the renderer's complaint about it is discarded
+	dst := &rawWriter{dst: out}
+
+	done := make(chan struct{})
+	go func() {
+		eventstream.Render(dst, events, eventstream.RenderOptions{})
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			events.Close()
+
+			fmt.Fprint(out, ui.OffColor.Sprint("\r\npress any key to go back"))
+			<-keys
+
+			return nil
+
+		case key, ok := <-keys:
+			if !ok || key == "q" || key == KeyEscape || key == KeyInterrupt {
+				dst.Stop()
+				events.Close()
+				<-done
+
+				return nil
+			}
+		}
+	}
+}
+
+func readKeys(in io.Reader, keys chan<- Key) {
+	defer close(keys)
+
+	buf := make([]byte, 8)
+	for {
+		n, err := in.Read(buf)
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parseKeys parses the keys in the input, which may contain more than one key
+// if they were pressed quickly or pasted.
+func parseKeys(input []byte) []Key {
+	var keys []Key
+	for len(input) > 0 {
+		switch {
+		case bytes.HasPrefix(input, []byte("\x1b[A")):
+			keys = append(keys, KeyUp)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[B")):
+			keys = append(keys, KeyDown)
+			input = input[3:]
+		case bytes.HasPrefix(input, []byte("\x1b[")) && len(input) >= 3:
+			// some other escape sequence, such as left or right
+			input = input[3:]
+		case input[0] == keyEsc:
+			keys = append(keys, KeyEscape)
+			input = input[1:]
+		case input[0] == '\r' || input[0] == '\n':
+			keys = append(keys, KeyEnter)
+			input = input[1:]
+		case input[0] == keyCtrlC:
+			keys = append(keys, KeyInterrupt)
+			input = input[1:]
+		default:
+			keys = append(keys, Key(input[:1]))
+			input = input[1:]
+		}
+	}
+
+	return keys
+}
+
+// rawWriter writes to a terminal in raw mode, where a newline only moves the
+// cursor down, so it is preceded by a carriage return. Once stopped, writes
+// are discarded.
+type rawWriter struct {
+	dst io.Writer
+
+	lock    sync.Mutex
+	stopped bool
+}
+
+func (writer *rawWriter) Write(p []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if writer.stopped {
+		return len(p), nil
+	}
+
+	_, err := writer.dst.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n")))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (writer *rawWriter) Stop() {
+	writer.lock.Lock()
+	writer.stopped = true
+	writer.lock.Unlock()
+}
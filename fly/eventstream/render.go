@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
@@ -14,9 +15,21 @@ import (
 type RenderOptions struct {
 	ShowTimestamp            bool
 	IgnoreEventParsingErrors bool
+
+	// Output is the format to render the events in. Text is rendered if it is
+	// not set.
+	Output OutputFormat
+
+	// BuildName and StepNames label the events in structured output.
+	BuildName string
+	StepNames map[event.OriginID]string
 }
 
 func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) int {
+	if options.Output.IsStructured() {
+		return renderStructured(dst, src, options)
+	}
+
 	dstImpl := NewTimestampedWriter(dst, options.ShowTimestamp)
 
 	exitStatus := 0
@@ -44,6 +57,10 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1mno suitable workers found, waiting for worker...\x1b[0m\n")
 
+		case event.WaitingForTeamQuota:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mteam quota reached (%s), waiting...\x1b[0m\n", e.Quota)
+
 		case event.SelectedWorker:
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1mselected worker:\x1b[0m %s\n", e.WorkerName)
@@ -78,22 +95,10 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 				printColor = ui.SucceededColor
 			case "failed":
 				printColor = ui.FailedColor
-
-				if exitStatus == 0 {
-					exitStatus = 1
-				}
 			case "errored":
 				printColor = ui.ErroredColor
-
-				if exitStatus == 0 {
-					exitStatus = 2
-				}
 			case "aborted":
 				printColor = ui.AbortedColor
-
-				if exitStatus == 0 {
-					exitStatus = 3
-				}
 			default:
 				fmt.Fprintf(dstImpl, "unknown status: %s", e.Status)
 				return 255
@@ -102,11 +107,30 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			printColorFunc := printColor.SprintFunc()
 			fmt.Fprintf(dstImpl, "%s\n", printColorFunc(e.Status))
 
-			return exitStatus
+			return finalExitStatus(e.Status, exitStatus)
 		}
 	}
 }
 
+// finalExitStatus returns the exit status for a build which finished with the
+// given status, where exitStatus is that of its last task.
+func finalExitStatus(status atc.BuildStatus, exitStatus int) int {
+	if exitStatus != 0 {
+		return exitStatus
+	}
+
+	switch status {
+	case atc.StatusFailed:
+		return 1
+	case atc.StatusErrored:
+		return 2
+	case atc.StatusAborted:
+		return 3
+	}
+
+	return exitStatus
+}
+
 func isEventParseError(err error) bool {
 	if _, ok := err.(event.UnknownEventTypeError); ok {
 		return true
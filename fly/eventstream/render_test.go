@@ -296,6 +296,19 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a WaitingForTeamQuota event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingForTeamQuota{
+				Time:  time.Now().Unix(),
+				Quota: "max 2 concurrent builds",
+			}
+		})
+
+		It("prints the quota which was reached", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mteam quota reached (max 2 concurrent builds), waiting...\x1b[0m\n"))
+		})
+	})
+
 	Context("when a WaitingForWorker event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.WaitingForWorker{
@@ -0,0 +1,402 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
+)
+
+// OutputFormat is the format in which build events are rendered.
+type OutputFormat string
+
+const (
+	OutputText   OutputFormat = "text"
+	OutputJSON   OutputFormat = "json"
+	OutputNDJSON OutputFormat = "ndjson"
+	OutputJUnit  OutputFormat = "junit"
+)
+
+// IsStructured returns true if the format is meant to be read by programs
+// rather than people.
+func (format OutputFormat) IsStructured() bool {
+	return format != "" && format != OutputText
+}
+
+// Record is a build event as rendered by the json and ndjson formats.
+type Record struct {
+	Event   atc.EventType      `json:"event"`
+	Version atc.EventVersion   `json:"version"`
+	Origin  event.OriginID     `json:"origin,omitempty"`
+	Source  event.OriginSource `json:"source,omitempty"`
+	Step    string             `json:"step,omitempty"`
+	Time    int64              `json:"time,omitempty"`
+	Data    atc.Event          `json:"data"`
+}
+
+// StepNames maps the IDs of the steps in a build's plan to their names, so
+// that the events they emit can be labelled.
+func StepNames(plan atc.PublicBuildPlan) map[event.OriginID]string {
+	names := map[event.OriginID]string{}
+	if plan.Plan == nil {
+		return names
+	}
+
+	var publicPlan interface{}
+	err := json.Unmarshal(*plan.Plan, &publicPlan)
+	if err != nil {
+		return names
+	}
+
+	collectStepNames(publicPlan, names)
+
+	return names
+}
+
+func collectStepNames(plan interface{}, names map[event.OriginID]string) {
+	switch p := plan.(type) {
+	case map[string]interface{}:
+		id, _ := p["id"].(string)
+		for _, value := range p {
+			if step, ok := value.(map[string]interface{}); ok && id != "" {
+				if name, ok := step["name"].(string); ok {
+					names[event.OriginID(id)] = name
+				}
+			}
+
+			collectStepNames(value, names)
+		}
+
+	case []interface{}:
+		for _, value := range p {
+			collectStepNames(value, names)
+		}
+	}
+}
+
+type structuredRenderer interface {
+	Event(Record) error
+	Finish() error
+}
+
+func renderStructured(dst io.Writer, src eventstream.EventStream, options RenderOptions) int {
+	var renderer structuredRenderer
+	switch options.Output {
+	case OutputJSON:
+		renderer = &jsonRenderer{dst: dst}
+	case OutputNDJSON:
+		renderer = &ndjsonRenderer{dst: dst}
+	case OutputJUnit:
+		renderer = newJUnitRenderer(dst, options.BuildName)
+	default:
+		fmt.Fprintf(dst, "unknown output format: %s\n", options.Output)
+		return 255
+	}
+
+	exitStatus := 0
+
+	for {
+		ev, err := src.NextEvent()
+		if err != nil {
+			if err == io.EOF {
+				break
+			} else if options.IgnoreEventParsingErrors && isEventParseError(err) {
+				continue
+			}
+
+			// report the error in the same format as the events so that
+			// the output can still be parsed
+			renderer.Event(newRecord(event.Error{Message: fmt.Sprintf("failed to parse next event: %s", err)}, nil))
+			renderer.Finish()
+			return 255
+		}
+
+		err = renderer.Event(newRecord(ev, options.StepNames))
+		if err != nil {
+			return 255
+		}
+
+		switch e := ev.(type) {
+		case event.FinishTask:
+			exitStatus = e.ExitStatus
+
+		case event.Status:
+			switch e.Status {
+			case atc.StatusStarted:
+				continue
+			case atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored, atc.StatusAborted:
+				exitStatus = finalExitStatus(e.Status, exitStatus)
+			default:
+				exitStatus = 255
+			}
+
+			err = renderer.Finish()
+			if err != nil {
+				return 255
+			}
+
+			return exitStatus
+		}
+	}
+
+	err := renderer.Finish()
+	if err != nil {
+		return 255
+	}
+
+	return exitStatus
+}
+
+func newRecord(ev atc.Event, stepNames map[event.OriginID]string) Record {
+	record := Record{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    ev,
+	}
+
+	// every event has a time, and every event emitted by a step has an
+	// origin, but they are not exposed by the atc.Event interface
+	var metadata struct {
+		Origin event.Origin `json:"origin"`
+		Time   int64        `json:"time"`
+	}
+
+	payload, err := json.Marshal(ev)
+	if err == nil {
+		_ = json.Unmarshal(payload, &metadata)
+	}
+
+	record.Origin = metadata.Origin.ID
+	record.Source = metadata.Origin.Source
+	record.Step = stepNames[metadata.Origin.ID]
+	record.Time = metadata.Time
+
+	return record
+}
+
+// jsonRenderer renders the events as a single JSON array. Each event is
+// written as it is received, so the array can still be parsed as a stream.
+type jsonRenderer struct {
+	dst     io.Writer
+	started bool
+}
+
+func (renderer *jsonRenderer) Event(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if !renderer.started {
+		separator = "[\n"
+		renderer.started = true
+	}
+
+	_, err = fmt.Fprintf(renderer.dst, "%s%s", separator, payload)
+	return err
+}
+
+func (renderer *jsonRenderer) Finish() error {
+	if !renderer.started {
+		_, err := fmt.Fprintln(renderer.dst, "[]")
+		return err
+	}
+
+	_, err := fmt.Fprintln(renderer.dst, "\n]")
+	return err
+}
+
+// ndjsonRenderer renders each event as a JSON object on its own line.
+type ndjsonRenderer struct {
+	dst io.Writer
+}
+
+func (renderer *ndjsonRenderer) Event(record Record) error {
+	return json.NewEncoder(renderer.dst).Encode(record)
+}
+
+func (renderer *ndjsonRenderer) Finish() error {
+	return nil
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitStep struct {
+	name      string
+	startTime int64
+	endTime   int64
+	output    strings.Builder
+	failure   string
+	errors    []string
+}
+
+// junitRenderer renders a JUnit report once the build has finished, with a
+// test case for each step which emitted events. Events which did not come
+// from a step, such as errors while scheduling the build, are reported
+// under a test case named after the build.
+type junitRenderer struct {
+	dst       io.Writer
+	buildName string
+
+	steps     []*junitStep
+	stepsByID map[event.OriginID]*junitStep
+
+	startTime int64
+	endTime   int64
+}
+
+func newJUnitRenderer(dst io.Writer, buildName string) *junitRenderer {
+	if buildName == "" {
+		buildName = "build"
+	}
+
+	return &junitRenderer{
+		dst:       dst,
+		buildName: buildName,
+		stepsByID: map[event.OriginID]*junitStep{},
+	}
+}
+
+func (renderer *junitRenderer) Event(record Record) error {
+	if record.Time != 0 {
+		if renderer.startTime == 0 {
+			renderer.startTime = record.Time
+		}
+
+		renderer.endTime = record.Time
+	}
+
+	if _, isStatus := record.Data.(event.Status); isStatus {
+		return nil
+	}
+
+	step := renderer.step(record)
+	if record.Time != 0 {
+		if step.startTime == 0 {
+			step.startTime = record.Time
+		}
+
+		step.endTime = record.Time
+	}
+
+	switch e := record.Data.(type) {
+	case event.Log:
+		step.output.WriteString(e.Payload)
+	case event.Error:
+		step.errors = append(step.errors, e.Message)
+	case event.FinishTask:
+		step.finish(e.ExitStatus)
+	case event.FinishGet:
+		step.finish(e.ExitStatus)
+	case event.FinishPut:
+		step.finish(e.ExitStatus)
+	case event.Finish:
+		if !e.Succeeded {
+			step.failure = "step failed"
+		}
+	}
+
+	return nil
+}
+
+func (renderer *junitRenderer) step(record Record) *junitStep {
+	step, found := renderer.stepsByID[record.Origin]
+	if found {
+		return step
+	}
+
+	step = &junitStep{name: record.Step}
+	if step.name == "" {
+		if record.Origin != "" {
+			step.name = string(record.Origin)
+		} else {
+			step.name = renderer.buildName
+		}
+	}
+
+	renderer.steps = append(renderer.steps, step)
+	renderer.stepsByID[record.Origin] = step
+
+	return step
+}
+
+func (step *junitStep) finish(exitStatus int) {
+	if exitStatus != 0 {
+		step.failure = fmt.Sprintf("exit status %d", exitStatus)
+	}
+}
+
+func (renderer *junitRenderer) Finish() error {
+	suite := junitTestSuite{
+		Name:  renderer.buildName,
+		Tests: len(renderer.steps),
+		Time:  duration(renderer.startTime, renderer.endTime),
+	}
+
+	for _, step := range renderer.steps {
+		testCase := junitTestCase{
+			Name:      step.name,
+			ClassName: renderer.buildName,
+			Time:      duration(step.startTime, step.endTime),
+			SystemOut: step.output.String(),
+		}
+
+		if len(step.errors) > 0 {
+			suite.Errors++
+			testCase.Error = &junitMessage{
+				Message:  step.errors[0],
+				Contents: strings.Join(step.errors, "\n"),
+			}
+		} else if step.failure != "" {
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: step.failure}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	payload, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(renderer.dst, "%s%s\n", xml.Header, payload)
+	return err
+}
+
+func duration(start int64, end int64) string {
+	if end < start {
+		return "0"
+	}
+
+	return strconv.FormatInt(end-start, 10)
+}
@@ -0,0 +1,180 @@
+package eventstream_test
+
+import (
+	"encoding/json"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/fly/eventstream"
+	"github.com/concourse/concourse/go-concourse/concourse/eventstream/eventstreamfakes"
+)
+
+type parsedRecord struct {
+	Event atc.EventType `json:"event"`
+	Step  string        `json:"step"`
+}
+
+var _ = Describe("Structured output", func() {
+	var (
+		out     *gbytes.Buffer
+		stream  *eventstreamfakes.FakeEventStream
+		options eventstream.RenderOptions
+
+		receivedEvents chan<- atc.Event
+
+		exitStatus int
+	)
+
+	BeforeEach(func() {
+		out = gbytes.NewBuffer()
+		stream = new(eventstreamfakes.FakeEventStream)
+		options = eventstream.RenderOptions{
+			BuildName: "some-pipeline/some-job #1",
+			StepNames: map[event.OriginID]string{
+				"get-id":  "some-input",
+				"task-id": "some-task",
+			},
+		}
+
+		events := make(chan atc.Event, 100)
+		receivedEvents = events
+
+		stream.NextEventStub = func() (atc.Event, error) {
+			select {
+			case ev := <-events:
+				return ev, nil
+			default:
+				return nil, io.EOF
+			}
+		}
+
+		receivedEvents <- event.Status{Status: atc.StatusStarted, Time: 1}
+		receivedEvents <- event.FinishGet{Origin: event.Origin{ID: "get-id"}, Time: 2, ExitStatus: 0}
+		receivedEvents <- event.Log{Origin: event.Origin{ID: "task-id", Source: event.OriginSourceStdout}, Time: 3, Payload: "hello\n"}
+		receivedEvents <- event.FinishTask{Origin: event.Origin{ID: "task-id"}, Time: 5, ExitStatus: 1}
+		receivedEvents <- event.Status{Status: atc.StatusFailed, Time: 6}
+	})
+
+	JustBeforeEach(func() {
+		exitStatus = eventstream.Render(out, stream, options)
+	})
+
+	Context("with ndjson output", func() {
+		BeforeEach(func() {
+			options.Output = eventstream.OutputNDJSON
+		})
+
+		It("prints each event on its own line with its origin, step name and time", func() {
+			lines := []string{}
+			decoder := json.NewDecoder(out)
+			for decoder.More() {
+				var line json.RawMessage
+				Expect(decoder.Decode(&line)).To(Succeed())
+				lines = append(lines, string(line))
+			}
+
+			Expect(lines).To(HaveLen(5))
+			Expect(lines[2]).To(MatchJSON(`{
+				"event": "log",
+				"version": "5.1",
+				"origin": "task-id",
+				"source": "stdout",
+				"step": "some-task",
+				"time": 3,
+				"data": {"origin": {"id": "task-id", "source": "stdout"}, "time": 3, "payload": "hello\n"}
+			}`))
+		})
+
+		It("exits with the task's exit status", func() {
+			Expect(exitStatus).To(Equal(1))
+		})
+	})
+
+	Context("with json output", func() {
+		BeforeEach(func() {
+			options.Output = eventstream.OutputJSON
+		})
+
+		It("prints the events as an array", func() {
+			var records []parsedRecord
+			Expect(json.Unmarshal(out.Contents(), &records)).To(Succeed())
+
+			Expect(records).To(HaveLen(5))
+			Expect(records[1].Event).To(Equal(atc.EventType("finish-get")))
+			Expect(records[1].Step).To(Equal("some-input"))
+			Expect(records[4].Event).To(Equal(atc.EventType("status")))
+		})
+
+		Context("when there are no events", func() {
+			BeforeEach(func() {
+				stream.NextEventStub = nil
+				stream.NextEventReturns(nil, io.EOF)
+			})
+
+			It("prints an empty array", func() {
+				Expect(out.Contents()).To(MatchJSON(`[]`))
+			})
+		})
+
+		Context("when an event cannot be parsed", func() {
+			BeforeEach(func() {
+				stream.NextEventStub = nil
+				stream.NextEventReturns(nil, event.UnknownEventTypeError{Type: "bogus"})
+			})
+
+			It("prints the error as an event and exits 255", func() {
+				var records []parsedRecord
+				Expect(json.Unmarshal(out.Contents(), &records)).To(Succeed())
+
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].Event).To(Equal(atc.EventType("error")))
+				Expect(exitStatus).To(Equal(255))
+			})
+		})
+	})
+
+	Context("with junit output", func() {
+		BeforeEach(func() {
+			options.Output = eventstream.OutputJUnit
+		})
+
+		It("prints a test case for each step", func() {
+			Expect(string(out.Contents())).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="some-pipeline/some-job #1" tests="2" failures="1" errors="0" time="5">
+  <testcase name="some-input" classname="some-pipeline/some-job #1" time="0"></testcase>
+  <testcase name="some-task" classname="some-pipeline/some-job #1" time="2">
+    <failure message="exit status 1"></failure>
+    <system-out>hello&#xA;</system-out>
+  </testcase>
+</testsuite>
+`))
+		})
+
+		It("exits with the task's exit status", func() {
+			Expect(exitStatus).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("StepNames", func() {
+	It("maps the IDs of the steps in the plan to their names", func() {
+		plan := json.RawMessage(`{
+			"id": "do-id",
+			"do": [
+				{"id": "get-id", "get": {"type": "git", "name": "some-input", "resource": "some-resource"}},
+				{"id": "task-id", "task": {"name": "some-task", "privileged": false}}
+			]
+		}`)
+
+		Expect(eventstream.StepNames(atc.PublicBuildPlan{Plan: &plan})).To(Equal(map[event.OriginID]string{
+			"get-id":  "some-input",
+			"task-id": "some-task",
+		}))
+	})
+})
@@ -0,0 +1,135 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var expectedApprovalsURL = "/api/v1/builds/23/approvals"
+
+	var expectedBuild = atc.Build{
+		ID:      23,
+		Name:    "42",
+		Status:  "started",
+		JobName: "my-job",
+		APIURL:  "api/v1/builds/23",
+	}
+
+	Context("when the build id is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedApprovalsURL),
+					ghttp.VerifyJSONRepresenting(atc.BuildApprovalRequest{
+						Approved: true,
+						Comment:  "ship it",
+					}),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-c", "ship it")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("build successfully approved"))
+		})
+	})
+
+	Context("when the job and step are specified and the build is rejected", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/builds/42"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedApprovalsURL),
+					ghttp.VerifyJSONRepresenting(atc.BuildApprovalRequest{
+						StepName: "production",
+						Approved: false,
+					}),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("rejects the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-j", "my-pipeline/my-job", "-b", "42", "-s", "production", "--reject")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("build successfully rejected"))
+		})
+	})
+
+	Context("when the build has no matching approve step", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedApprovalsURL),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: approve step does not exist"))
+		})
+	})
+
+	Context("when the build does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "42")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: build does not exist"))
+		})
+	})
+})
@@ -0,0 +1,160 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("diff-builds", func() {
+		var (
+			flyCmd *exec.Cmd
+			diff   atc.BuildDiff
+
+			fromURL string
+			toURL   string
+			toFound bool
+		)
+
+		fromBuild := atc.Build{ID: 23, Name: "1", JobName: "some-job"}
+		toBuild := atc.Build{ID: 24, Name: "2", JobName: "some-job"}
+
+		BeforeEach(func() {
+			fromConfig := json.RawMessage(`{"run":{"path":"old"}}`)
+			toConfig := json.RawMessage(`{"run":{"path":"new"}}`)
+			fromDuration := int64(10)
+
+			diff = atc.BuildDiff{
+				From: fromBuild,
+				To:   toBuild,
+				Inputs: []atc.BuildVersionDiff{
+					{Name: "repo", From: atc.Version{"ref": "v1"}, To: atc.Version{"ref": "v2"}},
+				},
+				Plan: []atc.BuildStepDiff{},
+				Tasks: []atc.BuildStepDiff{
+					{Step: "task:unit", From: &fromConfig, To: &toConfig},
+				},
+				Images: []atc.BuildVersionDiff{},
+				Workers: []atc.BuildWorkerDiff{
+					{Step: "task:unit", From: "worker-a", To: "worker-b"},
+				},
+				Durations: []atc.BuildDurationDiff{
+					{Step: "task:unit", From: &fromDuration},
+				},
+			}
+
+			fromURL = "/api/v1/builds/23"
+			toURL = "/api/v1/builds/24"
+			toFound = true
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "diff-builds", "--from", "23", "--to", "24")
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", fromURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, fromBuild),
+				),
+			)
+
+			if !toFound {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", toURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+				return
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", toURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, toBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/diff/24"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, diff),
+				),
+			)
+		})
+
+		It("prints the differences between the builds", func() {
+			Expect(flyCmd).To(PrintTable(ui.Table{
+				Data: []ui.TableRow{
+					{{Contents: "input"}, {Contents: "repo"}, {Contents: "ref:v1"}, {Contents: "ref:v2"}},
+					{{Contents: "task config"}, {Contents: "task:unit"}, {Contents: `{"run":{"path":"old"}}`}, {Contents: `{"run":{"path":"new"}}`}},
+					{{Contents: "worker"}, {Contents: "task:unit"}, {Contents: "worker-a"}, {Contents: "worker-b"}},
+					{{Contents: "duration"}, {Contents: "task:unit"}, {Contents: "10s"}, {Contents: "n/a"}},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the diff as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				expected, err := json.Marshal(diff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sess.Out.Contents()).To(MatchJSON(expected))
+			})
+		})
+
+		Context("when the builds do not differ", func() {
+			BeforeEach(func() {
+				diff = atc.BuildDiff{From: fromBuild, To: toBuild}
+			})
+
+			It("says so", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("builds 1 and 2 do not differ"))
+			})
+		})
+
+		Context("when a job is specified", func() {
+			BeforeEach(func() {
+				fromURL = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds/1"
+				toURL = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds/2"
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "diff-builds", "-j", "some-pipeline/some-job", "--from", "1", "--to", "2", "--json")
+			})
+
+			It("looks up the builds by name", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when a build does not exist", func() {
+			BeforeEach(func() {
+				toFound = false
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("build 24 does not exist"))
+			})
+		})
+	})
+})
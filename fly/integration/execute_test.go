@@ -247,6 +247,38 @@ run:
 		Expect(uploadedBits).To(HaveLen(1))
 	})
 
+	Context("when an output format is given", func() {
+		BeforeEach(func() {
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/plan",
+				ghttp.RespondWithJSONEncoded(200, atc.PublicBuildPlan{Schema: "exec.v2", Plan: expectedPlan.Public()}),
+			)
+		})
+
+		It("prints the events to stdout and everything else to stderr", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "e", "-c", taskConfigPath, "--output-format", "ndjson")
+			flyCmd.Dir = buildDir
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			Eventually(sess.Err).Should(gbytes.Say("executing build 128"))
+
+			events <- event.Log{Payload: "sup"}
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(string(sess.Out.Contents())).To(Equal(
+				`{"event":"log","version":"5.1","data":{"time":0,"origin":{},"payload":"sup"}}` + "\n" +
+					`{"event":"status","version":"1.0","data":{"status":"succeeded","time":0}}` + "\n",
+			))
+		})
+	})
+
 	Context("when there is a pipeline job with the same input", func() {
 		BeforeEach(func() {
 			taskPlan.Task.VersionedResourceTypes = atc.VersionedResourceTypes{
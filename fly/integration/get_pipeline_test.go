@@ -16,6 +16,8 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
 )
 
 var _ = Describe("Fly CLI", func() {
@@ -226,6 +228,59 @@ var _ = Describe("Fly CLI", func() {
 						})
 					})
 				})
+
+				Context("when --imports is given", func() {
+					BeforeEach(func() {
+						config.Imports = atc.ImportConfigs{
+							{
+								File: "snippets/test.yml",
+								Resolved: &atc.ResolvedImport{
+									Digest:    "sha256:abc",
+									Jobs:      []string{"some-other-job"},
+									Resources: []string{"some-other-resource"},
+								},
+							},
+							{
+								Library: "some-library",
+								Resolved: &atc.ResolvedImport{
+									Version:       2,
+									Digest:        "sha256:def",
+									ResourceTypes: []string{"some-other-resource-type"},
+								},
+							},
+						}
+
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", path),
+								ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+							),
+						)
+					})
+
+					It("prints where each import came from", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--imports")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gexec.Exit(0))
+						Expect(sess.Out).To(PrintTable(ui.Table{
+							Headers: ui.TableRow{
+								{Contents: "name", Color: color.New(color.Bold)},
+								{Contents: "kind", Color: color.New(color.Bold)},
+								{Contents: "source", Color: color.New(color.Bold)},
+								{Contents: "version", Color: color.New(color.Bold)},
+								{Contents: "digest", Color: color.New(color.Bold)},
+							},
+							Data: []ui.TableRow{
+								{{Contents: "some-other-resource"}, {Contents: "resource"}, {Contents: "snippets/test.yml"}, {Contents: "n/a"}, {Contents: "sha256:abc"}},
+								{{Contents: "some-other-job"}, {Contents: "job"}, {Contents: "snippets/test.yml"}, {Contents: "n/a"}, {Contents: "sha256:abc"}},
+								{{Contents: "some-other-resource-type"}, {Contents: "resource type"}, {Contents: "library:some-library"}, {Contents: "2"}, {Contents: "sha256:def"}},
+							},
+						}))
+					})
+				})
 			})
 
 			Context("with a custom team", func() {
@@ -0,0 +1,145 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Pipeline config history", func() {
+	var history []atc.ConfigHistoryEntry
+
+	BeforeEach(func() {
+		history = []atc.ConfigHistoryEntry{
+			{
+				Version:   7,
+				Author:    "some-user",
+				CreatedAt: 200,
+				Config: atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "some-new-job"}},
+				},
+			},
+			{
+				Version:   3,
+				CreatedAt: 100,
+				Config: atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}},
+				},
+			},
+		}
+	})
+
+	Describe("pipeline-history", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, history),
+				),
+			)
+		})
+
+		It("lists the versions with their authors, most recent first", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "version", Color: color.New(color.Bold)},
+					{Contents: "author", Color: color.New(color.Bold)},
+					{Contents: "set at", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "7 (current)"}, {Contents: "some-user"}, {Contents: time.Unix(200, 0).Local().Format("2006-01-02@15:04:05-0700")}},
+					{{Contents: "3"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: time.Unix(100, 0).Local().Format("2006-01-02@15:04:05-0700")}},
+				},
+			}))
+		})
+
+		It("shows what changed in a version", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline", "--diff", "7")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("job some-new-job has been added"))
+		})
+
+		It("fails when the version is not in the history", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline", "--diff", "5")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("version 5 is not in the pipeline's config history"))
+		})
+	})
+
+	Describe("rollback-pipeline", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/history"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, history),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: history[0].Config}, http.Header{atc.ConfigVersionHeader: {"7"}}),
+				),
+			)
+		})
+
+		It("sets the pipeline to the config from its history", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "7"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						var config atc.Config
+						Expect(yaml.Unmarshal(body, &config)).To(Succeed())
+						Expect(config).To(Equal(history[1].Config))
+					},
+					ghttp.RespondWith(http.StatusOK, "{}"),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--to", "3", "-n")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("job some-new-job has been removed"))
+			Expect(sess.Out).To(gbytes.Say("rolled back 'some-pipeline' to version 3"))
+		})
+
+		It("does nothing when the pipeline is already at that config", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--to", "7", "-n")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("no changes to apply"))
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-sync", func() {
+		Context("when the team syncs its pipelines", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline_sync"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineSyncResponse{
+							Config: atc.PipelineSyncConfig{
+								URI:    "https://example.com/pipelines.git",
+								Branch: "main",
+								Paths:  "ci/*.yml",
+							},
+							Pipelines: []atc.PipelineSyncStatus{
+								{
+									Pipeline: "some-pipeline",
+									File:     "ci/some-pipeline.yml",
+									Commit:   "abcdef0123456789",
+									Status:   atc.PipelineSyncStatusSynced,
+									Drifted:  true,
+									SyncedAt: 100,
+								},
+								{
+									Pipeline: "other-pipeline",
+									File:     "ci/other-pipeline.yml",
+									Status:   atc.PipelineSyncStatusErrored,
+									Error:    "failed to fetch: nope",
+									SyncedAt: 200,
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the status of each pipeline", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-sync")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`syncing ci/\*.yml from https://example.com/pipelines.git \(branch main\)`))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "file", Color: color.New(color.Bold)},
+						{Contents: "commit", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "drifted", Color: color.New(color.Bold)},
+						{Contents: "synced at", Color: color.New(color.Bold)},
+						{Contents: "error", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-pipeline"},
+							{Contents: "ci/some-pipeline.yml"},
+							{Contents: "abcdef0"},
+							{Contents: "synced", Color: ui.SucceededColor},
+							{Contents: "yes", Color: ui.StartedColor},
+							{Contents: time.Unix(100, 0).Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "n/a", Color: ui.OffColor},
+						},
+						{
+							{Contents: "other-pipeline"},
+							{Contents: "ci/other-pipeline.yml"},
+							{Contents: "n/a", Color: ui.OffColor},
+							{Contents: "errored", Color: ui.ErroredColor},
+							{Contents: "no"},
+							{Contents: time.Unix(200, 0).Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "failed to fetch: nope"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the team does not sync its pipelines", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline_sync"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-sync")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("team does not sync its pipelines"))
+			})
+		})
+
+		Context("when syncing now", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/main/pipeline_sync/sync"),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("requests a sync", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-sync", "--sync")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("started syncing pipelines of team 'main'"))
+			})
+		})
+	})
+})
@@ -0,0 +1,229 @@
+package integration_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/vito/go-sse/sse"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("run-job", func() {
+		var (
+			tmpdir     string
+			inputDir   string
+			configPath string
+
+			streaming    chan struct{}
+			events       chan atc.Event
+			uploadedBits chan struct{}
+
+			expectedPlan atc.Plan
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-run-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			inputDir = filepath.Join(tmpdir, "repo")
+			err = os.Mkdir(inputDir, 0755)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(inputDir, "some-file"), []byte("hello"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			configPath = filepath.Join(tmpdir, "pipeline.yml")
+			err = ioutil.WriteFile(
+				configPath,
+				[]byte(`---
+resources:
+- name: repo
+  type: git
+  source: {uri: https://example.com/repo.git}
+- name: release
+  type: s3
+  source: {bucket: ((bucket))}
+
+jobs:
+- name: build
+  plan:
+  - get: repo
+  - task: unit
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: busybox}
+      inputs:
+      - name: repo
+      run: {path: ls}
+  - put: release
+    params: {file: repo/some-file}
+`),
+				0644,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			streaming = make(chan struct{})
+			events = make(chan atc.Event)
+			uploadedBits = make(chan struct{}, 5)
+
+			planFactory := atc.NewPlanFactory(0)
+
+			expectedPlan = planFactory.NewPlan(atc.DoPlan{
+				planFactory.NewPlan(atc.ArtifactInputPlan{
+					ArtifactID: 125,
+					Name:       "repo",
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name: "unit",
+					Config: &atc.TaskConfig{
+						Platform: "linux",
+						ImageResource: &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "busybox"},
+						},
+						Inputs: []atc.TaskInputConfig{{Name: "repo"}},
+						Run:    atc.TaskRunConfig{Path: "ls"},
+					},
+				}),
+				planFactory.NewPlan(atc.DoPlan{}),
+			})
+
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/artifacts",
+				ghttp.CombineHandlers(
+					func(w http.ResponseWriter, req *http.Request) {
+						gr, err := gzip.NewReader(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						tr := tar.NewReader(gr)
+
+						var names []string
+						for {
+							hdr, err := tr.Next()
+							if err != nil {
+								break
+							}
+
+							names = append(names, hdr.Name)
+						}
+
+						Expect(names).To(ContainElement(MatchRegexp("(./)?some-file$")))
+
+						uploadedBits <- struct{}{}
+					},
+					ghttp.RespondWith(201, `{"id":125}`),
+				),
+			)
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/builds",
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/main/builds"),
+					VerifyPlan(expectedPlan),
+					ghttp.RespondWith(201, `{"id":128}`),
+				),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/events",
+				func(w http.ResponseWriter, r *http.Request) {
+					flusher := w.(http.Flusher)
+
+					w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+					w.WriteHeader(http.StatusOK)
+					flusher.Flush()
+
+					close(streaming)
+
+					id := 0
+					for e := range events {
+						payload, err := json.Marshal(event.Message{Event: e})
+						Expect(err).NotTo(HaveOccurred())
+
+						err = sse.Event{
+							ID:   fmt.Sprintf("%d", id),
+							Name: "event",
+							Data: payload,
+						}.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+
+						flusher.Flush()
+						id++
+					}
+
+					err := sse.Event{Name: "end"}.Write(w)
+					Expect(err).NotTo(HaveOccurred())
+				},
+			)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		It("uploads the local inputs, skips puts, and runs the job as a one-off build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "run-job",
+				"-c", configPath,
+				"-j", "build",
+				"-i", "repo="+inputDir,
+				"-v", "bucket=some-bucket",
+				"--dry-run",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			Eventually(sess.Out).Should(gbytes.Say("dry run: skipping put to release"))
+			Eventually(sess.Out).Should(gbytes.Say("running job build in build 128"))
+
+			events <- event.Log{Payload: "sup"}
+			Eventually(sess.Out).Should(gbytes.Say("sup"))
+
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(uploadedBits).To(HaveLen(1))
+		})
+
+		Context("when the job does not exist", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "run-job", "-c", configPath, "-j", "bogus", "-v", "bucket=b")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("job 'bogus' not found"))
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+
+		Context("when an input does not match a get step", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "run-job", "-c", configPath, "-j", "build", "-v", "bucket=b", "-i", "bogus="+inputDir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("unknown get step `bogus`"))
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("SetPipelineLibrary", func() {
+	var config []byte
+
+	BeforeEach(func() {
+		var err error
+		config, err = ioutil.ReadFile("fixtures/imports/test-job.yml")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when not specifying a library name", func() {
+		It("fails and says you should provide a library name", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline-library", "-c", "fixtures/imports/test-job.yml")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("l", "library") + "' was not specified"))
+		})
+	})
+
+	Context("when the ATC saves the library", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipeline_libraries/some-library"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/x-yaml"),
+					ghttp.VerifyBody(config),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineLibrary{
+						Name:     "some-library",
+						TeamName: "main",
+						Version:  2,
+						Digest:   "sha256:abc",
+					}),
+				),
+			)
+		})
+
+		It("saves the config as-is and prints the version", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline-library", "-l", "some-library", "-c", "fixtures/imports/test-job.yml")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`pipeline library some-library is at version 2 \(sha256:abc\)`))
+		})
+	})
+
+	Context("when the ATC rejects the config", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipeline_libraries/some-library"),
+					ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atc.SaveConfigResponse{
+						Errors: []string{"only jobs, resources, resource_types may be imported, not groups"},
+					}),
+				),
+			)
+		})
+
+		It("prints the errors and exits 1", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline-library", "-l", "some-library", "-c", "fixtures/imports/test-job.yml")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("only jobs, resources, resource_types may be imported, not groups"))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -400,6 +401,81 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when the config imports a file", func() {
+				BeforeEach(func() {
+					imported, err := ioutil.ReadFile("fixtures/imports/test-job.yml")
+					Expect(err).NotTo(HaveOccurred())
+
+					expectSaveConfig(atc.Config{
+						Imports: atc.ImportConfigs{
+							{
+								File: "imports/test-job.yml",
+								Vars: atc.Params{"name": "app"},
+								Resolved: &atc.ResolvedImport{
+									Digest:    fmt.Sprintf("sha256:%x", sha256.Sum256(imported)),
+									Jobs:      []string{"app-test"},
+									Resources: []string{"app-repo"},
+								},
+							},
+						},
+
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "app-repo",
+								Type: "git",
+								Source: atc.Source{
+									"uri": "((uri))",
+								},
+							},
+						},
+
+						Jobs: atc.JobConfigs{
+							{
+								Name: "deploy",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:   "app-repo",
+											Passed: []string{"app-test"},
+										},
+									},
+								},
+							},
+							{
+								Name: "app-test",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:    "app-repo",
+											Trigger: true,
+										},
+									},
+								},
+							},
+						},
+					})
+				})
+
+				It("resolves the imports and sends the merged config to the ATC", func() {
+					Expect(func() {
+						flyCmd := exec.Command(
+							flyPath, "-t", targetName,
+							"set-pipeline",
+							"-n",
+							"--pipeline", "awesome-pipeline",
+							"-c", "fixtures/imports-pipeline.yml",
+						)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					}).To(Change(func() int {
+						return len(atcServer.ReceivedRequests())
+					}).By(5))
+				})
+			})
+
 			Context("when a var is specified with -v", func() {
 				BeforeEach(func() {
 					expectSaveConfig(atc.Config{
@@ -1483,6 +1559,108 @@ this is super secure
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(1))
 				})
+
+			Context("when doing a dry run", func() {
+				var dryRunResponse atc.DryRunConfigResponse
+
+				BeforeEach(func() {
+					dryRunResponse = atc.DryRunConfigResponse{
+						Policy: atc.PolicyResult{Allowed: true},
+						Changes: []atc.ConfigChange{
+							{
+								Kind:   "job",
+								Name:   "some-new-job",
+								Action: atc.ConfigChangeAdded,
+								After:  "name: some-new-job\n",
+							},
+						},
+					}
+
+					path, err := atc.Routes.CreatePathForRoute(atc.DryRunConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", path, ghttp.CombineHandlers(
+						func(w http.ResponseWriter, r *http.Request) {
+							config := getConfig(r)
+							Expect(config).To(MatchYAML(payload))
+						},
+						func(w http.ResponseWriter, r *http.Request) {
+							ghttp.RespondWithJSONEncoded(http.StatusOK, dryRunResponse)(w, r)
+						},
+					))
+
+					savePath, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", savePath, func(w http.ResponseWriter, r *http.Request) {
+						Fail("the config should not be saved")
+					})
+				})
+
+				It("shows the changes without setting the pipeline", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--dry-run")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say("job some-new-job has been added"))
+					Eventually(sess).Should(gbytes.Say("policy check passed"))
+					Eventually(sess).Should(gbytes.Say("dry run: the pipeline was not set"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+
+				Context("when the config would be rejected", func() {
+					BeforeEach(func() {
+						dryRunResponse.CredentialErrors = []string{"some-missing-var"}
+						dryRunResponse.Policy = atc.PolicyResult{
+							Allowed: false,
+							Reasons: []string{"some-policy-reason"},
+						}
+					})
+
+					It("shows why and exits 1", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--dry-run")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say("credential errors:"))
+						Eventually(sess).Should(gbytes.Say("some-missing-var"))
+						Eventually(sess).Should(gbytes.Say("policy check failed:"))
+						Eventually(sess).Should(gbytes.Say("some-policy-reason"))
+						Eventually(sess.Err).Should(gbytes.Say("the pipeline config would be rejected"))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(1))
+					})
+				})
+
+				Context("when the config is invalid", func() {
+					BeforeEach(func() {
+						path, err := atc.Routes.CreatePathForRoute(atc.DryRunConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+						Expect(err).NotTo(HaveOccurred())
+
+						atcServer.RouteToHandler("PUT", path,
+							ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atc.SaveConfigResponse{Errors: []string{"some-error"}}),
+						)
+					})
+
+					It("shows the errors and exits 1", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--dry-run")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess.Err).Should(gbytes.Say("invalid pipeline config:"))
+						Eventually(sess.Err).Should(gbytes.Say("some-error"))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(1))
+					})
+				})
+			})
 			})
 		})
 	})
@@ -717,6 +717,140 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("sending quotas", func() {
+			BeforeEach(func() {
+				cmdParams = []string{
+					"--local-user", "brock-obama",
+					"--max-concurrent-builds", "2",
+					"--max-volumes", "30",
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:brock-obama"],
+									"groups": []
+								}
+							},
+							"quotas": {
+								"max_concurrent_builds": 2,
+								"max_volumes": 30
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows the quotas and sends them", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("quotas: 2 concurrent builds, 30 volumes"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a quota is negative", func() {
+				BeforeEach(func() {
+					cmdParams = []string{
+						"--local-user", "brock-obama",
+						"--max-containers", "-1",
+					}
+				})
+
+				It("returns an error", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("quotas must not be negative"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
+		Describe("sending a pipeline sync", func() {
+			BeforeEach(func() {
+				cmdParams = []string{
+					"--local-user", "brock-obama",
+					"--pipeline-sync-uri", "https://example.com/pipelines.git",
+					"--pipeline-sync-paths", "ci/*.yml",
+					"--pipeline-sync-webhook-token", "some-token",
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:brock-obama"],
+									"groups": []
+								}
+							},
+							"pipeline_sync": {
+								"uri": "https://example.com/pipelines.git",
+								"paths": "ci/*.yml",
+								"webhook_token": "some-token"
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows the pipeline sync and sends it", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("pipeline sync:"))
+				Eventually(sess.Out).Should(gbytes.Say("uri: https://example.com/pipelines.git"))
+				Eventually(sess.Out).Should(gbytes.Say(`paths: ci/\*.yml`))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the paths are missing", func() {
+				BeforeEach(func() {
+					cmdParams = []string{
+						"--local-user", "brock-obama",
+						"--pipeline-sync-uri", "https://example.com/pipelines.git",
+					}
+				})
+
+				It("returns an error", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("pipeline sync must specify the paths of the pipeline configs"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"--local-user", "brock-obama"}
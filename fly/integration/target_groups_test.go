@@ -0,0 +1,254 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	var otherServer *ghttp.Server
+
+	BeforeEach(func() {
+		otherServer = ghttp.NewServer()
+
+		targets, err := rc.LoadTargets()
+		Expect(err).NotTo(HaveOccurred())
+
+		other := targets[targetName]
+		other.API = otherServer.URL()
+
+		err = rc.SaveTarget(
+			"other",
+			other.API,
+			other.Insecure,
+			other.TeamName,
+			other.Token,
+			other.CACert,
+			"",
+			"",
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		otherServer.Close()
+	})
+
+	Describe("set-target-group", func() {
+		It("saves the group", func() {
+			flyCmd := exec.Command(flyPath, "set-target-group", "-g", "prod", "-m", targetName, "-m", "other")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("saved target group: prod"))
+
+			members, err := rc.LoadTargetGroup("prod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]rc.TargetName{targetName, "other"}))
+		})
+
+		Context("when a member is not a saved target", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "set-target-group", "-g", "prod", "-m", "bogus")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("unknown target: bogus"))
+			})
+		})
+	})
+
+	Context("when a group has been saved", func() {
+		BeforeEach(func() {
+			err := rc.SaveTargetGroup("prod", []rc.TargetName{targetName, "other"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Describe("target-groups", func() {
+			It("lists the groups and their targets", func() {
+				flyCmd := exec.Command(flyPath, "--print-table-headers", "target-groups")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTableWithHeaders(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "targets", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "prod"}, {Contents: targetName + ",other"}},
+					},
+				}))
+			})
+		})
+
+		Describe("delete-target-group", func() {
+			It("deletes the group but not its targets", func() {
+				flyCmd := exec.Command(flyPath, "delete-target-group", "-g", "prod")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("deleted target group: prod"))
+
+				_, err = rc.LoadTargetGroup("prod")
+				Expect(err).To(MatchError("unknown target group: prod"))
+
+				targets, err := rc.LoadTargets()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(targets).To(HaveKey(rc.TargetName("other")))
+			})
+		})
+
+		Describe("pipelines --targets", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+							{ID: 1, Name: "pipeline-1", LastUpdated: 1},
+						}),
+					),
+				)
+			})
+
+			Context("when every target responds", func() {
+				BeforeEach(func() {
+					otherServer.AppendHandlers(
+						infoHandler(),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+							ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+								{ID: 7, Name: "pipeline-7", Paused: true, LastUpdated: 1},
+							}),
+						),
+					)
+				})
+
+				It("prints the pipelines of each target in one table", func() {
+					flyCmd := exec.Command(flyPath, "--print-table-headers", "pipelines", "--targets", "prod")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(PrintTableWithHeaders(ui.Table{
+						Headers: ui.TableRow{
+							{Contents: "target", Color: color.New(color.Bold)},
+							{Contents: "id", Color: color.New(color.Bold)},
+							{Contents: "name", Color: color.New(color.Bold)},
+							{Contents: "paused", Color: color.New(color.Bold)},
+							{Contents: "public", Color: color.New(color.Bold)},
+							{Contents: "last updated", Color: color.New(color.Bold)},
+						},
+						Data: []ui.TableRow{
+							{{Contents: targetName}, {Contents: "1"}, {Contents: "pipeline-1"}, {Contents: "no"}, {Contents: "no"}, {Contents: time.Unix(1, 0).String()}},
+							{{Contents: "other"}, {Contents: "7"}, {Contents: "pipeline-7"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "no"}, {Contents: time.Unix(1, 0).String()}},
+						},
+					}))
+				})
+
+				It("prints the result of each target as json", func() {
+					flyCmd := exec.Command(flyPath, "pipelines", "--targets", "prod", "--json")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"target": "testserver",
+							"result": [{"id": 1, "name": "pipeline-1", "paused": false, "public": false, "archived": false, "team_name": "", "last_updated": 1}]
+						},
+						{
+							"target": "other",
+							"result": [{"id": 7, "name": "pipeline-7", "paused": true, "public": false, "archived": false, "team_name": "", "last_updated": 1}]
+						}
+					]`))
+				})
+			})
+
+			Context("when a target fails", func() {
+				BeforeEach(func() {
+					otherServer.AppendHandlers(
+						infoHandler(),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+							ghttp.RespondWith(http.StatusInternalServerError, "boom"),
+						),
+					)
+				})
+
+				It("prints the pipelines of the others and the error, and exits 1", func() {
+					flyCmd := exec.Command(flyPath, "pipelines", "--targets", "prod")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Out).To(gbytes.Say("pipeline-1"))
+					Expect(sess.Err).To(gbytes.Say("other: "))
+					Expect(sess.Err).To(gbytes.Say("failed on 1 of 2 targets"))
+				})
+			})
+		})
+
+		Describe("pause-pipeline --targets", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/awesome-pipeline/pause"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+
+				otherServer.AppendHandlers(
+					infoHandler(),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/awesome-pipeline/pause"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("pauses the pipeline on each target and summarizes the results", func() {
+				flyCmd := exec.Command(flyPath, "pause-pipeline", "-p", "awesome-pipeline", "--targets", "prod", "-n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Out).To(gbytes.Say("target: " + targetName))
+				Expect(sess.Out).To(gbytes.Say("paused 'awesome-pipeline'"))
+				Expect(sess.Out).To(gbytes.Say("target: other"))
+				Expect(sess.Err).To(gbytes.Say("pipeline 'awesome-pipeline' not found"))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Data: []ui.TableRow{
+						{{Contents: targetName}, {Contents: "paused", Color: color.New(color.FgGreen)}},
+						{{Contents: "other"}, {Contents: "errored", Color: color.New(color.FgRed, color.Bold)}},
+					},
+				}))
+				Expect(sess.Err).To(gbytes.Say("failed on 1 of 2 targets"))
+			})
+		})
+	})
+})
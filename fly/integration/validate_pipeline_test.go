@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"os/exec"
 
 	. "github.com/onsi/ginkgo"
@@ -116,5 +117,127 @@ var _ = Describe("Fly CLI", func() {
 			<-sess.Exited
 			Expect(sess.ExitCode()).To(Equal(0))
 		})
+
+		It("does not lint a pipeline with strict", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"validate-pipeline",
+				"-c", "fixtures/testConfigLint.yml",
+				"--strict",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(sess.Err).ToNot(gbytes.Say("Lint findings:"))
+		})
+
+		Context("when linting", func() {
+			It("reports lint findings with their rule IDs", func() {
+				flyCmd := exec.Command(
+					flyPath,
+					"validate-pipeline",
+					"-c", "fixtures/testConfigLint.yml",
+					"--lint",
+					"--enable-lint-rule", "task-timeout",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("Lint findings:"))
+				Eventually(sess.Err).Should(gbytes.Say(`  - fixtures/testConfigLint.yml:13: jobs.some-job task 'unit' has no timeout \(task-timeout\)`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("configuration invalid"))
+				Expect(sess.Err).ToNot(gbytes.Say("task 'lint'"))
+			})
+
+			It("reports lint findings as JSON", func() {
+				flyCmd := exec.Command(
+					flyPath,
+					"validate-pipeline",
+					"-c", "fixtures/testConfigLint.yml",
+					"--lint",
+					"--enable-lint-rule", "task-timeout",
+					"--format", "json",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"rule_id": "task-timeout",
+						"message": "jobs.some-job task 'unit' has no timeout",
+						"path": "$.jobs[0].plan[1]",
+						"line": 13,
+						"column": 9
+					}
+				]`))
+			})
+
+			It("reports lint findings as SARIF", func() {
+				flyCmd := exec.Command(
+					flyPath,
+					"validate-pipeline",
+					"-c", "fixtures/testConfigLint.yml",
+					"--lint",
+					"--enable-lint-rule", "task-timeout",
+					"--format", "sarif",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				var log struct {
+					Version string `json:"version"`
+					Runs    []struct {
+						Tool struct {
+							Driver struct {
+								Rules []struct {
+									ID string `json:"id"`
+								} `json:"rules"`
+							} `json:"driver"`
+						} `json:"tool"`
+						Results []struct {
+							RuleID    string `json:"ruleId"`
+							Locations []struct {
+								PhysicalLocation struct {
+									ArtifactLocation struct {
+										URI string `json:"uri"`
+									} `json:"artifactLocation"`
+									Region struct {
+										StartLine int `json:"startLine"`
+									} `json:"region"`
+								} `json:"physicalLocation"`
+							} `json:"locations"`
+						} `json:"results"`
+					} `json:"runs"`
+				}
+
+				err = json.Unmarshal(sess.Out.Contents(), &log)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(log.Version).To(Equal("2.1.0"))
+				Expect(log.Runs).To(HaveLen(1))
+				Expect(log.Runs[0].Tool.Driver.Rules).To(HaveLen(6))
+				Expect(log.Runs[0].Results).To(HaveLen(1))
+				Expect(log.Runs[0].Results[0].RuleID).To(Equal("task-timeout"))
+				Expect(log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("fixtures/testConfigLint.yml"))
+				Expect(log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine).To(Equal(13))
+			})
+		})
 	})
 })
@@ -127,6 +127,64 @@ var _ = Describe("Watching", func() {
 		})
 	})
 
+	Context("with structured output", func() {
+		BeforeEach(func() {
+			plan := json.RawMessage(`{"id": "task-id", "task": {"name": "some-task", "privileged": false}}`)
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/3"),
+					ghttp.RespondWithJSONEncoded(200, atc.Build{ID: 3, Name: "3", PipelineName: "some-pipeline", JobName: "some-job"}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/3/plan"),
+					ghttp.RespondWithJSONEncoded(200, atc.PublicBuildPlan{Schema: "exec.v2", Plan: &plan}),
+				),
+				eventsHandler(),
+			)
+		})
+
+		It("prints each event as a line of json labelled with its step", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "--build", "3", "--output", "ndjson")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Origin: event.Origin{ID: "task-id"}, Payload: "sup"}
+			events <- event.Status{Status: atc.StatusSucceeded}
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(sess.Out).To(gbytes.Say(`{"event":"log","version":"5.1","origin":"task-id","step":"some-task","data":{"time":0,"origin":{"id":"task-id"},"payload":"sup"}}`))
+			Expect(sess.Out).To(gbytes.Say(`{"event":"status","version":"1.0","data":{"status":"succeeded","time":0}}`))
+		})
+
+		It("prints a junit report", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "--build", "3", "--output", "junit")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Origin: event.Origin{ID: "task-id"}, Payload: "sup"}
+			events <- event.FinishTask{Origin: event.Origin{ID: "task-id"}, ExitStatus: 1}
+			events <- event.Status{Status: atc.StatusFailed}
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+
+			Expect(sess.Out).To(gbytes.Say(`<testsuite name="some-pipeline/some-job #3" tests="1" failures="1" errors="0" time="0">`))
+			Expect(sess.Out).To(gbytes.Say(`<testcase name="some-task" classname="some-pipeline/some-job #3" time="0">`))
+			Expect(sess.Out).To(gbytes.Say(`<failure message="exit status 1"></failure>`))
+		})
+	})
+
 	Context("with a specific job and pipeline", func() {
 
 		var (
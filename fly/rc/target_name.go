@@ -36,3 +36,33 @@ func (name *TargetName) Complete(match string) []flags.Completion {
 
 	return comps
 }
+
+type TargetGroupName string
+
+func (name *TargetGroupName) UnmarshalFlag(value string) error {
+	*name = TargetGroupName(value)
+	return nil
+}
+
+func (name *TargetGroupName) Complete(match string) []flags.Completion {
+	groups, err := LoadTargetGroups()
+	if err != nil {
+		return []flags.Completion{}
+	}
+
+	names := []string{}
+	for name := range groups {
+		if strings.HasPrefix(string(name), match) {
+			names = append(names, string(name))
+		}
+	}
+
+	sort.Strings(names)
+
+	comps := make([]flags.Completion, len(names))
+	for idx, name := range names {
+		comps[idx].Item = name
+	}
+
+	return comps
+}
@@ -18,6 +18,14 @@ var (
 	ErrNoTargetFromURL   = errors.New("no target matching url")
 )
 
+type UnknownTargetGroupError struct {
+	GroupName TargetGroupName
+}
+
+func (err UnknownTargetGroupError) Error() string {
+	return fmt.Sprintf("unknown target group: %s", err.GroupName)
+}
+
 type UnknownTargetError struct {
 	TargetName TargetName
 }
@@ -28,8 +36,13 @@ func (err UnknownTargetError) Error() string {
 
 type Targets map[TargetName]TargetProps
 
+// TargetGroups are named sets of targets which commands can be run against
+// all at once.
+type TargetGroups map[TargetGroupName][]TargetName
+
 type RC struct {
-	Targets Targets `json:"targets"`
+	Targets Targets      `json:"targets"`
+	Groups  TargetGroups `json:"groups,omitempty"`
 }
 
 type TargetProps struct {
@@ -67,18 +80,22 @@ func LogoutTarget(targetName TargetName) error {
 }
 
 func DeleteTarget(targetName TargetName) error {
-	flyTargets, err := LoadTargets()
+	flyrc, err := loadRC()
 	if err != nil {
 		return err
 	}
 
-	delete(flyTargets, targetName)
+	delete(flyrc.Targets, targetName)
 
-	return writeTargets(flyrcPath(), flyTargets)
+	for groupName, members := range flyrc.Groups {
+		flyrc.Groups[groupName] = removeMember(members, targetName)
+	}
+
+	return writeRC(flyrcPath(), flyrc)
 }
 
 func DeleteAllTargets() error {
-	return writeTargets(flyrcPath(), Targets{})
+	return writeRC(flyrcPath(), RC{Targets: Targets{}})
 }
 
 func UpdateTargetProps(targetName TargetName, targetProps TargetProps) error {
@@ -103,17 +120,102 @@ func UpdateTargetProps(targetName TargetName, targetProps TargetProps) error {
 }
 
 func UpdateTargetName(targetName TargetName, newTargetName TargetName) error {
-	flyTargets, err := LoadTargets()
+	flyrc, err := loadRC()
 	if err != nil {
 		return err
 	}
 
 	if newTargetName != "" {
-		flyTargets[newTargetName] = flyTargets[targetName]
-		delete(flyTargets, targetName)
+		flyrc.Targets[newTargetName] = flyrc.Targets[targetName]
+		delete(flyrc.Targets, targetName)
+
+		for _, members := range flyrc.Groups {
+			for i, member := range members {
+				if member == targetName {
+					members[i] = newTargetName
+				}
+			}
+		}
 	}
 
-	return writeTargets(flyrcPath(), flyTargets)
+	return writeRC(flyrcPath(), flyrc)
+}
+
+// SaveTargetGroup creates or replaces the group. Each of its members must be
+// a known target.
+func SaveTargetGroup(groupName TargetGroupName, members []TargetName) error {
+	flyrc, err := loadRC()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if _, ok := flyrc.Targets[member]; !ok {
+			return UnknownTargetError{member}
+		}
+	}
+
+	if flyrc.Groups == nil {
+		flyrc.Groups = TargetGroups{}
+	}
+
+	flyrc.Groups[groupName] = members
+
+	return writeRC(flyrcPath(), flyrc)
+}
+
+func DeleteTargetGroup(groupName TargetGroupName) error {
+	flyrc, err := loadRC()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := flyrc.Groups[groupName]; !ok {
+		return UnknownTargetGroupError{groupName}
+	}
+
+	delete(flyrc.Groups, groupName)
+
+	return writeRC(flyrcPath(), flyrc)
+}
+
+func LoadTargetGroups() (TargetGroups, error) {
+	flyrc, err := loadRC()
+	if err != nil {
+		return nil, err
+	}
+
+	if flyrc.Groups == nil {
+		return TargetGroups{}, nil
+	}
+
+	return flyrc.Groups, nil
+}
+
+// LoadTargetGroup returns the names of the group's targets.
+func LoadTargetGroup(groupName TargetGroupName) ([]TargetName, error) {
+	groups, err := LoadTargetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	members, ok := groups[groupName]
+	if !ok {
+		return nil, UnknownTargetGroupError{groupName}
+	}
+
+	return members, nil
+}
+
+func removeMember(members []TargetName, targetName TargetName) []TargetName {
+	remaining := []TargetName{}
+	for _, member := range members {
+		if member != targetName {
+			remaining = append(remaining, member)
+		}
+	}
+
+	return remaining
 }
 
 func SaveTarget(
@@ -183,37 +285,57 @@ func userHomeDir() string {
 }
 
 func LoadTargets() (Targets, error) {
+	flyrc, err := loadRC()
+	if err != nil {
+		return nil, err
+	}
+
+	return flyrc.Targets, nil
+}
+
+func loadRC() (RC, error) {
 	var rc RC
 
 	flyrc := flyrcPath()
 	if _, err := os.Stat(flyrc); err == nil {
 		flyTargetsBytes, err := ioutil.ReadFile(flyrc)
 		if err != nil {
-			return nil, err
+			return RC{}, err
 		}
 		err = yaml.Unmarshal(flyTargetsBytes, &rc)
 		if err != nil {
-			return nil, fmt.Errorf("in the file '%s': %s", flyrc, err)
+			return RC{}, fmt.Errorf("in the file '%s': %s", flyrc, err)
 		}
 	}
 
-	targets := rc.Targets
-	if targets == nil {
-		targets = map[TargetName]TargetProps{}
+	if rc.Targets == nil {
+		rc.Targets = map[TargetName]TargetProps{}
 	}
 
-	for name, targetProps := range targets {
+	for name, targetProps := range rc.Targets {
 		if targetProps.TeamName == "" {
 			targetProps.TeamName = atc.DefaultTeamName
-			targets[name] = targetProps
+			rc.Targets[name] = targetProps
 		}
 	}
 
-	return targets, nil
+	return rc, nil
 }
 
+// writeTargets writes the targets, keeping any groups already in the file.
 func writeTargets(configFileLocation string, targetsToWrite Targets) error {
-	yamlBytes, err := yaml.Marshal(RC{Targets: targetsToWrite})
+	flyrc, err := loadRC()
+	if err != nil {
+		return err
+	}
+
+	flyrc.Targets = targetsToWrite
+
+	return writeRC(configFileLocation, flyrc)
+}
+
+func writeRC(configFileLocation string, rcToWrite RC) error {
+	yamlBytes, err := yaml.Marshal(rcToWrite)
 	if err != nil {
 		return err
 	}
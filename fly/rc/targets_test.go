@@ -168,6 +168,88 @@ var _ = Describe("Targets", func() {
 		})
 	})
 
+	Describe("target groups", func() {
+		BeforeEach(func() {
+			flyrcContents := `targets:
+  target-a:
+    api: http://a.example.com
+  target-b:
+    api: http://b.example.com
+groups:
+  fleet: [target-a, target-b]`
+			ioutil.WriteFile(flyrc, []byte(flyrcContents), 0777)
+		})
+
+		It("loads the group's targets", func() {
+			members, err := rc.LoadTargetGroup("fleet")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(members).To(Equal([]rc.TargetName{"target-a", "target-b"}))
+		})
+
+		It("errors when the group is unknown", func() {
+			_, err := rc.LoadTargetGroup("bogus")
+			Expect(err).To(Equal(rc.UnknownTargetGroupError{GroupName: "bogus"}))
+		})
+
+		It("keeps the groups when a target is saved", func() {
+			err := rc.SaveTarget("target-c", "http://c.example.com", false, "main", nil, "", "", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			groups, err := rc.LoadTargetGroups()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groups).To(Equal(rc.TargetGroups{"fleet": {"target-a", "target-b"}}))
+		})
+
+		It("removes a deleted target from its groups", func() {
+			err := rc.DeleteTarget("target-a")
+			Expect(err).ToNot(HaveOccurred())
+
+			members, err := rc.LoadTargetGroup("fleet")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(members).To(Equal([]rc.TargetName{"target-b"}))
+		})
+
+		It("renames a renamed target in its groups", func() {
+			err := rc.UpdateTargetName("target-a", "target-z")
+			Expect(err).ToNot(HaveOccurred())
+
+			members, err := rc.LoadTargetGroup("fleet")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(members).To(Equal([]rc.TargetName{"target-z", "target-b"}))
+		})
+
+		Describe("SaveTargetGroup", func() {
+			It("saves the group", func() {
+				err := rc.SaveTargetGroup("just-a", []rc.TargetName{"target-a"})
+				Expect(err).ToNot(HaveOccurred())
+
+				members, err := rc.LoadTargetGroup("just-a")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(members).To(Equal([]rc.TargetName{"target-a"}))
+			})
+
+			It("errors when a member is not a known target", func() {
+				err := rc.SaveTargetGroup("broken", []rc.TargetName{"target-a", "bogus"})
+				Expect(err).To(Equal(rc.UnknownTargetError{TargetName: "bogus"}))
+			})
+		})
+
+		Describe("DeleteTargetGroup", func() {
+			It("deletes the group but not its targets", func() {
+				err := rc.DeleteTargetGroup("fleet")
+				Expect(err).ToNot(HaveOccurred())
+
+				groups, err := rc.LoadTargetGroups()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(groups).To(BeEmpty())
+
+				targets, err := rc.LoadTargets()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(targets).To(HaveLen(2))
+			})
+		})
+	})
+
 	Describe("SaveTarget", func() {
 		Context("when managing .flyrc", func() {
 			BeforeEach(func() {
@@ -2,6 +2,8 @@ package progress
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/vbauerster/mpb/v4"
@@ -9,6 +11,10 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// Output is where progress is shown. It is stdout unless stdout is reserved
+// for output which is meant to be parsed.
+var Output io.Writer = os.Stdout
+
 type Progress struct {
 	progress *mpb.Progress
 	errs     *errgroup.Group
@@ -16,7 +22,7 @@ type Progress struct {
 
 func New() *Progress {
 	return &Progress{
-		progress: mpb.New(mpb.WithWidth(1)),
+		progress: mpb.New(mpb.WithWidth(1), mpb.WithOutput(Output)),
 		errs:     new(errgroup.Group),
 	}
 }
@@ -0,0 +1,66 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildApprovals(buildID int) ([]atc.BuildApproval, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var approvals []atc.BuildApproval
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildApprovals,
+		Params:      params,
+	}, &internal.Response{
+		Result: &approvals,
+	})
+
+	switch err.(type) {
+	case nil:
+		return approvals, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (client *client) ApproveBuild(buildID int, request atc.BuildApprovalRequest) (bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(request)
+	if err != nil {
+		return false, fmt.Errorf("Unable to marshal approval: %s", err)
+	}
+
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.ApproveBuild,
+		Params:      params,
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
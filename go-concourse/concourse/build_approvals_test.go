@@ -0,0 +1,117 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Approvals", func() {
+	Describe("BuildApprovals", func() {
+		expectedURL := "/api/v1/builds/1234/approvals"
+
+		Context("when the build exists", func() {
+			expectedApprovals := []atc.BuildApproval{
+				{PlanID: "some-plan", StepName: "deploy", UserName: "some-user", Approved: true, Time: 100},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedApprovals),
+					),
+				)
+			})
+
+			It("returns the approvals", func() {
+				approvals, found, err := client.BuildApprovals(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approvals).To(Equal(expectedApprovals))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildApprovals(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ApproveBuild", func() {
+		expectedURL := "/api/v1/builds/1234/approvals"
+
+		request := atc.BuildApprovalRequest{
+			StepName: "deploy",
+			Approved: true,
+			Comment:  "lgtm",
+		}
+
+		Context("when the approval is recorded", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true and no error", func() {
+				found, err := client.ApproveBuild(1234, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the build or step does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := client.ApproveBuild(1234, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the user has already decided", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.RespondWith(http.StatusConflict, "user has already approved or rejected this step"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.ApproveBuild(1234, request)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("already approved or rejected"))
+			})
+		})
+	})
+})
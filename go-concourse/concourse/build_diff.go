@@ -0,0 +1,33 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildDiff(buildID int, otherBuildID int) (atc.BuildDiff, bool, error) {
+	params := rata.Params{
+		"build_id":       strconv.Itoa(buildID),
+		"other_build_id": strconv.Itoa(otherBuildID),
+	}
+
+	var buildDiff atc.BuildDiff
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildDiff,
+		Params:      params,
+	}, &internal.Response{
+		Result: &buildDiff,
+	})
+
+	switch err.(type) {
+	case nil:
+		return buildDiff, true, nil
+	case internal.ResourceNotFoundError:
+		return buildDiff, false, nil
+	default:
+		return buildDiff, false, err
+	}
+}
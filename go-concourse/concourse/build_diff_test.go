@@ -0,0 +1,64 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Diffs", func() {
+	Describe("BuildDiff", func() {
+		expectedURL := "/api/v1/builds/1234/diff/1235"
+
+		Context("when both builds exist", func() {
+			expectedDiff := atc.BuildDiff{
+				From: atc.Build{ID: 1234},
+				To:   atc.Build{ID: 1235},
+				Inputs: []atc.BuildVersionDiff{
+					{Name: "repo", From: atc.Version{"ref": "v1"}, To: atc.Version{"ref": "v2"}},
+				},
+				Plan:      []atc.BuildStepDiff{},
+				Tasks:     []atc.BuildStepDiff{},
+				Images:    []atc.BuildVersionDiff{},
+				Workers:   []atc.BuildWorkerDiff{{Step: "task:unit", From: "worker-a", To: "worker-b"}},
+				Durations: []atc.BuildDurationDiff{},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDiff),
+					),
+				)
+			})
+
+			It("returns the diff", func() {
+				diff, found, err := client.BuildDiff(1234, 1235)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diff).To(Equal(expectedDiff))
+			})
+		})
+
+		Context("when either build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildDiff(1234, 1235)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
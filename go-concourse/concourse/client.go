@@ -22,6 +22,9 @@ type Client interface {
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildDiff(buildID int, otherBuildID int) (atc.BuildDiff, bool, error)
+	BuildApprovals(buildID int) ([]atc.BuildApproval, bool, error)
+	ApproveBuild(buildID int, request atc.BuildApprovalRequest) (bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
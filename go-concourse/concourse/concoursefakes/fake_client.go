@@ -23,6 +23,20 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(int, atc.BuildApprovalRequest) (bool, error)
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 int
+		arg2 atc.BuildApprovalRequest
+	}
+	approveBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -38,6 +52,37 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildApprovalsStub        func(int) ([]atc.BuildApproval, bool, error)
+	buildApprovalsMutex       sync.RWMutex
+	buildApprovalsArgsForCall []struct {
+		arg1 int
+	}
+	buildApprovalsReturns struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	buildApprovalsReturnsOnCall map[int]struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	BuildDiffStub        func(int, int) (atc.BuildDiff, bool, error)
+	buildDiffMutex       sync.RWMutex
+	buildDiffArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	buildDiffReturns struct {
+		result1 atc.BuildDiff
+		result2 bool
+		result3 error
+	}
+	buildDiffReturnsOnCall map[int]struct {
+		result1 atc.BuildDiff
+		result2 bool
+		result3 error
+	}
 	BuildEventsStub        func(string) (concourse.Events, error)
 	buildEventsMutex       sync.RWMutex
 	buildEventsArgsForCall []struct {
@@ -355,6 +400,71 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 int, arg2 atc.BuildApprovalRequest) (bool, error) {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 int
+		arg2 atc.BuildApprovalRequest
+	}{arg1, arg2})
+	stub := fake.ApproveBuildStub
+	fakeReturns := fake.approveBuildReturns
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2})
+	fake.approveBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(int, atc.BuildApprovalRequest) (bool, error)) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (int, atc.BuildApprovalRequest) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -422,6 +532,141 @@ func (fake *FakeClient) BuildReturnsOnCall(i int, result1 atc.Build, result2 boo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildApprovals(arg1 int) ([]atc.BuildApproval, bool, error) {
+	fake.buildApprovalsMutex.Lock()
+	ret, specificReturn := fake.buildApprovalsReturnsOnCall[len(fake.buildApprovalsArgsForCall)]
+	fake.buildApprovalsArgsForCall = append(fake.buildApprovalsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildApprovalsStub
+	fakeReturns := fake.buildApprovalsReturns
+	fake.recordInvocation("BuildApprovals", []interface{}{arg1})
+	fake.buildApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildApprovalsCallCount() int {
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
+	return len(fake.buildApprovalsArgsForCall)
+}
+
+func (fake *FakeClient) BuildApprovalsCalls(stub func(int) ([]atc.BuildApproval, bool, error)) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = stub
+}
+
+func (fake *FakeClient) BuildApprovalsArgsForCall(i int) int {
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
+	argsForCall := fake.buildApprovalsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildApprovalsReturns(result1 []atc.BuildApproval, result2 bool, result3 error) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = nil
+	fake.buildApprovalsReturns = struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildApprovalsReturnsOnCall(i int, result1 []atc.BuildApproval, result2 bool, result3 error) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = nil
+	if fake.buildApprovalsReturnsOnCall == nil {
+		fake.buildApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildApprovalsReturnsOnCall[i] = struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildDiff(arg1 int, arg2 int) (atc.BuildDiff, bool, error) {
+	fake.buildDiffMutex.Lock()
+	ret, specificReturn := fake.buildDiffReturnsOnCall[len(fake.buildDiffArgsForCall)]
+	fake.buildDiffArgsForCall = append(fake.buildDiffArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.BuildDiffStub
+	fakeReturns := fake.buildDiffReturns
+	fake.recordInvocation("BuildDiff", []interface{}{arg1, arg2})
+	fake.buildDiffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildDiffCallCount() int {
+	fake.buildDiffMutex.RLock()
+	defer fake.buildDiffMutex.RUnlock()
+	return len(fake.buildDiffArgsForCall)
+}
+
+func (fake *FakeClient) BuildDiffCalls(stub func(int, int) (atc.BuildDiff, bool, error)) {
+	fake.buildDiffMutex.Lock()
+	defer fake.buildDiffMutex.Unlock()
+	fake.BuildDiffStub = stub
+}
+
+func (fake *FakeClient) BuildDiffArgsForCall(i int) (int, int) {
+	fake.buildDiffMutex.RLock()
+	defer fake.buildDiffMutex.RUnlock()
+	argsForCall := fake.buildDiffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) BuildDiffReturns(result1 atc.BuildDiff, result2 bool, result3 error) {
+	fake.buildDiffMutex.Lock()
+	defer fake.buildDiffMutex.Unlock()
+	fake.BuildDiffStub = nil
+	fake.buildDiffReturns = struct {
+		result1 atc.BuildDiff
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildDiffReturnsOnCall(i int, result1 atc.BuildDiff, result2 bool, result3 error) {
+	fake.buildDiffMutex.Lock()
+	defer fake.buildDiffMutex.Unlock()
+	fake.BuildDiffStub = nil
+	if fake.buildDiffReturnsOnCall == nil {
+		fake.buildDiffReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildDiff
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildDiffReturnsOnCall[i] = struct {
+		result1 atc.BuildDiff
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) BuildEvents(arg1 string) (concourse.Events, error) {
 	fake.buildEventsMutex.Lock()
 	ret, specificReturn := fake.buildEventsReturnsOnCall[len(fake.buildEventsArgsForCall)]
@@ -1642,8 +1887,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
+	fake.buildDiffMutex.RLock()
+	defer fake.buildDiffMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
 	defer fake.buildEventsMutex.RUnlock()
 	fake.buildPlanMutex.RLock()
@@ -292,6 +292,20 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	DryRunPipelineConfigStub        func(atc.PipelineRef, []byte) (atc.DryRunConfigResponse, error)
+	dryRunPipelineConfigMutex       sync.RWMutex
+	dryRunPipelineConfigArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}
+	dryRunPipelineConfigReturns struct {
+		result1 atc.DryRunConfigResponse
+		result2 error
+	}
+	dryRunPipelineConfigReturnsOnCall map[int]struct {
+		result1 atc.DryRunConfigResponse
+		result2 error
+	}
 	EnableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	enableResourceVersionMutex       sync.RWMutex
 	enableResourceVersionArgsForCall []struct {
@@ -609,6 +623,51 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineConfigHistoryStub        func(atc.PipelineRef) ([]atc.ConfigHistoryEntry, bool, error)
+	pipelineConfigHistoryMutex       sync.RWMutex
+	pipelineConfigHistoryArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	pipelineConfigHistoryReturns struct {
+		result1 []atc.ConfigHistoryEntry
+		result2 bool
+		result3 error
+	}
+	pipelineConfigHistoryReturnsOnCall map[int]struct {
+		result1 []atc.ConfigHistoryEntry
+		result2 bool
+		result3 error
+	}
+	PipelineLibraryStub        func(string, int) (atc.PipelineLibrary, bool, error)
+	pipelineLibraryMutex       sync.RWMutex
+	pipelineLibraryArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	pipelineLibraryReturns struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	pipelineLibraryReturnsOnCall map[int]struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}
+	PipelineSyncStub        func() (atc.PipelineSyncResponse, bool, error)
+	pipelineSyncMutex       sync.RWMutex
+	pipelineSyncArgsForCall []struct {
+	}
+	pipelineSyncReturns struct {
+		result1 atc.PipelineSyncResponse
+		result2 bool
+		result3 error
+	}
+	pipelineSyncReturnsOnCall map[int]struct {
+		result1 atc.PipelineSyncResponse
+		result2 bool
+		result3 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -692,6 +751,20 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	SavePipelineLibraryStub        func(string, []byte) (atc.PipelineLibrary, error)
+	savePipelineLibraryMutex       sync.RWMutex
+	savePipelineLibraryArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	savePipelineLibraryReturns struct {
+		result1 atc.PipelineLibrary
+		result2 error
+	}
+	savePipelineLibraryReturnsOnCall map[int]struct {
+		result1 atc.PipelineLibrary
+		result2 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -721,6 +794,18 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SyncPipelinesStub        func() (bool, error)
+	syncPipelinesMutex       sync.RWMutex
+	syncPipelinesArgsForCall []struct {
+	}
+	syncPipelinesReturns struct {
+		result1 bool
+		result2 error
+	}
+	syncPipelinesReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -2036,6 +2121,76 @@ func (fake *FakeTeam) DisableResourceVersionReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DryRunPipelineConfig(arg1 atc.PipelineRef, arg2 []byte) (atc.DryRunConfigResponse, error) {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.dryRunPipelineConfigMutex.Lock()
+	ret, specificReturn := fake.dryRunPipelineConfigReturnsOnCall[len(fake.dryRunPipelineConfigArgsForCall)]
+	fake.dryRunPipelineConfigArgsForCall = append(fake.dryRunPipelineConfigArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.DryRunPipelineConfigStub
+	fakeReturns := fake.dryRunPipelineConfigReturns
+	fake.recordInvocation("DryRunPipelineConfig", []interface{}{arg1, arg2Copy})
+	fake.dryRunPipelineConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DryRunPipelineConfigCallCount() int {
+	fake.dryRunPipelineConfigMutex.RLock()
+	defer fake.dryRunPipelineConfigMutex.RUnlock()
+	return len(fake.dryRunPipelineConfigArgsForCall)
+}
+
+func (fake *FakeTeam) DryRunPipelineConfigCalls(stub func(atc.PipelineRef, []byte) (atc.DryRunConfigResponse, error)) {
+	fake.dryRunPipelineConfigMutex.Lock()
+	defer fake.dryRunPipelineConfigMutex.Unlock()
+	fake.DryRunPipelineConfigStub = stub
+}
+
+func (fake *FakeTeam) DryRunPipelineConfigArgsForCall(i int) (atc.PipelineRef, []byte) {
+	fake.dryRunPipelineConfigMutex.RLock()
+	defer fake.dryRunPipelineConfigMutex.RUnlock()
+	argsForCall := fake.dryRunPipelineConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) DryRunPipelineConfigReturns(result1 atc.DryRunConfigResponse, result2 error) {
+	fake.dryRunPipelineConfigMutex.Lock()
+	defer fake.dryRunPipelineConfigMutex.Unlock()
+	fake.DryRunPipelineConfigStub = nil
+	fake.dryRunPipelineConfigReturns = struct {
+		result1 atc.DryRunConfigResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DryRunPipelineConfigReturnsOnCall(i int, result1 atc.DryRunConfigResponse, result2 error) {
+	fake.dryRunPipelineConfigMutex.Lock()
+	defer fake.dryRunPipelineConfigMutex.Unlock()
+	fake.DryRunPipelineConfigStub = nil
+	if fake.dryRunPipelineConfigReturnsOnCall == nil {
+		fake.dryRunPipelineConfigReturnsOnCall = make(map[int]struct {
+			result1 atc.DryRunConfigResponse
+			result2 error
+		})
+	}
+	fake.dryRunPipelineConfigReturnsOnCall[i] = struct {
+		result1 atc.DryRunConfigResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) EnableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.enableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.enableResourceVersionReturnsOnCall[len(fake.enableResourceVersionArgsForCall)]
@@ -3513,6 +3668,200 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineConfigHistory(arg1 atc.PipelineRef) ([]atc.ConfigHistoryEntry, bool, error) {
+	fake.pipelineConfigHistoryMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigHistoryReturnsOnCall[len(fake.pipelineConfigHistoryArgsForCall)]
+	fake.pipelineConfigHistoryArgsForCall = append(fake.pipelineConfigHistoryArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.PipelineConfigHistoryStub
+	fakeReturns := fake.pipelineConfigHistoryReturns
+	fake.recordInvocation("PipelineConfigHistory", []interface{}{arg1})
+	fake.pipelineConfigHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigHistoryCallCount() int {
+	fake.pipelineConfigHistoryMutex.RLock()
+	defer fake.pipelineConfigHistoryMutex.RUnlock()
+	return len(fake.pipelineConfigHistoryArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigHistoryCalls(stub func(atc.PipelineRef) ([]atc.ConfigHistoryEntry, bool, error)) {
+	fake.pipelineConfigHistoryMutex.Lock()
+	defer fake.pipelineConfigHistoryMutex.Unlock()
+	fake.PipelineConfigHistoryStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigHistoryArgsForCall(i int) atc.PipelineRef {
+	fake.pipelineConfigHistoryMutex.RLock()
+	defer fake.pipelineConfigHistoryMutex.RUnlock()
+	argsForCall := fake.pipelineConfigHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) PipelineConfigHistoryReturns(result1 []atc.ConfigHistoryEntry, result2 bool, result3 error) {
+	fake.pipelineConfigHistoryMutex.Lock()
+	defer fake.pipelineConfigHistoryMutex.Unlock()
+	fake.PipelineConfigHistoryStub = nil
+	fake.pipelineConfigHistoryReturns = struct {
+		result1 []atc.ConfigHistoryEntry
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigHistoryReturnsOnCall(i int, result1 []atc.ConfigHistoryEntry, result2 bool, result3 error) {
+	fake.pipelineConfigHistoryMutex.Lock()
+	defer fake.pipelineConfigHistoryMutex.Unlock()
+	fake.PipelineConfigHistoryStub = nil
+	if fake.pipelineConfigHistoryReturnsOnCall == nil {
+		fake.pipelineConfigHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.ConfigHistoryEntry
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigHistoryReturnsOnCall[i] = struct {
+		result1 []atc.ConfigHistoryEntry
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineLibrary(arg1 string, arg2 int) (atc.PipelineLibrary, bool, error) {
+	fake.pipelineLibraryMutex.Lock()
+	ret, specificReturn := fake.pipelineLibraryReturnsOnCall[len(fake.pipelineLibraryArgsForCall)]
+	fake.pipelineLibraryArgsForCall = append(fake.pipelineLibraryArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.PipelineLibraryStub
+	fakeReturns := fake.pipelineLibraryReturns
+	fake.recordInvocation("PipelineLibrary", []interface{}{arg1, arg2})
+	fake.pipelineLibraryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineLibraryCallCount() int {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	return len(fake.pipelineLibraryArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineLibraryCalls(stub func(string, int) (atc.PipelineLibrary, bool, error)) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = stub
+}
+
+func (fake *FakeTeam) PipelineLibraryArgsForCall(i int) (string, int) {
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	argsForCall := fake.pipelineLibraryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineLibraryReturns(result1 atc.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	fake.pipelineLibraryReturns = struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineLibraryReturnsOnCall(i int, result1 atc.PipelineLibrary, result2 bool, result3 error) {
+	fake.pipelineLibraryMutex.Lock()
+	defer fake.pipelineLibraryMutex.Unlock()
+	fake.PipelineLibraryStub = nil
+	if fake.pipelineLibraryReturnsOnCall == nil {
+		fake.pipelineLibraryReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineLibrary
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineLibraryReturnsOnCall[i] = struct {
+		result1 atc.PipelineLibrary
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineSync() (atc.PipelineSyncResponse, bool, error) {
+	fake.pipelineSyncMutex.Lock()
+	ret, specificReturn := fake.pipelineSyncReturnsOnCall[len(fake.pipelineSyncArgsForCall)]
+	fake.pipelineSyncArgsForCall = append(fake.pipelineSyncArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSyncStub
+	fakeReturns := fake.pipelineSyncReturns
+	fake.recordInvocation("PipelineSync", []interface{}{})
+	fake.pipelineSyncMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineSyncCallCount() int {
+	fake.pipelineSyncMutex.RLock()
+	defer fake.pipelineSyncMutex.RUnlock()
+	return len(fake.pipelineSyncArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSyncCalls(stub func() (atc.PipelineSyncResponse, bool, error)) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = stub
+}
+
+func (fake *FakeTeam) PipelineSyncReturns(result1 atc.PipelineSyncResponse, result2 bool, result3 error) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = nil
+	fake.pipelineSyncReturns = struct {
+		result1 atc.PipelineSyncResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineSyncReturnsOnCall(i int, result1 atc.PipelineSyncResponse, result2 bool, result3 error) {
+	fake.pipelineSyncMutex.Lock()
+	defer fake.pipelineSyncMutex.Unlock()
+	fake.PipelineSyncStub = nil
+	if fake.pipelineSyncReturnsOnCall == nil {
+		fake.pipelineSyncReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSyncResponse
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineSyncReturnsOnCall[i] = struct {
+		result1 atc.PipelineSyncResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3856,6 +4205,76 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) SavePipelineLibrary(arg1 string, arg2 []byte) (atc.PipelineLibrary, error) {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.savePipelineLibraryMutex.Lock()
+	ret, specificReturn := fake.savePipelineLibraryReturnsOnCall[len(fake.savePipelineLibraryArgsForCall)]
+	fake.savePipelineLibraryArgsForCall = append(fake.savePipelineLibraryArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.SavePipelineLibraryStub
+	fakeReturns := fake.savePipelineLibraryReturns
+	fake.recordInvocation("SavePipelineLibrary", []interface{}{arg1, arg2Copy})
+	fake.savePipelineLibraryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SavePipelineLibraryCallCount() int {
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
+	return len(fake.savePipelineLibraryArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineLibraryCalls(stub func(string, []byte) (atc.PipelineLibrary, error)) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineLibraryArgsForCall(i int) (string, []byte) {
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
+	argsForCall := fake.savePipelineLibraryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SavePipelineLibraryReturns(result1 atc.PipelineLibrary, result2 error) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = nil
+	fake.savePipelineLibraryReturns = struct {
+		result1 atc.PipelineLibrary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipelineLibraryReturnsOnCall(i int, result1 atc.PipelineLibrary, result2 error) {
+	fake.savePipelineLibraryMutex.Lock()
+	defer fake.savePipelineLibraryMutex.Unlock()
+	fake.SavePipelineLibraryStub = nil
+	if fake.savePipelineLibraryReturnsOnCall == nil {
+		fake.savePipelineLibraryReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineLibrary
+			result2 error
+		})
+	}
+	fake.savePipelineLibraryReturnsOnCall[i] = struct {
+		result1 atc.PipelineLibrary
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -3987,6 +4406,62 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SyncPipelines() (bool, error) {
+	fake.syncPipelinesMutex.Lock()
+	ret, specificReturn := fake.syncPipelinesReturnsOnCall[len(fake.syncPipelinesArgsForCall)]
+	fake.syncPipelinesArgsForCall = append(fake.syncPipelinesArgsForCall, struct {
+	}{})
+	stub := fake.SyncPipelinesStub
+	fakeReturns := fake.syncPipelinesReturns
+	fake.recordInvocation("SyncPipelines", []interface{}{})
+	fake.syncPipelinesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SyncPipelinesCallCount() int {
+	fake.syncPipelinesMutex.RLock()
+	defer fake.syncPipelinesMutex.RUnlock()
+	return len(fake.syncPipelinesArgsForCall)
+}
+
+func (fake *FakeTeam) SyncPipelinesCalls(stub func() (bool, error)) {
+	fake.syncPipelinesMutex.Lock()
+	defer fake.syncPipelinesMutex.Unlock()
+	fake.SyncPipelinesStub = stub
+}
+
+func (fake *FakeTeam) SyncPipelinesReturns(result1 bool, result2 error) {
+	fake.syncPipelinesMutex.Lock()
+	defer fake.syncPipelinesMutex.Unlock()
+	fake.SyncPipelinesStub = nil
+	fake.syncPipelinesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SyncPipelinesReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.syncPipelinesMutex.Lock()
+	defer fake.syncPipelinesMutex.Unlock()
+	fake.SyncPipelinesStub = nil
+	if fake.syncPipelinesReturnsOnCall == nil {
+		fake.syncPipelinesReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.syncPipelinesReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -4289,6 +4764,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.destroyTeamMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
+	fake.dryRunPipelineConfigMutex.RLock()
+	defer fake.dryRunPipelineConfigMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
 	fake.exposePipelineMutex.RLock()
@@ -4335,6 +4812,12 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.pipelineConfigHistoryMutex.RLock()
+	defer fake.pipelineConfigHistoryMutex.RUnlock()
+	fake.pipelineLibraryMutex.RLock()
+	defer fake.pipelineLibraryMutex.RUnlock()
+	fake.pipelineSyncMutex.RLock()
+	defer fake.pipelineSyncMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
@@ -4345,10 +4828,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.savePipelineLibraryMutex.RLock()
+	defer fake.savePipelineLibraryMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.syncPipelinesMutex.RLock()
+	defer fake.syncPipelinesMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
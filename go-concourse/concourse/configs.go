@@ -44,6 +44,31 @@ func (team *team) PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, strin
 	}
 }
 
+func (team *team) PipelineConfigHistory(pipelineRef atc.PipelineRef) ([]atc.ConfigHistoryEntry, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var history []atc.ConfigHistoryEntry
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetConfigHistory,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &history,
+	})
+
+	switch err.(type) {
+	case nil:
+		return history, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
 type ConfigWarning struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
@@ -112,6 +137,57 @@ func (team *team) CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, conf
 	}
 }
 
+func (team *team) DryRunPipelineConfig(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.DryRunConfigResponse, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.DryRunConfig,
+		Params:             params,
+		Query:              pipelineRef.QueryParams(),
+		Body:               bytes.NewBuffer(passedConfig),
+		Header: http.Header{
+			"Content-Type": {"application/x-yaml"},
+		},
+	})
+	if err != nil {
+		return atc.DryRunConfigResponse{}, err
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var dryRun atc.DryRunConfigResponse
+		err = json.Unmarshal(body, &dryRun)
+		if err != nil {
+			return atc.DryRunConfigResponse{}, err
+		}
+		return dryRun, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return atc.DryRunConfigResponse{}, err
+		}
+		return atc.DryRunConfigResponse{}, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusForbidden:
+		return atc.DryRunConfigResponse{}, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return atc.DryRunConfigResponse{}, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
+
 func merge(base, extra url.Values) url.Values {
 	if extra != nil {
 		for key, values := range extra {
@@ -170,6 +170,140 @@ var _ = Describe("ATC Handler Configs", func() {
 		})
 	})
 
+	Describe("PipelineConfigHistory", func() {
+		var expectedURL = "/api/v1/teams/some-team/pipelines/mypipeline/config/history"
+
+		Context("when the pipeline exists", func() {
+			var expectedHistory []atc.ConfigHistoryEntry
+
+			BeforeEach(func() {
+				expectedHistory = []atc.ConfigHistoryEntry{
+					{
+						Version:   7,
+						Author:    "some-user",
+						CreatedAt: 200,
+						Config:    atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+					},
+					{
+						Version:   3,
+						CreatedAt: 100,
+						Config:    atc.Config{},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedHistory),
+					),
+				)
+			})
+
+			It("returns the pipeline's config history", func() {
+				history, found, err := team.PipelineConfigHistory(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(history).To(Equal(expectedHistory))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.PipelineConfigHistory(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("DryRunPipelineConfig", func() {
+		var (
+			expectedConfig []byte
+
+			returnHeader int
+			returnBody   []byte
+		)
+
+		BeforeEach(func() {
+			expectedConfig = []byte("jobs: []")
+
+			atcServer.RouteToHandler("PUT", "/api/v1/teams/some-team/pipelines/mypipeline/config/dry_run",
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Content-Type", "application/x-yaml"),
+					ghttp.VerifyBody(expectedConfig),
+					func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(returnHeader)
+						w.Write(returnBody)
+					},
+				),
+			)
+		})
+
+		Context("when the dry run succeeds", func() {
+			BeforeEach(func() {
+				returnHeader = http.StatusOK
+				returnBody = []byte(`{
+					"credential_errors": ["missing-cred"],
+					"policy": {"allowed": false, "reasons": ["not-allowed"]},
+					"changes": [{"kind": "job", "name": "some-job", "action": "added", "after": "name: some-job\n"}]
+				}`)
+			})
+
+			It("returns the result", func() {
+				result, err := team.DryRunPipelineConfig(pipelineRef, expectedConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(atc.DryRunConfigResponse{
+					CredentialErrors: []string{"missing-cred"},
+					Policy: atc.PolicyResult{
+						Allowed: false,
+						Reasons: []string{"not-allowed"},
+					},
+					Changes: []atc.ConfigChange{
+						{
+							Kind:   "job",
+							Name:   "some-job",
+							Action: atc.ConfigChangeAdded,
+							After:  "name: some-job\n",
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the config is invalid", func() {
+			BeforeEach(func() {
+				returnHeader = http.StatusBadRequest
+				returnBody = []byte(`{"errors":["bad-config"]}`)
+			})
+
+			It("returns an InvalidConfigError", func() {
+				_, err := team.DryRunPipelineConfig(pipelineRef, expectedConfig)
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"bad-config"}}))
+			})
+		})
+
+		Context("when the request is forbidden", func() {
+			BeforeEach(func() {
+				returnHeader = http.StatusForbidden
+				returnBody = []byte("not-a-member")
+			})
+
+			It("returns an error", func() {
+				_, err := team.DryRunPipelineConfig(pipelineRef, expectedConfig)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not-a-member"))
+			})
+		})
+	})
 	Describe("CreateOrUpdatePipelineConfig", func() {
 		var (
 			expectedVersion string
@@ -0,0 +1,94 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) SavePipelineLibrary(libraryName string, config []byte) (atc.PipelineLibrary, error) {
+	params := rata.Params{
+		"library_name": libraryName,
+		"team_name":    team.Name(),
+	}
+
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.SavePipelineLibrary,
+		Params:             params,
+		Body:               bytes.NewBuffer(config),
+		Header: http.Header{
+			"Content-Type": {"application/x-yaml"},
+		},
+	})
+	if err != nil {
+		return atc.PipelineLibrary{}, err
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var library atc.PipelineLibrary
+		err = json.Unmarshal(body, &library)
+		if err != nil {
+			return atc.PipelineLibrary{}, err
+		}
+		return library, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return atc.PipelineLibrary{}, err
+		}
+		return atc.PipelineLibrary{}, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusForbidden:
+		return atc.PipelineLibrary{}, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return atc.PipelineLibrary{}, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
+
+func (team *team) PipelineLibrary(libraryName string, version int) (atc.PipelineLibrary, bool, error) {
+	params := rata.Params{
+		"library_name": libraryName,
+		"team_name":    team.Name(),
+	}
+
+	query := url.Values{}
+	if version != 0 {
+		query.Set("version", strconv.Itoa(version))
+	}
+
+	var library atc.PipelineLibrary
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetPipelineLibrary,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &library,
+	})
+
+	switch err.(type) {
+	case nil:
+		return library, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.PipelineLibrary{}, false, nil
+	default:
+		return atc.PipelineLibrary{}, false, err
+	}
+}
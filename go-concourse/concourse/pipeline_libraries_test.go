@@ -0,0 +1,109 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pipeline Libraries", func() {
+	var expectedURL = "/api/v1/teams/some-team/pipeline_libraries/some-library"
+
+	Describe("SavePipelineLibrary", func() {
+		var config = []byte("jobs: []\n")
+
+		Context("when the ATC saves the library", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.VerifyHeaderKV("Content-Type", "application/x-yaml"),
+						ghttp.VerifyBody(config),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineLibrary{
+							Name:     "some-library",
+							TeamName: "some-team",
+							Version:  2,
+							Digest:   "sha256:abc",
+						}),
+					),
+				)
+			})
+
+			It("returns the saved version", func() {
+				library, err := team.SavePipelineLibrary("some-library", config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(library).To(Equal(atc.PipelineLibrary{
+					Name:     "some-library",
+					TeamName: "some-team",
+					Version:  2,
+					Digest:   "sha256:abc",
+				}))
+			})
+		})
+
+		Context("when the ATC rejects the config", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atc.SaveConfigResponse{
+							Errors: []string{"only jobs may be imported"},
+						}),
+					),
+				)
+			})
+
+			It("returns the validation errors", func() {
+				_, err := team.SavePipelineLibrary("some-library", config)
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"only jobs may be imported"}}))
+			})
+		})
+	})
+
+	Describe("PipelineLibrary", func() {
+		Context("when the library exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "version=3"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineLibrary{
+							Name:    "some-library",
+							Version: 3,
+							Config:  "jobs: []\n",
+						}),
+					),
+				)
+			})
+
+			It("returns the requested version", func() {
+				library, found, err := team.PipelineLibrary("some-library", 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(library.Version).To(Equal(3))
+				Expect(library.Config).To(Equal("jobs: []\n"))
+			})
+		})
+
+		Context("when the library does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineLibrary("some-library", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
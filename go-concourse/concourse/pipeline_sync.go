@@ -0,0 +1,50 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PipelineSync() (atc.PipelineSyncResponse, bool, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var response atc.PipelineSyncResponse
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetPipelineSync,
+		Params:      params,
+	}, &internal.Response{
+		Result: &response,
+	})
+
+	switch err.(type) {
+	case nil:
+		return response, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.PipelineSyncResponse{}, false, nil
+	default:
+		return atc.PipelineSyncResponse{}, false, err
+	}
+}
+
+func (team *team) SyncPipelines() (bool, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SyncPipelines,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,102 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pipeline Sync", func() {
+	Describe("PipelineSync", func() {
+		var expectedURL = "/api/v1/teams/some-team/pipeline_sync"
+
+		Context("when the team syncs its pipelines", func() {
+			var expectedResponse atc.PipelineSyncResponse
+
+			BeforeEach(func() {
+				expectedResponse = atc.PipelineSyncResponse{
+					Config: atc.PipelineSyncConfig{
+						URI:   "https://example.com/pipelines.git",
+						Paths: "ci/*.yml",
+					},
+					Pipelines: []atc.PipelineSyncStatus{
+						{Pipeline: "some-pipeline", File: "ci/some-pipeline.yml", Status: atc.PipelineSyncStatusSynced, SyncedAt: 42},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResponse),
+					),
+				)
+			})
+
+			It("returns the config and the status of each pipeline", func() {
+				response, found, err := team.PipelineSync()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(response).To(Equal(expectedResponse))
+			})
+		})
+
+		Context("when the team does not sync its pipelines", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.PipelineSync()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("SyncPipelines", func() {
+		var expectedURL = "/api/v1/teams/some-team/pipeline_sync/sync"
+
+		Context("when the ATC accepts the request", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.SyncPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the team does not sync its pipelines", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.SyncPipelines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -29,7 +29,15 @@ type Team interface {
 	RenamePipeline(oldName, newName string) (bool, []ConfigWarning, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
+	PipelineConfigHistory(pipelineRef atc.PipelineRef) ([]atc.ConfigHistoryEntry, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	DryRunPipelineConfig(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.DryRunConfigResponse, error)
+
+	SavePipelineLibrary(libraryName string, config []byte) (atc.PipelineLibrary, error)
+	PipelineLibrary(libraryName string, version int) (atc.PipelineLibrary, bool, error)
+
+	PipelineSync() (atc.PipelineSyncResponse, bool, error)
+	SyncPipelines() (bool, error)
 
 	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
@@ -0,0 +1,155 @@
+package vars
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactedValue is substituted for secret values when they are redacted.
+const RedactedValue = "((redacted))"
+
+// Redactor is a TrackedVarsIterator which collects the values of interpolated
+// creds so that they can be redacted from build output.
+//
+// Along with the values themselves, common encodings of them (base64,
+// URL-encoding and JSON string escaping) are redacted too, as are the
+// individual lines of multi-line values.
+//
+// Creds can be yielded to the same Redactor again as more of them are
+// interpolated; the encodings of a value are only computed the first time it
+// is yielded, and the replacements are only rebuilt when new values come in.
+type Redactor struct {
+	lock sync.Mutex
+
+	values   map[string]bool
+	secrets  map[string]bool
+	replacer *strings.Replacer
+}
+
+func NewRedactor() *Redactor {
+	return &Redactor{
+		values:  map[string]bool{},
+		secrets: map[string]bool{},
+	}
+}
+
+func (r *Redactor) YieldCred(name, value string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.values[value] {
+		return
+	}
+
+	r.values[value] = true
+
+	candidates := []string{strings.TrimSpace(value)}
+	if strings.Contains(value, "\n") {
+		candidates = append(candidates, value)
+
+		for _, line := range strings.Split(value, "\n") {
+			candidates = append(candidates, strings.TrimSpace(line))
+		}
+	}
+
+	for _, candidate := range candidates {
+		// Don't consider a single char as a secret.
+		if len(candidate) <= 1 {
+			continue
+		}
+
+		for _, encoded := range encodings(candidate) {
+			if !r.secrets[encoded] {
+				r.secrets[encoded] = true
+				r.replacer = nil
+			}
+		}
+	}
+}
+
+// Empty returns true if no creds have been yielded, in which case Redact
+// always returns the text as-is.
+func (r *Redactor) Empty() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.secrets) == 0
+}
+
+// Redact replaces every occurrence of the yielded creds in the text with
+// RedactedValue.
+func (r *Redactor) Redact(text string) string {
+	replacer := r.currentReplacer()
+	if replacer == nil {
+		return text
+	}
+
+	return replacer.Replace(text)
+}
+
+func (r *Redactor) currentReplacer() *strings.Replacer {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.replacer != nil || len(r.secrets) == 0 {
+		return r.replacer
+	}
+
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+
+	// the replacer prefers the values that come first, so replace longer
+	// values first so that a secret which contains another secret is not left
+	// partially redacted
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+
+		return secrets[i] < secrets[j]
+	})
+
+	oldnew := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		oldnew = append(oldnew, secret, RedactedValue)
+	}
+
+	r.replacer = strings.NewReplacer(oldnew...)
+
+	return r.replacer
+}
+
+func encodings(value string) []string {
+	encoded := []string{
+		value,
+		base64.StdEncoding.EncodeToString([]byte(value)),
+		base64.URLEncoding.EncodeToString([]byte(value)),
+		base64.RawStdEncoding.EncodeToString([]byte(value)),
+		base64.RawURLEncoding.EncodeToString([]byte(value)),
+		url.QueryEscape(value),
+		url.PathEscape(value),
+	}
+
+	// JSON-escaped, both with and without HTML characters escaped
+	for _, escapeHTML := range []bool{true, false} {
+		buf := new(bytes.Buffer)
+
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(escapeHTML)
+
+		err := encoder.Encode(value)
+		if err == nil {
+			quoted := strings.TrimSuffix(buf.String(), "\n")
+			encoded = append(encoded, quoted[1:len(quoted)-1])
+		}
+	}
+
+	return encoded
+}
@@ -0,0 +1,79 @@
+package vars_test
+
+import (
+	"encoding/base64"
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("Redactor", func() {
+	var redactor *Redactor
+
+	BeforeEach(func() {
+		redactor = NewRedactor()
+	})
+
+	It("returns the text as-is when no creds were yielded", func() {
+		Expect(redactor.Empty()).To(BeTrue())
+		Expect(redactor.Redact("some text")).To(Equal("some text"))
+	})
+
+	It("redacts the value", func() {
+		redactor.YieldCred("password", "hunter2")
+
+		Expect(redactor.Empty()).To(BeFalse())
+		Expect(redactor.Redact("password: hunter2")).To(Equal("password: ((redacted))"))
+	})
+
+	It("redacts each line of multi-line values", func() {
+		redactor.YieldCred("key", "-----BEGIN KEY-----\nabc123\n-----END KEY-----\n")
+
+		Expect(redactor.Redact("found abc123 in the key")).To(Equal("found ((redacted)) in the key"))
+	})
+
+	It("does not consider single characters as secrets", func() {
+		redactor.YieldCred("flag", "y")
+
+		Expect(redactor.Redact("yes")).To(Equal("yes"))
+	})
+
+	It("redacts base64 encodings of the value", func() {
+		redactor.YieldCred("password", "hunter2?")
+
+		Expect(redactor.Redact(base64.StdEncoding.EncodeToString([]byte("hunter2?")))).To(Equal("((redacted))"))
+		Expect(redactor.Redact(base64.RawURLEncoding.EncodeToString([]byte("hunter2?")))).To(Equal("((redacted))"))
+	})
+
+	It("redacts URL encodings of the value", func() {
+		redactor.YieldCred("password", "p@ss word/&")
+
+		Expect(redactor.Redact("https://example.com/?p=" + url.QueryEscape("p@ss word/&"))).To(Equal("https://example.com/?p=((redacted))"))
+		Expect(redactor.Redact("https://example.com/" + url.PathEscape("p@ss word/&"))).To(Equal("https://example.com/((redacted))"))
+	})
+
+	It("redacts JSON-escaped values", func() {
+		redactor.YieldCred("password", `quote"d<pass>`)
+
+		Expect(redactor.Redact(`{"password":"quote\"d<pass>"}`)).To(Equal(`{"password":"((redacted))"}`))
+	})
+
+	It("redacts creds which are yielded after redacting", func() {
+		redactor.YieldCred("password", "hunter2")
+		Expect(redactor.Redact("hunter2 hunter3")).To(Equal("((redacted)) hunter3"))
+
+		redactor.YieldCred("password", "hunter2")
+		redactor.YieldCred("other-password", "hunter3")
+		Expect(redactor.Redact("hunter2 hunter3")).To(Equal("((redacted)) ((redacted))"))
+	})
+
+	It("redacts longer values first", func() {
+		redactor.YieldCred("short", "secret")
+		redactor.YieldCred("long", "secret-and-more")
+
+		Expect(redactor.Redact("secret-and-more")).To(Equal("((redacted))"))
+	})
+})
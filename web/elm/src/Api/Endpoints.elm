@@ -56,6 +56,7 @@ type BuildEndpoint
     | BuildPlan
     | BuildPrep
     | AbortBuild
+    | BuildApprovals
     | BuildResourcesList
     | BuildEventStream
 
@@ -248,6 +249,9 @@ buildEndpoint endpoint =
         AbortBuild ->
             [ "abort" ]
 
+        BuildApprovals ->
+            [ "approvals" ]
+
         BuildResourcesList ->
             [ "resources" ]
 
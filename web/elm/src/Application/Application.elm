@@ -144,6 +144,9 @@ handleCallback callback model =
         BuildAborted (Err err) ->
             redirectToLoginIfNecessary err ( model, [] )
 
+        BuildApproved (Err err) ->
+            redirectToLoginIfNecessary err ( model, [] )
+
         PausedToggled (Err err) ->
             redirectToLoginIfNecessary err ( model, [] )
 
@@ -235,6 +235,9 @@ handleCallback action ( model, effects ) =
         BuildAborted (Ok ()) ->
             ( model, effects )
 
+        BuildApproved (Ok ()) ->
+            ( model, effects )
+
         BuildPrepFetched buildId (Ok buildPrep) ->
             if buildId == model.id then
                 handleBuildPrepFetched buildPrep ( model, effects )
@@ -422,6 +425,12 @@ update msg ( model, effects ) =
         Click AbortBuildButton ->
             ( model, DoAbortBuild model.id :: effects )
 
+        Click (ApproveStepButton id) ->
+            ( model, DoApproveBuild model.id id True :: effects )
+
+        Click (RejectStepButton id) ->
+            ( model, DoApproveBuild model.id id False :: effects )
+
         Click (StepHeader id) ->
             updateOutput
                 (Build.Output.Output.handleStepTreeMsg <| StepTree.toggleStep id)
@@ -165,6 +165,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        WaitingForTeamQuota origin quota time ->
+            ( updateStep origin.id (setRunning << appendStepLog ("\u{001B}[1mteam quota reached (" ++ quota ++ "), waiting...\u{001B}[0m\n") time) model
+            , effects
+            )
+
         SelectedWorker origin output time ->
             ( updateStep origin.id (setRunning << appendStepLog ("\u{001B}[1mselected worker: \u{001B}[0m" ++ output ++ "\n") time) model
             , effects
@@ -50,6 +50,7 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | Approve StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | InParallel (Array StepTree)
@@ -202,6 +203,7 @@ type BuildEvent
     | SetPipelineChanged Origin Bool
     | Log Origin String (Maybe Time.Posix)
     | WaitingForWorker Origin (Maybe Time.Posix)
+    | WaitingForTeamQuota Origin String (Maybe Time.Posix)
     | SelectedWorker Origin String (Maybe Time.Posix)
     | Error Origin String Time.Posix
     | ImageCheck Origin Concourse.BuildPlan
@@ -273,6 +275,9 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        Approve stepId ->
+            [ stepId ]
+
         InParallel trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
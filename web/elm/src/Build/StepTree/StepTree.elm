@@ -98,6 +98,9 @@ init buildId hl resources plan =
         Concourse.BuildStepLoadVar _ ->
             step |> initBottom buildId hl resources plan LoadVar
 
+        Concourse.BuildStepApprove _ ->
+            step |> initBottom buildId hl resources plan Approve
+
         Concourse.BuildStepInParallel plans ->
             initMultiStep buildId hl resources plan.id InParallel plans Nothing
 
@@ -513,6 +516,16 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId
 
+        Approve stepId ->
+            assumeStep model stepId <|
+                \step ->
+                    viewStepWithBody model session depth step <|
+                        if step.state == StepStateRunning then
+                            [ viewApprovalButtons session step.id ]
+
+                        else
+                            []
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -711,6 +724,30 @@ viewRetryTab { hovered } model stepId activeTab tab step =
         [ Html.text label ]
 
 
+viewApprovalButtons : { r | hovered : HoverState.HoverState } -> StepID -> Html Message
+viewApprovalButtons { hovered } stepId =
+    Html.div Styles.approvalButtons
+        [ viewApprovalButton hovered True (ApproveStepButton stepId) "approve"
+        , viewApprovalButton hovered False (RejectStepButton stepId) "reject"
+        ]
+
+
+viewApprovalButton : HoverState.HoverState -> Bool -> DomID -> String -> Html Message
+viewApprovalButton hovered approve domId label =
+    Html.button
+        ([ id (toHtmlID domId)
+         , onMouseEnter <| Hover <| Just domId
+         , onMouseLeave <| Hover Nothing
+         , onClick <| Click domId
+         ]
+            ++ Styles.approvalButton
+                { isHovered = HoverState.isHovered domId hovered
+                , approve = approve
+                }
+        )
+        [ Html.text label ]
+
+
 viewSeq : { timeZone : Time.Zone, hovered : HoverState.HoverState } -> StepTreeModel -> Int -> StepTree -> Html Message
 viewSeq session model depth tree =
     Html.div [ class "seq" ] [ viewTree session model tree depth ]
@@ -1161,6 +1198,9 @@ viewStepHeader step =
         Concourse.BuildStepLoadVar name ->
             simpleHeader "load_var:" Nothing name
 
+        Concourse.BuildStepApprove name ->
+            simpleHeader "approve:" Nothing name
+
         Concourse.BuildStepCheck name ->
             simpleHeader "check:" Nothing name
 
@@ -1222,6 +1262,9 @@ stepName header =
         Concourse.BuildStepLoadVar name ->
             Just name
 
+        Concourse.BuildStepApprove name ->
+            Just name
+
         Concourse.BuildStepArtifactInput name ->
             Just name
 
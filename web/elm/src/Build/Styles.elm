@@ -1,6 +1,8 @@
 module Build.Styles exposing
     ( MetadataCellType(..)
     , abortButton
+    , approvalButton
+    , approvalButtons
     , body
     , changedStepTooltip
     , durationTooltip
@@ -132,6 +134,38 @@ abortButton isHovered =
         ++ button
 
 
+approvalButtons : List (Html.Attribute msg)
+approvalButtons =
+    [ style "display" "flex"
+    , style "padding" "5px 0"
+    ]
+
+
+approvalButton : { isHovered : Bool, approve : Bool } -> List (Html.Attribute msg)
+approvalButton { isHovered, approve } =
+    let
+        background =
+            case ( approve, isHovered ) of
+                ( True, False ) ->
+                    Colors.success
+
+                ( True, True ) ->
+                    Colors.successFaded
+
+                ( False, False ) ->
+                    Colors.failure
+
+                ( False, True ) ->
+                    Colors.failureFaded
+    in
+    button
+        ++ [ style "cursor" "pointer"
+           , style "margin-right" "5px"
+           , style "color" Colors.text
+           , style "background-color" background
+           ]
+
+
 button : List (Html.Attribute msg)
 button =
     [ style "padding" "10px"
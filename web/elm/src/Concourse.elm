@@ -375,6 +375,9 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepApprove _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -438,6 +441,7 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName InstanceVars
     | BuildStepLoadVar StepName
+    | BuildStepApprove StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName
     | BuildStepGet StepName (Maybe ResourceName) (Maybe Version)
@@ -649,6 +653,8 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "approve" <|
+                    lazy (\_ -> decodeBuildStepApprove)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -782,6 +788,12 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepApprove : Json.Decode.Decoder BuildStep
+decodeBuildStepApprove =
+    Json.Decode.succeed BuildStepApprove
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
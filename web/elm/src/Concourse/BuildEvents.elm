@@ -94,6 +94,20 @@ decodeBuildEvent =
                                 (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
                             )
 
+                    "waiting-for-team-quota" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 WaitingForTeamQuota
+                                -- builds waiting to be scheduled have no origin
+                                (Json.Decode.map (Maybe.withDefault { source = "", id = "" }) <|
+                                    Json.Decode.maybe <|
+                                        Json.Decode.field "origin" <|
+                                            Json.Decode.lazy (\_ -> decodeOrigin)
+                                )
+                                (Json.Decode.field "quota" Json.Decode.string)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "selected-worker" ->
                         Json.Decode.field
                             "data"
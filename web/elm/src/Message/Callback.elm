@@ -56,6 +56,7 @@ type Callback
     | BuildHistoryFetched (Fetched (Paginated Concourse.Build))
     | PlanAndResourcesFetched Int (Fetched ( Concourse.BuildPlan, Concourse.BuildResources ))
     | BuildAborted (Fetched ())
+    | BuildApproved (Fetched ())
     | VisibilityChanged VisibilityAction Concourse.PipelineIdentifier (Fetched ())
     | AllPipelinesFetched (Fetched (List Concourse.Pipeline))
     | GotViewport DomID (Result Browser.Dom.Error Browser.Dom.Viewport)
@@ -145,6 +145,7 @@ type Effect
     | DoTriggerBuild Concourse.JobIdentifier
     | RerunJobBuild Concourse.JobBuildIdentifier
     | DoAbortBuild Int
+    | DoApproveBuild Int Routes.StepID Bool
     | PauseJob Concourse.JobIdentifier
     | UnpauseJob Concourse.JobIdentifier
     | ResetPipelineFocus
@@ -574,6 +575,17 @@ runEffect effect key csrfToken =
                 |> Api.request
                 |> Task.attempt BuildAborted
 
+        DoApproveBuild buildId stepId approved ->
+            Api.post (Endpoints.BuildApprovals |> Endpoints.Build buildId) csrfToken
+                |> Api.withJsonBody
+                    (Json.Encode.object
+                        [ ( "plan_id", Json.Encode.string stepId )
+                        , ( "approved", Json.Encode.bool approved )
+                        ]
+                    )
+                |> Api.request
+                |> Task.attempt BuildApproved
+
         Scroll direction id ->
             scroll direction id
 
@@ -816,6 +828,12 @@ toHtmlID domId =
         StepVersion stepID ->
             stepID ++ "_version"
 
+        ApproveStepButton stepID ->
+            stepID ++ "_approve"
+
+        RejectStepButton stepID ->
+            stepID ++ "_reject"
+
         SideBarIcon ->
             "sidebar-icon"
 
@@ -88,6 +88,8 @@ type DomID
     | StepSubHeader String Int
     | StepInitialization String
     | StepVersion String
+    | ApproveStepButton StepID
+    | RejectStepButton StepID
     | ShowSearchButton
     | ClearSearchButton
     | LoginButton
@@ -386,6 +386,29 @@ all =
                     >> when iAmLookingAtTheStepBody
                     >> then_ iSeeTheLoadVarName
             ]
+        , describe "approve step"
+            [ test "should show approve and reject buttons while running" <|
+                given iVisitABuildWithAnApproveStep
+                    >> given theApproveStepStarted
+                    >> given theApproveStepIsExpanded
+                    >> when iAmLookingAtTheStepBody
+                    >> then_ iSeeTheApprovalButtons
+            , test "should not show the buttons before it starts" <|
+                given iVisitABuildWithAnApproveStep
+                    >> given theApproveStepIsExpanded
+                    >> when iAmLookingAtTheStepBody
+                    >> then_ iDoNotSeeTheApprovalButtons
+            , test "approves the step" <|
+                given iVisitABuildWithAnApproveStep
+                    >> given theApproveStepStarted
+                    >> when iClick (ApproveStepButton approveStepId)
+                    >> then_ myBrowserApproves True
+            , test "rejects the step" <|
+                given iVisitABuildWithAnApproveStep
+                    >> given theApproveStepStarted
+                    >> when iClick (RejectStepButton approveStepId)
+                    >> then_ myBrowserApproves False
+            ]
         ]
 
 
@@ -554,6 +577,12 @@ iVisitABuildWithALoadVarStep =
         >> thePlanContainsALoadVarStep
 
 
+iVisitABuildWithAnApproveStep =
+    iOpenTheBuildPage
+        >> myBrowserFetchedTheBuild
+        >> thePlanContainsAnApproveStep
+
+
 theGetStepIsExpanded =
     Tuple.first
         >> Application.update (Update <| Message.Click <| StepHeader "getStepId")
@@ -594,6 +623,38 @@ theLoadVarStepIsExpanded =
         >> Application.update (Update <| Message.Click <| StepHeader setLoadVarStepId)
 
 
+theApproveStepIsExpanded =
+    Tuple.first
+        >> Application.update (Update <| Message.Click <| StepHeader approveStepId)
+
+
+theApproveStepStarted =
+    Tuple.first
+        >> Application.handleDelivery
+            (EventsReceived <|
+                Ok
+                    [ { data =
+                            Start
+                                { source = ""
+                                , id = approveStepId
+                                }
+                                (Time.millisToPosix 0)
+                      , url = eventsUrl
+                      }
+                    ]
+            )
+
+
+iClick domId =
+    Tuple.first
+        >> Application.update (Update <| Message.Click domId)
+
+
+myBrowserApproves approved =
+    Tuple.second
+        >> Common.contains (Effects.DoApproveBuild 1 approveStepId approved)
+
+
 theAcrossStepIsExpanded =
     Tuple.first
         >> Application.update (Update <| Message.Click <| StepHeader acrossStepId)
@@ -841,6 +902,25 @@ setLoadVarStepId =
     "loadVarStep"
 
 
+thePlanContainsAnApproveStep =
+    Tuple.first
+        >> Application.handleCallback
+            (Callback.PlanAndResourcesFetched 1 <|
+                Ok
+                    ( { id = approveStepId
+                      , step = Concourse.BuildStepApprove "deploy"
+                      }
+                    , { inputs = []
+                      , outputs = []
+                      }
+                    )
+            )
+
+
+approveStepId =
+    "approveStep"
+
+
 acrossStepId =
     "acrossStep"
 
@@ -1166,6 +1246,17 @@ iSeeTheLoadVarName =
     Query.has [ text "var-name" ]
 
 
+iSeeTheApprovalButtons =
+    Expect.all
+        [ Query.has [ tag "button", containing [ text "approve" ] ]
+        , Query.has [ tag "button", containing [ text "reject" ] ]
+        ]
+
+
+iDoNotSeeTheApprovalButtons =
+    Query.hasNot [ tag "button" ]
+
+
 iSeeTheVarNames =
     Query.has [ text "var1, var2" ]
 
@@ -0,0 +1,233 @@
+// Package fuseoverlay provides a baggageclaim volume driver backed by
+// fuse-overlayfs. Unlike the kernel's overlay filesystem, fuse-overlayfs can be
+// mounted from within a user namespace on any kernel with FUSE support, which
+// makes it suitable for rootless workers.
+//
+package fuseoverlay
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/concourse/baggageclaim/volume"
+	"github.com/concourse/baggageclaim/volume/copy"
+)
+
+// Driver lays volumes out the same way as baggageclaim's overlay driver, but
+// mounts copy-on-write layers with fuse-overlayfs.
+//
+type Driver struct {
+	// OverlaysDir is the directory in which the layers of the volumes are
+	// stored.
+	//
+	OverlaysDir string
+
+	// Bin is the fuse-overlayfs executable.
+	//
+	Bin string
+}
+
+var _ volume.Driver = (*Driver)(nil)
+
+// New returns a driver that stores the layers of the volumes under
+// overlaysDir, mounting them with the fuse-overlayfs executable at bin.
+//
+func New(overlaysDir, bin string) *Driver {
+	return &Driver{
+		OverlaysDir: overlaysDir,
+		Bin:         bin,
+	}
+}
+
+func (driver *Driver) CreateVolume(vol volume.FilesystemInitVolume) error {
+	err := os.Mkdir(vol.DataPath(), 0755)
+	if err != nil {
+		return err
+	}
+
+	return driver.bindMount(vol)
+}
+
+func (driver *Driver) DestroyVolume(vol volume.FilesystemVolume) error {
+	path := vol.DataPath()
+
+	// unmounting a path that is no longer mounted fails with EINVAL, which is
+	// fine as we're tearing it down anyway
+	err := syscall.Unmount(path, 0)
+	if err != nil && err != syscall.EINVAL {
+		return fmt.Errorf("unmount %s: %w", path, err)
+	}
+
+	err = os.RemoveAll(driver.workDir(vol))
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(driver.layerDir(vol))
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(path)
+}
+
+func (driver *Driver) CreateCopyOnWriteLayer(
+	child volume.FilesystemInitVolume,
+	parent volume.FilesystemLiveVolume,
+) error {
+	err := os.MkdirAll(child.DataPath(), 0755)
+	if err != nil {
+		return err
+	}
+
+	rootParent, err := driver.findRootParent(child, parent)
+	if err != nil {
+		return err
+	}
+
+	return driver.overlayMount(child, rootParent)
+}
+
+func (driver *Driver) Recover(fs volume.Filesystem) error {
+	vols, err := fs.ListVolumes()
+	if err != nil {
+		return err
+	}
+
+	type cow struct {
+		parent volume.FilesystemLiveVolume
+		child  volume.FilesystemLiveVolume
+	}
+
+	cows := []cow{}
+	for _, vol := range vols {
+		parentVol, hasParent, err := vol.Parent()
+		if err != nil {
+			return fmt.Errorf("get parent: %w", err)
+		}
+
+		if hasParent {
+			cows = append(cows, cow{
+				parent: parentVol,
+				child:  vol,
+			})
+			continue
+		}
+
+		err = driver.bindMount(vol)
+		if err != nil {
+			return fmt.Errorf("recover bind mount: %w", err)
+		}
+	}
+
+	// the parents have to be mounted before their children can be layered on
+	// top of them
+	for _, cow := range cows {
+		rootParent, err := driver.findRootParent(cow.child, cow.parent)
+		if err != nil {
+			return err
+		}
+
+		err = driver.overlayMount(cow.child, rootParent)
+		if err != nil {
+			return fmt.Errorf("recover overlay mount: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findRootParent flattens a chain of copy-on-write volumes. The data of an
+// intermediate parent is copied into the layer of the child so that the child
+// only has to be layered on top of the volume at the root of the chain.
+//
+func (driver *Driver) findRootParent(
+	child volume.FilesystemVolume,
+	parent volume.FilesystemLiveVolume,
+) (volume.FilesystemLiveVolume, error) {
+	rootParent := parent
+
+	grandparent, hasGrandparent, err := parent.Parent()
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasGrandparent {
+		return rootParent, nil
+	}
+
+	err = copy.Cp(false, driver.layerDir(parent), driver.layerDir(child))
+	if err != nil {
+		return nil, fmt.Errorf("copy parent data to child: %w", err)
+	}
+
+	rootParent = grandparent
+
+	for {
+		grandparent, hasGrandparent, err := rootParent.Parent()
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasGrandparent {
+			return rootParent, nil
+		}
+
+		rootParent = grandparent
+	}
+}
+
+func (driver *Driver) bindMount(vol volume.FilesystemVolume) error {
+	layerDir := driver.layerDir(vol)
+
+	err := os.MkdirAll(layerDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = syscall.Mount(layerDir, vol.DataPath(), "", syscall.MS_BIND, "")
+	if err != nil {
+		return fmt.Errorf("bind mount %s: %w", layerDir, err)
+	}
+
+	return nil
+}
+
+func (driver *Driver) overlayMount(child volume.FilesystemVolume, parent volume.FilesystemLiveVolume) error {
+	childDir := driver.layerDir(child)
+	err := os.MkdirAll(childDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	workDir := driver.workDir(child)
+	err = os.MkdirAll(workDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	opts := fmt.Sprintf(
+		"lowerdir=%s,upperdir=%s,workdir=%s",
+		parent.DataPath(),
+		childDir,
+		workDir,
+	)
+
+	output, err := exec.Command(driver.Bin, "-o", opts, child.DataPath()).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("fuse-overlayfs: %w: %s", err, output)
+	}
+
+	return nil
+}
+
+func (driver *Driver) layerDir(vol volume.FilesystemVolume) string {
+	return filepath.Join(driver.OverlaysDir, vol.Handle())
+}
+
+func (driver *Driver) workDir(vol volume.FilesystemVolume) string {
+	return filepath.Join(driver.OverlaysDir, "work", vol.Handle())
+}
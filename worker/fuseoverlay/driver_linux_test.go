@@ -0,0 +1,120 @@
+package fuseoverlay_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/concourse/baggageclaim/volume/volumefakes"
+	"github.com/concourse/concourse/worker/fuseoverlay"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFuseOverlayFS writes a script that records the arguments it was invoked
+// with instead of mounting anything.
+//
+func fakeFuseOverlayFS(t *testing.T, dir string) (bin, argsFile string) {
+	bin = filepath.Join(dir, "fuse-overlayfs")
+	argsFile = filepath.Join(dir, "args")
+
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\n"
+	err := ioutil.WriteFile(bin, []byte(script), 0755)
+	require.NoError(t, err)
+
+	return bin, argsFile
+}
+
+func TestCreateCopyOnWriteLayerMountsOnTopOfParent(t *testing.T) {
+	dir := t.TempDir()
+	bin, argsFile := fakeFuseOverlayFS(t, dir)
+	overlaysDir := filepath.Join(dir, "overlays")
+
+	parent := new(volumefakes.FakeFilesystemLiveVolume)
+	parent.HandleReturns("parent")
+	parent.DataPathReturns(filepath.Join(dir, "volumes", "parent"))
+
+	child := new(volumefakes.FakeFilesystemInitVolume)
+	child.HandleReturns("child")
+	child.DataPathReturns(filepath.Join(dir, "volumes", "child"))
+
+	driver := fuseoverlay.New(overlaysDir, bin)
+
+	err := driver.CreateCopyOnWriteLayer(child, parent)
+	require.NoError(t, err)
+
+	args, err := ioutil.ReadFile(argsFile)
+	require.NoError(t, err)
+	require.Equal(t,
+		"-o lowerdir="+filepath.Join(dir, "volumes", "parent")+
+			",upperdir="+filepath.Join(overlaysDir, "child")+
+			",workdir="+filepath.Join(overlaysDir, "work", "child")+
+			" "+filepath.Join(dir, "volumes", "child"),
+		strings.TrimSpace(string(args)),
+	)
+
+	require.DirExists(t, filepath.Join(dir, "volumes", "child"))
+	require.DirExists(t, filepath.Join(overlaysDir, "child"))
+	require.DirExists(t, filepath.Join(overlaysDir, "work", "child"))
+}
+
+func TestCreateCopyOnWriteLayerFlattensNestedParents(t *testing.T) {
+	dir := t.TempDir()
+	bin, argsFile := fakeFuseOverlayFS(t, dir)
+	overlaysDir := filepath.Join(dir, "overlays")
+
+	root := new(volumefakes.FakeFilesystemLiveVolume)
+	root.HandleReturns("root")
+	root.DataPathReturns(filepath.Join(dir, "volumes", "root"))
+
+	parent := new(volumefakes.FakeFilesystemLiveVolume)
+	parent.HandleReturns("parent")
+	parent.DataPathReturns(filepath.Join(dir, "volumes", "parent"))
+	parent.ParentReturns(root, true, nil)
+
+	err := os.MkdirAll(filepath.Join(overlaysDir, "parent"), 0755)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(filepath.Join(overlaysDir, "parent", "some-file"), []byte("some-content"), 0644)
+	require.NoError(t, err)
+
+	child := new(volumefakes.FakeFilesystemInitVolume)
+	child.HandleReturns("child")
+	child.DataPathReturns(filepath.Join(dir, "volumes", "child"))
+
+	driver := fuseoverlay.New(overlaysDir, bin)
+
+	err = driver.CreateCopyOnWriteLayer(child, parent)
+	require.NoError(t, err)
+
+	args, err := ioutil.ReadFile(argsFile)
+	require.NoError(t, err)
+	require.Contains(t, string(args), "lowerdir="+filepath.Join(dir, "volumes", "root")+",")
+
+	content, err := ioutil.ReadFile(filepath.Join(overlaysDir, "child", "some-file"))
+	require.NoError(t, err)
+	require.Equal(t, "some-content", string(content))
+}
+
+func TestCreateCopyOnWriteLayerFailsWhenMountFails(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "fuse-overlayfs")
+
+	err := ioutil.WriteFile(bin, []byte("#!/bin/sh\necho nope >&2\nexit 1\n"), 0755)
+	require.NoError(t, err)
+
+	parent := new(volumefakes.FakeFilesystemLiveVolume)
+	parent.HandleReturns("parent")
+	parent.DataPathReturns(filepath.Join(dir, "volumes", "parent"))
+
+	child := new(volumefakes.FakeFilesystemInitVolume)
+	child.HandleReturns("child")
+	child.DataPathReturns(filepath.Join(dir, "volumes", "child"))
+
+	driver := fuseoverlay.New(filepath.Join(dir, "overlays"), bin)
+
+	err = driver.CreateCopyOnWriteLayer(child, parent)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "nope")
+}
@@ -7,7 +7,9 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -32,6 +34,10 @@ type GardenBackend struct {
 	userNamespace UserNamespace
 	initBinPath   string
 
+	// runtimeClasses maps the runtime classes containers can request to
+	// the containerd runtimes that run them.
+	runtimeClasses map[string]string
+
 	maxContainers  int
 	requestTimeout time.Duration
 	createLock     TimeoutWithByPassLock
@@ -102,6 +108,17 @@ func WithInitBinPath(initBinPath string) GardenBackendOpt {
 	}
 }
 
+// WithRuntimeClasses configures the runtime classes that containers can
+// request in addition to the default runtime, mapping the name of each class
+// to the containerd runtime implementing it, e.g. "runsc" to
+// "io.containerd.runsc.v1".
+//
+func WithRuntimeClasses(classes map[string]string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.runtimeClasses = classes
+	}
+}
+
 // NewGardenBackend instantiates a GardenBackend with tweakable configurations passed as Config.
 //
 func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b GardenBackend, err error) {
@@ -190,12 +207,17 @@ func (b *GardenBackend) Ping() (err error) {
 func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
 	ctx := context.Background()
 
+	policy, err := networkPolicy(gdnSpec.Properties)
+	if err != nil {
+		return nil, err
+	}
+
 	cont, err := b.createContainer(ctx, gdnSpec)
 	if err != nil {
 		return nil, fmt.Errorf("new container: %w", err)
 	}
 
-	err = b.startTask(ctx, cont)
+	err = b.startTask(ctx, cont, policy)
 	if err != nil {
 		return nil, fmt.Errorf("starting task: %w", err)
 	}
@@ -204,6 +226,7 @@ func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container,
 		cont,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -237,20 +260,64 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
-	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
+	var runtime string
+	if class := gdnSpec.Properties[RuntimeClassKey]; class != "" {
+		var found bool
+		runtime, found = b.runtimeClasses[class]
+		if !found {
+			return nil, ErrUnknownRuntimeClass(class)
+		}
+	}
+
+	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci, runtime)
+}
+
+// networkPolicy parses the network policy requested through the properties of
+// a container, if any.
+//
+func networkPolicy(properties garden.Properties) (*NetworkPolicy, error) {
+	payload, found := properties[NetworkPolicyKey]
+	if !found {
+		return nil, nil
+	}
+
+	var policy NetworkPolicy
+	err := json.Unmarshal([]byte(payload), &policy)
+	if err != nil {
+		return nil, ErrInvalidInput(fmt.Sprintf("invalid network policy: %s", err))
+	}
+
+	return &policy, nil
 }
 
-func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
+// startTask starts the task of the container once it has been added to the
+// network, so that its network policy is in place before anything runs in it.
+//
+func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container, policy *NetworkPolicy) error {
 	task, err := cont.NewTask(ctx, cio.NullIO, containerd.WithNoNewKeyring)
 	if err != nil {
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task)
+	ip, err := b.network.Add(ctx, task)
 	if err != nil {
 		return fmt.Errorf("network add: %w", err)
 	}
 
+	if ip != "" {
+		_, err = cont.SetLabels(ctx, map[string]string{ContainerIPKey: ip})
+		if err != nil {
+			return fmt.Errorf("set container ip label: %w", err)
+		}
+	}
+
+	if policy != nil {
+		err = b.network.RestrictEgress(cont.ID(), ip, *policy)
+		if err != nil {
+			return fmt.Errorf("restrict egress: %w", err)
+		}
+	}
+
 	return task.Start(ctx)
 }
 
@@ -326,6 +393,7 @@ func (b *GardenBackend) Containers(properties garden.Properties) (containers []g
 			containerdContainer,
 			b.killer,
 			b.rootfsManager,
+			b.network,
 		)
 	}
 
@@ -348,6 +416,7 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		containerdContainer,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -367,25 +436,76 @@ func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Dur
 	return duration
 }
 
-// Capacity - Not Implemented
+// Capacity returns the memory and disk of the host, and the maximum number
+// of containers if there is a limit.
 //
-func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) Capacity() (garden.Capacity, error) {
+	memory, err := hostMemory()
+	if err != nil {
+		return garden.Capacity{}, fmt.Errorf("host memory: %w", err)
+	}
+
+	var statfs syscall.Statfs_t
+	err = syscall.Statfs("/", &statfs)
+	if err != nil {
+		return garden.Capacity{}, fmt.Errorf("statfs: %w", err)
+	}
+
+	return garden.Capacity{
+		MemoryInBytes: memory,
+		DiskInBytes:   uint64(statfs.Blocks) * uint64(statfs.Bsize),
+		MaxContainers: uint64(b.maxContainers),
+	}, nil
 }
 
-// BulkInfo - Not Implemented
+// BulkInfo returns the info of each of the containers. Failing to get the
+// info of a container is reported in its entry.
 //
-func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	infos := make(map[string]garden.ContainerInfoEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info, err := container.Info()
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		infos[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return infos, nil
 }
 
-// BulkMetrics - Not Implemented
+// BulkMetrics returns the metrics of each of the containers. Failing to get
+// the metrics of a container is reported in its entry.
 //
-func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := make(map[string]garden.ContainerMetricsEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerMetrics, err := container.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: containerMetrics}
+	}
+
+	return metrics, nil
 }
 
 // checkContainerCapacity ensures that Garden.MaxContainers is respected
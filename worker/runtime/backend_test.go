@@ -146,6 +146,154 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerSetsContainerIP() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.AddReturns("10.80.0.2", nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, fakeContainer.SetLabelsCallCount())
+	_, labels := fakeContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{runtime.ContainerIPKey: "10.80.0.2"}, labels)
+}
+
+func (s *BackendSuite) TestCreateContainerWithDefaultRuntime() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, s.client.NewContainerCallCount())
+	_, _, _, _, runtimeName := s.client.NewContainerArgsForCall(0)
+	s.Empty(runtimeName)
+}
+
+func (s *BackendSuite) TestCreateContainerWithRuntimeClass() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithRuntimeClasses(map[string]string{
+			"runsc": "io.containerd.runsc.v1",
+		}),
+	)
+	s.NoError(err)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.RuntimeClassKey: "runsc",
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.client.NewContainerCallCount())
+	_, _, _, _, runtimeName := s.client.NewContainerArgsForCall(0)
+	s.Equal("io.containerd.runsc.v1", runtimeName)
+}
+
+func (s *BackendSuite) TestCreateContainerWithUnknownRuntimeClass() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.RuntimeClassKey: "kata",
+		},
+	})
+	s.True(errors.Is(err, runtime.ErrUnknownRuntimeClass("kata")))
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerRestrictsEgress() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.IDReturns("handle")
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.AddReturns("10.80.0.2", nil)
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.NetworkPolicyKey: `{"allow":["10.0.0.0/8","example.com"]}`,
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.network.RestrictEgressCallCount())
+	handle, ip, policy := s.network.RestrictEgressArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal(runtime.NetworkPolicy{Allow: []string{"10.0.0.0/8", "example.com"}}, policy)
+
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerWithoutNetworkPolicy() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(0, s.network.RestrictEgressCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerRestrictEgressFailure() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.RestrictEgressReturns(errors.New("restrict-err"))
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.NetworkPolicyKey: `{"allow":[]}`,
+		},
+	})
+	s.Error(err)
+
+	s.Equal(0, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerWithInvalidNetworkPolicy() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.NetworkPolicyKey: "nope",
+		},
+	})
+	s.Error(err)
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -174,7 +322,7 @@ func (s *BackendSuite) TestCreateMaxContainersReachedConcurrent() {
 
 	fakeContainer.NewTaskReturns(fakeTask, nil)
 
-	s.client.NewContainerStub = func(context context.Context, str string, strings map[string]string, spec *specs.Spec) (container containerd.Container, e error) {
+	s.client.NewContainerStub = func(context context.Context, str string, strings map[string]string, spec *specs.Spec, runtimeName string) (container containerd.Container, e error) {
 		s.client.ContainersReturns([]containerd.Container{fakeContainer}, nil)
 		return fakeContainer, nil
 	}
@@ -219,7 +367,7 @@ func (s *BackendSuite) TestCreateContainerLockTimeout() {
 	fakeContainer.IDReturns("handle")
 	fakeContainer.NewTaskReturns(fakeTask, nil)
 
-	s.client.NewContainerStub = func(context context.Context, str string, strings map[string]string, spec *specs.Spec) (container containerd.Container, e error) {
+	s.client.NewContainerStub = func(context context.Context, str string, strings map[string]string, spec *specs.Spec, runtimeName string) (container containerd.Container, e error) {
 		s.client.ContainersReturns([]containerd.Container{fakeContainer}, nil)
 		time.Sleep(500 * time.Millisecond)
 		return fakeContainer, nil
@@ -522,3 +670,55 @@ func (s *BackendSuite) TestGraceTimeReturnsDuration() {
 	result := s.backend.GraceTime(fakeContainer)
 	s.Equal(time.Duration(123), result)
 }
+
+func (s *BackendSuite) TestCapacity() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithMaxContainers(250),
+	)
+	s.NoError(err)
+
+	capacity, err := backend.Capacity()
+	s.NoError(err)
+	s.Equal(uint64(250), capacity.MaxContainers)
+	s.NotZero(capacity.MemoryInBytes)
+	s.NotZero(capacity.DiskInBytes)
+}
+
+func (s *BackendSuite) TestBulkInfo() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.LabelsReturns(map[string]string{"foo": "bar"}, nil)
+	fakeContainer.SpecReturns(&specs.Spec{}, nil)
+	fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	s.client.GetContainerReturnsOnCall(0, fakeContainer, nil)
+	s.client.GetContainerReturnsOnCall(1, nil, errors.New("get-container-err"))
+
+	infos, err := s.backend.BulkInfo([]string{"handle-1", "handle-2"})
+	s.NoError(err)
+	s.Len(infos, 2)
+
+	s.Nil(infos["handle-1"].Err)
+	s.Equal(garden.Properties{"foo": "bar"}, infos["handle-1"].Info.Properties)
+
+	s.NotNil(infos["handle-2"].Err)
+	s.Contains(infos["handle-2"].Err.Error(), "get-container-err")
+}
+
+func (s *BackendSuite) TestBulkMetrics() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(nil, errors.New("task-lookup-err"))
+
+	s.client.GetContainerReturnsOnCall(0, fakeContainer, nil)
+	s.client.GetContainerReturnsOnCall(1, nil, errors.New("get-container-err"))
+
+	metrics, err := s.backend.BulkMetrics([]string{"handle-1", "handle-2"})
+	s.NoError(err)
+	s.Len(metrics, 2)
+
+	s.NotNil(metrics["handle-1"].Err)
+	s.Contains(metrics["handle-1"].Err.Error(), "task-lookup-err")
+
+	s.NotNil(metrics["handle-2"].Err)
+	s.Contains(metrics["handle-2"].Err.Error(), "get-container-err")
+}
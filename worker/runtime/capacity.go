@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const memInfo = "/proc/meminfo"
+
+func hostMemory() (uint64, error) {
+	f, err := os.Open(memInfo)
+	if err != nil {
+		return 0, fmt.Errorf("open %s: %w", memInfo, err)
+	}
+	defer f.Close()
+
+	return TotalMemory(f)
+}
+
+// TotalMemory finds the total memory in bytes in the contents of
+// /proc/meminfo, where it is reported in kilobytes:
+//
+// 	MemTotal:       16318476 kB
+//
+func TotalMemory(reader io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "MemTotal:" || fields[2] != "kB" {
+			continue
+		}
+
+		kilobytes, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing MemTotal: %w", err)
+		}
+
+		return kilobytes * 1024, nil
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("scanning: %w", err)
+	}
+
+	return 0, fmt.Errorf("MemTotal not found")
+}
@@ -0,0 +1,55 @@
+package runtime_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CapacitySuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *CapacitySuite) TestTotalMemory() {
+	for _, tc := range []struct {
+		desc      string
+		input     string
+		shouldErr bool
+		val       uint64
+	}{
+		{
+			desc:      "empty input",
+			shouldErr: true,
+		},
+		{
+			desc:      "invalid value",
+			input:     "MemTotal:       lots kB",
+			shouldErr: true,
+		},
+		{
+			desc:  "meminfo",
+			input: "MemTotal:       16318476 kB\nMemFree:         2345678 kB\n",
+			val:   16318476 * 1024,
+		},
+		{
+			desc:  "MemTotal not first",
+			input: "MemFree:         2345678 kB\nMemTotal:       1024 kB\n",
+			val:   1024 * 1024,
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			res, err := runtime.TotalMemory(strings.NewReader(tc.input))
+			if tc.shouldErr {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tc.val, res)
+		})
+	}
+}
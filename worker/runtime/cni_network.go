@@ -2,13 +2,18 @@ package runtime
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"path/filepath"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime/iptables"
 	"github.com/containerd/containerd"
 	"github.com/containerd/go-cni"
+	"github.com/hashicorp/go-multierror"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -49,6 +54,24 @@ const (
 	binariesDir = "/usr/local/concourse/bin"
 
 	ipTablesAdminChainName = "CONCOURSE-OPERATOR"
+
+	// ipTablesContainerChainPrefix prefixes the chains holding the rules
+	// added for a single container through NetIn and NetOut.
+	//
+	ipTablesContainerChainPrefix = "CONCOURSE-"
+
+	// ipTablesEgressChainPrefix prefixes the chains enforcing the network
+	// policy of a single container.
+	//
+	ipTablesEgressChainPrefix = "CONCOURSE-EGRESS-"
+
+	// ipTablesMaxChainNameLength is the longest name iptables accepts for a
+	// chain.
+	//
+	ipTablesMaxChainNameLength = 28
+
+	filterTable = "filter"
+	natTable    = "nat"
 )
 
 var (
@@ -267,7 +290,7 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 }
 
 func (n cniNetwork) SetupRestrictedNetworks() error {
-	const tableName = "filter"
+	const tableName = filterTable
 	err := n.ipt.CreateChainOrFlushIfExists(tableName, ipTablesAdminChainName)
 	if err != nil {
 		return fmt.Errorf("create chain or flush if exists failed: %w", err)
@@ -290,32 +313,54 @@ func (n cniNetwork) SetupRestrictedNetworks() error {
 }
 
 func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
-	contents := ""
-	resolvConfEntries := n.nameServers
-	var err error
+	resolvConfEntries, err := n.resolvConfEntries()
+
+	contents := strings.Join(resolvConfEntries, "\n") + "\n"
+
+	return []byte(contents), err
+}
 
+func (n cniNetwork) resolvConfEntries() ([]string, error) {
 	if len(n.nameServers) == 0 {
-		resolvConfEntries, err = ParseHostResolveConf("/etc/resolv.conf")
+		return ParseHostResolveConf("/etc/resolv.conf")
 	}
 
-	contents = strings.Join(resolvConfEntries, "\n") + "\n"
+	return n.nameServers, nil
+}
 
-	return []byte(contents), err
+// nameServerIPs lists the addresses of the nameservers configured in the
+// /etc/resolv.conf of the containers.
+//
+func (n cniNetwork) nameServerIPs() ([]string, error) {
+	entries, err := n.resolvConfEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) == 2 && fields[0] == "nameserver" {
+			ips = append(ips, fields[1])
+		}
+	}
+
+	return ips, nil
 }
 
-func (n cniNetwork) Add(ctx context.Context, task containerd.Task) error {
+func (n cniNetwork) Add(ctx context.Context, task containerd.Task) (string, error) {
 	if task == nil {
-		return ErrInvalidInput("nil task")
+		return "", ErrInvalidInput("nil task")
 	}
 
 	id, netns := netId(task), netNsPath(task)
 
-	_, err := n.client.Setup(ctx, id, netns)
+	result, err := n.client.Setup(ctx, id, netns)
 	if err != nil {
-		return fmt.Errorf("cni net setup: %w", err)
+		return "", fmt.Errorf("cni net setup: %w", err)
 	}
 
-	return nil
+	return containerIP(result), nil
 }
 
 func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
@@ -325,14 +370,409 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
 
 	id, netns := netId(task), netNsPath(task)
 
+	// tear down the network even if the rules couldn't be removed, so that
+	// the container's IP is released either way
+	chainsErr := n.removeContainerChains(id)
+	if chainsErr != nil {
+		chainsErr = fmt.Errorf("removing iptables rules: %w", chainsErr)
+	}
+
 	err := n.client.Remove(ctx, id, netns)
 	if err != nil {
-		return fmt.Errorf("cni net teardown: %w", err)
+		err = fmt.Errorf("cni net teardown: %w", err)
+		if chainsErr != nil {
+			return multierror.Append(chainsErr, err)
+		}
+
+		return err
+	}
+
+	return chainsErr
+}
+
+// NetIn forwards TCP traffic sent to the host port to the container port,
+// using a DNAT rule in the container's chain of the nat table.
+//
+func (n cniNetwork) NetIn(handle, containerIP string, hostPort, containerPort uint32) error {
+	if containerIP == "" {
+		return ErrInvalidInput("container has no ip")
+	}
+
+	chain := containerChain(handle)
+
+	created, err := n.createChainIfNotExists(natTable, chain)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		for _, from := range []string{"PREROUTING", "OUTPUT"} {
+			err = n.ipt.AppendRule(natTable, from, natJump(chain)...)
+			if err != nil {
+				return fmt.Errorf("appending jump to %s: %w", chain, err)
+			}
+		}
+	}
+
+	err = n.ipt.AppendRule(natTable, chain,
+		"-p", "tcp",
+		"--dport", fmt.Sprint(hostPort),
+		"-j", "DNAT",
+		"--to-destination", fmt.Sprintf("%s:%d", containerIP, containerPort),
+	)
+	if err != nil {
+		return fmt.Errorf("appending dnat rule: %w", err)
+	}
+
+	return nil
+}
+
+// NetOut accepts the traffic of the container allowed by the rule in the
+// container's chain of the filter table. The chain is jumped to from the
+// admin chain before the restricted networks are rejected.
+//
+// Logging of the allowed traffic is not supported.
+//
+func (n cniNetwork) NetOut(handle, containerIP string, rule garden.NetOutRule) error {
+	if containerIP == "" {
+		return ErrInvalidInput("container has no ip")
+	}
+
+	rulespecs, err := netOutRulespecs(containerIP, rule)
+	if err != nil {
+		return err
+	}
+
+	chain := containerChain(handle)
+
+	created, err := n.createChainIfNotExists(filterTable, chain)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		// keep the RELATED,ESTABLISHED rule first
+		err = n.ipt.InsertRule(filterTable, ipTablesAdminChainName, 2, "-j", chain)
+		if err != nil {
+			return fmt.Errorf("inserting jump to %s: %w", chain, err)
+		}
+	}
+
+	for _, rulespec := range rulespecs {
+		err = n.ipt.AppendRule(filterTable, chain, rulespec...)
+		if err != nil {
+			return fmt.Errorf("appending accept rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RestrictEgress rejects the traffic of the container in its egress chain of
+// the filter table, except for the traffic to the destinations allowed by the
+// policy and, unless nothing is allowed, to the container's nameservers.
+// Hostnames are resolved once, when the policy is applied.
+//
+// The egress chain is jumped to from the admin chain, so that the restricted
+// networks still apply to the allowed traffic, and from the INPUT chain, so
+// that the container cannot reach the worker itself either. Rules added with
+// NetOut afterwards take precedence over the policy.
+//
+func (n cniNetwork) RestrictEgress(handle, containerIP string, policy NetworkPolicy) error {
+	if containerIP == "" {
+		return ErrInvalidInput("container has no ip")
+	}
+
+	destinations, err := resolveDestinations(policy.Allow)
+	if err != nil {
+		return err
+	}
+
+	var nameServers []string
+	if len(policy.Allow) > 0 {
+		nameServers, err = n.nameServerIPs()
+		if err != nil {
+			return fmt.Errorf("listing nameservers: %w", err)
+		}
+	}
+
+	chain := egressChain(handle)
+
+	err = n.ipt.CreateChain(filterTable, chain)
+	if err != nil {
+		return fmt.Errorf("creating chain %s: %w", chain, err)
+	}
+
+	rulespecs := [][]string{
+		{"-s", containerIP, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "RETURN"},
+	}
+
+	for _, destination := range destinations {
+		rulespecs = append(rulespecs, []string{"-s", containerIP, "-d", destination, "-j", "RETURN"})
+	}
+
+	for _, nameServer := range nameServers {
+		for _, protocol := range []string{"udp", "tcp"} {
+			rulespecs = append(rulespecs, []string{"-s", containerIP, "-d", nameServer, "-p", protocol, "--dport", "53", "-j", "RETURN"})
+		}
+	}
+
+	rulespecs = append(rulespecs, []string{"-s", containerIP, "-j", "REJECT"})
+
+	for _, rulespec := range rulespecs {
+		err = n.ipt.AppendRule(filterTable, chain, rulespec...)
+		if err != nil {
+			return fmt.Errorf("appending egress rule: %w", err)
+		}
+	}
+
+	// keep the RELATED,ESTABLISHED rule of the admin chain first
+	err = n.ipt.InsertRule(filterTable, ipTablesAdminChainName, 2, "-j", chain)
+	if err != nil {
+		return fmt.Errorf("inserting jump to %s: %w", chain, err)
+	}
+
+	err = n.ipt.InsertRule(filterTable, "INPUT", 1, "-j", chain)
+	if err != nil {
+		return fmt.Errorf("inserting jump to %s: %w", chain, err)
+	}
+
+	return nil
+}
+
+func (n cniNetwork) createChainIfNotExists(table, chain string) (bool, error) {
+	exists, err := n.ipt.ChainExists(table, chain)
+	if err != nil {
+		return false, fmt.Errorf("checking chain %s: %w", chain, err)
+	}
+
+	if exists {
+		return false, nil
+	}
+
+	err = n.ipt.CreateChain(table, chain)
+	if err != nil {
+		return false, fmt.Errorf("creating chain %s: %w", chain, err)
+	}
+
+	return true, nil
+}
+
+func (n cniNetwork) removeContainerChains(handle string) error {
+	chain, egress := containerChain(handle), egressChain(handle)
+
+	chains := []struct {
+		table string
+		chain string
+		jumps [][]string
+	}{
+		{
+			table: filterTable,
+			chain: chain,
+			jumps: [][]string{{ipTablesAdminChainName, "-j", chain}},
+		},
+		{
+			table: natTable,
+			chain: chain,
+			jumps: [][]string{
+				append([]string{"PREROUTING"}, natJump(chain)...),
+				append([]string{"OUTPUT"}, natJump(chain)...),
+			},
+		},
+		{
+			table: filterTable,
+			chain: egress,
+			jumps: [][]string{
+				{ipTablesAdminChainName, "-j", egress},
+				{"INPUT", "-j", egress},
+			},
+		},
+	}
+
+	for _, c := range chains {
+		exists, err := n.ipt.ChainExists(c.table, c.chain)
+		if err != nil {
+			return fmt.Errorf("checking chain %s: %w", c.chain, err)
+		}
+
+		if !exists {
+			continue
+		}
+
+		for _, jump := range c.jumps {
+			err = n.ipt.DeleteRule(c.table, jump[0], jump[1:]...)
+			if err != nil {
+				return fmt.Errorf("deleting jump to %s: %w", c.chain, err)
+			}
+		}
+
+		err = n.ipt.DeleteChain(c.table, c.chain)
+		if err != nil {
+			return fmt.Errorf("deleting chain %s: %w", c.chain, err)
+		}
 	}
 
 	return nil
 }
 
+// containerChain is the name of the chain holding the rules of a container.
+// Chain names are limited to 28 characters, so the handle is hashed.
+//
+func containerChain(handle string) string {
+	sum := sha256.Sum256([]byte(handle))
+	return ipTablesContainerChainPrefix + hex.EncodeToString(sum[:])[:16]
+}
+
+// egressChain is the name of the chain enforcing the network policy of a
+// container.
+//
+func egressChain(handle string) string {
+	sum := sha256.Sum256([]byte(handle))
+	name := ipTablesEgressChainPrefix + hex.EncodeToString(sum[:])
+	return name[:ipTablesMaxChainNameLength]
+}
+
+func natJump(chain string) []string {
+	return []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", chain}
+}
+
+// netOutRulespecs converts a garden.NetOutRule into iptables rules, one for
+// each combination of network and port range.
+//
+func netOutRulespecs(containerIP string, rule garden.NetOutRule) ([][]string, error) {
+	base := []string{"-s", containerIP}
+
+	switch rule.Protocol {
+	case garden.ProtocolAll:
+		if len(rule.Ports) > 0 {
+			return nil, ErrInvalidInput("ports cannot be specified for protocol all")
+		}
+	case garden.ProtocolTCP:
+		base = append(base, "-p", "tcp")
+	case garden.ProtocolUDP:
+		base = append(base, "-p", "udp")
+	case garden.ProtocolICMP:
+		if len(rule.Ports) > 0 {
+			return nil, ErrInvalidInput("ports cannot be specified for protocol icmp")
+		}
+
+		base = append(base, "-p", "icmp")
+
+		if rule.ICMPs != nil {
+			icmpType := fmt.Sprint(rule.ICMPs.Type)
+			if rule.ICMPs.Code != nil {
+				icmpType += fmt.Sprintf("/%d", *rule.ICMPs.Code)
+			}
+
+			base = append(base, "--icmp-type", icmpType)
+		}
+	default:
+		return nil, ErrInvalidInput(fmt.Sprintf("unknown protocol %d", rule.Protocol))
+	}
+
+	destinations := [][]string{nil}
+	if len(rule.Networks) > 0 {
+		destinations = nil
+	}
+
+	for _, network := range rule.Networks {
+		if network.Start == nil {
+			return nil, ErrInvalidInput("network range without a start")
+		}
+
+		if network.End == nil || network.Start.Equal(network.End) {
+			destinations = append(destinations, []string{"-d", network.Start.String()})
+		} else {
+			destinations = append(destinations, []string{"-m", "iprange", "--dst-range", network.Start.String() + "-" + network.End.String()})
+		}
+	}
+
+	ports := [][]string{nil}
+	if len(rule.Ports) > 0 {
+		ports = nil
+	}
+
+	for _, port := range rule.Ports {
+		if port.End == 0 || port.End == port.Start {
+			ports = append(ports, []string{"--dport", fmt.Sprint(port.Start)})
+		} else {
+			ports = append(ports, []string{"--dport", fmt.Sprintf("%d:%d", port.Start, port.End)})
+		}
+	}
+
+	var rulespecs [][]string
+	for _, destination := range destinations {
+		for _, port := range ports {
+			rulespec := append([]string{}, base...)
+			rulespec = append(rulespec, destination...)
+			rulespec = append(rulespec, port...)
+			rulespec = append(rulespec, "-j", "ACCEPT")
+
+			rulespecs = append(rulespecs, rulespec)
+		}
+	}
+
+	return rulespecs, nil
+}
+
+// resolveDestinations converts the destinations allowed by a network policy
+// into the addresses and networks iptables matches on. Containers only have an
+// IPv4 address, so the IPv6 addresses of hostnames are left out. Hostnames are
+// only resolved here, when the container is created.
+//
+func resolveDestinations(allow []string) ([]string, error) {
+	var destinations []string
+
+	for _, destination := range allow {
+		if _, network, err := net.ParseCIDR(destination); err == nil {
+			if network.IP.To4() != nil {
+				destinations = append(destinations, network.String())
+			}
+
+			continue
+		}
+
+		ips := []net.IP{net.ParseIP(destination)}
+		if ips[0] == nil {
+			var err error
+			ips, err = net.LookupIP(destination)
+			if err != nil {
+				return nil, fmt.Errorf("resolving %s: %w", destination, err)
+			}
+		}
+
+		for _, ip := range ips {
+			if ip.To4() != nil {
+				destinations = append(destinations, ip.String())
+			}
+		}
+	}
+
+	return destinations, nil
+}
+
+// containerIP is the IPv4 address given to the container's interface.
+//
+func containerIP(result *cni.Result) string {
+	if result == nil {
+		return ""
+	}
+
+	for _, iface := range result.Interfaces {
+		if iface == nil || iface.Sandbox == "" {
+			continue
+		}
+
+		for _, ipConfig := range iface.IPConfigs {
+			if ipConfig.IP.To4() != nil && !ipConfig.IP.IsLoopback() {
+				return ipConfig.IP.String()
+			}
+		}
+	}
+
+	return ""
+}
+
 func netId(task containerd.Task) string {
 	return task.ID()
 }
@@ -3,12 +3,15 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"net"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/iptables/iptablesfakes"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/go-cni"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -169,7 +172,7 @@ func (s *CNINetworkSuite) TestSetupRestrictedNetworksCreatesEmptyAdminChain() {
 }
 
 func (s *CNINetworkSuite) TestAddNilTask() {
-	err := s.network.Add(context.Background(), nil)
+	_, err := s.network.Add(context.Background(), nil)
 	s.EqualError(err, "nil task")
 }
 
@@ -177,7 +180,7 @@ func (s *CNINetworkSuite) TestAddSetupErrors() {
 	s.cni.SetupReturns(nil, errors.New("setup-err"))
 	task := new(libcontainerdfakes.FakeTask)
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.EqualError(errors.Unwrap(err), "setup-err")
 }
 
@@ -186,7 +189,7 @@ func (s *CNINetworkSuite) TestAdd() {
 	task.PidReturns(123)
 	task.IDReturns("id")
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.NoError(err)
 
 	s.Equal(1, s.cni.SetupCallCount())
@@ -195,6 +198,28 @@ func (s *CNINetworkSuite) TestAdd() {
 	s.Equal("/proc/123/ns/net", netns)
 }
 
+func (s *CNINetworkSuite) TestAddReturnsContainerIP() {
+	s.cni.SetupReturns(&cni.Result{
+		Interfaces: map[string]*cni.Config{
+			"concourse0": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("10.80.0.1")}},
+			},
+			"lo": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("127.0.0.1")}},
+				Sandbox:   "/proc/123/ns/net",
+			},
+			"eth0": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("10.80.0.2"), Gateway: net.ParseIP("10.80.0.1")}},
+				Sandbox:   "/proc/123/ns/net",
+			},
+		},
+	}, nil)
+
+	ip, err := s.network.Add(context.Background(), new(libcontainerdfakes.FakeTask))
+	s.NoError(err)
+	s.Equal("10.80.0.2", ip)
+}
+
 func (s *CNINetworkSuite) TestRemoveNilTask() {
 	err := s.network.Remove(context.Background(), nil)
 	s.EqualError(err, "nil task")
@@ -208,6 +233,27 @@ func (s *CNINetworkSuite) TestRemoveSetupErrors() {
 	s.EqualError(errors.Unwrap(err), "remove-err")
 }
 
+func (s *CNINetworkSuite) TestRemoveTearsDownWhenRemovingRulesFails() {
+	s.iptables.ChainExistsReturns(false, errors.New("iptables-err"))
+	task := new(libcontainerdfakes.FakeTask)
+
+	err := s.network.Remove(context.Background(), task)
+	s.Error(err)
+	s.Contains(err.Error(), "iptables-err")
+	s.Equal(1, s.cni.RemoveCallCount())
+}
+
+func (s *CNINetworkSuite) TestRemoveCombinesErrors() {
+	s.iptables.ChainExistsReturns(false, errors.New("iptables-err"))
+	s.cni.RemoveReturns(errors.New("remove-err"))
+	task := new(libcontainerdfakes.FakeTask)
+
+	err := s.network.Remove(context.Background(), task)
+	s.Error(err)
+	s.Contains(err.Error(), "iptables-err")
+	s.Contains(err.Error(), "remove-err")
+}
+
 func (s *CNINetworkSuite) TestRemove() {
 	task := new(libcontainerdfakes.FakeTask)
 	task.PidReturns(123)
@@ -221,3 +267,253 @@ func (s *CNINetworkSuite) TestRemove() {
 	s.Equal("id", id)
 	s.Equal("/proc/123/ns/net", netns)
 }
+
+func (s *CNINetworkSuite) TestRemoveDeletesContainerChains() {
+	s.iptables.ChainExistsReturns(true, nil)
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("handle")
+
+	err := s.network.Remove(context.Background(), task)
+	s.NoError(err)
+
+	s.Equal(5, s.iptables.DeleteRuleCallCount())
+
+	table, chain, rulespec := s.iptables.DeleteRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	containerChain := rulespec[1]
+	s.Equal([]string{"-j", containerChain}, rulespec)
+
+	table, chain, rulespec = s.iptables.DeleteRuleArgsForCall(1)
+	s.Equal("nat", table)
+	s.Equal("PREROUTING", chain)
+	s.Equal([]string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", containerChain}, rulespec)
+
+	table, chain, _ = s.iptables.DeleteRuleArgsForCall(2)
+	s.Equal("nat", table)
+	s.Equal("OUTPUT", chain)
+
+	table, chain, rulespec = s.iptables.DeleteRuleArgsForCall(3)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	egressChain := rulespec[1]
+	s.True(strings.HasPrefix(egressChain, "CONCOURSE-EGRESS-"))
+
+	table, chain, rulespec = s.iptables.DeleteRuleArgsForCall(4)
+	s.Equal("filter", table)
+	s.Equal("INPUT", chain)
+	s.Equal([]string{"-j", egressChain}, rulespec)
+
+	s.Equal(3, s.iptables.DeleteChainCallCount())
+	table, chain = s.iptables.DeleteChainArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal(containerChain, chain)
+	table, chain = s.iptables.DeleteChainArgsForCall(1)
+	s.Equal("nat", table)
+	s.Equal(containerChain, chain)
+	table, chain = s.iptables.DeleteChainArgsForCall(2)
+	s.Equal("filter", table)
+	s.Equal(egressChain, chain)
+}
+
+func (s *CNINetworkSuite) TestRemoveWithoutContainerChains() {
+	task := new(libcontainerdfakes.FakeTask)
+
+	err := s.network.Remove(context.Background(), task)
+	s.NoError(err)
+
+	s.Equal(3, s.iptables.ChainExistsCallCount())
+	s.Equal(0, s.iptables.DeleteRuleCallCount())
+	s.Equal(0, s.iptables.DeleteChainCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetInWithoutIP() {
+	err := s.network.NetIn("handle", "", 1234, 5678)
+	s.EqualError(err, "container has no ip")
+}
+
+func (s *CNINetworkSuite) TestNetInCreatesChainAndDNATRule() {
+	err := s.network.NetIn("handle", "10.80.0.2", 1234, 5678)
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.CreateChainCallCount())
+	table, containerChain := s.iptables.CreateChainArgsForCall(0)
+	s.Equal("nat", table)
+	s.True(strings.HasPrefix(containerChain, "CONCOURSE-"))
+	s.LessOrEqual(len(containerChain), 28)
+
+	s.Equal(3, s.iptables.AppendRuleCallCount())
+
+	table, chain, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal("nat", table)
+	s.Equal("PREROUTING", chain)
+	s.Equal([]string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", containerChain}, rulespec)
+
+	_, chain, _ = s.iptables.AppendRuleArgsForCall(1)
+	s.Equal("OUTPUT", chain)
+
+	table, chain, rulespec = s.iptables.AppendRuleArgsForCall(2)
+	s.Equal("nat", table)
+	s.Equal(containerChain, chain)
+	s.Equal([]string{"-p", "tcp", "--dport", "1234", "-j", "DNAT", "--to-destination", "10.80.0.2:5678"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetInReusesExistingChain() {
+	s.iptables.ChainExistsReturns(true, nil)
+
+	err := s.network.NetIn("handle", "10.80.0.2", 1234, 5678)
+	s.NoError(err)
+
+	s.Equal(0, s.iptables.CreateChainCallCount())
+	s.Equal(1, s.iptables.AppendRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetOutCreatesChainAndAcceptRules() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Protocol: garden.ProtocolTCP,
+		Networks: []garden.IPRange{
+			{Start: net.ParseIP("1.1.1.1")},
+			{Start: net.ParseIP("8.8.8.0"), End: net.ParseIP("8.8.8.255")},
+		},
+		Ports: []garden.PortRange{
+			{Start: 80, End: 80},
+			{Start: 8000, End: 9000},
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.CreateChainCallCount())
+	table, containerChain := s.iptables.CreateChainArgsForCall(0)
+	s.Equal("filter", table)
+
+	s.Equal(1, s.iptables.InsertRuleCallCount())
+	table, chain, pos, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	s.Equal(2, pos)
+	s.Equal([]string{"-j", containerChain}, rulespec)
+
+	var rulespecs [][]string
+	for i := 0; i < s.iptables.AppendRuleCallCount(); i++ {
+		table, chain, rulespec := s.iptables.AppendRuleArgsForCall(i)
+		s.Equal("filter", table)
+		s.Equal(containerChain, chain)
+
+		rulespecs = append(rulespecs, rulespec)
+	}
+
+	s.Equal([][]string{
+		{"-s", "10.80.0.2", "-p", "tcp", "-d", "1.1.1.1", "--dport", "80", "-j", "ACCEPT"},
+		{"-s", "10.80.0.2", "-p", "tcp", "-d", "1.1.1.1", "--dport", "8000:9000", "-j", "ACCEPT"},
+		{"-s", "10.80.0.2", "-p", "tcp", "-m", "iprange", "--dst-range", "8.8.8.0-8.8.8.255", "--dport", "80", "-j", "ACCEPT"},
+		{"-s", "10.80.0.2", "-p", "tcp", "-m", "iprange", "--dst-range", "8.8.8.0-8.8.8.255", "--dport", "8000:9000", "-j", "ACCEPT"},
+	}, rulespecs)
+}
+
+func (s *CNINetworkSuite) TestNetOutAllowsAllTraffic() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.AppendRuleCallCount())
+	_, _, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal([]string{"-s", "10.80.0.2", "-j", "ACCEPT"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetOutICMP() {
+	code := garden.ICMPCode(1)
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Protocol: garden.ProtocolICMP,
+		ICMPs:    &garden.ICMPControl{Type: 3, Code: &code},
+	})
+	s.NoError(err)
+
+	_, _, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal([]string{"-s", "10.80.0.2", "-p", "icmp", "--icmp-type", "3/1", "-j", "ACCEPT"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetOutPortsWithAllProtocols() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Ports: []garden.PortRange{{Start: 80}},
+	})
+	s.EqualError(err, "ports cannot be specified for protocol all")
+
+	s.Equal(0, s.iptables.CreateChainCallCount())
+}
+
+func (s *CNINetworkSuite) TestRestrictEgressWithoutIP() {
+	err := s.network.RestrictEgress("handle", "", runtime.NetworkPolicy{})
+	s.EqualError(err, "container has no ip")
+}
+
+func (s *CNINetworkSuite) TestRestrictEgressAllowsDestinationsAndNameServers() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithIptables(s.iptables),
+		runtime.WithNameServers([]string{"8.8.8.8"}),
+	)
+	s.NoError(err)
+
+	err = network.RestrictEgress("handle", "10.80.0.2", runtime.NetworkPolicy{
+		Allow: []string{"10.0.0.0/8", "1.1.1.1", "localhost", "::1"},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.CreateChainCallCount())
+	table, egressChain := s.iptables.CreateChainArgsForCall(0)
+	s.Equal("filter", table)
+	s.True(strings.HasPrefix(egressChain, "CONCOURSE-EGRESS-"))
+	s.LessOrEqual(len(egressChain), 28)
+
+	var rulespecs [][]string
+	for i := 0; i < s.iptables.AppendRuleCallCount(); i++ {
+		table, chain, rulespec := s.iptables.AppendRuleArgsForCall(i)
+		s.Equal("filter", table)
+		s.Equal(egressChain, chain)
+
+		rulespecs = append(rulespecs, rulespec)
+	}
+
+	s.Equal([][]string{
+		{"-s", "10.80.0.2", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-d", "10.0.0.0/8", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-d", "1.1.1.1", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-d", "127.0.0.1", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-d", "8.8.8.8", "-p", "udp", "--dport", "53", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-d", "8.8.8.8", "-p", "tcp", "--dport", "53", "-j", "RETURN"},
+		{"-s", "10.80.0.2", "-j", "REJECT"},
+	}, rulespecs)
+
+	s.Equal(2, s.iptables.InsertRuleCallCount())
+	table, chain, pos, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	s.Equal(2, pos)
+	s.Equal([]string{"-j", egressChain}, rulespec)
+
+	table, chain, pos, rulespec = s.iptables.InsertRuleArgsForCall(1)
+	s.Equal("filter", table)
+	s.Equal("INPUT", chain)
+	s.Equal(1, pos)
+	s.Equal([]string{"-j", egressChain}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestRestrictEgressWithNothingAllowed() {
+	err := s.network.RestrictEgress("handle", "10.80.0.2", runtime.NetworkPolicy{})
+	s.NoError(err)
+
+	s.Equal(2, s.iptables.AppendRuleCallCount())
+	_, _, rulespec := s.iptables.AppendRuleArgsForCall(1)
+	s.Equal([]string{"-s", "10.80.0.2", "-j", "REJECT"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestRestrictEgressCreateChainFailure() {
+	s.iptables.CreateChainReturns(errors.New("create-err"))
+
+	err := s.network.RestrictEgress("handle", "10.80.0.2", runtime.NetworkPolicy{})
+	s.Error(err)
+
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+	s.Equal(0, s.iptables.InsertRuleCallCount())
+}
@@ -1,15 +1,22 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -19,6 +26,33 @@ const (
 	Path          = "PATH=/usr/local/bin:/usr/bin:/bin"
 
 	GraceTimeKey = "garden.grace-time"
+
+	// ContainerIPKey is the property holding the IP address the container
+	// was given when it was added to the network.
+	//
+	ContainerIPKey = "garden.network.container-ip"
+
+	// MappedPortsKey is the property holding the ports forwarded to the
+	// container with NetIn, encoded as JSON.
+	//
+	MappedPortsKey = "garden.network.mapped-ports"
+
+	// RuntimeClassKey is the property through which a container requests
+	// one of the runtime classes offered by the backend.
+	//
+	RuntimeClassKey = "concourse:runtime-class"
+
+	// NetworkPolicyKey is the property holding the NetworkPolicy of the
+	// container, encoded as JSON.
+	//
+	NetworkPolicyKey = "concourse:network-policy"
+
+	// ResourceUsageKey is a read-only property holding the ResourceUsage of
+	// the container's task, encoded as JSON. It is read from the task's
+	// cgroup each time the property is retrieved, as garden.Metrics has no
+	// room for it.
+	//
+	ResourceUsageKey = "concourse:resource-usage"
 )
 
 type UserNotFoundError struct {
@@ -33,17 +67,20 @@ type Container struct {
 	container     containerd.Container
 	killer        Killer
 	rootfsManager RootfsManager
+	network       Network
 }
 
 func NewContainer(
 	container containerd.Container,
 	killer Killer,
 	rootfsManager RootfsManager,
+	network Network,
 ) *Container {
 	return &Container{
 		container:     container,
 		killer:        killer,
 		rootfsManager: rootfsManager,
+		network:       network,
 	}
 }
 
@@ -190,6 +227,10 @@ func (c *Container) Properties() (garden.Properties, error) {
 // Property returns the value of the property with the specified name.
 //
 func (c *Container) Property(name string) (string, error) {
+	if name == ResourceUsageKey {
+		return c.resourceUsage()
+	}
+
 	properties, err := c.Properties()
 	if err != nil {
 		return "", err
@@ -218,34 +259,222 @@ func (c *Container) SetProperty(name string, value string) error {
 	return nil
 }
 
-// RemoveProperty - Not Implemented
-func (c *Container) RemoveProperty(name string) (err error) {
-	err = ErrNotImplemented
-	return
+// RemoveProperty removes a property from the container.
+//
+func (c *Container) RemoveProperty(name string) error {
+	_, err := c.Property(name)
+	if err != nil {
+		return err
+	}
+
+	// containerd removes labels which are set to an empty value
+	_, err = c.container.SetLabels(context.Background(), map[string]string{
+		name: "",
+	})
+	if err != nil {
+		return fmt.Errorf("remove label: %w", err)
+	}
+
+	return nil
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info returns the state, network and properties of the container.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	properties, err := c.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	mappedPorts, err := mappedPorts(properties)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	info := garden.ContainerInfo{
+		State:       "stopped",
+		ContainerIP: properties[ContainerIPKey],
+		Properties:  properties,
+		MappedPorts: mappedPorts,
+	}
+
+	if spec.Root != nil {
+		info.ContainerPath = spec.Root.Path
+	}
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return info, nil
+		}
+
+		return garden.ContainerInfo{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task status: %w", err)
+	}
+
+	if status.Status == containerd.Running {
+		info.State = "active"
+	}
+
+	pids, err := task.Pids(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task pids: %w", err)
+	}
+
+	for _, pid := range pids {
+		info.ProcessIDs = append(info.ProcessIDs, strconv.FormatUint(uint64(pid.Pid), 10))
+	}
+
+	return info, nil
 }
 
-// Metrics - Not Implemented
-func (c *Container) Metrics() (metrics garden.Metrics, err error) {
-	err = ErrNotImplemented
-	return
+// Metrics returns the memory, CPU and PID usage of the container, read from
+// the cgroup of its task.
+//
+func (c *Container) Metrics() (garden.Metrics, error) {
+	ctx := context.Background()
+
+	containerInfo, err := c.container.Info(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container info: %w", err)
+	}
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	metric, err := task.Metrics(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task metrics: %w", err)
+	}
+
+	metrics, err := gardenMetrics(metric)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	metrics.Age = time.Since(containerInfo.CreatedAt)
+
+	return metrics, nil
+}
+
+// resourceUsage returns the ResourceUsage of the container's task, encoded as
+// JSON.
+//
+func (c *Container) resourceUsage() (string, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return "", fmt.Errorf("task lookup: %w", err)
+	}
+
+	metric, err := task.Metrics(ctx)
+	if err != nil {
+		return "", fmt.Errorf("task metrics: %w", err)
+	}
+
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container spec: %w", err)
+	}
+
+	var cgroupsPath string
+	if spec.Linux != nil {
+		cgroupsPath = spec.Linux.CgroupsPath
+	}
+
+	usage, err := taskResourceUsage(metric, cgroupsPath)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(usage)
+	if err != nil {
+		return "", fmt.Errorf("marshal resource usage: %w", err)
+	}
+
+	return string(payload), nil
 }
 
-// StreamIn - Not Implemented
-func (c *Container) StreamIn(spec garden.StreamInSpec) (err error) {
-	err = ErrNotImplemented
-	return
+// StreamIn extracts a tar stream into a directory of the container, creating
+// the directory if it doesn't exist. The tar is extracted by a process in the
+// container, so the image needs to have `sh` and `tar` (see the `--runtime`
+// flag of the worker).
+//
+func (c *Container) StreamIn(spec garden.StreamInSpec) error {
+	stderr := new(bytes.Buffer)
+
+	proc, err := c.Run(garden.ProcessSpec{
+		Path: "/bin/sh",
+		Args: []string{"-c", `mkdir -p "$0" && exec tar -x -C "$0"`, spec.Path},
+		User: spec.User,
+	}, garden.ProcessIO{
+		Stdin:  spec.TarStream,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("stream in: %w", err)
+	}
+
+	exitCode, err := proc.Wait()
+	if err != nil {
+		return fmt.Errorf("stream in: %w", err)
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("stream in: tar exited %d: %s", exitCode, stderr.String())
+	}
+
+	return nil
 }
 
-// StreamOut - Not Implemented
-func (c *Container) StreamOut(spec garden.StreamOutSpec) (readCloser io.ReadCloser, err error) {
-	err = ErrNotImplemented
-	return
+// StreamOut streams a tar of a file or directory of the container. A path
+// ending in `/` streams the contents of the directory.
+//
+func (c *Container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	args := []string{"-c", "-C", filepath.Dir(spec.Path), filepath.Base(spec.Path)}
+	if strings.HasSuffix(spec.Path, "/") {
+		args = []string{"-c", "-C", spec.Path, "."}
+	}
+
+	reader, writer := io.Pipe()
+	stderr := new(bytes.Buffer)
+
+	proc, err := c.Run(garden.ProcessSpec{
+		Path: "tar",
+		Args: args,
+		User: spec.User,
+	}, garden.ProcessIO{
+		Stdout: writer,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stream out: %w", err)
+	}
+
+	go func() {
+		exitCode, err := proc.Wait()
+		if err == nil && exitCode != 0 {
+			err = fmt.Errorf("tar exited %d: %s", exitCode, stderr.String())
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
 }
 
 // SetGraceTime stores the grace time as a containerd label with key "garden.grace-time"
@@ -309,22 +538,105 @@ func (c *Container) CurrentMemoryLimits() (limits garden.MemoryLimits, err error
 	}, nil
 }
 
-// NetIn - Not Implemented
-func (c *Container) NetIn(hostPort, containerPort uint32) (a, b uint32, err error) {
-	err = ErrNotImplemented
-	return
+// NetIn forwards a port of the host to a port of the container. When the
+// host port is 0 a free port is picked, and when the container port is 0 the
+// same port as the host's is used.
+//
+func (c *Container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	mappings, err := mappedPorts(properties)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if hostPort == 0 {
+		hostPort, err = freePort()
+		if err != nil {
+			return 0, 0, fmt.Errorf("picking host port: %w", err)
+		}
+	}
+
+	if containerPort == 0 {
+		containerPort = hostPort
+	}
+
+	err = c.network.NetIn(c.Handle(), properties[ContainerIPKey], hostPort, containerPort)
+	if err != nil {
+		return 0, 0, fmt.Errorf("net in: %w", err)
+	}
+
+	mappings = append(mappings, garden.PortMapping{
+		HostPort:      hostPort,
+		ContainerPort: containerPort,
+	})
+
+	payload, err := json.Marshal(mappings)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = c.SetProperty(MappedPortsKey, string(payload))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return hostPort, containerPort, nil
 }
 
-// NetOut - Not Implemented
-func (c *Container) NetOut(netOutRule garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// NetOut allows the container to reach the destinations described by the
+// rule, even if they are in a restricted network.
+//
+func (c *Container) NetOut(netOutRule garden.NetOutRule) error {
+	return c.BulkNetOut([]garden.NetOutRule{netOutRule})
 }
 
-// BulkNetOut - Not Implemented
-func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// BulkNetOut allows the container to reach the destinations described by
+// each of the rules.
+//
+func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	ip, err := c.Property(ContainerIPKey)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range netOutRules {
+		err = c.network.NetOut(c.Handle(), ip, rule)
+		if err != nil {
+			return fmt.Errorf("net out: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func mappedPorts(properties garden.Properties) ([]garden.PortMapping, error) {
+	payload, found := properties[MappedPortsKey]
+	if !found {
+		return nil, nil
+	}
+
+	var mappings []garden.PortMapping
+	err := json.Unmarshal([]byte(payload), &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("parsing mapped ports: %w", err)
+	}
+
+	return mappings, nil
+}
+
+func freePort() (uint32, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer listener.Close()
+
+	return uint32(listener.Addr().(*net.TCPAddr).Port), nil
 }
 
 func procID(gdnProcSpec garden.ProcessSpec) string {
@@ -2,12 +2,23 @@ package runtime_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +34,7 @@ type ContainerSuite struct {
 	containerdTask      *libcontainerdfakes.FakeTask
 	rootfsManager       *runtimefakes.FakeRootfsManager
 	killer              *runtimefakes.FakeKiller
+	network             *runtimefakes.FakeNetwork
 }
 
 func (s *ContainerSuite) SetupTest() {
@@ -31,11 +43,13 @@ func (s *ContainerSuite) SetupTest() {
 	s.containerdTask = new(libcontainerdfakes.FakeTask)
 	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
 	s.killer = new(runtimefakes.FakeKiller)
+	s.network = new(runtimefakes.FakeNetwork)
 
 	s.container = runtime.NewContainer(
 		s.containerdContainer,
 		s.killer,
 		s.rootfsManager,
+		s.network,
 	)
 }
 
@@ -374,6 +388,102 @@ func (s *ContainerSuite) TestPropertyReturnsValue() {
 	s.Equal("some-value", result)
 }
 
+func (s *ContainerSuite) TestPropertyResourceUsageCgroupsV1() {
+	data, err := typeurl.MarshalAny(&v1.Metrics{
+		Memory: &v1.MemoryStat{
+			Usage: &v1.MemoryEntry{Usage: 300, Max: 500},
+		},
+		Blkio: &v1.BlkIOStat{
+			IoServiceBytesRecursive: []*v1.BlkIOEntry{
+				{Op: "Read", Value: 10},
+				{Op: "Write", Value: 20},
+				{Op: "Total", Value: 30},
+				{Op: "Read", Value: 1},
+			},
+		},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+
+	result, err := s.container.Property(runtime.ResourceUsageKey)
+	s.NoError(err)
+	s.JSONEq(`{"memory_peak_bytes":500,"disk_read_bytes":11,"disk_write_bytes":20}`, result)
+	s.Equal(0, s.containerdContainer.LabelsCallCount())
+}
+
+func (s *ContainerSuite) TestPropertyResourceUsageCgroupsV2() {
+	root, err := ioutil.TempDir("", "cgroup")
+	s.NoError(err)
+	defer os.RemoveAll(root)
+
+	originalRoot := runtime.CgroupV2Root
+	runtime.CgroupV2Root = root
+	defer func() { runtime.CgroupV2Root = originalRoot }()
+
+	err = os.MkdirAll(filepath.Join(root, "garden", "handle"), 0755)
+	s.NoError(err)
+	err = ioutil.WriteFile(filepath.Join(root, "garden", "handle", "memory.peak"), []byte("500\n"), 0644)
+	s.NoError(err)
+
+	data, err := typeurl.MarshalAny(&v2.Metrics{
+		Memory: &v2.MemoryStat{Usage: 300},
+		Io: &v2.IOStat{
+			Usage: []*v2.IOEntry{
+				{Rbytes: 10, Wbytes: 20},
+				{Rbytes: 1, Wbytes: 2},
+			},
+		},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Linux: &specs.Linux{CgroupsPath: "/garden/handle"},
+	}, nil)
+
+	result, err := s.container.Property(runtime.ResourceUsageKey)
+	s.NoError(err)
+	s.JSONEq(`{"memory_peak_bytes":500,"disk_read_bytes":11,"disk_write_bytes":22}`, result)
+}
+
+func (s *ContainerSuite) TestPropertyResourceUsageCgroupsV2WithoutMemoryPeak() {
+	root, err := ioutil.TempDir("", "cgroup")
+	s.NoError(err)
+	defer os.RemoveAll(root)
+
+	originalRoot := runtime.CgroupV2Root
+	runtime.CgroupV2Root = root
+	defer func() { runtime.CgroupV2Root = originalRoot }()
+
+	data, err := typeurl.MarshalAny(&v2.Metrics{
+		Memory: &v2.MemoryStat{Usage: 300},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Linux: &specs.Linux{CgroupsPath: "/garden/handle"},
+	}, nil)
+
+	result, err := s.container.Property(runtime.ResourceUsageKey)
+	s.NoError(err)
+	s.JSONEq(`{"memory_peak_bytes":0,"disk_read_bytes":0,"disk_write_bytes":0}`, result)
+}
+
+func (s *ContainerSuite) TestPropertyResourceUsageTaskMetricsFails() {
+	expectedErr := errors.New("metrics-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, expectedErr)
+
+	_, err := s.container.Property(runtime.ResourceUsageKey)
+	s.True(errors.Is(err, expectedErr))
+}
+
 func (s *ContainerSuite) TestCurrentCPULimitsGetInfoFails() {
 	expectedErr := errors.New("get-spec-error")
 	s.containerdContainer.SpecReturns(nil, expectedErr)
@@ -445,3 +555,280 @@ func (s *ContainerSuite) TestCurrentMemoryLimitsReturnsLimit() {
 	s.NoError(err)
 	s.Equal(garden.MemoryLimits{LimitInBytes: uint64(limitBytes)}, limits)
 }
+
+func (s *ContainerSuite) TestRemovePropertyNotFound() {
+	s.containerdContainer.LabelsReturns(garden.Properties{}, nil)
+	err := s.container.RemoveProperty("any")
+	s.Equal(runtime.ErrNotFound("any"), err)
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ContainerSuite) TestRemovePropertyClearsLabel() {
+	s.containerdContainer.LabelsReturns(garden.Properties{"any": "some-value"}, nil)
+	err := s.container.RemoveProperty("any")
+	s.NoError(err)
+
+	s.Equal(1, s.containerdContainer.SetLabelsCallCount())
+	_, labelSet := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{"any": ""}, labelSet)
+}
+
+func (s *ContainerSuite) TestInfoRunningTask() {
+	properties := garden.Properties{
+		"any":                  "some-value",
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.MappedPortsKey: `[{"HostPort":1234,"ContainerPort":5678}]`,
+	}
+	s.containerdContainer.LabelsReturns(properties, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{Root: &specs.Root{Path: "/rootfs"}}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+	s.containerdTask.PidsReturns([]containerd.ProcessInfo{{Pid: 123}, {Pid: 456}}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal(garden.ContainerInfo{
+		State:         "active",
+		ContainerIP:   "10.80.0.2",
+		ContainerPath: "/rootfs",
+		ProcessIDs:    []string{"123", "456"},
+		Properties:    properties,
+		MappedPorts:   []garden.PortMapping{{HostPort: 1234, ContainerPort: 5678}},
+	}, info)
+}
+
+func (s *ContainerSuite) TestInfoWithoutTask() {
+	s.containerdContainer.LabelsReturns(garden.Properties{}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{Root: &specs.Root{Path: "/rootfs"}}, nil)
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("stopped", info.State)
+	s.Empty(info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupFails() {
+	expectedErr := errors.New("task-lookup-err")
+	s.containerdContainer.LabelsReturns(garden.Properties{}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	s.containerdContainer.TaskReturns(nil, expectedErr)
+
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV1() {
+	data, err := typeurl.MarshalAny(&v1.Metrics{
+		Memory: &v1.MemoryStat{
+			RSS:               100,
+			TotalInactiveFile: 10,
+			Usage:             &v1.MemoryEntry{Usage: 300},
+		},
+		CPU: &v1.CPUStat{
+			Usage: &v1.CPUUsage{Total: 1000, User: 600, Kernel: 400},
+		},
+		Pids: &v1.PidsStat{Current: 3, Limit: 10},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.InfoReturns(containers.Container{CreatedAt: time.Now().Add(-time.Minute)}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+
+	s.Equal(uint64(100), metrics.MemoryStat.Rss)
+	s.Equal(uint64(290), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(garden.ContainerCPUStat{Usage: 1000, User: 600, System: 400}, metrics.CPUStat)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 10}, metrics.PidStat)
+	s.True(metrics.Age >= time.Minute)
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV2() {
+	data, err := typeurl.MarshalAny(&v2.Metrics{
+		Memory: &v2.MemoryStat{
+			Anon:         100,
+			InactiveFile: 10,
+			Usage:        300,
+			UsageLimit:   1000,
+		},
+		CPU: &v2.CPUStat{UsageUsec: 10, UserUsec: 6, SystemUsec: 4},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+
+	s.Equal(uint64(100), metrics.MemoryStat.Rss)
+	s.Equal(uint64(1000), metrics.MemoryStat.HierarchicalMemoryLimit)
+	s.Equal(uint64(290), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(garden.ContainerCPUStat{Usage: 10000, User: 6000, System: 4000}, metrics.CPUStat)
+}
+
+func (s *ContainerSuite) TestMetricsTaskMetricsFails() {
+	expectedErr := errors.New("metrics-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, expectedErr)
+
+	_, err := s.container.Metrics()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestStreamInRunsTar() {
+	s.setupProcess(0)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/some/dir",
+		User:      "",
+		TarStream: strings.NewReader("some-tar"),
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.containerdTask.ExecCallCount())
+	_, _, procSpec, _ := s.containerdTask.ExecArgsForCall(0)
+	s.Equal([]string{"/bin/sh", "-c", `mkdir -p "$0" && exec tar -x -C "$0"`, "/some/dir"}, procSpec.Args)
+}
+
+func (s *ContainerSuite) TestStreamInTarFails() {
+	s.setupProcess(2)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/some/dir",
+		TarStream: strings.NewReader("some-tar"),
+	})
+	s.EqualError(err, "stream in: tar exited 2: ")
+}
+
+func (s *ContainerSuite) TestStreamOutDirectory() {
+	s.setupProcess(0)
+
+	reader, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/some/dir"})
+	s.NoError(err)
+
+	_, err = ioutil.ReadAll(reader)
+	s.NoError(err)
+
+	_, _, procSpec, _ := s.containerdTask.ExecArgsForCall(0)
+	s.Equal([]string{"tar", "-c", "-C", "/some", "dir"}, procSpec.Args)
+}
+
+func (s *ContainerSuite) TestStreamOutDirectoryContents() {
+	s.setupProcess(0)
+
+	reader, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/some/dir/"})
+	s.NoError(err)
+
+	_, err = ioutil.ReadAll(reader)
+	s.NoError(err)
+
+	_, _, procSpec, _ := s.containerdTask.ExecArgsForCall(0)
+	s.Equal([]string{"tar", "-c", "-C", "/some/dir/", "."}, procSpec.Args)
+}
+
+func (s *ContainerSuite) TestStreamOutTarFails() {
+	s.setupProcess(1)
+
+	reader, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/some/dir"})
+	s.NoError(err)
+
+	_, err = ioutil.ReadAll(reader)
+	s.EqualError(err, "tar exited 1: ")
+}
+
+func (s *ContainerSuite) TestNetInForwardsPort() {
+	s.containerdContainer.IDReturns("handle")
+	s.containerdContainer.LabelsReturns(garden.Properties{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(1234, 5678)
+	s.NoError(err)
+	s.Equal(uint32(1234), hostPort)
+	s.Equal(uint32(5678), containerPort)
+
+	s.Equal(1, s.network.NetInCallCount())
+	handle, ip, hostPort, containerPort := s.network.NetInArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal(uint32(1234), hostPort)
+	s.Equal(uint32(5678), containerPort)
+
+	_, labelSet := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{
+		runtime.MappedPortsKey: `[{"HostPort":1234,"ContainerPort":5678}]`,
+	}, labelSet)
+}
+
+func (s *ContainerSuite) TestNetInPicksHostPort() {
+	s.containerdContainer.LabelsReturns(garden.Properties{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(0, 0)
+	s.NoError(err)
+	s.NotZero(hostPort)
+	s.Equal(hostPort, containerPort)
+}
+
+func (s *ContainerSuite) TestNetInNetworkFails() {
+	expectedErr := errors.New("net-in-err")
+	s.containerdContainer.LabelsReturns(garden.Properties{}, nil)
+	s.network.NetInReturns(expectedErr)
+
+	_, _, err := s.container.NetIn(1234, 5678)
+	s.True(errors.Is(err, expectedErr))
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ContainerSuite) TestBulkNetOutAddsEachRule() {
+	s.containerdContainer.IDReturns("handle")
+	s.containerdContainer.LabelsReturns(garden.Properties{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	rules := []garden.NetOutRule{
+		{Protocol: garden.ProtocolTCP},
+		{Protocol: garden.ProtocolUDP},
+	}
+
+	err := s.container.BulkNetOut(rules)
+	s.NoError(err)
+
+	s.Equal(2, s.network.NetOutCallCount())
+	for i, rule := range rules {
+		handle, ip, actualRule := s.network.NetOutArgsForCall(i)
+		s.Equal("handle", handle)
+		s.Equal("10.80.0.2", ip)
+		s.Equal(rule, actualRule)
+	}
+}
+
+func (s *ContainerSuite) TestNetOutWithoutIP() {
+	s.containerdContainer.LabelsReturns(garden.Properties{}, nil)
+
+	err := s.container.NetOut(garden.NetOutRule{})
+	s.Equal(runtime.ErrNotFound(runtime.ContainerIPKey), err)
+	s.Equal(0, s.network.NetOutCallCount())
+}
+
+// setupProcess makes processes run in the container exit with the exit code.
+//
+func (s *ContainerSuite) setupProcess(exitCode uint32) {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Process: &specs.Process{},
+		Root:    &specs.Root{},
+	}, nil)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.ExecReturns(s.containerdProcess, nil)
+
+	exitStatusC := make(chan containerd.ExitStatus, 1)
+	exitStatusC <- *containerd.NewExitStatus(exitCode, time.Now(), nil)
+	s.containerdProcess.WaitReturns(exitStatusC, nil)
+	s.containerdProcess.IOReturns(new(libcontainerdfakes.FakeIO))
+}
@@ -18,6 +18,15 @@ func (e ErrNotFound) Error() string {
 	return "not found: " + string(e)
 }
 
+// ErrUnknownRuntimeClass indicates that a container requested a runtime class
+// that the backend does not offer.
+//
+type ErrUnknownRuntimeClass string
+
+func (e ErrUnknownRuntimeClass) Error() string {
+	return "unknown runtime class: " + string(e)
+}
+
 var (
 	// ErrGracePeriodTimeout indicates that the grace period for a graceful
 	// termination has been reached.
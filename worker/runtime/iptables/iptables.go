@@ -10,6 +10,11 @@ import (
 type Iptables interface {
 	CreateChainOrFlushIfExists(table string, chain string) error
 	AppendRule(table string, chain string, rulespec ...string) error
+	InsertRule(table string, chain string, pos int, rulespec ...string) error
+	DeleteRule(table string, chain string, rulespec ...string) error
+	ChainExists(table string, chain string) (bool, error)
+	CreateChain(table string, chain string) error
+	DeleteChain(table string, chain string) error
 }
 
 type iptables struct {
@@ -40,3 +45,30 @@ func (ipt *iptables) AppendRule(table string, chain string, rulespec ...string)
 	err := ipt.goipt.Append(table, chain, rulespec...)
 	return err
 }
+
+func (ipt *iptables) InsertRule(table string, chain string, pos int, rulespec ...string) error {
+	err := ipt.goipt.Insert(table, chain, pos, rulespec...)
+	return err
+}
+
+// DeleteRule deletes the rule, doing nothing if it does not exist.
+func (ipt *iptables) DeleteRule(table string, chain string, rulespec ...string) error {
+	err := ipt.goipt.DeleteIfExists(table, chain, rulespec...)
+	return err
+}
+
+func (ipt *iptables) ChainExists(table string, chain string) (bool, error) {
+	return ipt.goipt.ChainExists(table, chain)
+}
+
+func (ipt *iptables) CreateChain(table string, chain string) error {
+	err := ipt.goipt.NewChain(table, chain)
+	return err
+}
+
+// DeleteChain flushes and deletes the chain, doing nothing if it does not
+// exist.
+func (ipt *iptables) DeleteChain(table string, chain string) error {
+	err := ipt.goipt.ClearAndDeleteChain(table, chain)
+	return err
+}
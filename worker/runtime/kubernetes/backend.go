@@ -0,0 +1,427 @@
+// Package kubernetes provides the implementation of a Garden server backed by
+// Kubernetes, running each container as a pod.
+//
+// Container root filesystems and bind mounts must live on the volume claim
+// which baggageclaim stores its volumes on, so that pods can mount them.
+//
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+const (
+	// ManagedByLabel is set on every pod created by the backend.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	// WorkerLabel identifies the worker which created a pod.
+	WorkerLabel = "concourse-ci.org/worker"
+
+	// PropertiesAnnotation holds the JSON-encoded Garden properties of a
+	// container.
+	PropertiesAnnotation = "concourse-ci.org/properties"
+
+	managedBy = "concourse"
+)
+
+var _ garden.Backend = (*GardenBackend)(nil)
+
+// GardenBackend implements a Garden backend backed by Kubernetes pods.
+//
+type GardenBackend struct {
+	client   kubernetes.Interface
+	executor Executor
+
+	namespace  string
+	workerName string
+
+	initImage    string
+	volumesClaim string
+	volumesDir   string
+
+	allowPrivileged bool
+	maxContainers   int
+
+	processes *processTable
+}
+
+// GardenBackendOpt defines a functional option that when applied, modifies the
+// configuration of a GardenBackend.
+//
+type GardenBackendOpt func(b *GardenBackend)
+
+// WithExecutor configures the Executor used to run processes in pods.
+//
+func WithExecutor(e Executor) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.executor = e
+	}
+}
+
+// WithInitImage configures the image that pods run. It must provide a shell,
+// `env`, `kill`, `tar`, and a `chroot` supporting `--userspec`.
+//
+func WithInitImage(image string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.initImage = image
+	}
+}
+
+// WithVolumesClaim configures the PersistentVolumeClaim which holds the
+// baggageclaim volumes, and the directory it is mounted at on the worker.
+//
+func WithVolumesClaim(claim string, dir string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.volumesClaim = claim
+		b.volumesDir = dir
+	}
+}
+
+// WithAllowPrivileged allows privileged containers to be created as
+// privileged pods.
+//
+func WithAllowPrivileged(allow bool) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.allowPrivileged = allow
+	}
+}
+
+// WithMaxContainers configures the max number of containers that can be created
+//
+func WithMaxContainers(limit int) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.maxContainers = limit
+	}
+}
+
+// DefaultInitImage is the image pods run when none is configured.
+//
+const DefaultInitImage = "debian:bullseye-slim"
+
+// NewGardenBackend instantiates a GardenBackend which creates pods in the
+// given namespace on behalf of the named worker.
+//
+func NewGardenBackend(client kubernetes.Interface, namespace string, workerName string, opts ...GardenBackendOpt) (b GardenBackend, err error) {
+	if client == nil {
+		err = runtime.ErrInvalidInput("nil client")
+		return
+	}
+
+	if namespace == "" {
+		err = runtime.ErrInvalidInput("empty namespace")
+		return
+	}
+
+	b = GardenBackend{
+		client:     client,
+		namespace:  namespace,
+		workerName: workerName,
+		processes:  newProcessTable(),
+	}
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	if b.executor == nil {
+		err = runtime.ErrInvalidInput("nil executor")
+		return
+	}
+
+	if b.initImage == "" {
+		b.initImage = DefaultInitImage
+	}
+
+	return b, nil
+}
+
+// Start - Nothing to do, the Kubernetes client is stateless.
+//
+func (b *GardenBackend) Start() error {
+	return nil
+}
+
+// Stop - Nothing to do, the Kubernetes client is stateless.
+//
+func (b *GardenBackend) Stop() {}
+
+// Ping checks that the worker's pods can be listed.
+//
+func (b *GardenBackend) Ping() error {
+	_, err := b.client.CoreV1().Pods(b.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: b.selector(),
+		Limit:         1,
+	})
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	return nil
+}
+
+// Create creates a pod for the container.
+//
+func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
+	ctx := context.Background()
+
+	err := b.checkContainerCapacity(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking container capacity: %w", err)
+	}
+
+	pod, err := b.podSpec(gdnSpec)
+	if err != nil {
+		return nil, fmt.Errorf("garden spec to pod spec: %w", err)
+	}
+
+	pod, err = b.client.CoreV1().Pods(b.namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create pod: %w", err)
+	}
+
+	return b.container(pod), nil
+}
+
+// Destroy deletes the container's pod.
+//
+func (b *GardenBackend) Destroy(handle string) error {
+	if handle == "" {
+		return runtime.ErrInvalidInput("empty handle")
+	}
+
+	err := b.client.CoreV1().Pods(b.namespace).Delete(context.Background(), handle, metav1.DeleteOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		return fmt.Errorf("delete pod: %w", err)
+	}
+
+	b.processes.removeContainer(handle)
+
+	return nil
+}
+
+// Containers lists all containers filtered by properties (which are ANDed
+// together).
+//
+func (b *GardenBackend) Containers(properties garden.Properties) ([]garden.Container, error) {
+	pods, err := b.client.CoreV1().Pods(b.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: b.selector(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	containers := []garden.Container{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		podProperties, err := podProperties(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matchesProperties(podProperties, properties) {
+			continue
+		}
+
+		containers = append(containers, b.container(pod))
+	}
+
+	return containers, nil
+}
+
+// Lookup returns the container with the specified handle.
+//
+func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
+	if handle == "" {
+		return nil, runtime.ErrInvalidInput("empty handle")
+	}
+
+	pod, err := b.client.CoreV1().Pods(b.namespace).Get(context.Background(), handle, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		return nil, fmt.Errorf("get pod: %w", err)
+	}
+
+	return b.container(pod), nil
+}
+
+// GraceTime returns the value of the "garden.grace-time" property
+//
+func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Duration) {
+	property, err := container.Property(runtime.GraceTimeKey)
+	if err != nil {
+		return 0
+	}
+
+	_, err = fmt.Sscanf(property, "%d", &duration)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
+
+// Capacity - Not Implemented
+//
+func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+// BulkInfo - Not Implemented
+//
+func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+// BulkMetrics - Not Implemented
+//
+func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+func (b *GardenBackend) container(pod *corev1.Pod) *Container {
+	return newContainer(pod.Name, b.namespace, b.client, b.executor, b.processes)
+}
+
+// checkContainerCapacity ensures that MaxContainers is respected
+//
+func (b *GardenBackend) checkContainerCapacity(ctx context.Context) error {
+	if b.maxContainers == 0 {
+		return nil
+	}
+
+	pods, err := b.client.CoreV1().Pods(b.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: b.selector(),
+	})
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	if len(pods.Items) >= b.maxContainers {
+		return fmt.Errorf("max containers reached")
+	}
+
+	return nil
+}
+
+func (b *GardenBackend) labels() map[string]string {
+	return map[string]string{
+		ManagedByLabel: managedBy,
+		WorkerLabel:    b.workerName,
+	}
+}
+
+func (b *GardenBackend) selector() string {
+	return labels.SelectorFromSet(b.labels()).String()
+}
+
+// volumeSubPath returns the path of the given path relative to the volumes
+// directory, which is how it is mounted from the volumes claim.
+//
+func (b *GardenBackend) volumeSubPath(path string) (string, error) {
+	if b.volumesDir == "" {
+		return "", fmt.Errorf("no volumes claim configured")
+	}
+
+	rel, err := filepath.Rel(b.volumesDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("path %s is not within the volumes directory %s", path, b.volumesDir)
+	}
+
+	return rel, nil
+}
+
+func podProperties(pod *corev1.Pod) (garden.Properties, error) {
+	properties := garden.Properties{}
+
+	payload, found := pod.Annotations[PropertiesAnnotation]
+	if !found {
+		return properties, nil
+	}
+
+	err := json.Unmarshal([]byte(payload), &properties)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal properties of pod %s: %w", pod.Name, err)
+	}
+
+	if properties == nil {
+		properties = garden.Properties{}
+	}
+
+	return properties, nil
+}
+
+func matchesProperties(properties garden.Properties, filter garden.Properties) bool {
+	for k, v := range filter {
+		if properties[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// processTable tracks the processes running in the backend's containers so
+// that they can be attached to.
+//
+type processTable struct {
+	lock      sync.Mutex
+	processes map[string]map[string]*Process
+}
+
+func newProcessTable() *processTable {
+	return &processTable{
+		processes: map[string]map[string]*Process{},
+	}
+}
+
+func (t *processTable) add(handle string, process *Process) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.processes[handle] == nil {
+		t.processes[handle] = map[string]*Process{}
+	}
+
+	t.processes[handle][process.ID()] = process
+}
+
+func (t *processTable) get(handle string, id string) (*Process, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	process, found := t.processes[handle][id]
+	return process, found
+}
+
+func (t *processTable) removeContainer(handle string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.processes, handle)
+}
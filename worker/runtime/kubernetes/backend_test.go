@@ -0,0 +1,287 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/kubernetes"
+	"github.com/concourse/concourse/worker/runtime/kubernetes/kubernetesfakes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type BackendSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	backend  kubernetes.GardenBackend
+	client   *fake.Clientset
+	executor *kubernetesfakes.FakeExecutor
+}
+
+func (s *BackendSuite) SetupTest() {
+	s.client = fake.NewSimpleClientset()
+	s.executor = new(kubernetesfakes.FakeExecutor)
+
+	var err error
+	s.backend, err = kubernetes.NewGardenBackend(s.client, "some-namespace", "some-worker",
+		kubernetes.WithExecutor(s.executor),
+		kubernetes.WithInitImage("some-init-image"),
+		kubernetes.WithVolumesClaim("some-claim", "/volumes"),
+	)
+	s.NoError(err)
+}
+
+func (s *BackendSuite) TestNew() {
+	_, err := kubernetes.NewGardenBackend(nil, "some-namespace", "some-worker")
+	s.EqualError(err, "nil client")
+
+	_, err = kubernetes.NewGardenBackend(s.client, "", "some-worker")
+	s.EqualError(err, "empty namespace")
+
+	_, err = kubernetes.NewGardenBackend(s.client, "some-namespace", "some-worker")
+	s.EqualError(err, "nil executor")
+}
+
+func (s *BackendSuite) TestPing() {
+	s.NoError(s.backend.Ping())
+
+	s.client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, nil, errors.New("unreachable")
+	})
+
+	err := s.backend.Ping()
+	s.EqualError(errors.Unwrap(err), "unreachable")
+}
+
+func (s *BackendSuite) TestCreateCreatesPod() {
+	container, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "some-handle",
+		RootFSPath: "raw:///volumes/live/some-volume/volume",
+		Env:        []string{"FOO=bar"},
+		Properties: garden.Properties{"user": "property"},
+		BindMounts: []garden.BindMount{
+			{
+				SrcPath: "/volumes/live/some-input/volume",
+				DstPath: "/tmp/build/some-input",
+				Mode:    garden.BindMountModeRO,
+			},
+		},
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{Weight: 512},
+			Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024},
+		},
+	})
+	s.NoError(err)
+	s.Equal("some-handle", container.Handle())
+
+	pod, err := s.client.CoreV1().Pods("some-namespace").Get(context.Background(), "some-handle", metav1.GetOptions{})
+	s.NoError(err)
+
+	s.Equal(map[string]string{
+		kubernetes.ManagedByLabel: "concourse",
+		kubernetes.WorkerLabel:    "some-worker",
+	}, pod.Labels)
+	s.JSONEq(`{"user":"property"}`, pod.Annotations[kubernetes.PropertiesAnnotation])
+
+	s.Equal(corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	s.False(*pod.Spec.AutomountServiceAccountToken)
+	s.Equal("some-claim", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	s.Len(pod.Spec.Containers, 1)
+	main := pod.Spec.Containers[0]
+	s.Equal("some-init-image", main.Image)
+	s.False(*main.SecurityContext.Privileged)
+	s.Equal([]corev1.VolumeMount{
+		{
+			Name:      "volumes",
+			MountPath: "/rootfs",
+			SubPath:   "live/some-volume/volume",
+		},
+		{
+			Name:      "volumes",
+			MountPath: "/rootfs/tmp/build/some-input",
+			SubPath:   "live/some-input/volume",
+			ReadOnly:  true,
+		},
+	}, main.VolumeMounts)
+	s.Equal([]corev1.EnvVar{
+		{Name: "FOO", Value: "bar"},
+		{Name: "PATH", Value: "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+	}, main.Env)
+	s.Equal(int64(500), main.Resources.Limits.Cpu().MilliValue())
+	s.Equal(int64(1024*1024), main.Resources.Limits.Memory().Value())
+}
+
+func (s *BackendSuite) TestCreateWithInvalidSpec() {
+	for _, tc := range []struct {
+		desc string
+		spec garden.ContainerSpec
+		err  string
+	}{
+		{
+			desc: "empty handle",
+			spec: garden.ContainerSpec{RootFSPath: "raw:///volumes/rootfs"},
+			err:  "empty handle",
+		},
+		{
+			desc: "handle which is not a valid pod name",
+			spec: garden.ContainerSpec{Handle: "Some_Handle", RootFSPath: "raw:///volumes/rootfs"},
+			err:  "invalid handle",
+		},
+		{
+			desc: "unsupported rootfs scheme",
+			spec: garden.ContainerSpec{Handle: "some-handle", RootFSPath: "docker:///busybox"},
+			err:  "unsupported scheme 'docker'",
+		},
+		{
+			desc: "rootfs outside of the volumes dir",
+			spec: garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///elsewhere/rootfs"},
+			err:  "not within the volumes directory",
+		},
+		{
+			desc: "bind mount outside of the volumes dir",
+			spec: garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "raw:///volumes/rootfs",
+				BindMounts: []garden.BindMount{{SrcPath: "/etc/ssl/certs", DstPath: "/etc/ssl/certs"}},
+			},
+			err: "not within the volumes directory",
+		},
+		{
+			desc: "privileged",
+			spec: garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///volumes/rootfs", Privileged: true},
+			err:  "privileged containers are not allowed",
+		},
+	} {
+		s.Run(tc.desc, func() {
+			_, err := s.backend.Create(tc.spec)
+			s.Error(err)
+			s.Contains(err.Error(), tc.err)
+		})
+	}
+
+	pods, err := s.client.CoreV1().Pods("some-namespace").List(context.Background(), metav1.ListOptions{})
+	s.NoError(err)
+	s.Empty(pods.Items)
+}
+
+func (s *BackendSuite) TestCreatePrivilegedWhenAllowed() {
+	backend, err := kubernetes.NewGardenBackend(s.client, "some-namespace", "some-worker",
+		kubernetes.WithExecutor(s.executor),
+		kubernetes.WithVolumesClaim("some-claim", "/volumes"),
+		kubernetes.WithAllowPrivileged(true),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "some-handle",
+		RootFSPath: "raw:///volumes/rootfs",
+		Privileged: true,
+	})
+	s.NoError(err)
+
+	pod, err := s.client.CoreV1().Pods("some-namespace").Get(context.Background(), "some-handle", metav1.GetOptions{})
+	s.NoError(err)
+	s.True(*pod.Spec.Containers[0].SecurityContext.Privileged)
+	s.Equal(kubernetes.DefaultInitImage, pod.Spec.Containers[0].Image)
+}
+
+func (s *BackendSuite) TestCreateMaxContainersReached() {
+	backend, err := kubernetes.NewGardenBackend(s.client, "some-namespace", "some-worker",
+		kubernetes.WithExecutor(s.executor),
+		kubernetes.WithVolumesClaim("some-claim", "/volumes"),
+		kubernetes.WithMaxContainers(1),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///volumes/rootfs"})
+	s.NoError(err)
+
+	_, err = backend.Create(garden.ContainerSpec{Handle: "other-handle", RootFSPath: "raw:///volumes/rootfs"})
+	s.EqualError(err, "checking container capacity: max containers reached")
+}
+
+func (s *BackendSuite) TestContainersFiltersByProperties() {
+	for _, spec := range []garden.ContainerSpec{
+		{Handle: "handle-1", RootFSPath: "raw:///volumes/rootfs", Properties: garden.Properties{"type": "check"}},
+		{Handle: "handle-2", RootFSPath: "raw:///volumes/rootfs", Properties: garden.Properties{"type": "task"}},
+	} {
+		_, err := s.backend.Create(spec)
+		s.NoError(err)
+	}
+
+	// pods of other workers are ignored
+	_, err := s.client.CoreV1().Pods("some-namespace").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other-worker-handle",
+			Labels: map[string]string{
+				kubernetes.ManagedByLabel: "concourse",
+				kubernetes.WorkerLabel:    "other-worker",
+			},
+		},
+	}, metav1.CreateOptions{})
+	s.NoError(err)
+
+	containers, err := s.backend.Containers(nil)
+	s.NoError(err)
+	s.Len(containers, 2)
+
+	containers, err = s.backend.Containers(garden.Properties{"type": "task"})
+	s.NoError(err)
+	s.Len(containers, 1)
+	s.Equal("handle-2", containers[0].Handle())
+}
+
+func (s *BackendSuite) TestLookup() {
+	_, err := s.backend.Lookup("")
+	s.EqualError(err, "empty handle")
+
+	_, err = s.backend.Lookup("some-handle")
+	s.Equal(garden.ContainerNotFoundError{Handle: "some-handle"}, err)
+
+	_, err = s.backend.Create(garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///volumes/rootfs"})
+	s.NoError(err)
+
+	container, err := s.backend.Lookup("some-handle")
+	s.NoError(err)
+	s.Equal("some-handle", container.Handle())
+}
+
+func (s *BackendSuite) TestDestroy() {
+	err := s.backend.Destroy("some-handle")
+	s.Equal(garden.ContainerNotFoundError{Handle: "some-handle"}, err)
+
+	_, err = s.backend.Create(garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///volumes/rootfs"})
+	s.NoError(err)
+
+	err = s.backend.Destroy("some-handle")
+	s.NoError(err)
+
+	_, err = s.backend.Lookup("some-handle")
+	s.Equal(garden.ContainerNotFoundError{Handle: "some-handle"}, err)
+}
+
+func (s *BackendSuite) TestGraceTime() {
+	container, err := s.backend.Create(garden.ContainerSpec{Handle: "some-handle", RootFSPath: "raw:///volumes/rootfs"})
+	s.NoError(err)
+
+	s.Equal(time.Duration(0), s.backend.GraceTime(container))
+
+	err = container.SetGraceTime(time.Minute)
+	s.NoError(err)
+	s.Equal(time.Minute, s.backend.GraceTime(container))
+}
+
+func (s *BackendSuite) TestNotImplemented() {
+	_, err := s.backend.Capacity()
+	s.Equal(runtime.ErrNotImplemented, err)
+}
@@ -0,0 +1,481 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	uuid "github.com/nu7hatch/gouuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+var (
+	// PodPollInterval is how often a pod is checked while waiting for it to
+	// start running.
+	//
+	PodPollInterval = time.Second
+
+	// PodStartTimeout is how long a pod may take to start running before
+	// processes fail to run in it.
+	//
+	PodStartTimeout = 5 * time.Minute
+)
+
+// Container is a Garden container backed by a pod.
+//
+type Container struct {
+	handle    string
+	namespace string
+
+	client    kubernetes.Interface
+	executor  Executor
+	processes *processTable
+}
+
+func newContainer(
+	handle string,
+	namespace string,
+	client kubernetes.Interface,
+	executor Executor,
+	processes *processTable,
+) *Container {
+	return &Container{
+		handle:    handle,
+		namespace: namespace,
+		client:    client,
+		executor:  executor,
+		processes: processes,
+	}
+}
+
+var _ garden.Container = (*Container)(nil)
+
+func (c *Container) Handle() string {
+	return c.handle
+}
+
+// Stop signals all processes in the container to terminate, killing them if
+// `kill` is set.
+//
+func (c *Container) Stop(kill bool) error {
+	signal := "TERM"
+	if kill {
+		signal = "KILL"
+	}
+
+	// `kill -1` signals every process except the caller and the pod's init
+	// process. its exit status is ignored, as it fails when there is
+	// nothing to signal.
+	_, err := c.exec(context.Background(), []string{"/bin/sh", "-c", "kill -" + signal + " -1"}, nil, nil, nil, false)
+	if err != nil {
+		return fmt.Errorf("kill: %w", err)
+	}
+
+	return nil
+}
+
+// Run a process inside the container's pod, chrooted into its root
+// filesystem.
+//
+func (c *Container) Run(
+	spec garden.ProcessSpec,
+	processIO garden.ProcessIO,
+) (garden.Process, error) {
+	if spec.Path == "" {
+		return nil, runtime.ErrInvalidInput("empty path")
+	}
+
+	ctx := context.Background()
+
+	err := c.waitForPod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := spec.ID
+	if id == "" {
+		uuid, err := uuid.NewV4()
+		if err != nil {
+			return nil, fmt.Errorf("uuid gen: %w", err)
+		}
+
+		id = uuid.String()
+	}
+
+	process := newProcess(id, processIO)
+	c.processes.add(c.handle, process)
+
+	go func() {
+		exitCode, err := c.exec(
+			ctx,
+			processCommand(spec),
+			processIO.Stdin,
+			process.stdout,
+			process.stderr,
+			spec.TTY != nil,
+		)
+
+		process.exited(exitCode, err)
+	}()
+
+	return process, nil
+}
+
+// Attach starts streaming the output back to the client from a specified process.
+//
+func (c *Container) Attach(pid string, processIO garden.ProcessIO) (garden.Process, error) {
+	if pid == "" {
+		return nil, runtime.ErrInvalidInput("empty pid")
+	}
+
+	process, found := c.processes.get(c.handle, pid)
+	if !found {
+		return nil, garden.ProcessNotFoundError{ProcessID: pid}
+	}
+
+	process.attach(processIO)
+
+	return process, nil
+}
+
+// Properties returns the current set of properties
+//
+func (c *Container) Properties() (garden.Properties, error) {
+	pod, err := c.pod(context.Background())
+	if err != nil {
+		return garden.Properties{}, err
+	}
+
+	return podProperties(pod)
+}
+
+// Property returns the value of the property with the specified name.
+//
+func (c *Container) Property(name string) (string, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	v, found := properties[name]
+	if !found {
+		return "", runtime.ErrNotFound(name)
+	}
+
+	return v, nil
+}
+
+// Set a named property on a container to a specified value.
+//
+func (c *Container) SetProperty(name string, value string) error {
+	err := c.updateProperties(func(properties garden.Properties) {
+		properties[name] = value
+	})
+	if err != nil {
+		return fmt.Errorf("set property: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProperty removes a property from the container.
+//
+func (c *Container) RemoveProperty(name string) error {
+	err := c.updateProperties(func(properties garden.Properties) {
+		delete(properties, name)
+	})
+	if err != nil {
+		return fmt.Errorf("remove property: %w", err)
+	}
+
+	return nil
+}
+
+// Info returns the state of the container's pod and its IP.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	pod, err := c.pod(context.Background())
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	properties, err := podProperties(pod)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	return garden.ContainerInfo{
+		State:       strings.ToLower(string(pod.Status.Phase)),
+		ContainerIP: pod.Status.PodIP,
+		HostIP:      pod.Status.HostIP,
+		Properties:  properties,
+	}, nil
+}
+
+// Metrics - Not Implemented
+func (c *Container) Metrics() (metrics garden.Metrics, err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+// StreamIn extracts a tar stream into a directory of the container.
+//
+func (c *Container) StreamIn(spec garden.StreamInSpec) error {
+	dir := filepath.Join(rootfsPath, spec.Path)
+
+	stderr := new(bytes.Buffer)
+	exitCode, err := c.exec(
+		context.Background(),
+		[]string{"/bin/sh", "-c", `mkdir -p "$0" && exec tar -x -C "$0"`, dir},
+		spec.TarStream,
+		nil,
+		stderr,
+		false,
+	)
+	if err != nil {
+		return fmt.Errorf("stream in: %w", err)
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("stream in: tar exited %d: %s", exitCode, stderr.String())
+	}
+
+	return nil
+}
+
+// StreamOut streams a tar of a file or directory of the container. A path
+// ending in `/` streams the contents of the directory.
+//
+func (c *Container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	path := filepath.Join(rootfsPath, spec.Path)
+
+	cmd := []string{"tar", "-c", "-C", filepath.Dir(path), filepath.Base(path)}
+	if strings.HasSuffix(spec.Path, "/") {
+		cmd = []string{"tar", "-c", "-C", path, "."}
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		stderr := new(bytes.Buffer)
+		exitCode, err := c.exec(context.Background(), cmd, nil, writer, stderr, false)
+		if err == nil && exitCode != 0 {
+			err = fmt.Errorf("tar exited %d: %s", exitCode, stderr.String())
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+// SetGraceTime stores the grace time as a property with key "garden.grace-time"
+//
+func (c *Container) SetGraceTime(graceTime time.Duration) error {
+	err := c.SetProperty(runtime.GraceTimeKey, fmt.Sprintf("%d", graceTime))
+	if err != nil {
+		return fmt.Errorf("set grace time: %w", err)
+	}
+
+	return nil
+}
+
+// CurrentBandwidthLimits returns no limits (achieves parity with Guardian)
+func (c *Container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, nil
+}
+
+// CurrentCPULimits returns the CPU shares allocated to the pod's container
+func (c *Container) CurrentCPULimits() (garden.CPULimits, error) {
+	pod, err := c.pod(context.Background())
+	if err != nil {
+		return garden.CPULimits{}, err
+	}
+
+	cpu, found := pod.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU]
+	if !found {
+		return garden.CPULimits{}, nil
+	}
+
+	return garden.CPULimits{
+		Weight: uint64(cpu.MilliValue() * 1024 / 1000),
+	}, nil
+}
+
+// CurrentDiskLimits returns no limits (achieves parity with Guardian)
+func (c *Container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return garden.DiskLimits{}, nil
+}
+
+// CurrentMemoryLimits returns the memory limit in bytes allocated to the pod's
+// container
+func (c *Container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	pod, err := c.pod(context.Background())
+	if err != nil {
+		return garden.MemoryLimits{}, err
+	}
+
+	memory, found := pod.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]
+	if !found {
+		return garden.MemoryLimits{}, nil
+	}
+
+	return garden.MemoryLimits{
+		LimitInBytes: uint64(memory.Value()),
+	}, nil
+}
+
+// NetIn - Not Implemented
+func (c *Container) NetIn(hostPort, containerPort uint32) (a, b uint32, err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+// NetOut - Not Implemented
+func (c *Container) NetOut(netOutRule garden.NetOutRule) (err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+// BulkNetOut - Not Implemented
+func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) (err error) {
+	err = runtime.ErrNotImplemented
+	return
+}
+
+func (c *Container) pod(ctx context.Context) (*corev1.Pod, error) {
+	pod, err := c.client.CoreV1().Pods(c.namespace).Get(ctx, c.handle, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: c.handle}
+		}
+
+		return nil, fmt.Errorf("get pod: %w", err)
+	}
+
+	return pod, nil
+}
+
+func (c *Container) updateProperties(update func(garden.Properties)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ctx := context.Background()
+
+		pod, err := c.pod(ctx)
+		if err != nil {
+			return err
+		}
+
+		properties, err := podProperties(pod)
+		if err != nil {
+			return err
+		}
+
+		update(properties)
+
+		payload, err := json.Marshal(properties)
+		if err != nil {
+			return fmt.Errorf("marshal properties: %w", err)
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		pod.Annotations[PropertiesAnnotation] = string(payload)
+
+		_, err = c.client.CoreV1().Pods(c.namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// waitForPod waits for the container's pod to be running, as processes can
+// only be exec'ed into running pods.
+//
+func (c *Container) waitForPod(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, PodStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(PodPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pod, err := c.pod(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return nil
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return fmt.Errorf("pod %s is no longer running: %s", c.handle, pod.Status.Phase)
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting == nil {
+				continue
+			}
+
+			switch status.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+				return fmt.Errorf("pod %s cannot start: %s: %s", c.handle, status.State.Waiting.Reason, status.State.Waiting.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for pod %s to run: %w", c.handle, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Container) exec(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer, tty bool) (int, error) {
+	return c.executor.Exec(ctx, c.namespace, c.handle, containerName, cmd, stdin, stdout, stderr, tty)
+}
+
+// processCommand builds the command which runs the process in the pod,
+// chrooted into the container's root filesystem as the process' user.
+//
+func processCommand(spec garden.ProcessSpec) []string {
+	cmd := []string{"env"}
+	cmd = append(cmd, spec.Env...)
+
+	if spec.User != "" {
+		cmd = append(cmd, "USER="+spec.User)
+	}
+
+	if spec.User != "" && spec.User != "root" && spec.User != "0" {
+		cmd = append(cmd, runtime.Path)
+	}
+
+	cmd = append(cmd, "chroot")
+	if spec.User != "" {
+		cmd = append(cmd, "--userspec="+spec.User)
+	}
+
+	cmd = append(cmd, rootfsPath)
+
+	if spec.Dir != "" {
+		// the working directory is created as root before switching to the
+		// process' user, as with the containerd runtime
+		cmd = append(cmd, "/bin/sh", "-c", `cd "$0" && exec "$@"`, spec.Dir)
+		cmd = append([]string{"/bin/sh", "-c", `mkdir -p "$0" && exec "$@"`, filepath.Join(rootfsPath, spec.Dir)}, cmd...)
+	}
+
+	cmd = append(cmd, spec.Path)
+	cmd = append(cmd, spec.Args...)
+
+	return cmd
+}
@@ -0,0 +1,340 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/kubernetes"
+	"github.com/concourse/concourse/worker/runtime/kubernetes/kubernetesfakes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type ContainerSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	container garden.Container
+	client    *fake.Clientset
+	executor  *kubernetesfakes.FakeExecutor
+}
+
+func (s *ContainerSuite) SetupTest() {
+	kubernetes.PodPollInterval = time.Millisecond
+	kubernetes.PodStartTimeout = time.Second
+
+	s.client = fake.NewSimpleClientset()
+	s.executor = new(kubernetesfakes.FakeExecutor)
+
+	backend, err := kubernetes.NewGardenBackend(s.client, "some-namespace", "some-worker",
+		kubernetes.WithExecutor(s.executor),
+		kubernetes.WithVolumesClaim("some-claim", "/volumes"),
+	)
+	s.NoError(err)
+
+	s.container, err = backend.Create(garden.ContainerSpec{
+		Handle:     "some-handle",
+		RootFSPath: "raw:///volumes/rootfs",
+		Properties: garden.Properties{"some": "property"},
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{Weight: 1024},
+			Memory: garden.MemoryLimits{LimitInBytes: 4096},
+		},
+	})
+	s.NoError(err)
+}
+
+func (s *ContainerSuite) setPodStatus(status corev1.PodStatus) {
+	pods := s.client.CoreV1().Pods("some-namespace")
+
+	pod, err := pods.Get(context.Background(), "some-handle", metav1.GetOptions{})
+	s.NoError(err)
+
+	pod.Status = status
+
+	_, err = pods.UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	s.NoError(err)
+}
+
+func (s *ContainerSuite) TestRunExecsChrootedProcess() {
+	s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning})
+
+	s.executor.ExecStub = func(_ context.Context, _, _, _ string, _ []string, stdin io.Reader, stdout, stderr io.Writer, _ bool) (int, error) {
+		input, err := ioutil.ReadAll(stdin)
+		s.NoError(err)
+
+		stdout.Write([]byte("hello " + string(input)))
+		stderr.Write([]byte("oops"))
+		return 42, nil
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	process, err := s.container.Run(garden.ProcessSpec{
+		ID:   "some-process",
+		Path: "/bin/echo",
+		Args: []string{"some", "args"},
+		Env:  []string{"FOO=bar"},
+	}, garden.ProcessIO{
+		Stdin:  strings.NewReader("world"),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	s.NoError(err)
+	s.Equal("some-process", process.ID())
+
+	exitCode, err := process.Wait()
+	s.NoError(err)
+	s.Equal(42, exitCode)
+	s.Equal("hello world", stdout.String())
+	s.Equal("oops", stderr.String())
+
+	_, namespace, pod, container, cmd, _, _, _, tty := s.executor.ExecArgsForCall(0)
+	s.Equal("some-namespace", namespace)
+	s.Equal("some-handle", pod)
+	s.Equal("main", container)
+	s.False(tty)
+	s.Equal([]string{"env", "FOO=bar", "chroot", "/rootfs", "/bin/echo", "some", "args"}, cmd)
+}
+
+func (s *ContainerSuite) TestRunAsUserInDir() {
+	s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning})
+
+	process, err := s.container.Run(garden.ProcessSpec{
+		Path: "/bin/ls",
+		User: "some-user",
+		Dir:  "/tmp/build",
+		TTY:  &garden.TTYSpec{},
+	}, garden.ProcessIO{})
+	s.NoError(err)
+	s.NotEmpty(process.ID())
+
+	_, err = process.Wait()
+	s.NoError(err)
+
+	_, _, _, _, cmd, _, _, _, tty := s.executor.ExecArgsForCall(0)
+	s.True(tty)
+	s.Equal([]string{
+		"/bin/sh", "-c", `mkdir -p "$0" && exec "$@"`, "/rootfs/tmp/build",
+		"env", "USER=some-user", "PATH=/usr/local/bin:/usr/bin:/bin",
+		"chroot", "--userspec=some-user", "/rootfs",
+		"/bin/sh", "-c", `cd "$0" && exec "$@"`, "/tmp/build",
+		"/bin/ls",
+	}, cmd)
+}
+
+func (s *ContainerSuite) TestRunExecFails() {
+	s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning})
+	s.executor.ExecReturns(0, errors.New("connection lost"))
+
+	process, err := s.container.Run(garden.ProcessSpec{Path: "/bin/true"}, garden.ProcessIO{})
+	s.NoError(err)
+
+	_, err = process.Wait()
+	s.EqualError(errors.Unwrap(err), "connection lost")
+}
+
+func (s *ContainerSuite) TestRunWaitsForPodToRun() {
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning})
+	}()
+
+	process, err := s.container.Run(garden.ProcessSpec{Path: "/bin/true"}, garden.ProcessIO{})
+	s.NoError(err)
+
+	_, err = process.Wait()
+	s.NoError(err)
+	s.Equal(1, s.executor.ExecCallCount())
+}
+
+func (s *ContainerSuite) TestRunWhenPodCannotStart() {
+	for _, tc := range []struct {
+		desc   string
+		status corev1.PodStatus
+		err    string
+	}{
+		{
+			desc:   "pod failed",
+			status: corev1.PodStatus{Phase: corev1.PodFailed},
+			err:    "pod some-handle is no longer running: Failed",
+		},
+		{
+			desc: "image cannot be pulled",
+			status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+						},
+					},
+				},
+			},
+			err: "pod some-handle cannot start: ImagePullBackOff: not found",
+		},
+	} {
+		s.Run(tc.desc, func() {
+			s.setPodStatus(tc.status)
+
+			_, err := s.container.Run(garden.ProcessSpec{Path: "/bin/true"}, garden.ProcessIO{})
+			s.EqualError(err, tc.err)
+			s.Equal(0, s.executor.ExecCallCount())
+		})
+	}
+}
+
+func (s *ContainerSuite) TestAttach() {
+	s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning})
+
+	written := make(chan struct{})
+	attached := make(chan struct{})
+	s.executor.ExecStub = func(_ context.Context, _, _, _ string, _ []string, _ io.Reader, stdout, _ io.Writer, _ bool) (int, error) {
+		stdout.Write([]byte("before "))
+		close(written)
+		<-attached
+		stdout.Write([]byte("after"))
+		return 0, nil
+	}
+
+	firstStdout := new(bytes.Buffer)
+	_, err := s.container.Run(garden.ProcessSpec{ID: "some-process", Path: "/bin/true"}, garden.ProcessIO{Stdout: firstStdout})
+	s.NoError(err)
+
+	_, err = s.container.Attach("unknown-process", garden.ProcessIO{})
+	s.Equal(garden.ProcessNotFoundError{ProcessID: "unknown-process"}, err)
+
+	<-written
+
+	secondStdout := new(bytes.Buffer)
+	process, err := s.container.Attach("some-process", garden.ProcessIO{Stdout: secondStdout})
+	s.NoError(err)
+	close(attached)
+
+	_, err = process.Wait()
+	s.NoError(err)
+	s.Equal("before ", firstStdout.String())
+	s.Equal("after", secondStdout.String())
+}
+
+func (s *ContainerSuite) TestStop() {
+	s.NoError(s.container.Stop(false))
+	s.NoError(s.container.Stop(true))
+
+	_, _, _, _, cmd, _, _, _, _ := s.executor.ExecArgsForCall(0)
+	s.Equal([]string{"/bin/sh", "-c", "kill -TERM -1"}, cmd)
+
+	_, _, _, _, cmd, _, _, _, _ = s.executor.ExecArgsForCall(1)
+	s.Equal([]string{"/bin/sh", "-c", "kill -KILL -1"}, cmd)
+
+	s.executor.ExecReturns(0, errors.New("connection lost"))
+	s.EqualError(errors.Unwrap(s.container.Stop(false)), "connection lost")
+}
+
+func (s *ContainerSuite) TestProperties() {
+	value, err := s.container.Property("some")
+	s.NoError(err)
+	s.Equal("property", value)
+
+	_, err = s.container.Property("missing")
+	s.Equal(runtime.ErrNotFound("missing"), err)
+
+	s.NoError(s.container.SetProperty("other", "value"))
+
+	properties, err := s.container.Properties()
+	s.NoError(err)
+	s.Equal(garden.Properties{"some": "property", "other": "value"}, properties)
+
+	s.NoError(s.container.RemoveProperty("some"))
+
+	properties, err = s.container.Properties()
+	s.NoError(err)
+	s.Equal(garden.Properties{"other": "value"}, properties)
+}
+
+func (s *ContainerSuite) TestInfo() {
+	s.setPodStatus(corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.2", HostIP: "10.1.0.1"})
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal(garden.ContainerInfo{
+		State:       "running",
+		ContainerIP: "10.0.0.2",
+		HostIP:      "10.1.0.1",
+		Properties:  garden.Properties{"some": "property"},
+	}, info)
+}
+
+func (s *ContainerSuite) TestLimits() {
+	cpu, err := s.container.CurrentCPULimits()
+	s.NoError(err)
+	s.Equal(garden.CPULimits{Weight: 1024}, cpu)
+
+	memory, err := s.container.CurrentMemoryLimits()
+	s.NoError(err)
+	s.Equal(garden.MemoryLimits{LimitInBytes: 4096}, memory)
+}
+
+func (s *ContainerSuite) TestStreamIn() {
+	s.executor.ExecStub = func(_ context.Context, _, _, _ string, _ []string, stdin io.Reader, _, _ io.Writer, _ bool) (int, error) {
+		input, err := ioutil.ReadAll(stdin)
+		s.NoError(err)
+		s.Equal("some-tar", string(input))
+		return 0, nil
+	}
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/some/dir",
+		TarStream: strings.NewReader("some-tar"),
+	})
+	s.NoError(err)
+
+	_, _, _, _, cmd, _, _, _, _ := s.executor.ExecArgsForCall(0)
+	s.Equal([]string{"/bin/sh", "-c", `mkdir -p "$0" && exec tar -x -C "$0"`, "/rootfs/some/dir"}, cmd)
+
+	s.executor.ExecStub = func(_ context.Context, _, _, _ string, _ []string, _ io.Reader, _, stderr io.Writer, _ bool) (int, error) {
+		stderr.Write([]byte("disk full"))
+		return 2, nil
+	}
+
+	err = s.container.StreamIn(garden.StreamInSpec{Path: "/some/dir", TarStream: strings.NewReader("")})
+	s.EqualError(err, "stream in: tar exited 2: disk full")
+}
+
+func (s *ContainerSuite) TestStreamOut() {
+	s.executor.ExecStub = func(_ context.Context, _, _, _ string, _ []string, _ io.Reader, stdout, _ io.Writer, _ bool) (int, error) {
+		stdout.Write([]byte("some-tar"))
+		return 0, nil
+	}
+
+	reader, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/some/file"})
+	s.NoError(err)
+
+	output, err := ioutil.ReadAll(reader)
+	s.NoError(err)
+	s.Equal("some-tar", string(output))
+
+	_, _, _, _, cmd, _, _, _, _ := s.executor.ExecArgsForCall(0)
+	s.Equal([]string{"tar", "-c", "-C", "/rootfs/some", "file"}, cmd)
+
+	s.executor.ExecStub = nil
+	s.executor.ExecReturns(2, nil)
+
+	reader, err = s.container.StreamOut(garden.StreamOutSpec{Path: "/some/dir/"})
+	s.NoError(err)
+
+	_, err = ioutil.ReadAll(reader)
+	s.EqualError(err, "tar exited 2: ")
+
+	_, _, _, _, cmd, _, _, _, _ = s.executor.ExecArgsForCall(1)
+	s.Equal([]string{"tar", "-c", "-C", "/rootfs/some/dir", "."}, cmd)
+}
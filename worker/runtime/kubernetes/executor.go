@@ -0,0 +1,109 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+//counterfeiter:generate . Executor
+
+// Executor runs commands in the containers of a pod.
+//
+type Executor interface {
+	// Exec runs cmd in the given container of a pod, returning its exit
+	// status once it has finished.
+	//
+	Exec(
+		ctx context.Context,
+		namespace, pod, container string,
+		cmd []string,
+		stdin io.Reader,
+		stdout, stderr io.Writer,
+		tty bool,
+	) (int, error)
+}
+
+// NewExecutor instantiates an Executor which runs commands through the
+// Kubernetes API's `exec` subresource of pods.
+//
+func NewExecutor(client kubernetes.Interface, config *rest.Config) Executor {
+	return &spdyExecutor{
+		client: client,
+		config: config,
+	}
+}
+
+type spdyExecutor struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+func (e *spdyExecutor) Exec(
+	ctx context.Context,
+	namespace, pod, container string,
+	cmd []string,
+	stdin io.Reader,
+	stdout, stderr io.Writer,
+	tty bool,
+) (int, error) {
+	req := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   cmd,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil && !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return 0, fmt.Errorf("new spdy executor: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Tty:    tty,
+	}
+	if !tty {
+		streamOpts.Stderr = stderr
+	}
+
+	// the executor does not take a context, so stop waiting on the stream
+	// once the context is done
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- executor.Stream(streamOpts)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case err = <-streamErr:
+	}
+
+	if err != nil {
+		var exitErr exec.CodeExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.Code, nil
+		}
+
+		return 0, fmt.Errorf("stream: %w", err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,139 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package kubernetesfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/worker/runtime/kubernetes"
+)
+
+type FakeExecutor struct {
+	ExecStub        func(context.Context, string, string, string, []string, io.Reader, io.Writer, io.Writer, bool) (int, error)
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+		arg6 io.Reader
+		arg7 io.Writer
+		arg8 io.Writer
+		arg9 bool
+	}
+	execReturns struct {
+		result1 int
+		result2 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExecutor) Exec(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 []string, arg6 io.Reader, arg7 io.Writer, arg8 io.Writer, arg9 bool) (int, error) {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+		arg6 io.Reader
+		arg7 io.Writer
+		arg8 io.Writer
+		arg9 bool
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7, arg8, arg9})
+	stub := fake.ExecStub
+	fakeReturns := fake.execReturns
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7, arg8, arg9})
+	fake.execMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeExecutor) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *FakeExecutor) ExecCalls(stub func(context.Context, string, string, string, []string, io.Reader, io.Writer, io.Writer, bool) (int, error)) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *FakeExecutor) ExecArgsForCall(i int) (context.Context, string, string, string, []string, io.Reader, io.Writer, io.Writer, bool) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9
+}
+
+func (fake *FakeExecutor) ExecReturns(result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExecutor) ExecReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExecutor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeExecutor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ kubernetes.Executor = new(FakeExecutor)
@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// containerName is the name of the container in a pod that processes are
+	// exec'ed into.
+	containerName = "main"
+
+	// volumesVolumeName is the name of the pod volume backed by the volumes
+	// claim.
+	volumesVolumeName = "volumes"
+
+	// rootfsPath is where the container's root filesystem is mounted in the
+	// pod. Processes are chrooted into it.
+	rootfsPath = "/rootfs"
+)
+
+// keepAliveScript keeps the pod running until it is deleted, after making the
+// pod's devices and name resolution available to the root filesystem.
+//
+const keepAliveScript = `
+mkdir -p /rootfs/dev /rootfs/etc
+for dev in null zero full random urandom tty; do
+  [ -e /rootfs/dev/$dev ] || cp -a /dev/$dev /rootfs/dev/ 2>/dev/null
+done
+cp /etc/resolv.conf /etc/hosts /rootfs/etc/ 2>/dev/null
+trap 'exit 0' TERM
+while true; do sleep 3600 & wait $!; done
+`
+
+// podSpec converts a Garden container spec into the pod which runs the
+// container.
+//
+func (b *GardenBackend) podSpec(gdnSpec garden.ContainerSpec) (*corev1.Pod, error) {
+	if gdnSpec.Handle == "" {
+		return nil, runtime.ErrInvalidInput("empty handle")
+	}
+
+	if errs := validation.IsDNS1123Subdomain(gdnSpec.Handle); len(errs) > 0 {
+		return nil, runtime.ErrInvalidInput(fmt.Sprintf("invalid handle %q: %s", gdnSpec.Handle, strings.Join(errs, ", ")))
+	}
+
+	if gdnSpec.Privileged && !b.allowPrivileged {
+		return nil, runtime.ErrInvalidInput("privileged containers are not allowed")
+	}
+
+	rootfsURI := gdnSpec.RootFSPath
+	if rootfsURI == "" {
+		rootfsURI = gdnSpec.Image.URI
+	}
+
+	rootfs, err := rootfsDir(rootfsURI)
+	if err != nil {
+		return nil, err
+	}
+
+	rootfsSubPath, err := b.volumeSubPath(rootfs)
+	if err != nil {
+		return nil, fmt.Errorf("rootfs: %w", err)
+	}
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      volumesVolumeName,
+			MountPath: rootfsPath,
+			SubPath:   rootfsSubPath,
+		},
+	}
+
+	for _, bindMount := range gdnSpec.BindMounts {
+		subPath, err := b.volumeSubPath(bindMount.SrcPath)
+		if err != nil {
+			return nil, fmt.Errorf("bind mount: %w", err)
+		}
+
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumesVolumeName,
+			MountPath: filepath.Join(rootfsPath, bindMount.DstPath),
+			SubPath:   subPath,
+			ReadOnly:  bindMount.Mode == garden.BindMountModeRO,
+		})
+	}
+
+	if gdnSpec.Properties == nil {
+		gdnSpec.Properties = garden.Properties{}
+	}
+
+	properties, err := json.Marshal(gdnSpec.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("marshal properties: %w", err)
+	}
+
+	privileged := gdnSpec.Privileged
+	automountToken := false
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gdnSpec.Handle,
+			Namespace: b.namespace,
+			Labels:    b.labels(),
+			Annotations: map[string]string{
+				PropertiesAnnotation: string(properties),
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: &automountToken,
+			Containers: []corev1.Container{
+				{
+					Name:         containerName,
+					Image:        b.initImage,
+					Command:      []string{"/bin/sh", "-c", keepAliveScript},
+					Env:          podEnv(gdnSpec.Env),
+					VolumeMounts: mounts,
+					Resources:    podResources(gdnSpec.Limits),
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: &privileged,
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: volumesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: b.volumesClaim,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// podEnv converts a list of `KEY=VALUE` pairs into the environment of the
+// pod's container, defaulting the PATH to the superuser's.
+//
+func podEnv(env []string) []corev1.EnvVar {
+	vars := []corev1.EnvVar{}
+
+	hasPath := false
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		if parts[0] == "PATH" {
+			hasPath = true
+		}
+
+		vars = append(vars, corev1.EnvVar{Name: parts[0], Value: parts[1]})
+	}
+
+	if !hasPath {
+		path := strings.TrimPrefix(runtime.SuperuserPath, "PATH=")
+		vars = append(vars, corev1.EnvVar{Name: "PATH", Value: path})
+	}
+
+	return vars
+}
+
+// podResources converts the Garden limits into the pod container's limits.
+// CPU shares are relative to 1024 shares per CPU.
+//
+func podResources(limits garden.Limits) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	list := corev1.ResourceList{}
+	if limits.Memory.LimitInBytes > 0 {
+		list[corev1.ResourceMemory] = *resource.NewQuantity(int64(limits.Memory.LimitInBytes), resource.BinarySI)
+	}
+
+	shares := limits.CPU.Weight
+	if shares == 0 {
+		shares = limits.CPU.LimitInShares
+	}
+
+	if shares > 0 {
+		list[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(shares*1000/1024), resource.DecimalSI)
+	}
+
+	if len(list) > 0 {
+		resources.Limits = list
+	}
+
+	return resources
+}
+
+// rootfsDir takes a raw rootfs uri and extracts the directory that it points to,
+// if using a valid scheme (`raw://`)
+//
+func rootfsDir(raw string) (directory string, err error) {
+	if raw == "" {
+		err = fmt.Errorf("rootfs must not be empty")
+		return
+	}
+
+	parts := strings.SplitN(raw, "://", 2)
+	if len(parts) != 2 {
+		err = fmt.Errorf("malformatted rootfs: must be of form 'scheme://<abs_dir>'")
+		return
+	}
+
+	var scheme string
+	scheme, directory = parts[0], parts[1]
+	if scheme != "raw" {
+		err = fmt.Errorf("unsupported scheme '%s'", scheme)
+		return
+	}
+
+	if !filepath.IsAbs(directory) {
+		err = fmt.Errorf("directory must be an absolute path")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+)
+
+// Process is a process exec'ed into a container's pod.
+//
+type Process struct {
+	id string
+
+	stdout *swappableWriter
+	stderr *swappableWriter
+
+	done     chan struct{}
+	exitCode int
+	err      error
+}
+
+func newProcess(id string, io garden.ProcessIO) *Process {
+	return &Process{
+		id:     id,
+		stdout: newSwappableWriter(io.Stdout),
+		stderr: newSwappableWriter(io.Stderr),
+		done:   make(chan struct{}),
+	}
+}
+
+var _ garden.Process = (*Process)(nil)
+
+// ID retrieves the ID associated with this process.
+//
+func (p *Process) ID() string {
+	return p.id
+}
+
+// Wait for the process to terminate.
+//
+func (p *Process) Wait() (int, error) {
+	<-p.done
+
+	if p.err != nil {
+		return 0, fmt.Errorf("exec: %w", p.err)
+	}
+
+	return p.exitCode, nil
+}
+
+// SetTTY - Not Implemented
+//
+// The terminal of an exec session is sized when the session starts.
+//
+func (p *Process) SetTTY(spec garden.TTYSpec) error {
+	return nil
+}
+
+// Signal - Not Implemented
+//
+func (p *Process) Signal(signal garden.Signal) error {
+	return runtime.ErrNotImplemented
+}
+
+func (p *Process) attach(io garden.ProcessIO) {
+	p.stdout.swap(io.Stdout)
+	p.stderr.swap(io.Stderr)
+}
+
+func (p *Process) exited(exitCode int, err error) {
+	p.exitCode = exitCode
+	p.err = err
+	close(p.done)
+}
+
+// swappableWriter allows the writer a process streams its output to to be
+// replaced when it is attached to again.
+//
+type swappableWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func newSwappableWriter(w io.Writer) *swappableWriter {
+	return &swappableWriter{w: w}
+}
+
+func (s *swappableWriter) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.w == nil {
+		return len(p), nil
+	}
+
+	_, err := s.w.Write(p)
+	if err != nil {
+		// the attached client went away; keep the process running
+		s.w = nil
+	}
+
+	return len(p), nil
+}
+
+func (s *swappableWriter) swap(w io.Writer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.w = w
+}
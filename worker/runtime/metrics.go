@@ -0,0 +1,288 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/typeurl"
+)
+
+// CgroupV2Root is where the unified cgroup hierarchy is mounted on cgroups v2
+// hosts.
+//
+var CgroupV2Root = "/sys/fs/cgroup"
+
+// ResourceUsage is the usage of a task's cgroup over the lifetime of the
+// task, which garden.Metrics only reports a snapshot of.
+//
+type ResourceUsage struct {
+	// MemoryPeakBytes is the high-water mark of the cgroup's memory usage,
+	// including the page cache. It is zero on cgroups v2 hosts whose kernel
+	// doesn't track it (before 5.19).
+	//
+	MemoryPeakBytes uint64 `json:"memory_peak_bytes"`
+
+	// DiskReadBytes and DiskWriteBytes are the bytes read from and written
+	// to block devices by the cgroup.
+	//
+	DiskReadBytes  uint64 `json:"disk_read_bytes"`
+	DiskWriteBytes uint64 `json:"disk_write_bytes"`
+}
+
+// taskResourceUsage determines the ResourceUsage of a task from its cgroup
+// metrics. On cgroups v2 the memory high-water mark isn't part of the
+// metrics, so it is read from the task's cgroup at the given path instead.
+//
+func taskResourceUsage(metric *types.Metric, cgroupsPath string) (ResourceUsage, error) {
+	if metric == nil || metric.Data == nil {
+		return ResourceUsage{}, fmt.Errorf("no metrics data")
+	}
+
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return ResourceUsage{}, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	var usage ResourceUsage
+
+	switch m := data.(type) {
+	case *v1.Metrics:
+		if m.Memory != nil && m.Memory.Usage != nil {
+			usage.MemoryPeakBytes = m.Memory.Usage.Max
+		}
+
+		if m.Blkio != nil {
+			for _, entry := range m.Blkio.IoServiceBytesRecursive {
+				switch strings.ToLower(entry.Op) {
+				case "read":
+					usage.DiskReadBytes += entry.Value
+				case "write":
+					usage.DiskWriteBytes += entry.Value
+				}
+			}
+		}
+	case *v2.Metrics:
+		usage.MemoryPeakBytes, err = v2MemoryPeak(cgroupsPath)
+		if err != nil {
+			return ResourceUsage{}, err
+		}
+
+		if m.Io != nil {
+			for _, entry := range m.Io.Usage {
+				usage.DiskReadBytes += entry.Rbytes
+				usage.DiskWriteBytes += entry.Wbytes
+			}
+		}
+	default:
+		return ResourceUsage{}, fmt.Errorf("unknown metrics type %T", data)
+	}
+
+	return usage, nil
+}
+
+// v2MemoryPeak reads the memory high-water mark of the cgroup at the path,
+// or zero if the kernel doesn't track it. Like runc, a relative path is
+// resolved against the cgroup of the current process.
+//
+func v2MemoryPeak(cgroupsPath string) (uint64, error) {
+	if cgroupsPath == "" {
+		return 0, nil
+	}
+
+	if !filepath.IsAbs(cgroupsPath) {
+		own, err := ownV2Cgroup()
+		if err != nil {
+			return 0, err
+		}
+
+		cgroupsPath = filepath.Join(own, cgroupsPath)
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(CgroupV2Root, cgroupsPath, "memory.peak"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("read memory peak: %w", err)
+	}
+
+	peak, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse memory peak: %w", err)
+	}
+
+	return peak, nil
+}
+
+// ownV2Cgroup returns the path of the cgroup of the current process in the
+// unified hierarchy.
+//
+func ownV2Cgroup() (string, error) {
+	contents, err := ioutil.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", fmt.Errorf("read own cgroup: %w", err)
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		if strings.HasPrefix(line, "0::") {
+			return strings.TrimPrefix(line, "0::"), nil
+		}
+	}
+
+	return "/", nil
+}
+
+// gardenMetrics converts the cgroup metrics of a task into garden.Metrics.
+// Both cgroups v1 and v2 hosts are supported.
+//
+func gardenMetrics(metric *types.Metric) (garden.Metrics, error) {
+	if metric == nil || metric.Data == nil {
+		return garden.Metrics{}, fmt.Errorf("no metrics data")
+	}
+
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	switch m := data.(type) {
+	case *v1.Metrics:
+		return v1GardenMetrics(m), nil
+	case *v2.Metrics:
+		return v2GardenMetrics(m), nil
+	default:
+		return garden.Metrics{}, fmt.Errorf("unknown metrics type %T", data)
+	}
+}
+
+func v1GardenMetrics(m *v1.Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	if m.Memory != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              m.Memory.ActiveAnon,
+			ActiveFile:              m.Memory.ActiveFile,
+			Cache:                   m.Memory.Cache,
+			HierarchicalMemoryLimit: m.Memory.HierarchicalMemoryLimit,
+			InactiveAnon:            m.Memory.InactiveAnon,
+			InactiveFile:            m.Memory.InactiveFile,
+			MappedFile:              m.Memory.MappedFile,
+			Pgfault:                 m.Memory.PgFault,
+			Pgmajfault:              m.Memory.PgMajFault,
+			Pgpgin:                  m.Memory.PgPgIn,
+			Pgpgout:                 m.Memory.PgPgOut,
+			Rss:                     m.Memory.RSS,
+			TotalActiveAnon:         m.Memory.TotalActiveAnon,
+			TotalActiveFile:         m.Memory.TotalActiveFile,
+			TotalCache:              m.Memory.TotalCache,
+			TotalInactiveAnon:       m.Memory.TotalInactiveAnon,
+			TotalInactiveFile:       m.Memory.TotalInactiveFile,
+			TotalMappedFile:         m.Memory.TotalMappedFile,
+			TotalPgfault:            m.Memory.TotalPgFault,
+			TotalPgmajfault:         m.Memory.TotalPgMajFault,
+			TotalPgpgin:             m.Memory.TotalPgPgIn,
+			TotalPgpgout:            m.Memory.TotalPgPgOut,
+			TotalRss:                m.Memory.TotalRSS,
+			TotalUnevictable:        m.Memory.TotalUnevictable,
+			Unevictable:             m.Memory.Unevictable,
+			HierarchicalMemswLimit:  m.Memory.HierarchicalSwapLimit,
+		}
+
+		if m.Memory.Swap != nil {
+			metrics.MemoryStat.Swap = m.Memory.Swap.Usage
+			metrics.MemoryStat.TotalSwap = m.Memory.Swap.Usage
+		}
+
+		if m.Memory.Usage != nil {
+			// the inactive file cache is reclaimed before the limit is enforced
+			metrics.MemoryStat.TotalUsageTowardLimit = subtractOrZero(m.Memory.Usage.Usage, m.Memory.TotalInactiveFile)
+		}
+	}
+
+	if m.CPU != nil && m.CPU.Usage != nil {
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.Usage.Total,
+			User:   m.CPU.Usage.User,
+			System: m.CPU.Usage.Kernel,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	return metrics
+}
+
+func v2GardenMetrics(m *v2.Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	if m.Memory != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              m.Memory.ActiveAnon,
+			ActiveFile:              m.Memory.ActiveFile,
+			Cache:                   m.Memory.File,
+			HierarchicalMemoryLimit: m.Memory.UsageLimit,
+			InactiveAnon:            m.Memory.InactiveAnon,
+			InactiveFile:            m.Memory.InactiveFile,
+			MappedFile:              m.Memory.FileMapped,
+			Pgfault:                 m.Memory.Pgfault,
+			Pgmajfault:              m.Memory.Pgmajfault,
+			Rss:                     m.Memory.Anon,
+			TotalActiveAnon:         m.Memory.ActiveAnon,
+			TotalActiveFile:         m.Memory.ActiveFile,
+			TotalCache:              m.Memory.File,
+			TotalInactiveAnon:       m.Memory.InactiveAnon,
+			TotalInactiveFile:       m.Memory.InactiveFile,
+			TotalMappedFile:         m.Memory.FileMapped,
+			TotalPgfault:            m.Memory.Pgfault,
+			TotalPgmajfault:         m.Memory.Pgmajfault,
+			TotalRss:                m.Memory.Anon,
+			TotalUnevictable:        m.Memory.Unevictable,
+			Unevictable:             m.Memory.Unevictable,
+			Swap:                    m.Memory.SwapUsage,
+			HierarchicalMemswLimit:  m.Memory.SwapLimit,
+			TotalSwap:               m.Memory.SwapUsage,
+			// the inactive file cache is reclaimed before the limit is enforced
+			TotalUsageTowardLimit: subtractOrZero(m.Memory.Usage, m.Memory.InactiveFile),
+		}
+	}
+
+	if m.CPU != nil {
+		// cgroups v2 reports microseconds, garden expects nanoseconds
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.UsageUsec * 1000,
+			User:   m.CPU.UserUsec * 1000,
+			System: m.CPU.SystemUsec * 1000,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	return metrics
+}
+
+func subtractOrZero(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+
+	return a - b
+}
@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -19,11 +20,38 @@ type Network interface {
 	//
 	SetupRestrictedNetworks() (err error)
 
-	// Add adds a task to the network.
+	// Add adds a task to the network, returning the IP address the task
+	// was given.
 	//
-	Add(ctx context.Context, task containerd.Task) (err error)
+	Add(ctx context.Context, task containerd.Task) (ip string, err error)
 
 	// Removes a task from the network.
 	//
 	Remove(ctx context.Context, task containerd.Task) (err error)
+
+	// NetIn forwards traffic sent to a port of the host to a port of the
+	// container with the given IP.
+	//
+	NetIn(handle, containerIP string, hostPort, containerPort uint32) (err error)
+
+	// NetOut allows the container with the given IP to reach the
+	// destinations described by the rule, even if they are in a restricted
+	// network.
+	//
+	NetOut(handle, containerIP string, rule garden.NetOutRule) (err error)
+
+	// RestrictEgress prevents the container with the given IP from reaching
+	// anything but the destinations allowed by the policy.
+	//
+	RestrictEgress(handle, containerIP string, policy NetworkPolicy) (err error)
+}
+
+// NetworkPolicy restricts the traffic a container can send.
+//
+type NetworkPolicy struct {
+	// Allow lists the CIDRs, IP addresses and hostnames that the container
+	// is allowed to reach. When empty, the container is cut off from the
+	// network entirely.
+	//
+	Allow []string `json:"allow"`
 }
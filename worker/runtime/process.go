@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"syscall"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
@@ -79,9 +80,25 @@ func (p *Process) SetTTY(spec garden.TTYSpec) error {
 	return nil
 }
 
-// Signal - Not Implemented
+// Signal sends a signal to the process: SIGTERM for garden.SignalTerminate
+// and SIGKILL for garden.SignalKill.
 //
-func (p *Process) Signal(signal garden.Signal) (err error) {
-	err = ErrNotImplemented
-	return
+func (p *Process) Signal(signal garden.Signal) error {
+	var sig syscall.Signal
+	switch signal {
+	case garden.SignalTerminate:
+		sig = syscall.SIGTERM
+	case garden.SignalKill:
+		sig = syscall.SIGKILL
+	default:
+		return ErrInvalidInput(fmt.Sprintf("unknown signal %d", signal))
+	}
+
+	err := p.process.Kill(context.Background(), sig)
+	// ignore "not found" errors - the process has already exited
+	if err != nil && !errors.Is(err, errdefs.ErrNotFound) {
+		return fmt.Errorf("kill: %w", err)
+	}
+
+	return nil
 }
@@ -3,6 +3,7 @@ package runtime_test
 import (
 	"errors"
 	"fmt"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -119,3 +120,36 @@ func (s *ProcessSuite) TestSetTTYResize() {
 	s.Equal(123, int(width))
 	s.Equal(456, int(height))
 }
+
+func (s *ProcessSuite) TestSignalTerminate() {
+	err := s.process.Signal(garden.SignalTerminate)
+	s.NoError(err)
+
+	s.Equal(1, s.containerdProcess.KillCallCount())
+	_, signal, _ := s.containerdProcess.KillArgsForCall(0)
+	s.Equal(syscall.SIGTERM, signal)
+}
+
+func (s *ProcessSuite) TestSignalKill() {
+	err := s.process.Signal(garden.SignalKill)
+	s.NoError(err)
+
+	s.Equal(1, s.containerdProcess.KillCallCount())
+	_, signal, _ := s.containerdProcess.KillArgsForCall(0)
+	s.Equal(syscall.SIGKILL, signal)
+}
+
+func (s *ProcessSuite) TestSignalProcessAlreadyExited() {
+	s.containerdProcess.KillReturns(fmt.Errorf("kill: %w", errdefs.ErrNotFound))
+
+	err := s.process.Signal(garden.SignalTerminate)
+	s.NoError(err)
+}
+
+func (s *ProcessSuite) TestSignalKillError() {
+	expectedErr := errors.New("kill-err")
+	s.containerdProcess.KillReturns(expectedErr)
+
+	err := s.process.Signal(garden.SignalKill)
+	s.True(errors.Is(err, expectedErr))
+}
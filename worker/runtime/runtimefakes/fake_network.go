@@ -5,22 +5,52 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/containerd/containerd"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type FakeNetwork struct {
-	AddStub        func(context.Context, containerd.Task) error
+	AddStub        func(context.Context, containerd.Task) (string, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		arg1 context.Context
 		arg2 containerd.Task
 	}
 	addReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	addReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	NetInStub        func(string, string, uint32, uint32) error
+	netInMutex       sync.RWMutex
+	netInArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint32
+		arg4 uint32
+	}
+	netInReturns struct {
+		result1 error
+	}
+	netInReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NetOutStub        func(string, string, garden.NetOutRule) error
+	netOutMutex       sync.RWMutex
+	netOutArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 garden.NetOutRule
+	}
+	netOutReturns struct {
+		result1 error
+	}
+	netOutReturnsOnCall map[int]struct {
 		result1 error
 	}
 	RemoveStub        func(context.Context, containerd.Task) error
@@ -35,6 +65,19 @@ type FakeNetwork struct {
 	removeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RestrictEgressStub        func(string, string, runtime.NetworkPolicy) error
+	restrictEgressMutex       sync.RWMutex
+	restrictEgressArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 runtime.NetworkPolicy
+	}
+	restrictEgressReturns struct {
+		result1 error
+	}
+	restrictEgressReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetupMountsStub        func(string) ([]specs.Mount, error)
 	setupMountsMutex       sync.RWMutex
 	setupMountsArgsForCall []struct {
@@ -62,7 +105,7 @@ type FakeNetwork struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
+func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) (string, error) {
 	fake.addMutex.Lock()
 	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
 	fake.addArgsForCall = append(fake.addArgsForCall, struct {
@@ -77,9 +120,9 @@ func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNetwork) AddCallCount() int {
@@ -88,7 +131,7 @@ func (fake *FakeNetwork) AddCallCount() int {
 	return len(fake.addArgsForCall)
 }
 
-func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) error) {
+func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) (string, error)) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = stub
@@ -101,25 +144,155 @@ func (fake *FakeNetwork) AddArgsForCall(i int) (context.Context, containerd.Task
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeNetwork) AddReturns(result1 error) {
+func (fake *FakeNetwork) AddReturns(result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	fake.addReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
+func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	if fake.addReturnsOnCall == nil {
 		fake.addReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.addReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) NetIn(arg1 string, arg2 string, arg3 uint32, arg4 uint32) error {
+	fake.netInMutex.Lock()
+	ret, specificReturn := fake.netInReturnsOnCall[len(fake.netInArgsForCall)]
+	fake.netInArgsForCall = append(fake.netInArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint32
+		arg4 uint32
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.NetInStub
+	fakeReturns := fake.netInReturns
+	fake.recordInvocation("NetIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.netInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) NetInCallCount() int {
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	return len(fake.netInArgsForCall)
+}
+
+func (fake *FakeNetwork) NetInCalls(stub func(string, string, uint32, uint32) error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = stub
+}
+
+func (fake *FakeNetwork) NetInArgsForCall(i int) (string, string, uint32, uint32) {
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	argsForCall := fake.netInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeNetwork) NetInReturns(result1 error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = nil
+	fake.netInReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetInReturnsOnCall(i int, result1 error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = nil
+	if fake.netInReturnsOnCall == nil {
+		fake.netInReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.netInReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetOut(arg1 string, arg2 string, arg3 garden.NetOutRule) error {
+	fake.netOutMutex.Lock()
+	ret, specificReturn := fake.netOutReturnsOnCall[len(fake.netOutArgsForCall)]
+	fake.netOutArgsForCall = append(fake.netOutArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 garden.NetOutRule
+	}{arg1, arg2, arg3})
+	stub := fake.NetOutStub
+	fakeReturns := fake.netOutReturns
+	fake.recordInvocation("NetOut", []interface{}{arg1, arg2, arg3})
+	fake.netOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) NetOutCallCount() int {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	return len(fake.netOutArgsForCall)
+}
+
+func (fake *FakeNetwork) NetOutCalls(stub func(string, string, garden.NetOutRule) error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = stub
+}
+
+func (fake *FakeNetwork) NetOutArgsForCall(i int) (string, string, garden.NetOutRule) {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	argsForCall := fake.netOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNetwork) NetOutReturns(result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	fake.netOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetOutReturnsOnCall(i int, result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	if fake.netOutReturnsOnCall == nil {
+		fake.netOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.netOutReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -186,6 +359,69 @@ func (fake *FakeNetwork) RemoveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetwork) RestrictEgress(arg1 string, arg2 string, arg3 runtime.NetworkPolicy) error {
+	fake.restrictEgressMutex.Lock()
+	ret, specificReturn := fake.restrictEgressReturnsOnCall[len(fake.restrictEgressArgsForCall)]
+	fake.restrictEgressArgsForCall = append(fake.restrictEgressArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 runtime.NetworkPolicy
+	}{arg1, arg2, arg3})
+	stub := fake.RestrictEgressStub
+	fakeReturns := fake.restrictEgressReturns
+	fake.recordInvocation("RestrictEgress", []interface{}{arg1, arg2, arg3})
+	fake.restrictEgressMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) RestrictEgressCallCount() int {
+	fake.restrictEgressMutex.RLock()
+	defer fake.restrictEgressMutex.RUnlock()
+	return len(fake.restrictEgressArgsForCall)
+}
+
+func (fake *FakeNetwork) RestrictEgressCalls(stub func(string, string, runtime.NetworkPolicy) error) {
+	fake.restrictEgressMutex.Lock()
+	defer fake.restrictEgressMutex.Unlock()
+	fake.RestrictEgressStub = stub
+}
+
+func (fake *FakeNetwork) RestrictEgressArgsForCall(i int) (string, string, runtime.NetworkPolicy) {
+	fake.restrictEgressMutex.RLock()
+	defer fake.restrictEgressMutex.RUnlock()
+	argsForCall := fake.restrictEgressArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNetwork) RestrictEgressReturns(result1 error) {
+	fake.restrictEgressMutex.Lock()
+	defer fake.restrictEgressMutex.Unlock()
+	fake.RestrictEgressStub = nil
+	fake.restrictEgressReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) RestrictEgressReturnsOnCall(i int, result1 error) {
+	fake.restrictEgressMutex.Lock()
+	defer fake.restrictEgressMutex.Unlock()
+	fake.RestrictEgressStub = nil
+	if fake.restrictEgressReturnsOnCall == nil {
+		fake.restrictEgressReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restrictEgressReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) SetupMounts(arg1 string) ([]specs.Mount, error) {
 	fake.setupMountsMutex.Lock()
 	ret, specificReturn := fake.setupMountsReturnsOnCall[len(fake.setupMountsArgsForCall)]
@@ -308,8 +544,14 @@ func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
+	fake.restrictEgressMutex.RLock()
+	defer fake.restrictEgressMutex.RUnlock()
 	fake.setupMountsMutex.RLock()
 	defer fake.setupMountsMutex.RUnlock()
 	fake.setupRestrictedNetworksMutex.RLock()
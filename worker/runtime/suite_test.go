@@ -9,6 +9,7 @@ import (
 
 func TestSuite(t *testing.T) {
 	suite.Run(t, &BackendSuite{Assertions: require.New(t)})
+	suite.Run(t, &CapacitySuite{Assertions: require.New(t)})
 	suite.Run(t, &CNINetworkSuite{Assertions: require.New(t)})
 	suite.Run(t, &ContainerSuite{Assertions: require.New(t)})
 	suite.Run(t, &FileStoreSuite{Assertions: require.New(t)})
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"syscall"
 
 	"code.cloudfoundry.org/garden/server"
@@ -91,6 +92,7 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
 		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
 		runtime.WithInitBinPath(cmd.Containerd.InitBin),
+		runtime.WithRuntimeClasses(cmd.Containerd.RuntimeClasses),
 	)
 
 	gardenBackend, err := runtime.NewGardenBackend(
@@ -213,6 +215,19 @@ func (cmd ContainerdRuntime) externalIP() (net.IP, error) {
 	return net.ParseIP(localIP), nil
 }
 
+// runtimeClassNames lists the configured runtime classes in a stable order, so
+// that the worker's registration doesn't change between heartbeats.
+func (cmd ContainerdRuntime) runtimeClassNames() []string {
+	var names []string
+	for name := range cmd.RuntimeClasses {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (cmd ContainerdRuntime) mtu() (int, error) {
 	if cmd.Network.MTU != 0 {
 		return cmd.Network.MTU, nil
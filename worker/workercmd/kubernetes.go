@@ -0,0 +1,75 @@
+// +build linux
+
+package workercmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden/server"
+	"code.cloudfoundry.org/lager"
+	runtimekubernetes "github.com/concourse/concourse/worker/runtime/kubernetes"
+	"github.com/tedsuo/ifrit"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// kubernetesRunner launches a Garden server which runs containers as pods of
+// the Kubernetes cluster the worker is configured for.
+func (cmd *WorkerCommand) kubernetesRunner(logger lager.Logger, workerName string) (ifrit.Runner, error) {
+	const graceTime = 0
+
+	if cmd.Kubernetes.VolumesClaim == "" {
+		return nil, errors.New("a volumes claim must be configured to use the Kubernetes runtime")
+	}
+
+	config, err := cmd.Kubernetes.restConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes config: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes client: %w", err)
+	}
+
+	// baggageclaim is configured with this as its volumes directory when its
+	// runner is created
+	volumesDir := filepath.Join(cmd.WorkDir.Path(), "volumes")
+
+	gardenBackend, err := runtimekubernetes.NewGardenBackend(
+		client,
+		cmd.Kubernetes.Namespace,
+		workerName,
+		runtimekubernetes.WithExecutor(runtimekubernetes.NewExecutor(client, config)),
+		runtimekubernetes.WithInitImage(cmd.Kubernetes.InitImage),
+		runtimekubernetes.WithVolumesClaim(cmd.Kubernetes.VolumesClaim, volumesDir),
+		runtimekubernetes.WithAllowPrivileged(cmd.Kubernetes.AllowPrivileged),
+		runtimekubernetes.WithMaxContainers(cmd.Kubernetes.MaxContainers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes backend init: %w", err)
+	}
+
+	server := server.New("tcp", cmd.bindAddr(),
+		graceTime,
+		&gardenBackend,
+		logger,
+	)
+
+	return gardenServerRunner{logger, server}, nil
+}
+
+func (cmd KubernetesRuntime) restConfig() (*rest.Config, error) {
+	if cmd.InClusterConfig && cmd.ConfigPath != "" {
+		return nil, errors.New("either in-cluster or config-path can be used, not both")
+	}
+
+	if cmd.InClusterConfig {
+		return rest.InClusterConfig()
+	}
+
+	return clientcmd.BuildConfigFromFlags("", cmd.ConfigPath)
+}
@@ -0,0 +1,170 @@
+// +build linux
+
+package workercmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"syscall"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim/api"
+	"github.com/concourse/baggageclaim/uidgid"
+	"github.com/concourse/baggageclaim/volume"
+	"github.com/concourse/concourse/worker/fuseoverlay"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+	"github.com/tedsuo/ifrit/http_server"
+)
+
+// rootlesskit exports the location of its state directory to the process it
+// runs, which tells us that we're already in its namespaces.
+//
+const rootlessKitStateDirEnv = "ROOTLESSKIT_STATE_DIR"
+
+var ErrRootlessRuntime = errors.New("rootless workers are only supported by the containerd runtime")
+var ErrNotInRootlessKit = errors.New("rootless workers must be run through rootlesskit")
+
+func inRootlessKit() bool {
+	return os.Getenv(rootlessKitStateDirEnv) != ""
+}
+
+func (cmd *WorkerCommand) verifyRootless() error {
+	if cmd.Runtime != containerdRuntime {
+		return ErrRootlessRuntime
+	}
+
+	if !inRootlessKit() {
+		return ErrNotInRootlessKit
+	}
+
+	return nil
+}
+
+// needsRootlessKit determines whether the worker has to re-execute itself
+// through rootlesskit before it can run.
+//
+func (cmd *WorkerCommand) needsRootlessKit() bool {
+	return cmd.Rootless && !inRootlessKit()
+}
+
+// rootlessKitRunner re-executes the worker inside the user, mount and network
+// namespaces set up by rootlesskit. Within them the worker is root, so that
+// containerd, the CNI plugins and the volume driver can do their work without
+// the worker holding any privileges on the host.
+//
+// Containers reach the outside world through slirp4netns, a network stack
+// running in user mode. The health check endpoint is the only port published
+// on the host, as the Garden and baggageclaim servers are reached through the
+// tunnels the worker opens to the TSA.
+//
+func (cmd *WorkerCommand) rootlessKitRunner() (ifrit.Runner, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("find worker executable: %w", err)
+	}
+
+	args := []string{
+		"--net=slirp4netns",
+		"--disable-host-loopback",
+		// containerd, the CNI plugins and iptables write to these, so they
+		// are replaced by writable copies within the mount namespace
+		"--copy-up=/etc",
+		"--copy-up=/run",
+		"--port-driver=builtin",
+		fmt.Sprintf("--publish=%s:%d:%d/tcp", cmd.HealthcheckBindIP.IP, cmd.HealthcheckBindPort, cmd.HealthcheckBindPort),
+		self,
+	}
+
+	command := exec.Command(cmd.Containerd.RootlessKitBin, append(args, os.Args[1:]...)...)
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.SysProcAttr = &syscall.SysProcAttr{
+		Pdeathsig: syscall.SIGKILL,
+	}
+
+	return CmdRunner{command}, nil
+}
+
+// fuseOverlayEnabled determines whether volumes should be mounted with
+// fuse-overlayfs, which is the case for rootless workers unless a specific
+// baggageclaim driver was requested.
+//
+func (cmd *WorkerCommand) fuseOverlayEnabled() bool {
+	return cmd.Rootless && cmd.Baggageclaim.Driver == "detect"
+}
+
+// fuseOverlayBaggageclaimRunner runs a baggageclaim server just like the
+// baggageclaim command does, but with volumes managed by the fuse-overlayfs
+// driver, which the command has no option for.
+//
+func (cmd *WorkerCommand) fuseOverlayBaggageclaimRunner(logger lager.Logger) (ifrit.Runner, error) {
+	bc := cmd.Baggageclaim
+
+	var privilegedNamespacer, unprivilegedNamespacer uidgid.Namespacer
+
+	if !bc.DisableUserNamespaces && uidgid.Supported() {
+		privilegedNamespacer = &uidgid.UidNamespacer{
+			Translator: uidgid.NewTranslator(uidgid.NewPrivilegedMapper()),
+			Logger:     logger.Session("uid-namespacer"),
+		}
+
+		unprivilegedNamespacer = &uidgid.UidNamespacer{
+			Translator: uidgid.NewTranslator(uidgid.NewUnprivilegedMapper()),
+			Logger:     logger.Session("uid-namespacer"),
+		}
+	} else {
+		privilegedNamespacer = uidgid.NoopNamespacer{}
+		unprivilegedNamespacer = uidgid.NoopNamespacer{}
+	}
+
+	logger.Info("using-driver", lager.Data{"driver": "fuse-overlayfs"})
+
+	driver := fuseoverlay.New(bc.OverlaysDir, cmd.Containerd.FuseOverlayFSBin)
+
+	filesystem, err := volume.NewFilesystem(driver, bc.VolumesDir.Path())
+	if err != nil {
+		return nil, fmt.Errorf("initialize filesystem: %w", err)
+	}
+
+	err = driver.Recover(filesystem)
+	if err != nil {
+		return nil, fmt.Errorf("recover volume driver: %w", err)
+	}
+
+	volumeRepo := volume.NewRepository(
+		filesystem,
+		volume.NewLockManager(),
+		privilegedNamespacer,
+		unprivilegedNamespacer,
+	)
+
+	p2pInterfacePattern, err := regexp.Compile(bc.P2pInterfaceNamePattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile p2p interface name pattern: %w", err)
+	}
+
+	apiHandler, err := api.NewHandler(
+		logger.Session("api"),
+		volume.NewStrategerizer(),
+		volumeRepo,
+		p2pInterfacePattern,
+		bc.P2pInterfaceFamily,
+		bc.BindPort,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create api handler: %w", err)
+	}
+
+	listenAddr := fmt.Sprintf("%s:%d", bc.BindIP.IP, bc.BindPort)
+	debugAddr := fmt.Sprintf("%s:%d", bc.DebugBindIP.IP, bc.DebugBindPort)
+
+	return grouper.NewParallel(os.Interrupt, grouper.Members{
+		{Name: "api", Runner: http_server.New(listenAddr, apiHandler)},
+		{Name: "debug-server", Runner: http_server.New(debugAddr, http.DefaultServeMux)},
+	}), nil
+}
@@ -56,6 +56,8 @@ type WorkerCommand struct {
 
 	Containerd ContainerdRuntime `group:"Containerd Configuration" namespace:"containerd"`
 
+	Kubernetes KubernetesRuntime `group:"Kubernetes Configuration" namespace:"kubernetes"`
+
 	ExternalGardenURL flag.URL `long:"external-garden-url" description:"API endpoint of an externally managed Garden server to use instead of running the embedded Garden server."`
 
 	Baggageclaim baggageclaimcmd.BaggageclaimCommand `group:"Baggageclaim Configuration" namespace:"baggageclaim"`
@@ -66,7 +68,14 @@ type WorkerCommand struct {
 }
 
 func (cmd *WorkerCommand) Execute(args []string) error {
-	runner, err := cmd.Runner(args)
+	var runner ifrit.Runner
+	var err error
+
+	if cmd.needsRootlessKit() {
+		runner, err = cmd.rootlessKitRunner()
+	} else {
+		runner, err = cmd.Runner(args)
+	}
 	if err != nil {
 		return err
 	}
@@ -257,5 +266,9 @@ func (cmd *WorkerCommand) baggageclaimRunner(logger lager.Logger) (ifrit.Runner,
 
 	cmd.Baggageclaim.OverlaysDir = filepath.Join(cmd.WorkDir.Path(), "overlays")
 
+	if cmd.fuseOverlayEnabled() {
+		return cmd.fuseOverlayBaggageclaimRunner(logger)
+	}
+
 	return cmd.Baggageclaim.Runner(nil)
 }
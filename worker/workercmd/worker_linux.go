@@ -25,7 +25,8 @@ type Certs struct {
 }
 
 type RuntimeConfiguration struct {
-	Runtime string `long:"runtime" default:"guardian" choice:"guardian" choice:"containerd" choice:"houdini" description:"Runtime to use with the worker. Please note that Houdini is insecure and doesn't run 'tasks' in containers."`
+	Runtime  string `long:"runtime" default:"guardian" choice:"guardian" choice:"containerd" choice:"houdini" choice:"kubernetes" description:"Runtime to use with the worker. Please note that Houdini is insecure and doesn't run 'tasks' in containers, and that the containerd runtime streams files into containers with the container's own 'sh' and 'tar'."`
+	Rootless bool   `long:"rootless" description:"Run the worker without root privileges, inside the user namespace of rootlesskit. Only supported by the containerd runtime. Privileged containers are not placed on rootless workers."`
 }
 
 type GuardianRuntime struct {
@@ -55,6 +56,23 @@ type ContainerdRuntime struct {
 	} `group:"Container Networking"`
 
 	MaxContainers int `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
+
+	RuntimeClasses map[string]string `long:"runtime-class" value-name:"NAME:RUNTIME" description:"Runtime class that tasks can request, and the containerd runtime implementing it, e.g. 'runsc:io.containerd.runsc.v1'. Can be specified multiple times."`
+
+	RootlessKitBin   string `long:"rootlesskit-bin" default:"rootlesskit" description:"Path to a rootlesskit executable, used to run rootless workers (non-absolute names get resolved from $PATH)."`
+	FuseOverlayFSBin string `long:"fuse-overlayfs-bin" default:"fuse-overlayfs" description:"Path to a fuse-overlayfs executable, used to mount the volumes of rootless workers (non-absolute names get resolved from $PATH)."`
+}
+
+type KubernetesRuntime struct {
+	InClusterConfig bool   `long:"in-cluster" description:"Enables the in-cluster client."`
+	ConfigPath      string `long:"config-path" description:"Path to Kubernetes config when running the worker outside Kubernetes."`
+	Namespace       string `long:"namespace" default:"concourse" description:"Namespace to create container pods in."`
+
+	InitImage    string `long:"init-image" default:"debian:bullseye-slim" description:"Image run by container pods. It must provide a shell, env, kill, tar, and a chroot supporting --userspec."`
+	VolumesClaim string `long:"volumes-claim" description:"Name of the PersistentVolumeClaim mounted at the worker's volumes directory, from which container pods mount their volumes."`
+
+	AllowPrivileged bool `long:"allow-privileged" description:"Run privileged containers as privileged pods. Otherwise, privileged containers cannot be created."`
+	MaxContainers   int  `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
 }
 
 type DNSConfig struct {
@@ -64,6 +82,7 @@ type DNSConfig struct {
 const containerdRuntime = "containerd"
 const guardianRuntime = "guardian"
 const houdiniRuntime = "houdini"
+const kubernetesRuntime = "kubernetes"
 
 func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
 	// configured as work-dir/volumes
@@ -75,18 +94,36 @@ func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Comman
 // endpoints that allow the ATC to make container related requests to the worker.
 // The runner may also include additional processes such as the runtime's daemon or a DNS proxy server.
 func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
-	err := cmd.checkRoot()
-	if err != nil {
-		return atc.Worker{}, nil, err
+	if cmd.Rootless {
+		err := cmd.verifyRootless()
+		if err != nil {
+			return atc.Worker{}, nil, err
+		}
 	}
 
-	err = cmd.verifyRuntimeFlags()
+	// container pods are managed through the Kubernetes API, so root is
+	// only needed by the other runtimes. rootless workers are root within
+	// the user namespace of rootlesskit.
+	if cmd.Runtime != kubernetesRuntime {
+		err := cmd.checkRoot()
+		if err != nil {
+			return atc.Worker{}, nil, err
+		}
+	}
+
+	err := cmd.verifyRuntimeFlags()
 	if err != nil {
 		return atc.Worker{}, nil, err
 	}
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Rootless = cmd.Rootless
+
+	if cmd.Runtime == containerdRuntime {
+		worker.RuntimeClasses = cmd.Containerd.runtimeClassNames()
+		worker.NetworkPolicies = true
+	}
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
@@ -113,6 +150,8 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 		runner, err = cmd.containerdRunner(logger)
 	case cmd.Runtime == guardianRuntime:
 		runner, err = cmd.guardianRunner(logger)
+	case cmd.Runtime == kubernetesRuntime:
+		runner, err = cmd.kubernetesRunner(logger, worker.Name)
 	default:
 		err = fmt.Errorf("unsupported Runtime :%s", cmd.Runtime)
 	}
@@ -230,21 +269,26 @@ func (cmd *WorkerCommand) hasFlags(prefix string) bool {
 
 const guardianEnvPrefix = "CONCOURSE_GARDEN_"
 const containerdEnvPrefix = "CONCOURSE_CONTAINERD_"
+const kubernetesEnvPrefix = "CONCOURSE_KUBERNETES_"
 
 // Checks if runtime specific flags provided match the selected runtime type
 func (cmd *WorkerCommand) verifyRuntimeFlags() error {
 	switch {
 	case cmd.Runtime == houdiniRuntime:
-		if cmd.hasFlags(guardianEnvPrefix) || cmd.hasFlags(containerdEnvPrefix) {
-			return fmt.Errorf("cannot use %s or %s environment variables with Houdini", guardianEnvPrefix, containerdEnvPrefix)
+		if cmd.hasFlags(guardianEnvPrefix) || cmd.hasFlags(containerdEnvPrefix) || cmd.hasFlags(kubernetesEnvPrefix) {
+			return fmt.Errorf("cannot use %s, %s or %s environment variables with Houdini", guardianEnvPrefix, containerdEnvPrefix, kubernetesEnvPrefix)
 		}
 	case cmd.Runtime == containerdRuntime:
-		if cmd.hasFlags(guardianEnvPrefix) {
-			return fmt.Errorf("cannot use %s environment variables with Containerd", guardianEnvPrefix)
+		if cmd.hasFlags(guardianEnvPrefix) || cmd.hasFlags(kubernetesEnvPrefix) {
+			return fmt.Errorf("cannot use %s or %s environment variables with Containerd", guardianEnvPrefix, kubernetesEnvPrefix)
 		}
 	case cmd.Runtime == guardianRuntime:
-		if cmd.hasFlags(containerdEnvPrefix) {
-			return fmt.Errorf("cannot use %s environment variables with Guardian", containerdEnvPrefix)
+		if cmd.hasFlags(containerdEnvPrefix) || cmd.hasFlags(kubernetesEnvPrefix) {
+			return fmt.Errorf("cannot use %s or %s environment variables with Guardian", containerdEnvPrefix, kubernetesEnvPrefix)
+		}
+	case cmd.Runtime == kubernetesRuntime:
+		if cmd.hasFlags(guardianEnvPrefix) || cmd.hasFlags(containerdEnvPrefix) {
+			return fmt.Errorf("cannot use %s or %s environment variables with Kubernetes", guardianEnvPrefix, containerdEnvPrefix)
 		}
 	default:
 		return fmt.Errorf("unsupported Runtime :%s", cmd.Runtime)
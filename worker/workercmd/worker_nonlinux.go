@@ -3,6 +3,7 @@
 package workercmd
 
 import (
+	"errors"
 	"runtime"
 	"time"
 
@@ -22,6 +23,9 @@ type GuardianRuntime struct {
 type ContainerdRuntime struct {
 }
 
+type KubernetesRuntime struct {
+}
+
 type Certs struct{}
 
 func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
@@ -45,3 +49,19 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 
 	return worker, runner, nil
 }
+
+func (cmd *WorkerCommand) needsRootlessKit() bool {
+	return false
+}
+
+func (cmd *WorkerCommand) rootlessKitRunner() (ifrit.Runner, error) {
+	return nil, errors.New("rootless workers are only supported on linux")
+}
+
+func (cmd *WorkerCommand) fuseOverlayEnabled() bool {
+	return false
+}
+
+func (cmd *WorkerCommand) fuseOverlayBaggageclaimRunner(logger lager.Logger) (ifrit.Runner, error) {
+	return nil, errors.New("fuse-overlayfs volumes are only supported on linux")
+}